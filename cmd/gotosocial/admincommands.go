@@ -120,6 +120,19 @@ func adminCommands() []*cli.Command {
 								return runAction(c, account.Suspend)
 							},
 						},
+						{
+							Name:  "delete",
+							Usage: "delete an account along with all of its posts, media, etc, and tell other servers that it's gone",
+							Flags: []cli.Flag{
+								&cli.StringFlag{
+									Name:  config.UsernameFlag,
+									Usage: config.UsernameUsage,
+								},
+							},
+							Action: func(c *cli.Context) error {
+								return runAction(c, account.Delete)
+							},
+						},
 						{
 							Name:  "password",
 							Usage: "set a new password for the given account",
@@ -137,6 +150,19 @@ func adminCommands() []*cli.Command {
 								return runAction(c, account.Password)
 							},
 						},
+						{
+							Name:  "disable-2fa",
+							Usage: "turn off two-factor authentication for the given account, for when the user has lost their authenticator app and backup codes",
+							Flags: []cli.Flag{
+								&cli.StringFlag{
+									Name:  config.UsernameFlag,
+									Usage: config.UsernameUsage,
+								},
+							},
+							Action: func(c *cli.Context) error {
+								return runAction(c, account.DisableTwoFactor)
+							},
+						},
 					},
 				},
 			},
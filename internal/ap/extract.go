@@ -167,6 +167,24 @@ func ExtractPublished(i WithPublished) (time.Time, error) {
 	return t, nil
 }
 
+// ExtractUpdated extracts the time at which an object was last updated.
+func ExtractUpdated(i WithUpdated) (time.Time, error) {
+	updatedProp := i.GetActivityStreamsUpdated()
+	if updatedProp == nil {
+		return time.Time{}, errors.New("updated prop was nil")
+	}
+
+	if !updatedProp.IsXMLSchemaDateTime() {
+		return time.Time{}, errors.New("updated prop was not date time")
+	}
+
+	t := updatedProp.Get()
+	if t.IsZero() {
+		return time.Time{}, errors.New("updated time was zero")
+	}
+	return t, nil
+}
+
 // ExtractIconURL extracts a URL to a supported image file from something like:
 //   "icon": {
 //     "mediaType": "image/jpeg",
@@ -613,3 +631,156 @@ func ExtractObject(i WithObject) (*url.URL, error) {
 	}
 	return nil, errors.New("no iri found for object prop")
 }
+
+// ExtractObjects extracts all the URL objects from a WithObject interface.
+func ExtractObjects(i WithObject) ([]*url.URL, error) {
+	objectProp := i.GetActivityStreamsObject()
+	if objectProp == nil {
+		return nil, errors.New("object property was nil")
+	}
+
+	objects := []*url.URL{}
+	for iter := objectProp.Begin(); iter != objectProp.End(); iter = iter.Next() {
+		if iter.IsIRI() && iter.GetIRI() != nil {
+			objects = append(objects, iter.GetIRI())
+		}
+	}
+
+	if len(objects) == 0 {
+		return nil, errors.New("no iris found for object prop")
+	}
+	return objects, nil
+}
+
+// ExtractTarget extracts the target ID/IRI from an interface WithTarget.
+func ExtractTarget(i WithTarget) (*url.URL, error) {
+	targetProp := i.GetActivityStreamsTarget()
+	if targetProp == nil {
+		return nil, errors.New("target property was nil")
+	}
+	for iter := targetProp.Begin(); iter != targetProp.End(); iter = iter.Next() {
+		if iter.IsIRI() && iter.GetIRI() != nil {
+			return iter.GetIRI(), nil
+		}
+	}
+	return nil, errors.New("no iri found for target prop")
+}
+
+// ExtractAlsoKnownAs extracts the alsoKnownAs IRIs of an account from its unknown properties,
+// since alsoKnownAs isn't part of the go-fed vocabulary. Values that can't be parsed as IRIs are skipped.
+func ExtractAlsoKnownAs(i WithUnknownProperties) []*url.URL {
+	return unknownPropertyIRIs(i.GetUnknownProperties()[PropertyAlsoKnownAs])
+}
+
+// ExtractMovedTo extracts the movedTo IRI of an account from its unknown properties,
+// since movedTo isn't part of the go-fed vocabulary.
+func ExtractMovedTo(i WithUnknownProperties) (*url.URL, error) {
+	iris := unknownPropertyIRIs(i.GetUnknownProperties()[PropertyMovedTo])
+	if len(iris) == 0 {
+		return nil, errors.New("no iri found for movedTo prop")
+	}
+	return iris[0], nil
+}
+
+// unknownPropertyIRIs parses the value of an unknown property as a list of IRIs.
+// The value can be a single IRI string, an object with an id, or an array of either.
+func unknownPropertyIRIs(v interface{}) []*url.URL {
+	iris := []*url.URL{}
+	switch value := v.(type) {
+	case string:
+		if iri, err := url.Parse(value); err == nil && iri.IsAbs() {
+			iris = append(iris, iri)
+		}
+	case []string:
+		for _, s := range value {
+			iris = append(iris, unknownPropertyIRIs(s)...)
+		}
+	case map[string]interface{}:
+		iris = append(iris, unknownPropertyIRIs(value["id"])...)
+	case []interface{}:
+		for _, item := range value {
+			iris = append(iris, unknownPropertyIRIs(item)...)
+		}
+	}
+	return iris
+}
+
+// ExtractPoll extracts a minimal gtsmodel Poll from a Pollable, with the options, vote counts,
+// and expiry/closed times set. Fields relating to the parent status or database ID are not set.
+func ExtractPoll(i Pollable) (*gtsmodel.Poll, error) {
+	poll := &gtsmodel.Poll{}
+
+	// a poll uses either oneOf (single choice) or anyOf (multiple choice) to list its options
+	if anyOfProp := i.GetActivityStreamsAnyOf(); anyOfProp != nil && anyOfProp.Len() != 0 {
+		poll.Multiple = true
+		for iter := anyOfProp.Begin(); iter != anyOfProp.End(); iter = iter.Next() {
+			if !iter.IsActivityStreamsNote() {
+				continue
+			}
+			name, votes, err := ExtractPollOption(iter.GetActivityStreamsNote())
+			if err != nil {
+				return nil, err
+			}
+			poll.Options = append(poll.Options, name)
+			poll.Votes = append(poll.Votes, votes)
+		}
+	} else if oneOfProp := i.GetActivityStreamsOneOf(); oneOfProp != nil {
+		for iter := oneOfProp.Begin(); iter != oneOfProp.End(); iter = iter.Next() {
+			if !iter.IsActivityStreamsNote() {
+				continue
+			}
+			name, votes, err := ExtractPollOption(iter.GetActivityStreamsNote())
+			if err != nil {
+				return nil, err
+			}
+			poll.Options = append(poll.Options, name)
+			poll.Votes = append(poll.Votes, votes)
+		}
+	}
+
+	if len(poll.Options) == 0 {
+		return nil, errors.New("no poll options found")
+	}
+
+	if endTimeProp := i.GetActivityStreamsEndTime(); endTimeProp != nil && endTimeProp.IsXMLSchemaDateTime() {
+		poll.ExpiresAt = endTimeProp.Get()
+	}
+
+	// closed can be either a datetime or a boolean, we handle both
+	if closedProp := i.GetActivityStreamsClosed(); closedProp != nil {
+		for iter := closedProp.Begin(); iter != closedProp.End(); iter = iter.Next() {
+			if iter.IsXMLSchemaDateTime() {
+				poll.ClosedAt = iter.GetXMLSchemaDateTime()
+				break
+			}
+			if iter.IsXMLSchemaBoolean() && iter.GetXMLSchemaBoolean() {
+				poll.ClosedAt = time.Now()
+				break
+			}
+		}
+	}
+
+	if votersCountProp := i.GetTootVotersCount(); votersCountProp != nil && votersCountProp.IsXMLSchemaNonNegativeInteger() {
+		poll.VotersCount = votersCountProp.Get()
+	}
+
+	return poll, nil
+}
+
+// ExtractPollOption extracts the name of a poll option, and the number of votes
+// it has received so far, from the replies.totalItems property of the option.
+func ExtractPollOption(i PollOptionable) (string, int, error) {
+	name, err := ExtractName(i)
+	if err != nil {
+		return "", 0, fmt.Errorf("error extracting name of poll option: %s", err)
+	}
+
+	votes := 0
+	if repliesProp := i.GetActivityStreamsReplies(); repliesProp != nil && repliesProp.IsActivityStreamsCollection() {
+		if totalItemsProp := repliesProp.GetActivityStreamsCollection().GetActivityStreamsTotalItems(); totalItemsProp != nil && totalItemsProp.IsXMLSchemaNonNegativeInteger() {
+			votes = totalItemsProp.Get()
+		}
+	}
+
+	return name, votes, nil
+}
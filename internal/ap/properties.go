@@ -0,0 +1,29 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package ap
+
+// Property names that aren't part of the go-fed vocabulary, so have to be set and read as 'unknown' properties.
+const (
+	// PropertyAlsoKnownAs https://www.w3.org/TR/did-core/#dfn-alsoknownas
+	PropertyAlsoKnownAs = "alsoKnownAs"
+	// PropertyMovedTo https://docs.joinmastodon.org/spec/activitypub/#as
+	PropertyMovedTo = "movedTo"
+	// PropertyEndorsements https://docs.joinmastodon.org/spec/activitypub/#as
+	PropertyEndorsements = "endorsements"
+)
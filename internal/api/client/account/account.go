@@ -45,6 +45,12 @@ const (
 
 	// IDKey is the key to use for retrieving account ID in requests
 	IDKey = "id"
+	// ImportIDKey is the key to use for retrieving account import ID in requests
+	ImportIDKey = "import_id"
+	// ExportTypeKey is the key to use for retrieving the type of export in requests
+	ExportTypeKey = "export_type"
+	// ArchiveIDKey is the key to use for retrieving account archive ID in requests
+	ArchiveIDKey = "archive_id"
 	// BasePath is the base API path for this module
 	BasePath = "/api/v1/accounts"
 	// BasePathWithID is the base path for this module with the ID key
@@ -69,6 +75,34 @@ const (
 	BlockPath = BasePathWithID + "/block"
 	// UnblockPath is for removing a block of an account
 	UnblockPath = BasePathWithID + "/unblock"
+	// MutePath is for creating or updating a mute of an account
+	MutePath = BasePathWithID + "/mute"
+	// UnmutePath is for removing a mute of an account
+	UnmutePath = BasePathWithID + "/unmute"
+	// PinPath is for endorsing an account, so that it's featured on the requesting account's profile
+	PinPath = BasePathWithID + "/pin"
+	// UnpinPath is for removing an endorsement of an account
+	UnpinPath = BasePathWithID + "/unpin"
+	// GetFeaturedTagsPath is for showing the hashtags featured on an account's profile
+	GetFeaturedTagsPath = BasePathWithID + "/featured_tags"
+	// DeletePath is for deleting the requesting account
+	DeletePath = BasePath + "/delete"
+	// MovePath is for moving the requesting account to another account
+	MovePath = BasePath + "/move"
+	// ImportsPath is for uploading CSV files of follows, blocks, or mutes to import
+	ImportsPath = BasePath + "/imports"
+	// ImportPathWithID is for checking the progress of an import
+	ImportPathWithID = ImportsPath + "/:" + ImportIDKey
+	// ExportPath is for downloading CSV files of follows, blocks, or mutes
+	ExportPath = BasePath + "/exports/:" + ExportTypeKey
+	// ArchivesPath is for requesting a new archive of the requesting account's data
+	ArchivesPath = BasePath + "/archives"
+	// ArchiveImportPath is for uploading an archive to import
+	ArchiveImportPath = ArchivesPath + "/import"
+	// ArchivePathWithID is for checking the progress of an archive
+	ArchivePathWithID = ArchivesPath + "/:" + ArchiveIDKey
+	// ArchiveDownloadPath is for downloading a finished archive
+	ArchiveDownloadPath = ArchivePathWithID + "/download"
 )
 
 // Module implements the ClientAPIModule interface for account-related actions
@@ -92,6 +126,23 @@ func (m *Module) Route(r router.Router) error {
 	// create account
 	r.AttachHandler(http.MethodPost, BasePath, m.AccountCreatePOSTHandler)
 
+	// delete own account
+	r.AttachHandler(http.MethodPost, DeletePath, m.AccountDeletePOSTHandler)
+
+	// move own account to another account
+	r.AttachHandler(http.MethodPost, MovePath, m.AccountMovePOSTHandler)
+
+	// import or export follows, blocks, and mutes
+	r.AttachHandler(http.MethodPost, ImportsPath, m.AccountImportPOSTHandler)
+	r.AttachHandler(http.MethodGet, ImportPathWithID, m.AccountImportGETHandler)
+	r.AttachHandler(http.MethodGet, ExportPath, m.AccountExportGETHandler)
+
+	// export or import whole account archives
+	r.AttachHandler(http.MethodPost, ArchivesPath, m.AccountArchivePOSTHandler)
+	r.AttachHandler(http.MethodPost, ArchiveImportPath, m.AccountArchiveImportPOSTHandler)
+	r.AttachHandler(http.MethodGet, ArchivePathWithID, m.AccountArchiveGETHandler)
+	r.AttachHandler(http.MethodGet, ArchiveDownloadPath, m.AccountArchiveDownloadGETHandler)
+
 	// get account
 	r.AttachHandler(http.MethodGet, BasePathWithID, m.muxHandler)
 
@@ -116,6 +167,17 @@ func (m *Module) Route(r router.Router) error {
 	r.AttachHandler(http.MethodPost, BlockPath, m.AccountBlockPOSTHandler)
 	r.AttachHandler(http.MethodPost, UnblockPath, m.AccountUnblockPOSTHandler)
 
+	// mute or unmute account
+	r.AttachHandler(http.MethodPost, MutePath, m.AccountMutePOSTHandler)
+	r.AttachHandler(http.MethodPost, UnmutePath, m.AccountUnmutePOSTHandler)
+
+	// endorse or unendorse account
+	r.AttachHandler(http.MethodPost, PinPath, m.AccountPinPOSTHandler)
+	r.AttachHandler(http.MethodPost, UnpinPath, m.AccountUnpinPOSTHandler)
+
+	// get account's featured hashtags
+	r.AttachHandler(http.MethodGet, GetFeaturedTagsPath, m.AccountFeaturedTagsGETHandler)
+
 	return nil
 }
 
@@ -0,0 +1,241 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// AccountArchivePOSTHandler swagger:operation POST /api/v1/accounts/archives accountArchiveCreate
+//
+// Request a zip archive of your account's data.
+//
+// The archive contains your profile as actor.json, your statuses as Create activities in outbox.json, the media attached to them,
+// and the statuses you've liked and bookmarked. It's generated in the background; use the returned ID to check on its progress,
+// and download it from the url of the archive once it's finished.
+//
+// Only one archive can be generated at a time, and generating a new archive replaces any previous one.
+//
+// ---
+// tags:
+// - accounts
+//
+// produces:
+// - application/json
+//
+// security:
+// - OAuth2 Bearer:
+//   - read:accounts
+//
+// responses:
+//   '202':
+//     description: "The archive that was requested, which will be generated in the background."
+//     schema:
+//       "$ref": "#/definitions/accountArchive"
+//   '401':
+//      description: unauthorized
+//   '422':
+//      description: unprocessable, eg., an archive is already being generated
+func (m *Module) AccountArchivePOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	archive, errWithCode := m.processor.AccountArchiveExportCreate(c.Request.Context(), authed)
+	if errWithCode != nil {
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, archive)
+}
+
+// AccountArchiveImportPOSTHandler swagger:operation POST /api/v1/accounts/archives/import accountArchiveImport
+//
+// Import a zip archive into your account.
+//
+// The statuses in the outbox.json of the archive are recreated as statuses of your account, keeping their original dates, along with their media.
+// They're added as history only: they aren't sent to other instances, and they don't show up in anyone's home timeline.
+// Bookmarks in the archive are restored for statuses that this instance already knows about.
+//
+// ---
+// tags:
+// - accounts
+//
+// consumes:
+// - multipart/form-data
+//
+// parameters:
+// - name: data
+//   required: true
+//   in: formData
+//   description: The zip archive to import.
+//   type: file
+//
+// produces:
+// - application/json
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:statuses
+//
+// responses:
+//   '202':
+//     description: "The archive that was uploaded, which will be imported in the background."
+//     schema:
+//       "$ref": "#/definitions/accountArchive"
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+func (m *Module) AccountArchiveImportPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	form := &model.AccountArchiveImportRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	archive, errWithCode := m.processor.AccountArchiveImportCreate(c.Request.Context(), authed, form)
+	if errWithCode != nil {
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, archive)
+}
+
+// AccountArchiveGETHandler swagger:operation GET /api/v1/accounts/archives/{archive_id} accountArchiveGet
+//
+// Check the progress of an archive export or import.
+//
+// ---
+// tags:
+// - accounts
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: archive_id
+//   type: string
+//   description: The id of the archive.
+//   in: path
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - read:accounts
+//
+// responses:
+//   '200':
+//     description: "The requested archive."
+//     schema:
+//       "$ref": "#/definitions/accountArchive"
+//   '401':
+//      description: unauthorized
+//   '404':
+//      description: not found
+func (m *Module) AccountArchiveGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	archiveID := c.Param(ArchiveIDKey)
+	if archiveID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errors.New("no archive id specified").Error()})
+		return
+	}
+
+	archive, errWithCode := m.processor.AccountArchiveGet(c.Request.Context(), authed, archiveID)
+	if errWithCode != nil {
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, archive)
+}
+
+// AccountArchiveDownloadGETHandler swagger:operation GET /api/v1/accounts/archives/{archive_id}/download accountArchiveDownload
+//
+// Download a finished archive of your account's data.
+//
+// ---
+// tags:
+// - accounts
+//
+// produces:
+// - application/zip
+//
+// parameters:
+// - name: archive_id
+//   type: string
+//   description: The id of the archive.
+//   in: path
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - read:accounts
+//
+// responses:
+//   '200':
+//     description: "The zip archive."
+//   '401':
+//      description: unauthorized
+//   '404':
+//      description: not found
+//   '422':
+//      description: unprocessable, eg., the archive hasn't finished yet
+func (m *Module) AccountArchiveDownloadGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	archiveID := c.Param(ArchiveIDKey)
+	if archiveID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errors.New("no archive id specified").Error()})
+		return
+	}
+
+	b, errWithCode := m.processor.AccountArchiveDownload(c.Request.Context(), authed, archiveID)
+	if errWithCode != nil {
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename=\"archive-"+archiveID+".zip\"")
+	c.Data(http.StatusOK, "application/zip", b)
+}
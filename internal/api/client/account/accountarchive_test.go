@@ -0,0 +1,375 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/account"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type AccountArchiveTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *AccountArchiveTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testAttachments = testrig.NewTestAttachments()
+	suite.testStatuses = testrig.NewTestStatuses()
+}
+
+func (suite *AccountArchiveTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.storage = testrig.NewTestStorage()
+	suite.log = testrig.NewTestLog()
+	suite.federator = testrig.NewTestFederator(suite.db, testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db), suite.storage)
+	suite.processor = testrig.NewTestProcessor(suite.db, suite.storage, suite.federator)
+	suite.accountModule = account.New(suite.config, suite.processor, suite.log).(*account.Module)
+	testrig.StandardDBSetup(suite.db, nil)
+	testrig.StandardStorageSetup(suite.storage, "../../../../testrig/media")
+}
+
+func (suite *AccountArchiveTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StandardStorageTeardown(suite.storage)
+}
+
+func (suite *AccountArchiveTestSuite) newContext(recorder *httptest.ResponseRecorder, request *http.Request, accountKey string) *gin.Context {
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[accountKey])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[accountKey]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[accountKey])
+	ctx.Request = request
+	return ctx
+}
+
+func (suite *AccountArchiveTestSuite) waitForArchive(archiveID string, accountKey string) *apimodel.AccountArchive {
+	archive := &apimodel.AccountArchive{}
+	for i := 0; i < 50; i++ {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:8080/api/v1/accounts/archives/%s", archiveID), nil)
+		ctx := suite.newContext(recorder, request, accountKey)
+		ctx.Params = gin.Params{gin.Param{Key: account.ArchiveIDKey, Value: archiveID}}
+		suite.accountModule.AccountArchiveGETHandler(ctx)
+		suite.Equal(http.StatusOK, recorder.Code)
+
+		suite.NoError(json.NewDecoder(recorder.Body).Decode(archive))
+		if archive.Finished {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	suite.True(archive.Finished)
+	return archive
+}
+
+func (suite *AccountArchiveTestSuite) importArchive(data []byte, accountKey string) *httptest.ResponseRecorder {
+	b := &bytes.Buffer{}
+	w := multipart.NewWriter(b)
+	fw, err := w.CreateFormFile("data", "archive.zip")
+	suite.NoError(err)
+	_, err = fw.Write(data)
+	suite.NoError(err)
+	suite.NoError(w.Close())
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080%s", account.ArchiveImportPath), b) // the endpoint we're hitting
+	request.Header.Set("Content-Type", w.FormDataContentType())
+	suite.accountModule.AccountArchiveImportPOSTHandler(suite.newContext(recorder, request, accountKey))
+	return recorder
+}
+
+func (suite *AccountArchiveTestSuite) TestExportArchive() {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080%s", account.ArchivesPath), nil)
+	suite.accountModule.AccountArchivePOSTHandler(suite.newContext(recorder, request, "local_account_1"))
+	suite.Equal(http.StatusAccepted, recorder.Code)
+
+	created := &apimodel.AccountArchive{}
+	suite.NoError(json.NewDecoder(recorder.Body).Decode(created))
+	suite.Equal("export", created.Type)
+	suite.Empty(created.URL)
+
+	archive := suite.waitForArchive(created.ID, "local_account_1")
+	suite.Empty(archive.Error)
+	suite.Equal(fmt.Sprintf("http://localhost:8080/api/v1/accounts/archives/%s/download", created.ID), archive.URL)
+
+	// every status that isn't a boost should be in the archive
+	statuses, err := suite.db.GetAccountStatuses(context.Background(), suite.testAccounts["local_account_1"].ID, 0, false, "", false, false)
+	suite.NoError(err)
+	expected := 0
+	for _, s := range statuses {
+		if s.BoostOfID == "" {
+			expected++
+		}
+	}
+	suite.Equal(expected, archive.StatusesCount)
+
+	// download it
+	recorder = httptest.NewRecorder()
+	request = httptest.NewRequest(http.MethodGet, archive.URL, nil)
+	ctx := suite.newContext(recorder, request, "local_account_1")
+	ctx.Params = gin.Params{gin.Param{Key: account.ArchiveIDKey, Value: created.ID}}
+	suite.accountModule.AccountArchiveDownloadGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("application/zip", recorder.Header().Get("Content-Type"))
+
+	b, err := ioutil.ReadAll(recorder.Body)
+	suite.NoError(err)
+	suite.Equal(archive.Size, len(b))
+
+	z, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	suite.NoError(err)
+	files := map[string]*zip.File{}
+	for _, f := range z.File {
+		files[f.Name] = f
+	}
+	suite.Contains(files, "actor.json")
+	suite.Contains(files, "likes.json")
+	suite.Contains(files, "bookmarks.json")
+	suite.Contains(files, "avatar.jpeg")
+	suite.Contains(files, "header.jpeg")
+
+	r, err := files["outbox.json"].Open()
+	suite.NoError(err)
+	outbox := map[string]interface{}{}
+	suite.NoError(json.NewDecoder(r).Decode(&outbox))
+	suite.Equal("OrderedCollection", outbox["type"])
+	items := outbox["orderedItems"].([]interface{})
+	suite.Len(items, expected)
+
+	// attachments should point at media in the archive
+	attachment := suite.testAttachments["local_account_1_status_4_attachment_1"]
+	mediaPath := "media_attachments/" + attachment.ID + path.Ext(attachment.File.Path)
+	suite.Contains(files, mediaPath)
+	found := false
+	for _, item := range items {
+		create := item.(map[string]interface{})
+		suite.Equal("Create", create["type"])
+		object := create["object"].(map[string]interface{})
+		if a, ok := object["attachment"].(map[string]interface{}); ok && a["url"] == mediaPath {
+			found = true
+		}
+	}
+	suite.True(found)
+
+	// someone else can't download it
+	recorder = httptest.NewRecorder()
+	request = httptest.NewRequest(http.MethodGet, archive.URL, nil)
+	ctx = suite.newContext(recorder, request, "local_account_2")
+	ctx.Params = gin.Params{gin.Param{Key: account.ArchiveIDKey, Value: created.ID}}
+	suite.accountModule.AccountArchiveDownloadGETHandler(ctx)
+	suite.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func (suite *AccountArchiveTestSuite) TestImportArchive() {
+	image, err := ioutil.ReadFile("../../../../testrig/media/test-jpeg.jpg")
+	suite.NoError(err)
+
+	outbox := map[string]interface{}{
+		"@context": "https://www.w3.org/ns/activitystreams",
+		"id":       "outbox.json",
+		"type":     "OrderedCollection",
+		"orderedItems": []interface{}{
+			map[string]interface{}{
+				"type": "Create",
+				"object": map[string]interface{}{
+					"id":        "https://old.example.org/users/turtle/statuses/1",
+					"type":      "Note",
+					"published": "2020-01-01T12:00:00Z",
+					"content":   "<p>hello from my old instance</p>",
+					"to":        "https://www.w3.org/ns/activitystreams#Public",
+					"cc":        []string{"https://old.example.org/users/turtle/followers"},
+					"attachment": []interface{}{
+						map[string]interface{}{"type": "Document", "url": "/media_attachments/1.jpg", "name": "a nice picture"},
+					},
+				},
+			},
+			map[string]interface{}{
+				"type": "Create",
+				"object": map[string]interface{}{
+					"id":        "https://old.example.org/users/turtle/statuses/2",
+					"type":      "Note",
+					"published": "2020-01-02T12:00:00Z",
+					"summary":   "secret",
+					"content":   "<p>just for my followers</p>",
+					"to":        []string{"https://old.example.org/users/turtle/followers"},
+				},
+			},
+			map[string]interface{}{
+				"type":   "Announce",
+				"object": "https://example.org/some/status",
+			},
+		},
+	}
+
+	b := &bytes.Buffer{}
+	w := zip.NewWriter(b)
+	f, err := w.Create("outbox.json")
+	suite.NoError(err)
+	suite.NoError(json.NewEncoder(f).Encode(outbox))
+	f, err = w.Create("actor.json")
+	suite.NoError(err)
+	_, err = f.Write([]byte(`{"type":"Person","followers":"https://old.example.org/users/turtle/followers"}`))
+	suite.NoError(err)
+	f, err = w.Create("media_attachments/1.jpg")
+	suite.NoError(err)
+	_, err = f.Write(image)
+	suite.NoError(err)
+	suite.NoError(w.Close())
+
+	before, err := suite.db.CountAccountStatuses(context.Background(), suite.testAccounts["local_account_2"].ID)
+	suite.NoError(err)
+
+	recorder := suite.importArchive(b.Bytes(), "local_account_2")
+	suite.Equal(http.StatusAccepted, recorder.Code)
+	created := &apimodel.AccountArchive{}
+	suite.NoError(json.NewDecoder(recorder.Body).Decode(created))
+	suite.Equal("import", created.Type)
+
+	archive := suite.waitForArchive(created.ID, "local_account_2")
+	suite.Empty(archive.Error)
+	suite.Empty(archive.URL)
+	suite.Equal(2, archive.StatusesCount)
+
+	after, err := suite.db.CountAccountStatuses(context.Background(), suite.testAccounts["local_account_2"].ID)
+	suite.NoError(err)
+	suite.Equal(before+2, after)
+
+	statuses, err := suite.db.GetAccountStatuses(context.Background(), suite.testAccounts["local_account_2"].ID, 0, false, "", false, false)
+	suite.NoError(err)
+	var public, private *gtsmodel.Status
+	for _, s := range statuses {
+		switch s.Content {
+		case "<p>hello from my old instance</p>":
+			public = s
+		case "<p>just for my followers</p>":
+			private = s
+		}
+	}
+	suite.NotNil(public)
+	suite.NotNil(private)
+	suite.Equal(gtsmodel.VisibilityPublic, public.Visibility)
+	suite.True(public.Local)
+	suite.Equal(2020, public.CreatedAt.Year())
+	suite.Len(public.AttachmentIDs, 1)
+	suite.Equal(gtsmodel.VisibilityFollowersOnly, private.Visibility)
+	suite.Equal("secret", private.ContentWarning)
+
+	attachment, err := suite.db.GetAttachmentByID(context.Background(), public.AttachmentIDs[0])
+	suite.NoError(err)
+	suite.Equal(public.ID, attachment.StatusID)
+	suite.Equal("a nice picture", attachment.Description)
+
+	// importing the same archive again shouldn't duplicate anything
+	recorder = suite.importArchive(b.Bytes(), "local_account_2")
+	suite.Equal(http.StatusAccepted, recorder.Code)
+	suite.NoError(json.NewDecoder(recorder.Body).Decode(created))
+	archive = suite.waitForArchive(created.ID, "local_account_2")
+	suite.Equal(0, archive.StatusesCount)
+}
+
+func (suite *AccountArchiveTestSuite) TestImportArchiveMediaTooBig() {
+	outbox := map[string]interface{}{
+		"@context": "https://www.w3.org/ns/activitystreams",
+		"id":       "outbox.json",
+		"type":     "OrderedCollection",
+		"orderedItems": []interface{}{
+			map[string]interface{}{
+				"type": "Create",
+				"object": map[string]interface{}{
+					"id":        "https://old.example.org/users/turtle/statuses/3",
+					"type":      "Note",
+					"published": "2020-01-03T12:00:00Z",
+					"content":   "<p>look at this huge picture</p>",
+					"to":        "https://www.w3.org/ns/activitystreams#Public",
+					"attachment": []interface{}{
+						map[string]interface{}{"type": "Document", "url": "/media_attachments/huge.jpg"},
+					},
+				},
+			},
+		},
+	}
+
+	b := &bytes.Buffer{}
+	w := zip.NewWriter(b)
+	f, err := w.Create("outbox.json")
+	suite.NoError(err)
+	suite.NoError(json.NewEncoder(f).Encode(outbox))
+
+	// this compresses down to almost nothing, but is bigger than the max media size once it's decompressed
+	f, err = w.Create("media_attachments/huge.jpg")
+	suite.NoError(err)
+	_, err = f.Write(make([]byte, suite.config.MediaConfig.MaxImageSize+suite.config.MediaConfig.MaxVideoSize))
+	suite.NoError(err)
+	suite.NoError(w.Close())
+
+	recorder := suite.importArchive(b.Bytes(), "local_account_2")
+	suite.Equal(http.StatusAccepted, recorder.Code)
+	created := &apimodel.AccountArchive{}
+	suite.NoError(json.NewDecoder(recorder.Body).Decode(created))
+
+	// the status should still be imported, just without the attachment
+	archive := suite.waitForArchive(created.ID, "local_account_2")
+	suite.Empty(archive.Error)
+	suite.Equal(1, archive.StatusesCount)
+
+	statuses, err := suite.db.GetAccountStatuses(context.Background(), suite.testAccounts["local_account_2"].ID, 0, false, "", false, false)
+	suite.NoError(err)
+	var imported *gtsmodel.Status
+	for _, s := range statuses {
+		if s.Content == "<p>look at this huge picture</p>" {
+			imported = s
+		}
+	}
+	suite.NotNil(imported)
+	suite.Empty(imported.AttachmentIDs)
+}
+
+func (suite *AccountArchiveTestSuite) TestImportNotZip() {
+	recorder := suite.importArchive([]byte("this is not a zip"), "local_account_2")
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func TestAccountArchiveTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountArchiveTestSuite))
+}
@@ -0,0 +1,200 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// AccountImportPOSTHandler swagger:operation POST /api/v1/accounts/imports accountImportCreate
+//
+// Import a CSV file of follows, blocks, mutes, or domain blocks into your account.
+//
+// The file should be in the format produced by /api/v1/accounts/exports, or by a mastodon export.
+// The import is carried out in the background; use the returned ID to check on its progress.
+// Accounts that aren't known to this instance yet will be looked up, which can take a while for long files.
+//
+// ---
+// tags:
+// - accounts
+//
+// consumes:
+// - multipart/form-data
+//
+// parameters:
+// - name: type
+//   required: true
+//   in: formData
+//   description: What is being imported; one of following, blocks, mutes, or domain_blocks.
+//   type: string
+// - name: data
+//   required: true
+//   in: formData
+//   description: The CSV file to import, at most 1MiB in size.
+//   type: file
+//
+// produces:
+// - application/json
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:follows
+//   - write:blocks
+//   - write:mutes
+//
+// responses:
+//   '202':
+//     description: "The import that was created, which will be carried out in the background."
+//     schema:
+//       "$ref": "#/definitions/accountImport"
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+func (m *Module) AccountImportPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	form := &model.AccountImportRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	accountImport, errWithCode := m.processor.AccountImportCreate(c.Request.Context(), authed, form)
+	if errWithCode != nil {
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, accountImport)
+}
+
+// AccountImportGETHandler swagger:operation GET /api/v1/accounts/imports/{import_id} accountImportGet
+//
+// Check the progress of an import, and the result of each line of the file that has been processed so far.
+//
+// ---
+// tags:
+// - accounts
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: import_id
+//   type: string
+//   description: The id of the import.
+//   in: path
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - read:accounts
+//
+// responses:
+//   '200':
+//     description: "The requested import."
+//     schema:
+//       "$ref": "#/definitions/accountImport"
+//   '401':
+//      description: unauthorized
+//   '404':
+//      description: not found
+func (m *Module) AccountImportGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	importID := c.Param(ImportIDKey)
+	if importID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errors.New("no import id specified").Error()})
+		return
+	}
+
+	accountImport, errWithCode := m.processor.AccountImportGet(c.Request.Context(), authed, importID)
+	if errWithCode != nil {
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, accountImport)
+}
+
+// AccountExportGETHandler swagger:operation GET /api/v1/accounts/exports/{export_type} accountExport
+//
+// Download a CSV file of the accounts you follow, block, or mute, or the domains you block.
+//
+// The files use the same format as mastodon exports, and can be imported using /api/v1/accounts/imports.
+//
+// ---
+// tags:
+// - accounts
+//
+// produces:
+// - text/csv
+//
+// parameters:
+// - name: export_type
+//   type: string
+//   description: What to export; one of following.csv, blocks.csv, mutes.csv, or domain_blocks.csv.
+//   in: path
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - read:follows
+//   - read:blocks
+//   - read:mutes
+//
+// responses:
+//   '200':
+//     description: "The requested CSV file."
+//   '401':
+//      description: unauthorized
+//   '404':
+//      description: not found
+func (m *Module) AccountExportGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	exportType := c.Param(ExportTypeKey)
+
+	b, errWithCode := m.processor.AccountExport(c.Request.Context(), authed, exportType)
+	if errWithCode != nil {
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename=\""+exportType+"\"")
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", b)
+}
@@ -0,0 +1,235 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/account"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type AccountImportTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *AccountImportTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testAttachments = testrig.NewTestAttachments()
+	suite.testStatuses = testrig.NewTestStatuses()
+}
+
+func (suite *AccountImportTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.storage = testrig.NewTestStorage()
+	suite.log = testrig.NewTestLog()
+	suite.federator = testrig.NewTestFederator(suite.db, testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db), suite.storage)
+	suite.processor = testrig.NewTestProcessor(suite.db, suite.storage, suite.federator)
+	suite.accountModule = account.New(suite.config, suite.processor, suite.log).(*account.Module)
+	testrig.StandardDBSetup(suite.db, nil)
+	testrig.StandardStorageSetup(suite.storage, "../../../../testrig/media")
+}
+
+func (suite *AccountImportTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StandardStorageTeardown(suite.storage)
+}
+
+func (suite *AccountImportTestSuite) newContext(recorder *httptest.ResponseRecorder, request *http.Request) *gin.Context {
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Request = request
+	return ctx
+}
+
+func (suite *AccountImportTestSuite) importRequest(importType string, data string) *httptest.ResponseRecorder {
+	b := &bytes.Buffer{}
+	w := multipart.NewWriter(b)
+	suite.NoError(w.WriteField("type", importType))
+	fw, err := w.CreateFormFile("data", "import.csv")
+	suite.NoError(err)
+	_, err = fw.Write([]byte(data))
+	suite.NoError(err)
+	suite.NoError(w.Close())
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080%s", account.ImportsPath), b) // the endpoint we're hitting
+	request.Header.Set("Content-Type", w.FormDataContentType())
+	suite.accountModule.AccountImportPOSTHandler(suite.newContext(recorder, request))
+	return recorder
+}
+
+func (suite *AccountImportTestSuite) getImport(importID string) *apimodel.AccountImport {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:8080/api/v1/accounts/imports/%s", importID), nil)
+	ctx := suite.newContext(recorder, request)
+	ctx.Params = gin.Params{gin.Param{Key: account.ImportIDKey, Value: importID}}
+	suite.accountModule.AccountImportGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	accountImport := &apimodel.AccountImport{}
+	suite.NoError(json.NewDecoder(recorder.Body).Decode(accountImport))
+	return accountImport
+}
+
+func (suite *AccountImportTestSuite) export(exportType string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:8080/api/v1/accounts/exports/%s", exportType), nil)
+	ctx := suite.newContext(recorder, request)
+	ctx.Params = gin.Params{gin.Param{Key: account.ExportTypeKey, Value: exportType}}
+	suite.accountModule.AccountExportGETHandler(ctx)
+	return recorder
+}
+
+func (suite *AccountImportTestSuite) TestExportFollowing() {
+	recorder := suite.export("following.csv")
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("text/csv; charset=utf-8", recorder.Header().Get("Content-Type"))
+
+	b, err := ioutil.ReadAll(recorder.Body)
+	suite.NoError(err)
+	suite.Contains(string(b), "Account address,Show boosts,Notify on new posts,Languages\n")
+	suite.Contains(string(b), "admin@localhost:8080,true,false,\n")
+	suite.Contains(string(b), "1happyturtle@localhost:8080,true,false,\n")
+}
+
+func (suite *AccountImportTestSuite) TestExportUnknownType() {
+	recorder := suite.export("lists.csv")
+	suite.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func (suite *AccountImportTestSuite) TestImportBlocks() {
+	data := "@foss_satan@fossbros-anonymous.io\nnot an address\nthe_mighty_zork@localhost:8080\n\n1happyturtle@localhost:8080\n"
+
+	recorder := suite.importRequest("blocks", data)
+	suite.Equal(http.StatusAccepted, recorder.Code)
+
+	created := &apimodel.AccountImport{}
+	suite.NoError(json.NewDecoder(recorder.Body).Decode(created))
+	suite.Equal("blocks", created.Type)
+	suite.Equal(4, created.Total)
+
+	// the import runs in the background, so wait for it to finish
+	var accountImport *apimodel.AccountImport
+	for i := 0; i < 50; i++ {
+		accountImport = suite.getImport(created.ID)
+		if accountImport.Finished {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	suite.True(accountImport.Finished)
+	suite.Equal(4, accountImport.Processed)
+	suite.Equal(2, accountImport.Succeeded)
+	suite.Equal(2, accountImport.Failed)
+
+	suite.Equal("foss_satan@fossbros-anonymous.io", accountImport.Results[0].Account)
+	suite.Equal("succeeded", accountImport.Results[0].Result)
+	suite.Equal(2, accountImport.Results[1].Line)
+	suite.Equal("failed", accountImport.Results[1].Result)
+	suite.Equal("not a valid account address", accountImport.Results[1].Error)
+	suite.Equal("failed", accountImport.Results[2].Result)
+	suite.Equal("can't import your own account", accountImport.Results[2].Error)
+	suite.Equal("succeeded", accountImport.Results[3].Result)
+
+	blocked, err := suite.db.IsBlocked(context.Background(), suite.testAccounts["local_account_1"].ID, suite.testAccounts["remote_account_1"].ID, false)
+	suite.NoError(err)
+	suite.True(blocked)
+	blocked, err = suite.db.IsBlocked(context.Background(), suite.testAccounts["local_account_1"].ID, suite.testAccounts["local_account_2"].ID, false)
+	suite.NoError(err)
+	suite.True(blocked)
+}
+
+func (suite *AccountImportTestSuite) TestImportDomainBlocks() {
+	recorder := suite.importRequest("domain_blocks", "example.org\nlocalhost:8080\nFossbros-Anonymous.io\n")
+	suite.Equal(http.StatusAccepted, recorder.Code)
+
+	created := &apimodel.AccountImport{}
+	suite.NoError(json.NewDecoder(recorder.Body).Decode(created))
+	suite.Equal("domain_blocks", created.Type)
+	suite.Equal(3, created.Total)
+
+	// the import runs in the background, so wait for it to finish
+	var accountImport *apimodel.AccountImport
+	for i := 0; i < 50; i++ {
+		accountImport = suite.getImport(created.ID)
+		if accountImport.Finished {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	suite.True(accountImport.Finished)
+	suite.Equal(2, accountImport.Succeeded)
+	suite.Equal(1, accountImport.Failed)
+	suite.Equal("failed", accountImport.Results[1].Result)
+	suite.Contains(accountImport.Results[1].Error, "you can't block the domain of this instance")
+
+	blocked, err := suite.db.IsDomainBlockedByAccount(context.Background(), suite.testAccounts["local_account_1"].ID, "fossbros-anonymous.io")
+	suite.NoError(err)
+	suite.True(blocked)
+
+	// the blocked domains can be exported again as a bare list
+	recorder = suite.export("domain_blocks.csv")
+	suite.Equal(http.StatusOK, recorder.Code)
+	b, err := ioutil.ReadAll(recorder.Body)
+	suite.NoError(err)
+	suite.Contains(string(b), "example.org\n")
+	suite.Contains(string(b), "fossbros-anonymous.io\n")
+	suite.NotContains(string(b), "Account address")
+}
+
+func (suite *AccountImportTestSuite) TestImportFileTooBig() {
+	recorder := suite.importRequest("blocks", strings.Repeat("foss_satan@fossbros-anonymous.io\n", 40000))
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+
+	b, err := ioutil.ReadAll(recorder.Body)
+	suite.NoError(err)
+	suite.Contains(string(b), "file is too big")
+}
+
+func (suite *AccountImportTestSuite) TestImportEmptyFile() {
+	recorder := suite.importRequest("following", "Account address,Show boosts,Notify on new posts,Languages\n")
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func TestAccountImportTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountImportTestSuite))
+}
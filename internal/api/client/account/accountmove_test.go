@@ -0,0 +1,143 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/account"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type AccountMoveTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *AccountMoveTestSuite) SetupTest() {
+	// moving modifies the authed account, so get fresh models for each test
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testAttachments = testrig.NewTestAttachments()
+	suite.testStatuses = testrig.NewTestStatuses()
+
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.storage = testrig.NewTestStorage()
+	suite.log = testrig.NewTestLog()
+	suite.federator = testrig.NewTestFederator(suite.db, testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db), suite.storage)
+	suite.processor = testrig.NewTestProcessor(suite.db, suite.storage, suite.federator)
+	suite.accountModule = account.New(suite.config, suite.processor, suite.log).(*account.Module)
+	testrig.StandardDBSetup(suite.db, nil)
+	testrig.StandardStorageSetup(suite.storage, "../../../../testrig/media")
+}
+
+func (suite *AccountMoveTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StandardStorageTeardown(suite.storage)
+}
+
+func (suite *AccountMoveTestSuite) moveRequest(body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080%s", account.MovePath), bytes.NewReader([]byte(body))) // the endpoint we're hitting
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	suite.accountModule.AccountMovePOSTHandler(ctx)
+	return recorder
+}
+
+// addAlias makes local_account_2 list local_account_1 as an alias, so that local_account_1 can move to it.
+func (suite *AccountMoveTestSuite) addAlias() {
+	target := suite.testAccounts["local_account_2"]
+	target.AlsoKnownAs = []string{suite.testAccounts["local_account_1"].URI}
+	_, err := suite.db.UpdateAccount(context.Background(), target)
+	suite.NoError(err)
+}
+
+func (suite *AccountMoveTestSuite) TestAccountMove() {
+	suite.addAlias()
+	origin := suite.testAccounts["local_account_1"]
+	target := suite.testAccounts["local_account_2"]
+
+	recorder := suite.moveRequest(fmt.Sprintf(`{"target":"%s","password":"password"}`, target.URI))
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	movedAccount := &apimodel.Account{}
+	suite.NoError(json.Unmarshal(b, movedAccount))
+	suite.NotNil(movedAccount.Moved)
+	suite.Equal(target.ID, movedAccount.Moved.ID)
+
+	dbAccount, err := suite.db.GetAccountByID(context.Background(), origin.ID)
+	suite.NoError(err)
+	suite.Equal(target.ID, dbAccount.MovedToAccountID)
+
+	// moving again shouldn't work
+	recorder = suite.moveRequest(fmt.Sprintf(`{"target":"%s","password":"password"}`, target.URI))
+	suite.Equal(http.StatusUnprocessableEntity, recorder.Code)
+}
+
+func (suite *AccountMoveTestSuite) TestAccountMoveNotAlias() {
+	target := suite.testAccounts["local_account_2"]
+
+	recorder := suite.moveRequest(fmt.Sprintf(`{"target":"%s","password":"password"}`, target.URI))
+	suite.Equal(http.StatusUnprocessableEntity, recorder.Code)
+
+	dbAccount, err := suite.db.GetAccountByID(context.Background(), suite.testAccounts["local_account_1"].ID)
+	suite.NoError(err)
+	suite.Empty(dbAccount.MovedToAccountID)
+}
+
+func (suite *AccountMoveTestSuite) TestAccountMoveWrongPassword() {
+	suite.addAlias()
+	target := suite.testAccounts["local_account_2"]
+
+	recorder := suite.moveRequest(fmt.Sprintf(`{"target":"%s","password":"not the password"}`, target.URI))
+	suite.Equal(http.StatusForbidden, recorder.Code)
+}
+
+func (suite *AccountMoveTestSuite) TestAccountMoveToSelf() {
+	recorder := suite.moveRequest(fmt.Sprintf(`{"target":"%s","password":"password"}`, suite.testAccounts["local_account_1"].URI))
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func TestAccountMoveTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountMoveTestSuite))
+}
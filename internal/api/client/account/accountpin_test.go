@@ -0,0 +1,122 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/account"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type AccountPinTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *AccountPinTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testAttachments = testrig.NewTestAttachments()
+	suite.testStatuses = testrig.NewTestStatuses()
+}
+
+func (suite *AccountPinTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.storage = testrig.NewTestStorage()
+	suite.log = testrig.NewTestLog()
+	suite.federator = testrig.NewTestFederator(suite.db, testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db), suite.storage)
+	suite.processor = testrig.NewTestProcessor(suite.db, suite.storage, suite.federator)
+	suite.accountModule = account.New(suite.config, suite.processor, suite.log).(*account.Module)
+	testrig.StandardDBSetup(suite.db, nil)
+	testrig.StandardStorageSetup(suite.storage, "../../../../testrig/media")
+}
+
+func (suite *AccountPinTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StandardStorageTeardown(suite.storage)
+}
+
+func (suite *AccountPinTestSuite) pinRequest(path string, targetAccountID string, handler func(*gin.Context)) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080%s", strings.Replace(path, ":"+account.IDKey, targetAccountID, 1)), nil) // the endpoint we're hitting
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   account.IDKey,
+			Value: targetAccountID,
+		},
+	}
+	handler(ctx)
+	return recorder
+}
+
+func (suite *AccountPinTestSuite) TestPinAndUnpinAccount() {
+	targetAccount := suite.testAccounts["local_account_2"]
+
+	recorder := suite.pinRequest(account.PinPath, targetAccount.ID, suite.accountModule.AccountPinPOSTHandler)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := ioutil.ReadAll(recorder.Body)
+	suite.NoError(err)
+	relationship := &apimodel.Relationship{}
+	suite.NoError(json.Unmarshal(b, relationship))
+	suite.True(relationship.Endorsed)
+
+	recorder = suite.pinRequest(account.UnpinPath, targetAccount.ID, suite.accountModule.AccountUnpinPOSTHandler)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err = ioutil.ReadAll(recorder.Body)
+	suite.NoError(err)
+	relationship = &apimodel.Relationship{}
+	suite.NoError(json.Unmarshal(b, relationship))
+	suite.False(relationship.Endorsed)
+}
+
+func (suite *AccountPinTestSuite) TestPinNotFollowedAccount() {
+	// local_account_1 doesn't follow remote_account_1
+	recorder := suite.pinRequest(account.PinPath, suite.testAccounts["remote_account_1"].ID, suite.accountModule.AccountPinPOSTHandler)
+	suite.Equal(http.StatusUnprocessableEntity, recorder.Code)
+}
+
+func (suite *AccountPinTestSuite) TestPinSelf() {
+	recorder := suite.pinRequest(account.PinPath, suite.testAccounts["local_account_1"].ID, suite.accountModule.AccountPinPOSTHandler)
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func TestAccountPinTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountPinTestSuite))
+}
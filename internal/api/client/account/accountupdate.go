@@ -83,6 +83,40 @@ import (
 //   in: formData
 //   description: Default language to use for authored statuses (ISO 6391).
 //   type: string
+// - name: source[expand_media]
+//   in: formData
+//   description: |-
+//     How media attachments should be shown by default.
+//     One of default (hide media marked as sensitive), show_all, or hide_all.
+//   type: string
+// - name: source[expand_spoilers]
+//   in: formData
+//   description: Expand content warnings by default.
+//   type: boolean
+// - name: source[email_notify_follow]
+//   in: formData
+//   description: Include new followers in notification digest emails.
+//   type: boolean
+// - name: source[email_notify_follow_request]
+//   in: formData
+//   description: Include new follow requests in notification digest emails.
+//   type: boolean
+// - name: source[email_notify_mention]
+//   in: formData
+//   description: Include mentions in notification digest emails.
+//   type: boolean
+// - name: source[email_notify_direct]
+//   in: formData
+//   description: Include direct messages in notification digest emails.
+//   type: boolean
+// - name: also_known_as
+//   in: formData
+//   description: |-
+//     ActivityPub URIs of other accounts that this account is also known as.
+//     Accounts listed here are allowed to move to this account. Replaces any existing aliases.
+//   type: array
+//   items:
+//     type: string
 //
 // security:
 // - OAuth2 Bearer:
@@ -116,7 +150,7 @@ func (m *Module) AccountUpdateCredentialsPATCHHandler(c *gin.Context) {
 	}
 
 	// if everything on the form is nil, then nothing has been set and we shouldn't continue
-	if form.Discoverable == nil && form.Bot == nil && form.DisplayName == nil && form.Note == nil && form.Avatar == nil && form.Header == nil && form.Locked == nil && form.Source == nil && form.FieldsAttributes == nil {
+	if form.Discoverable == nil && form.Bot == nil && form.DisplayName == nil && form.Note == nil && form.Avatar == nil && form.Header == nil && form.Locked == nil && form.Source == nil && form.FieldsAttributes == nil && form.AlsoKnownAs == nil {
 		l.Debugf("could not parse form from request")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "empty form submitted"})
 		return
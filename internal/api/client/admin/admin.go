@@ -37,11 +37,43 @@ const (
 	DomainBlocksPath = BasePath + "/domain_blocks"
 	// DomainBlocksPathWithID is used for interacting with a single domain block.
 	DomainBlocksPathWithID = DomainBlocksPath + "/:" + IDKey
+	// ReportsPath is used for viewing the moderation queue.
+	ReportsPath = BasePath + "/reports"
+	// ReportsPathWithID is used for interacting with a single report.
+	ReportsPathWithID = ReportsPath + "/:" + IDKey
+	// ReportAssignToSelfPath is used for assigning a report to the requesting admin.
+	ReportAssignToSelfPath = ReportsPathWithID + "/assign_to_self"
+	// ReportUnassignPath is used for removing the assigned admin from a report.
+	ReportUnassignPath = ReportsPathWithID + "/unassign"
+	// ReportResolvePath is used for marking a report as resolved.
+	ReportResolvePath = ReportsPathWithID + "/resolve"
+	// ReportReopenPath is used for marking a report as unresolved again.
+	ReportReopenPath = ReportsPathWithID + "/reopen"
+	// ReportActionPath is used for taking action against the target account of a report.
+	ReportActionPath = ReportsPathWithID + "/action"
+	// FeaturedAccountsPath is used for viewing and creating accounts featured as follow suggestions.
+	FeaturedAccountsPath = BasePath + "/featured_accounts"
+	// FeaturedAccountsPathWithID is used for removing an account from the featured accounts.
+	FeaturedAccountsPathWithID = FeaturedAccountsPath + "/:" + IDKey
 
 	// ExportQueryKey is for requesting a public export of some data.
 	ExportQueryKey = "export"
 	// ImportQueryKey is for submitting an import of some data.
 	ImportQueryKey = "import"
+	// ResolvedKey is for filtering reports by whether they have been resolved.
+	ResolvedKey = "resolved"
+	// AccountIDKey is for filtering reports by the account that made them.
+	AccountIDKey = "account_id"
+	// TargetAccountIDKey is for filtering reports by the account they target.
+	TargetAccountIDKey = "target_account_id"
+	// MaxIDKey is for paging: return items older than this ID.
+	MaxIDKey = "max_id"
+	// SinceIDKey is for paging: return items newer than this ID.
+	SinceIDKey = "since_id"
+	// MinIDKey is for paging: return items immediately newer than this ID.
+	MinIDKey = "min_id"
+	// LimitKey is for paging: the maximum number of items to return.
+	LimitKey = "limit"
 	// IDKey specifies the ID of a single item being interacted with.
 	IDKey = "id"
 )
@@ -69,5 +101,15 @@ func (m *Module) Route(r router.Router) error {
 	r.AttachHandler(http.MethodGet, DomainBlocksPath, m.DomainBlocksGETHandler)
 	r.AttachHandler(http.MethodGet, DomainBlocksPathWithID, m.DomainBlockGETHandler)
 	r.AttachHandler(http.MethodDelete, DomainBlocksPathWithID, m.DomainBlockDELETEHandler)
+	r.AttachHandler(http.MethodGet, ReportsPath, m.ReportsGETHandler)
+	r.AttachHandler(http.MethodGet, ReportsPathWithID, m.ReportGETHandler)
+	r.AttachHandler(http.MethodPost, ReportAssignToSelfPath, m.ReportAssignToSelfPOSTHandler)
+	r.AttachHandler(http.MethodPost, ReportUnassignPath, m.ReportUnassignPOSTHandler)
+	r.AttachHandler(http.MethodPost, ReportResolvePath, m.ReportResolvePOSTHandler)
+	r.AttachHandler(http.MethodPost, ReportReopenPath, m.ReportReopenPOSTHandler)
+	r.AttachHandler(http.MethodPost, ReportActionPath, m.ReportActionPOSTHandler)
+	r.AttachHandler(http.MethodGet, FeaturedAccountsPath, m.FeaturedAccountsGETHandler)
+	r.AttachHandler(http.MethodPost, FeaturedAccountsPath, m.FeaturedAccountPOSTHandler)
+	r.AttachHandler(http.MethodDelete, FeaturedAccountsPathWithID, m.FeaturedAccountDELETEHandler)
 	return nil
 }
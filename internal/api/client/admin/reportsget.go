@@ -0,0 +1,138 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// ReportsGETHandler swagger:operation GET /api/v1/admin/reports reportsGet
+//
+// View reports in the moderation queue.
+//
+// Reports are sorted newest first. By default only unresolved reports are returned.
+//
+// The next and previous queries can be parsed from the returned Link header.
+//
+// ---
+// tags:
+// - admin
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: resolved
+//   type: boolean
+//   description: If true, return resolved reports instead of unresolved ones.
+//   default: false
+//   in: query
+// - name: account_id
+//   type: string
+//   description: Return only reports made by the account with this ID.
+//   in: query
+// - name: target_account_id
+//   type: string
+//   description: Return only reports targeting the account with this ID.
+//   in: query
+// - name: limit
+//   type: integer
+//   description: Number of reports to return.
+//   default: 20
+//   in: query
+// - name: max_id
+//   type: string
+//   description: Return only reports *OLDER* than the given report ID.
+//   in: query
+// - name: since_id
+//   type: string
+//   description: Return only reports *NEWER* than the given report ID.
+//   in: query
+// - name: min_id
+//   type: string
+//   description: Return only reports immediately *NEWER* than the given report ID.
+//   in: query
+//
+// security:
+// - OAuth2 Bearer:
+//   - admin
+//
+// responses:
+//   '200':
+//     headers:
+//       Link:
+//         type: string
+//         description: Links to the next and previous queries.
+//     schema:
+//       type: array
+//       items:
+//         "$ref": "#/definitions/adminReport"
+//   '403':
+//      description: forbidden
+//   '400':
+//      description: bad request
+func (m *Module) ReportsGETHandler(c *gin.Context) {
+	l := m.log.WithFields(logrus.Fields{
+		"func":        "ReportsGETHandler",
+		"request_uri": c.Request.RequestURI,
+		"user_agent":  c.Request.UserAgent(),
+		"origin_ip":   c.ClientIP(),
+	})
+
+	// make sure we're authed with an admin account
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("couldn't auth: %s", err)
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if !authed.User.Admin {
+		l.Debugf("user %s not an admin", authed.User.ID)
+		c.JSON(http.StatusForbidden, gin.H{"error": "not an admin"})
+		return
+	}
+
+	resolved := false
+	resolvedString := c.Query(ResolvedKey)
+	if resolvedString != "" {
+		i, err := strconv.ParseBool(resolvedString)
+		if err != nil {
+			l.Debugf("error parsing resolved string: %s", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse resolved query param"})
+			return
+		}
+		resolved = i
+	}
+
+	limit := 20
+	limitString := c.Query(LimitKey)
+	if limitString != "" {
+		i, err := strconv.ParseInt(limitString, 10, 64)
+		if err != nil {
+			l.Debugf("error parsing limit string: %s", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse limit query param"})
+			return
+		}
+		limit = int(i)
+	}
+
+	// don't let anyone page through too many at once
+	if limit > 200 {
+		limit = 200
+	}
+
+	resp, errWithCode := m.processor.AdminReportsGet(c.Request.Context(), authed, resolved, c.Query(AccountIDKey), c.Query(TargetAccountIDKey), c.Query(MaxIDKey), c.Query(SinceIDKey), c.Query(MinIDKey), limit)
+	if errWithCode != nil {
+		l.Debugf("error from processor AdminReportsGet: %s", errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	if resp.LinkHeader != "" {
+		c.Header("Link", resp.LinkHeader)
+	}
+	c.JSON(http.StatusOK, resp.Reports)
+}
@@ -0,0 +1,199 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// ReportAssignToSelfPOSTHandler swagger:operation POST /api/v1/admin/reports/{id}/assign_to_self reportAssignToSelf
+//
+// Assign the report with the given ID to the requesting admin.
+//
+// ---
+// tags:
+// - admin
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: The id of the report.
+//   in: path
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - admin
+//
+// responses:
+//   '200':
+//     description: The updated report.
+//     schema:
+//       "$ref": "#/definitions/adminReport"
+//   '403':
+//      description: forbidden
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) ReportAssignToSelfPOSTHandler(c *gin.Context) {
+	m.reportUpdate(c, "ReportAssignToSelfPOSTHandler", m.processor.AdminReportAssignToSelf)
+}
+
+// ReportUnassignPOSTHandler swagger:operation POST /api/v1/admin/reports/{id}/unassign reportUnassign
+//
+// Remove the assigned admin from the report with the given ID.
+//
+// ---
+// tags:
+// - admin
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: The id of the report.
+//   in: path
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - admin
+//
+// responses:
+//   '200':
+//     description: The updated report.
+//     schema:
+//       "$ref": "#/definitions/adminReport"
+//   '403':
+//      description: forbidden
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) ReportUnassignPOSTHandler(c *gin.Context) {
+	m.reportUpdate(c, "ReportUnassignPOSTHandler", m.processor.AdminReportUnassign)
+}
+
+// ReportResolvePOSTHandler swagger:operation POST /api/v1/admin/reports/{id}/resolve reportResolve
+//
+// Mark the report with the given ID as resolved, without taking any action against the reported account.
+//
+// ---
+// tags:
+// - admin
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: The id of the report.
+//   in: path
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - admin
+//
+// responses:
+//   '200':
+//     description: The resolved report.
+//     schema:
+//       "$ref": "#/definitions/adminReport"
+//   '403':
+//      description: forbidden
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) ReportResolvePOSTHandler(c *gin.Context) {
+	m.reportUpdate(c, "ReportResolvePOSTHandler", m.processor.AdminReportResolve)
+}
+
+// ReportReopenPOSTHandler swagger:operation POST /api/v1/admin/reports/{id}/reopen reportReopen
+//
+// Mark the report with the given ID as unresolved again.
+//
+// ---
+// tags:
+// - admin
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: The id of the report.
+//   in: path
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - admin
+//
+// responses:
+//   '200':
+//     description: The reopened report.
+//     schema:
+//       "$ref": "#/definitions/adminReport"
+//   '403':
+//      description: forbidden
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) ReportReopenPOSTHandler(c *gin.Context) {
+	m.reportUpdate(c, "ReportReopenPOSTHandler", m.processor.AdminReportReopen)
+}
+
+// reportUpdate handles the common parts of the simple report state changes: authing an admin, checking
+// the report id, and passing the request on to the given processor function.
+func (m *Module) reportUpdate(c *gin.Context, handlerName string, update func(context.Context, *oauth.Auth, string) (*apimodel.AdminReport, gtserror.WithCode)) {
+	l := m.log.WithFields(logrus.Fields{
+		"func":        handlerName,
+		"request_uri": c.Request.RequestURI,
+		"user_agent":  c.Request.UserAgent(),
+		"origin_ip":   c.ClientIP(),
+	})
+
+	// make sure we're authed with an admin account
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("couldn't auth: %s", err)
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if !authed.User.Admin {
+		l.Debugf("user %s not an admin", authed.User.ID)
+		c.JSON(http.StatusForbidden, gin.H{"error": "not an admin"})
+		return
+	}
+
+	reportID := c.Param(IDKey)
+	if reportID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no report id provided"})
+		return
+	}
+
+	report, errWithCode := update(c.Request.Context(), authed, reportID)
+	if errWithCode != nil {
+		l.Debugf("error updating report: %s", errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -28,8 +28,18 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/router"
 )
 
-// BasePath is the base path for this api module
-const BasePath = "/api/v1/apps"
+const (
+	// IDKey is for specifying the id of an application in the path
+	IDKey = "id"
+	// BasePath is the base path for this api module
+	BasePath = "/api/v1/apps"
+	// VerifyPath is for checking that an application's token works
+	VerifyPath = BasePath + "/verify_credentials"
+	// AuthorizedPath is for listing the applications that a user has allowed to use their account
+	AuthorizedPath = BasePath + "/authorized"
+	// AuthorizedPathWithID is for revoking one authorized application
+	AuthorizedPathWithID = AuthorizedPath + "/:" + IDKey
+)
 
 // Module implements the ClientAPIModule interface for requests relating to registering/removing applications
 type Module struct {
@@ -50,5 +60,8 @@ func New(config *config.Config, processor processing.Processor, log *logrus.Logg
 // Route satisfies the RESTAPIModule interface
 func (m *Module) Route(s router.Router) error {
 	s.AttachHandler(http.MethodPost, BasePath, m.AppsPOSTHandler)
+	s.AttachHandler(http.MethodGet, VerifyPath, m.AppVerifyGETHandler)
+	s.AttachHandler(http.MethodGet, AuthorizedPath, m.AuthorizedAppsGETHandler)
+	s.AttachHandler(http.MethodDelete, AuthorizedPathWithID, m.AuthorizedAppDELETEHandler)
 	return nil
 }
@@ -0,0 +1,130 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// AuthorizedAppsGETHandler swagger:operation GET /api/v1/apps/authorized authorizedAppsGet
+//
+// List the applications that the requesting user has allowed to use their account, oldest first.
+//
+// ---
+// tags:
+// - apps
+//
+// produces:
+// - application/json
+//
+// security:
+// - OAuth2 Bearer:
+//   - read:accounts
+//
+// responses:
+//   '200':
+//     description: The authorized applications.
+//     schema:
+//       type: array
+//       items:
+//         "$ref": "#/definitions/authorizedApplication"
+//   '401':
+//      description: unauthorized
+func (m *Module) AuthorizedAppsGETHandler(c *gin.Context) {
+	l := m.log.WithField("func", "AuthorizedAppsGETHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	apps, errWithCode := m.processor.AppsAuthorizedGet(c.Request.Context(), authed)
+	if errWithCode != nil {
+		l.Debugf("error from processor AppsAuthorizedGet: %s", errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, apps)
+}
+
+// AuthorizedAppDELETEHandler swagger:operation DELETE /api/v1/apps/authorized/{id} authorizedAppRevoke
+//
+// Revoke an application's access to the requesting user's account.
+//
+// All of the tokens that the user has given to the application stop working straight away,
+// and any streaming connections that the application has open with them are closed.
+// This includes the token used to make this request, if it belongs to the same application.
+//
+// ---
+// tags:
+// - apps
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: The id of the application.
+//   in: path
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:accounts
+//
+// responses:
+//   '200':
+//     description: The application's access was revoked.
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) AuthorizedAppDELETEHandler(c *gin.Context) {
+	l := m.log.WithField("func", "AuthorizedAppDELETEHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	appID := c.Param(IDKey)
+	if appID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no application id provided"})
+		return
+	}
+
+	if errWithCode := m.processor.AppAuthorizedRevoke(c.Request.Context(), authed, appID); errWithCode != nil {
+		l.Debugf("error from processor AppAuthorizedRevoke: %s", errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
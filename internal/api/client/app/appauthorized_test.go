@@ -0,0 +1,157 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package app_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/app"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type AuthorizedAppsTestSuite struct {
+	AppStandardTestSuite
+}
+
+func (suite *AuthorizedAppsTestSuite) getAuthorized() []*model.AuthorizedApplication {
+	recorder := httptest.NewRecorder()
+	suite.appModule.AuthorizedAppsGETHandler(suite.newContext(recorder, http.MethodGet, app.AuthorizedPath))
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	apps := []*model.AuthorizedApplication{}
+	suite.NoError(json.NewDecoder(recorder.Body).Decode(&apps))
+	return apps
+}
+
+func (suite *AuthorizedAppsTestSuite) revoke(appID string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodDelete, app.AuthorizedPath+"/"+appID)
+	ctx.Params = gin.Params{gin.Param{Key: app.IDKey, Value: appID}}
+	suite.appModule.AuthorizedAppDELETEHandler(ctx)
+	return recorder
+}
+
+func (suite *AuthorizedAppsTestSuite) TestVerifyCredentials() {
+	recorder := httptest.NewRecorder()
+	suite.appModule.AppVerifyGETHandler(suite.newContext(recorder, http.MethodGet, app.VerifyPath))
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	verified := &model.Application{}
+	suite.NoError(json.NewDecoder(recorder.Body).Decode(verified))
+
+	testApp := suite.testApplications["application_1"]
+	suite.Equal(testApp.Name, verified.Name)
+	suite.Equal(testApp.Website, verified.Website)
+	suite.Equal(testApp.VapidKey, verified.VapidKey)
+	suite.Empty(verified.ClientID)
+	suite.Empty(verified.ClientSecret)
+}
+
+func (suite *AuthorizedAppsTestSuite) TestListAuthorizedApps() {
+	// give application_2 a couple of tokens for zork as well, one of which isn't exchanged for an access token yet
+	now := time.Now()
+	for _, t := range []*oauth.Token{
+		{
+			ID:             "01FD2K0C7A3Q2PH2TSCMC8SQEE",
+			ClientID:       suite.testApplications["application_2"].ClientID,
+			UserID:         suite.testUsers["local_account_1"].ID,
+			Scope:          "read",
+			Access:         "SOMEOTHERACCESSTOKEN",
+			AccessCreateAt: now.Add(-48 * time.Hour),
+		},
+		{
+			ID:             "01FD2K0C7A3Q2PH2TSCMC8SQEF",
+			ClientID:       suite.testApplications["application_2"].ClientID,
+			UserID:         suite.testUsers["local_account_1"].ID,
+			Scope:          "write",
+			Access:         "YETANOTHERACCESSTOKEN",
+			AccessCreateAt: now.Add(-24 * time.Hour),
+		},
+		{
+			ID:           "01FD2K0C7A3Q2PH2TSCMC8SQEG",
+			ClientID:     suite.testApplications["admin_account"].ClientID,
+			UserID:       suite.testUsers["local_account_1"].ID,
+			Scope:        "read write",
+			Code:         "SOMECODE",
+			CodeCreateAt: now,
+		},
+	} {
+		suite.NoError(suite.db.Put(context.Background(), t))
+	}
+
+	apps := suite.getAuthorized()
+	suite.Len(apps, 2)
+
+	// oldest first
+	suite.Equal(suite.testApplications["application_2"].ID, apps[0].ID)
+	suite.Equal("kindaweird", apps[0].Name)
+	suite.Equal([]string{"read", "write"}, apps[0].Scopes)
+	suite.Equal(now.Add(-48*time.Hour).Format(time.RFC3339), apps[0].CreatedAt)
+
+	suite.Equal(suite.testApplications["application_1"].ID, apps[1].ID)
+	suite.Equal([]string{"follow", "push", "read", "write"}, apps[1].Scopes)
+}
+
+func (suite *AuthorizedAppsTestSuite) TestRevokeAuthorizedApp() {
+	token := suite.testTokens["local_account_1"]
+
+	stream, errWithCode := suite.processor.OpenStreamForAccount(context.Background(), suite.testAccounts["local_account_1"], "user", token.Access)
+	suite.NoError(errWithCode)
+
+	recorder := suite.revoke(suite.testApplications["application_1"].ID)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	suite.Empty(suite.getAuthorized())
+
+	// the token should be gone, and the stream opened with it told to hang up
+	dbToken := &oauth.Token{}
+	suite.Error(suite.db.GetByID(context.Background(), token.ID, dbToken))
+	select {
+	case <-stream.Closed:
+	default:
+		suite.Fail("stream was not closed")
+	}
+
+	// other users' tokens for the same app should still be there
+	suite.NoError(suite.db.GetByID(context.Background(), suite.testTokens["local_account_2"].ID, dbToken))
+
+	// and there's nothing left to revoke
+	recorder = suite.revoke(suite.testApplications["application_1"].ID)
+	suite.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func (suite *AuthorizedAppsTestSuite) TestRevokeUnauthorizedApp() {
+	recorder := suite.revoke(suite.testApplications["application_2"].ID)
+	suite.Equal(http.StatusNotFound, recorder.Code)
+
+	recorder = suite.revoke("01FD2K0C7A3Q2PH2TSCMC8SQEH")
+	suite.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func TestAuthorizedAppsTestSuite(t *testing.T) {
+	suite.Run(t, new(AuthorizedAppsTestSuite))
+}
@@ -0,0 +1,67 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// AppVerifyGETHandler swagger:operation GET /api/v1/apps/verify_credentials appVerify
+//
+// Verify that the application token (or a user token) used to make this request works.
+//
+// ---
+// tags:
+// - apps
+//
+// produces:
+// - application/json
+//
+// security:
+// - OAuth2 Bearer: []
+//
+// responses:
+//   '200':
+//     description: "The application that the token belongs to. The client id and secret aren't included."
+//     schema:
+//       "$ref": "#/definitions/application"
+//   '401':
+//      description: unauthorized
+func (m *Module) AppVerifyGETHandler(c *gin.Context) {
+	l := m.log.WithField("func", "AppVerifyGETHandler")
+
+	authed, err := oauth.Authed(c, true, true, false, false)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	mastoApp, errWithCode := m.processor.AppVerifyCredentials(c.Request.Context(), authed)
+	if errWithCode != nil {
+		l.Debugf("error from processor AppVerifyCredentials: %s", errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mastoApp)
+}
@@ -27,14 +27,23 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/oidc"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
 )
 
 const (
 	// AuthSignInPath is the API path for users to sign in through
 	AuthSignInPath = "/auth/sign_in"
+	// AuthTwoFactorPath is the API path for users to give a two-factor authentication code after signing in with their password
+	AuthTwoFactorPath = "/auth/two_factor"
+	// AuthWebauthnPath is the API path for users to sign in with a security key or passkey, either after giving their password or instead of it
+	AuthWebauthnPath = "/auth/webauthn"
+	// AuthWebauthnOptionsPath is the API path for getting the options to pass to the browser before signing in with a security key or passkey
+	AuthWebauthnOptionsPath = AuthWebauthnPath + "/options"
 	// OauthTokenPath is the API path to use for granting token requests to users with valid credentials
 	OauthTokenPath = "/oauth/token"
+	// OauthRevokePath is the API path for clients to revoke tokens that they don't need anymore
+	OauthRevokePath = "/oauth/revoke"
 	// OauthAuthorizePath is the API path for authorization requests (eg., authorize this app to act on my behalf as a user)
 	OauthAuthorizePath = "/oauth/authorize"
 	// CallbackPath is the API path for receiving callback tokens from external OIDC providers
@@ -50,25 +59,31 @@ const (
 	sessionResponseType = "response_type"
 	sessionScope        = "scope"
 	sessionState        = "state"
+
+	sessionTwoFactorUserID   = "two_factor_userid"
+	sessionTwoFactorAttempts = "two_factor_attempts"
+	sessionWebauthnChallenge = "webauthn_challenge"
 )
 
 // Module implements the ClientAPIModule interface for
 type Module struct {
-	config *config.Config
-	db     db.DB
-	server oauth.Server
-	idp    oidc.IDP
-	log    *logrus.Logger
+	config    *config.Config
+	db        db.DB
+	server    oauth.Server
+	idp       oidc.IDP
+	processor processing.Processor
+	log       *logrus.Logger
 }
 
 // New returns a new auth module
-func New(config *config.Config, db db.DB, server oauth.Server, idp oidc.IDP, log *logrus.Logger) api.ClientModule {
+func New(config *config.Config, db db.DB, server oauth.Server, idp oidc.IDP, processor processing.Processor, log *logrus.Logger) api.ClientModule {
 	return &Module{
-		config: config,
-		db:     db,
-		server: server,
-		idp:    idp,
-		log:    log,
+		config:    config,
+		db:        db,
+		server:    server,
+		idp:       idp,
+		processor: processor,
+		log:       log,
 	}
 }
 
@@ -77,7 +92,14 @@ func (m *Module) Route(s router.Router) error {
 	s.AttachHandler(http.MethodGet, AuthSignInPath, m.SignInGETHandler)
 	s.AttachHandler(http.MethodPost, AuthSignInPath, m.SignInPOSTHandler)
 
+	s.AttachHandler(http.MethodGet, AuthTwoFactorPath, m.TwoFactorGETHandler)
+	s.AttachHandler(http.MethodPost, AuthTwoFactorPath, m.TwoFactorPOSTHandler)
+
+	s.AttachHandler(http.MethodPost, AuthWebauthnOptionsPath, m.WebauthnOptionsPOSTHandler)
+	s.AttachHandler(http.MethodPost, AuthWebauthnPath, m.WebauthnPOSTHandler)
+
 	s.AttachHandler(http.MethodPost, OauthTokenPath, m.TokenPOSTHandler)
+	s.AttachHandler(http.MethodPost, OauthRevokePath, m.RevokePOSTHandler)
 
 	s.AttachHandler(http.MethodGet, OauthAuthorizePath, m.AuthorizeGETHandler)
 	s.AttachHandler(http.MethodPost, OauthAuthorizePath, m.AuthorizePOSTHandler)
@@ -0,0 +1,84 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package auth
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	oautherrors "github.com/superseriousbusiness/oauth2/v4/errors"
+)
+
+// revokeBody wraps a token revocation request, see https://datatracker.ietf.org/doc/html/rfc7009#section-2.1
+type revokeBody struct {
+	ClientID      string `form:"client_id" json:"client_id" xml:"client_id"`
+	ClientSecret  string `form:"client_secret" json:"client_secret" xml:"client_secret"`
+	Token         string `form:"token" json:"token" xml:"token"`
+	TokenTypeHint string `form:"token_type_hint" json:"token_type_hint" xml:"token_type_hint"`
+}
+
+// RevokePOSTHandler should be served as a POST at https://example.org/oauth/revoke
+// It lets a client revoke an access token that it doesn't need anymore, eg., when the user logs out of the app,
+// as described in https://datatracker.ietf.org/doc/html/rfc7009. Any streams opened with the token are closed.
+//
+// The client authenticates with its id and secret, either with http basic auth or in the request body.
+// Revoking a token that doesn't exist succeeds, so that clients can't use this to probe for valid tokens.
+func (m *Module) RevokePOSTHandler(c *gin.Context) {
+	l := m.log.WithField("func", "RevokePOSTHandler")
+
+	form := &revokeBody{}
+	if err := c.ShouldBind(form); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": oautherrors.ErrInvalidRequest.Error(), "error_description": err.Error()})
+		return
+	}
+
+	clientID, clientSecret, ok := c.Request.BasicAuth()
+	if !ok {
+		clientID = form.ClientID
+		clientSecret = form.ClientSecret
+	}
+
+	if err := m.server.ValidateClient(c.Request.Context(), clientID, clientSecret); err != nil {
+		if err != oautherrors.ErrInvalidClient {
+			l.Errorf("error validating client: %s", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": oautherrors.ErrServerError.Error()})
+			return
+		}
+		c.Header("WWW-Authenticate", `Basic realm="oauth"`)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": oautherrors.ErrInvalidClient.Error(), "error_description": oautherrors.Descriptions[oautherrors.ErrInvalidClient]})
+		return
+	}
+
+	if form.Token == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": oautherrors.ErrInvalidRequest.Error(), "error_description": "no token provided"})
+		return
+	}
+
+	if errWithCode := m.processor.OAuthRevokeToken(c.Request.Context(), clientID, form.Token, form.TokenTypeHint); errWithCode != nil {
+		l.Debugf("error from processor OAuthRevokeToken: %s", errWithCode)
+		if errWithCode.Code() == http.StatusForbidden {
+			c.JSON(http.StatusForbidden, gin.H{"error": oautherrors.ErrUnauthorizedClient.Error(), "error_description": "the token was not issued to this client"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": oautherrors.ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
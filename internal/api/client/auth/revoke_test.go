@@ -0,0 +1,171 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package auth_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/auth"
+	"github.com/superseriousbusiness/gotosocial/internal/blob"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type RevokeTestSuite struct {
+	suite.Suite
+	db          db.DB
+	storage     blob.Storage
+	oauthServer oauth.Server
+	processor   processing.Processor
+
+	testTokens   map[string]*oauth.Token
+	testClients  map[string]*oauth.Client
+	testAccounts map[string]*gtsmodel.Account
+
+	authModule *auth.Module
+}
+
+func (suite *RevokeTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+	suite.testAccounts = testrig.NewTestAccounts()
+}
+
+func (suite *RevokeTestSuite) SetupTest() {
+	suite.db = testrig.NewTestDB()
+	suite.storage = testrig.NewTestStorage()
+	suite.oauthServer = testrig.NewTestOauthServer(suite.db)
+	federator := testrig.NewTestFederator(suite.db, testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db), suite.storage)
+	suite.processor = testrig.NewTestProcessor(suite.db, suite.storage, federator)
+	suite.authModule = auth.New(testrig.NewTestConfig(), suite.db, suite.oauthServer, nil, suite.processor, testrig.NewTestLog()).(*auth.Module)
+	testrig.StandardDBSetup(suite.db, nil)
+}
+
+func (suite *RevokeTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+}
+
+func (suite *RevokeTestSuite) revoke(form url.Values, basicAuth *oauth.Client) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "http://localhost:8080"+auth.OauthRevokePath, strings.NewReader(form.Encode()))
+	ctx.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if basicAuth != nil {
+		ctx.Request.SetBasicAuth(basicAuth.ID, basicAuth.Secret)
+	}
+	suite.authModule.RevokePOSTHandler(ctx)
+	return recorder
+}
+
+func (suite *RevokeTestSuite) errorCode(recorder *httptest.ResponseRecorder) string {
+	body := map[string]string{}
+	suite.NoError(json.NewDecoder(recorder.Body).Decode(&body))
+	return body["error"]
+}
+
+func (suite *RevokeTestSuite) TestRevokeWithFormCredentials() {
+	token := suite.testTokens["local_account_1"]
+	client := suite.testClients["local_account_1"]
+
+	stream, errWithCode := suite.processor.OpenStreamForAccount(context.Background(), suite.testAccounts["local_account_1"], "user", token.Access)
+	suite.NoError(errWithCode)
+
+	recorder := suite.revoke(url.Values{
+		"client_id":     {client.ID},
+		"client_secret": {client.Secret},
+		"token":         {token.Access},
+	}, nil)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	_, err := suite.oauthServer.LoadAccessToken(context.Background(), token.Access)
+	suite.Error(err)
+
+	// the stream opened with the token should have been told to hang up
+	select {
+	case <-stream.Closed:
+	default:
+		suite.Fail("stream was not closed")
+	}
+}
+
+func (suite *RevokeTestSuite) TestRevokeWithBasicAuth() {
+	token := suite.testTokens["local_account_1"]
+
+	recorder := suite.revoke(url.Values{
+		"token":           {token.Access},
+		"token_type_hint": {"access_token"},
+	}, suite.testClients["local_account_1"])
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	_, err := suite.oauthServer.LoadAccessToken(context.Background(), token.Access)
+	suite.Error(err)
+}
+
+func (suite *RevokeTestSuite) TestRevokeUnknownToken() {
+	recorder := suite.revoke(url.Values{
+		"token": {"not a real token"},
+	}, suite.testClients["local_account_1"])
+	suite.Equal(http.StatusOK, recorder.Code)
+}
+
+func (suite *RevokeTestSuite) TestRevokeBadClientSecret() {
+	client := suite.testClients["local_account_1"]
+
+	recorder := suite.revoke(url.Values{
+		"client_id":     {client.ID},
+		"client_secret": {"wrong"},
+		"token":         {suite.testTokens["local_account_1"].Access},
+	}, nil)
+	suite.Equal(http.StatusUnauthorized, recorder.Code)
+	suite.Equal("invalid_client", suite.errorCode(recorder))
+}
+
+func (suite *RevokeTestSuite) TestRevokeOtherClientsToken() {
+	token := suite.testTokens["local_account_1"]
+
+	recorder := suite.revoke(url.Values{
+		"token": {token.Access},
+	}, suite.testClients["local_account_2"])
+	suite.Equal(http.StatusForbidden, recorder.Code)
+	suite.Equal("unauthorized_client", suite.errorCode(recorder))
+
+	_, err := suite.oauthServer.LoadAccessToken(context.Background(), token.Access)
+	suite.NoError(err)
+}
+
+func (suite *RevokeTestSuite) TestRevokeNoToken() {
+	recorder := suite.revoke(url.Values{}, suite.testClients["local_account_1"])
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+	suite.Equal("invalid_request", suite.errorCode(recorder))
+}
+
+func TestRevokeTestSuite(t *testing.T) {
+	suite.Run(t, new(RevokeTestSuite))
+}
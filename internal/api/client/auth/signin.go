@@ -27,6 +27,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/webauthn"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -82,6 +83,36 @@ func (m *Module) SignInPOSTHandler(c *gin.Context) {
 		return
 	}
 
+	user := &gtsmodel.User{}
+	if err := m.db.GetByID(c.Request.Context(), userid, user); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		m.clearSession(s)
+		return
+	}
+
+	credentials, err := webauthn.UserCredentials(c.Request.Context(), m.db, user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		m.clearSession(s)
+		return
+	}
+
+	if user.OTPRequiredForLogin || len(credentials) != 0 {
+		// the password was right, but the user isn't signed in until they've given us a code
+		// or used a security key too, so store them in the session as pending for now
+		s.Set(sessionTwoFactorUserID, userid)
+		s.Set(sessionTwoFactorAttempts, 0)
+		if err := s.Save(); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			m.clearSession(s)
+			return
+		}
+
+		l.Trace("redirecting to two factor page")
+		c.Redirect(http.StatusFound, AuthTwoFactorPath)
+		return
+	}
+
 	s.Set(sessionUserID, userid)
 	if err := s.Save(); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -0,0 +1,165 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package auth
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/otp"
+	"github.com/superseriousbusiness/gotosocial/internal/webauthn"
+)
+
+// maxTwoFactorAttempts is the number of wrong codes a user can give before they have to start signing in again.
+const maxTwoFactorAttempts = 5
+
+// twoFactorLogin wraps a form-submitted two-factor authentication code
+type twoFactorLogin struct {
+	Code string `form:"code"`
+}
+
+// TwoFactorGETHandler should be served at https://example.org/auth/two_factor.
+// It presents a page where users with two-factor authentication enabled can enter a code, or use
+// a security key, after they've given their password to SignInPOSTHandler.
+func (m *Module) TwoFactorGETHandler(c *gin.Context) {
+	s := sessions.Default(c)
+
+	userID, ok := s.Get(sessionTwoFactorUserID).(string)
+	if !ok || userID == "" {
+		m.clearSession(s)
+		c.JSON(http.StatusForbidden, gin.H{"error": "no pending sign in found in session"})
+		return
+	}
+
+	page, err := m.twoFactorPage(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		m.clearSession(s)
+		return
+	}
+
+	c.HTML(http.StatusOK, "two-factor.tmpl", page)
+}
+
+// TwoFactorPOSTHandler should be served at https://example.org/auth/two_factor.
+// It checks the submitted code for the user who is pending in the session, and if it's valid,
+// signs them in and redirects to the auth handler served at /auth, just like SignInPOSTHandler does
+// for users without two-factor authentication.
+func (m *Module) TwoFactorPOSTHandler(c *gin.Context) {
+	l := m.log.WithField("func", "TwoFactorPOSTHandler")
+	s := sessions.Default(c)
+
+	userID, ok := s.Get(sessionTwoFactorUserID).(string)
+	if !ok || userID == "" {
+		m.clearSession(s)
+		c.JSON(http.StatusForbidden, gin.H{"error": "no pending sign in found in session"})
+		return
+	}
+
+	form := &twoFactorLogin{}
+	if err := c.ShouldBind(form); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		m.clearSession(s)
+		return
+	}
+
+	user := &gtsmodel.User{}
+	if err := m.db.GetByID(c.Request.Context(), userID, user); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		m.clearSession(s)
+		return
+	}
+
+	// users who only have security keys registered may still have an authenticator app set up
+	// but not enabled, and we don't want to accept codes from that
+	valid := false
+	if user.OTPRequiredForLogin {
+		var err error
+		valid, err = otp.Verify(c.Request.Context(), m.db, user, form.Code)
+		if err != nil {
+			l.Errorf("error verifying two factor code for user %s: %s", userID, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "error verifying code"})
+			m.clearSession(s)
+			return
+		}
+	}
+
+	if !valid {
+		attempts, _ := s.Get(sessionTwoFactorAttempts).(int)
+		attempts++
+		if attempts >= maxTwoFactorAttempts {
+			l.Debugf("too many incorrect two factor codes for user %s", userID)
+			c.String(http.StatusForbidden, "too many incorrect codes, please sign in again")
+			m.clearSession(s)
+			return
+		}
+
+		s.Set(sessionTwoFactorAttempts, attempts)
+		if err := s.Save(); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			m.clearSession(s)
+			return
+		}
+
+		page, err := m.twoFactorPage(c.Request.Context(), userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			m.clearSession(s)
+			return
+		}
+		page["error"] = "That code wasn't valid, please try again."
+
+		c.HTML(http.StatusForbidden, "two-factor.tmpl", page)
+		return
+	}
+
+	s.Delete(sessionTwoFactorUserID)
+	s.Delete(sessionTwoFactorAttempts)
+	s.Set(sessionUserID, userID)
+	if err := s.Save(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		m.clearSession(s)
+		return
+	}
+
+	l.Trace("redirecting to auth page")
+	c.Redirect(http.StatusFound, OauthAuthorizePath)
+}
+
+// twoFactorPage returns the template values for the two-factor page, which only shows
+// the ways of completing sign in that the given user has actually set up.
+func (m *Module) twoFactorPage(ctx context.Context, userID string) (gin.H, error) {
+	user := &gtsmodel.User{}
+	if err := m.db.GetByID(ctx, userID, user); err != nil {
+		return nil, err
+	}
+
+	credentials, err := webauthn.UserCredentials(ctx, m.db, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return gin.H{
+		"otp":      user.OTPRequiredForLogin,
+		"webauthn": len(credentials) != 0,
+	}, nil
+}
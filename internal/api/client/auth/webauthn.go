@@ -0,0 +1,133 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package auth
+
+import (
+	"net/http"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/webauthn"
+)
+
+// WebauthnOptionsPOSTHandler should be served at https://example.org/auth/webauthn/options.
+// It generates a new challenge, stores it in the session, and returns the options that the sign in page
+// should pass to navigator.credentials.get().
+//
+// If the user has already given their password to SignInPOSTHandler, only their own credentials are allowed.
+// Otherwise any discoverable credential (passkey) registered on this instance can be used, as long as the
+// authenticator verifies the user.
+func (m *Module) WebauthnOptionsPOSTHandler(c *gin.Context) {
+	l := m.log.WithField("func", "WebauthnOptionsPOSTHandler")
+	s := sessions.Default(c)
+
+	rp, err := webauthn.NewRelyingParty(m.config)
+	if err != nil {
+		l.Errorf("error creating relying party: %s", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "webauthn is not available"})
+		return
+	}
+
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	allowCredentialIDs := []string{}
+	userVerification := webauthn.UserVerificationRequired
+	if userID, ok := s.Get(sessionTwoFactorUserID).(string); ok && userID != "" {
+		credentials, err := webauthn.UserCredentials(c.Request.Context(), m.db, userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if len(credentials) == 0 {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "no security keys are registered for this account"})
+			return
+		}
+		allowCredentialIDs = webauthn.CredentialIDs(credentials)
+		userVerification = webauthn.UserVerificationPreferred
+	}
+
+	s.Set(sessionWebauthnChallenge, challenge)
+	if err := s.Save(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rp.LoginOptions(challenge, allowCredentialIDs, userVerification))
+}
+
+// WebauthnPOSTHandler should be served at https://example.org/auth/webauthn.
+// It checks the credential returned by navigator.credentials.get() against the challenge stored in the session
+// by WebauthnOptionsPOSTHandler. If it's valid, the user is signed in, and the response tells the page where to
+// go next, since the request is made from javascript rather than by submitting a form.
+func (m *Module) WebauthnPOSTHandler(c *gin.Context) {
+	l := m.log.WithField("func", "WebauthnPOSTHandler")
+	s := sessions.Default(c)
+
+	challenge, ok := s.Get(sessionWebauthnChallenge).(string)
+	if !ok || challenge == "" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "no webauthn challenge found in session"})
+		return
+	}
+
+	// a challenge can only be answered once, whatever happens next
+	s.Delete(sessionWebauthnChallenge)
+	if err := s.Save(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		m.clearSession(s)
+		return
+	}
+
+	form := &webauthn.AssertionResponse{}
+	if err := c.ShouldBindJSON(form); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rp, err := webauthn.NewRelyingParty(m.config)
+	if err != nil {
+		l.Errorf("error creating relying party: %s", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "webauthn is not available"})
+		return
+	}
+
+	// if the user already gave their password, the credential has to be one of theirs
+	pendingUserID, _ := s.Get(sessionTwoFactorUserID).(string)
+
+	user, err := webauthn.Login(c.Request.Context(), m.db, rp, challenge, form, pendingUserID)
+	if err != nil {
+		l.Debugf("webauthn sign in failed: %s", err)
+		c.JSON(http.StatusForbidden, gin.H{"error": "security key or passkey could not be verified"})
+		return
+	}
+
+	s.Delete(sessionTwoFactorUserID)
+	s.Delete(sessionTwoFactorAttempts)
+	s.Set(sessionUserID, user.ID)
+	if err := s.Save(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		m.clearSession(s)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"redirect": OauthAuthorizePath})
+}
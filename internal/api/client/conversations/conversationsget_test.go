@@ -0,0 +1,181 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package conversations_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/conversations"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ConversationsTestSuite struct {
+	ConversationsStandardTestSuite
+	testConversation *gtsmodel.Conversation
+}
+
+func (suite *ConversationsTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testStatuses = testrig.NewTestStatuses()
+}
+
+func (suite *ConversationsTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.storage = testrig.NewTestStorage()
+	suite.log = testrig.NewTestLog()
+	suite.federator = testrig.NewTestFederator(suite.db, testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db), suite.storage)
+	suite.processor = testrig.NewTestProcessor(suite.db, suite.storage, suite.federator)
+	suite.conversationsModule = conversations.New(suite.config, suite.processor, suite.log).(*conversations.Module)
+	testrig.StandardDBSetup(suite.db, nil)
+	testrig.StandardStorageSetup(suite.storage, "../../../../testrig/media")
+
+	// local_account_1 has one unread conversation with local_account_2
+	status := suite.testStatuses["local_account_2_status_1"]
+	suite.testConversation = &gtsmodel.Conversation{
+		ID:               "01FY0W2M4Y1T6M1Q7RB5QZ9H7B",
+		AccountID:        suite.testAccounts["local_account_1"].ID,
+		ThreadID:         status.ID,
+		OtherAccountIDs:  []string{suite.testAccounts["local_account_2"].ID},
+		OtherAccountsKey: suite.testAccounts["local_account_2"].ID,
+		LastStatusID:     status.ID,
+	}
+	if err := suite.db.PutConversation(context.Background(), suite.testConversation); err != nil {
+		panic(err)
+	}
+	if err := suite.db.PutConversationToStatus(context.Background(), suite.testConversation.ID, status.ID); err != nil {
+		panic(err)
+	}
+}
+
+func (suite *ConversationsTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StandardStorageTeardown(suite.storage)
+}
+
+func (suite *ConversationsTestSuite) newContext(recorder *httptest.ResponseRecorder, method string, path string, conversationID string) *gin.Context {
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(method, fmt.Sprintf("http://localhost:8080%s", strings.Replace(path, ":id", conversationID, 1)), nil) // the endpoint we're hitting
+
+	// normally the router would populate these params from the path values,
+	// but because we're calling the function directly, we need to set them manually.
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   conversations.IDKey,
+			Value: conversationID,
+		},
+	}
+	return ctx
+}
+
+func (suite *ConversationsTestSuite) getConversations() []*model.Conversation {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, conversations.BasePath, "")
+	suite.conversationsModule.ConversationsGETHandler(ctx)
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	apiConversations := []*model.Conversation{}
+	err = json.Unmarshal(b, &apiConversations)
+	suite.NoError(err)
+	return apiConversations
+}
+
+func (suite *ConversationsTestSuite) TestGetConversations() {
+	apiConversations := suite.getConversations()
+	suite.Len(apiConversations, 1)
+
+	apiConversation := apiConversations[0]
+	suite.Equal(suite.testConversation.ID, apiConversation.ID)
+	suite.True(apiConversation.Unread)
+	suite.Len(apiConversation.Accounts, 1)
+	suite.Equal(suite.testAccounts["local_account_2"].ID, apiConversation.Accounts[0].ID)
+	suite.NotNil(apiConversation.LastStatus)
+	suite.Equal(suite.testStatuses["local_account_2_status_1"].ID, apiConversation.LastStatus.ID)
+}
+
+func (suite *ConversationsTestSuite) TestReadConversation() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, conversations.ReadPath, suite.testConversation.ID)
+	suite.conversationsModule.ConversationReadPOSTHandler(ctx)
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	apiConversation := &model.Conversation{}
+	err = json.Unmarshal(b, apiConversation)
+	suite.NoError(err)
+	suite.False(apiConversation.Unread)
+
+	// the conversation should stay read
+	apiConversations := suite.getConversations()
+	suite.Len(apiConversations, 1)
+	suite.False(apiConversations[0].Unread)
+}
+
+func (suite *ConversationsTestSuite) TestDeleteConversation() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodDelete, conversations.BasePathWithID, suite.testConversation.ID)
+	suite.conversationsModule.ConversationDELETEHandler(ctx)
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	suite.Empty(suite.getConversations())
+
+	// the status in the conversation should still be there
+	_, err := suite.db.GetStatusByID(context.Background(), suite.testConversation.LastStatusID)
+	suite.NoError(err)
+}
+
+func (suite *ConversationsTestSuite) TestDeleteConversationNotFound() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodDelete, conversations.BasePathWithID, "01FY0W9V4C4PQ0W7C2RZ5GZ9S3")
+	suite.conversationsModule.ConversationDELETEHandler(ctx)
+	suite.EqualValues(http.StatusNotFound, recorder.Code)
+}
+
+func TestConversationsTestSuite(t *testing.T) {
+	suite.Run(t, new(ConversationsTestSuite))
+}
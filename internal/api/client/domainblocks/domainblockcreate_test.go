@@ -0,0 +1,156 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package domainblocks_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/domainblocks"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type DomainBlockCreateTestSuite struct {
+	DomainBlocksStandardTestSuite
+}
+
+func (suite *DomainBlockCreateTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+}
+
+func (suite *DomainBlockCreateTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.storage = testrig.NewTestStorage()
+	suite.log = testrig.NewTestLog()
+	suite.federator = testrig.NewTestFederator(suite.db, testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db), suite.storage)
+	suite.processor = testrig.NewTestProcessor(suite.db, suite.storage, suite.federator)
+	suite.domainBlocksModule = domainblocks.New(suite.config, suite.processor, suite.log).(*domainblocks.Module)
+	testrig.StandardDBSetup(suite.db, nil)
+	testrig.StandardStorageSetup(suite.storage, "../../../../testrig/media")
+}
+
+func (suite *DomainBlockCreateTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StandardStorageTeardown(suite.storage)
+}
+
+func (suite *DomainBlockCreateTestSuite) newContext(recorder *httptest.ResponseRecorder, method string, form url.Values) *gin.Context {
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(method, "http://localhost:8080"+domainblocks.BasePath, nil) // the endpoint we're hitting
+	ctx.Request.Form = form
+	return ctx
+}
+
+func (suite *DomainBlockCreateTestSuite) postDomainBlock(domain string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	suite.domainBlocksModule.DomainBlockPOSTHandler(suite.newContext(recorder, http.MethodPost, url.Values{"domain": {domain}}))
+	return recorder
+}
+
+func (suite *DomainBlockCreateTestSuite) getDomainBlocks() []string {
+	recorder := httptest.NewRecorder()
+	suite.domainBlocksModule.DomainBlocksGETHandler(suite.newContext(recorder, http.MethodGet, url.Values{}))
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	domains := []string{}
+	suite.NoError(json.NewDecoder(recorder.Body).Decode(&domains))
+	return domains
+}
+
+func (suite *DomainBlockCreateTestSuite) TestBlockDomain() {
+	recorder := suite.postDomainBlock("Fossbros-Anonymous.io")
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	blocked, err := suite.db.IsDomainBlockedByAccount(context.Background(), suite.testAccounts["local_account_1"].ID, "fossbros-anonymous.io")
+	suite.NoError(err)
+	suite.True(blocked)
+
+	// blocking the same domain again is fine, and doesn't add another block
+	recorder = suite.postDomainBlock("fossbros-anonymous.io")
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal([]string{"fossbros-anonymous.io"}, suite.getDomainBlocks())
+
+	// the block only applies to the account that made it
+	blocked, err = suite.db.IsDomainBlockedByAccount(context.Background(), suite.testAccounts["local_account_2"].ID, "fossbros-anonymous.io")
+	suite.NoError(err)
+	suite.False(blocked)
+}
+
+func (suite *DomainBlockCreateTestSuite) TestBlockDomainRemovesFollowers() {
+	follow := &gtsmodel.Follow{
+		ID:              "01G1TR6BADACCN3K3D5CVZHZ1G",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		AccountID:       suite.testAccounts["remote_account_1"].ID,
+		TargetAccountID: suite.testAccounts["local_account_1"].ID,
+		URI:             "http://fossbros-anonymous.io/users/foss_satan/follows/01G1TR6BADACCN3K3D5CVZHZ1G",
+	}
+	suite.NoError(suite.db.Put(context.Background(), follow))
+
+	recorder := suite.postDomainBlock("fossbros-anonymous.io")
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	following, err := suite.db.IsFollowing(context.Background(), suite.testAccounts["remote_account_1"], suite.testAccounts["local_account_1"])
+	suite.NoError(err)
+	suite.False(following)
+}
+
+func (suite *DomainBlockCreateTestSuite) TestBlockOwnDomain() {
+	recorder := suite.postDomainBlock("localhost:8080")
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+	suite.Empty(suite.getDomainBlocks())
+}
+
+func (suite *DomainBlockCreateTestSuite) TestBlockInvalidDomain() {
+	recorder := suite.postDomainBlock("https://example.org/some/path")
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+	suite.Empty(suite.getDomainBlocks())
+}
+
+func (suite *DomainBlockCreateTestSuite) TestUnblockDomain() {
+	recorder := suite.postDomainBlock("fossbros-anonymous.io")
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	suite.domainBlocksModule.DomainBlockDELETEHandler(suite.newContext(recorder, http.MethodDelete, url.Values{"domain": {"fossbros-anonymous.io"}}))
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Empty(suite.getDomainBlocks())
+}
+
+func TestDomainBlockCreateTestSuite(t *testing.T) {
+	suite.Run(t, new(DomainBlockCreateTestSuite))
+}
@@ -0,0 +1,183 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package featuredtags_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/featuredtags"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type FeaturedTagCreateTestSuite struct {
+	FeaturedTagsStandardTestSuite
+}
+
+func (suite *FeaturedTagCreateTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testTags = testrig.NewTestTags()
+}
+
+func (suite *FeaturedTagCreateTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.storage = testrig.NewTestStorage()
+	suite.log = testrig.NewTestLog()
+	suite.federator = testrig.NewTestFederator(suite.db, testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db), suite.storage)
+	suite.processor = testrig.NewTestProcessor(suite.db, suite.storage, suite.federator)
+	suite.featuredTagsModule = featuredtags.New(suite.config, suite.processor, suite.log).(*featuredtags.Module)
+	testrig.StandardDBSetup(suite.db, nil)
+	testrig.StandardStorageSetup(suite.storage, "../../../../testrig/media")
+}
+
+func (suite *FeaturedTagCreateTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StandardStorageTeardown(suite.storage)
+}
+
+func (suite *FeaturedTagCreateTestSuite) newContext(recorder *httptest.ResponseRecorder, accountName string, method string, path string, featuredTagID string) *gin.Context {
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	// there's no test token for the admin account, but the handlers only care that a token is present
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[accountName])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[accountName])
+	ctx.Request = httptest.NewRequest(method, fmt.Sprintf("http://localhost:8080%s", strings.Replace(path, ":"+featuredtags.IDKey, featuredTagID, 1)), nil) // the endpoint we're hitting
+
+	// normally the router would populate these params from the path values,
+	// but because we're calling the function directly, we need to set them manually.
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   featuredtags.IDKey,
+			Value: featuredTagID,
+		},
+	}
+	return ctx
+}
+
+func (suite *FeaturedTagCreateTestSuite) featureTag(accountName string, name string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, accountName, http.MethodPost, featuredtags.BasePath, "")
+	ctx.Request.Form = url.Values{
+		"name": {name},
+	}
+	suite.featuredTagsModule.FeaturedTagPOSTHandler(ctx)
+	return recorder
+}
+
+func (suite *FeaturedTagCreateTestSuite) getFeaturedTags(accountName string) []*model.FeaturedTag {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, accountName, http.MethodGet, featuredtags.BasePath, "")
+	suite.featuredTagsModule.FeaturedTagsGETHandler(ctx)
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	b, err := ioutil.ReadAll(recorder.Body)
+	suite.NoError(err)
+
+	featuredTags := []*model.FeaturedTag{}
+	suite.NoError(json.Unmarshal(b, &featuredTags))
+	return featuredTags
+}
+
+func (suite *FeaturedTagCreateTestSuite) TestFeatureAndUnfeatureTag() {
+	// the admin account has used the welcome tag once already
+	recorder := suite.featureTag("admin_account", "#Welcome")
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	b, err := ioutil.ReadAll(recorder.Body)
+	suite.NoError(err)
+
+	featuredTag := &model.FeaturedTag{}
+	suite.NoError(json.Unmarshal(b, featuredTag))
+	suite.NotEmpty(featuredTag.ID)
+	suite.Equal("welcome", featuredTag.Name)
+	suite.Equal("http://localhost:8080/tags/welcome", featuredTag.URL)
+	suite.Equal(1, featuredTag.StatusesCount)
+	suite.NotNil(featuredTag.LastStatusAt)
+
+	// featuring the same tag again should fail
+	recorder = suite.featureTag("admin_account", "welcome")
+	suite.EqualValues(http.StatusUnprocessableEntity, recorder.Code)
+
+	featuredTags := suite.getFeaturedTags("admin_account")
+	suite.Len(featuredTags, 1)
+	suite.Equal(featuredTag.ID, featuredTags[0].ID)
+
+	// someone else shouldn't be able to delete our featured tag
+	recorder = httptest.NewRecorder()
+	ctx := suite.newContext(recorder, "local_account_1", http.MethodDelete, featuredtags.BasePathWithID, featuredTag.ID)
+	suite.featuredTagsModule.FeaturedTagDELETEHandler(ctx)
+	suite.EqualValues(http.StatusNotFound, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	ctx = suite.newContext(recorder, "admin_account", http.MethodDelete, featuredtags.BasePathWithID, featuredTag.ID)
+	suite.featuredTagsModule.FeaturedTagDELETEHandler(ctx)
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	suite.Empty(suite.getFeaturedTags("admin_account"))
+}
+
+func (suite *FeaturedTagCreateTestSuite) TestFeatureInvalidTag() {
+	recorder := suite.featureTag("local_account_1", "not a hashtag")
+	suite.EqualValues(http.StatusBadRequest, recorder.Code)
+}
+
+func (suite *FeaturedTagCreateTestSuite) TestFeaturedTagSuggestions() {
+	getSuggestions := func() []*model.Tag {
+		recorder := httptest.NewRecorder()
+		ctx := suite.newContext(recorder, "admin_account", http.MethodGet, featuredtags.SuggestionsPath, "")
+		suite.featuredTagsModule.FeaturedTagSuggestionsGETHandler(ctx)
+		suite.EqualValues(http.StatusOK, recorder.Code)
+
+		b, err := ioutil.ReadAll(recorder.Body)
+		suite.NoError(err)
+
+		tags := []*model.Tag{}
+		suite.NoError(json.Unmarshal(b, &tags))
+		return tags
+	}
+
+	tags := getSuggestions()
+	suite.Len(tags, 1)
+	suite.Equal("welcome", tags[0].Name)
+
+	// once the tag is featured it shouldn't be suggested anymore
+	recorder := suite.featureTag("admin_account", "welcome")
+	suite.EqualValues(http.StatusOK, recorder.Code)
+	suite.Empty(getSuggestions())
+}
+
+func TestFeaturedTagCreateTestSuite(t *testing.T) {
+	suite.Run(t, &FeaturedTagCreateTestSuite{})
+}
@@ -29,8 +29,12 @@ import (
 )
 
 const (
+	// IDKey is for filter UUIDs
+	IDKey = "id"
 	// BasePath is the base path for serving the filter API
 	BasePath = "/api/v1/filters"
+	// BasePathWithID is the base path with the ID key in it, for operations on an existing filter.
+	BasePathWithID = BasePath + "/:" + IDKey
 )
 
 // Module implements the ClientAPIModule interface for every related to filters
@@ -52,5 +56,9 @@ func New(config *config.Config, processor processing.Processor, log *logrus.Logg
 // Route attaches all routes from this module to the given router
 func (m *Module) Route(r router.Router) error {
 	r.AttachHandler(http.MethodGet, BasePath, m.FiltersGETHandler)
+	r.AttachHandler(http.MethodPost, BasePath, m.FilterCreatePOSTHandler)
+	r.AttachHandler(http.MethodGet, BasePathWithID, m.FilterGETHandler)
+	r.AttachHandler(http.MethodPut, BasePathWithID, m.FilterUpdatePUTHandler)
+	r.AttachHandler(http.MethodDelete, BasePathWithID, m.FilterDELETEHandler)
 	return nil
 }
@@ -0,0 +1,123 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package followrequest_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/followrequest"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type FollowRequestDenyTestSuite struct {
+	FollowRequestStandardTestSuite
+}
+
+func (suite *FollowRequestDenyTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+}
+
+func (suite *FollowRequestDenyTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.storage = testrig.NewTestStorage()
+	suite.log = testrig.NewTestLog()
+	suite.federator = testrig.NewTestFederator(suite.db, testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db), suite.storage)
+	suite.processor = testrig.NewTestProcessor(suite.db, suite.storage, suite.federator)
+	suite.followRequestModule = followrequest.New(suite.config, suite.processor, suite.log).(*followrequest.Module)
+	testrig.StandardDBSetup(suite.db, nil)
+	testrig.StandardStorageSetup(suite.storage, "../../../../testrig/media")
+}
+
+func (suite *FollowRequestDenyTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StandardStorageTeardown(suite.storage)
+}
+
+func (suite *FollowRequestDenyTestSuite) denyFollowRequest(requestingAccountID string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080%s", strings.Replace(followrequest.DenyPath, ":"+followrequest.IDKey, requestingAccountID, 1)), nil)
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   followrequest.IDKey,
+			Value: requestingAccountID,
+		},
+	}
+	suite.followRequestModule.FollowRequestDenyPOSTHandler(ctx)
+	return recorder
+}
+
+func (suite *FollowRequestDenyTestSuite) TestDenyFollowRequest() {
+	requestingAccount := suite.testAccounts["local_account_2"]
+	targetAccount := suite.testAccounts["local_account_1"]
+
+	fr := &gtsmodel.FollowRequest{
+		ID:              "01FJ1S8DX3STJJ6CEYPMZ1M0R3",
+		AccountID:       requestingAccount.ID,
+		TargetAccountID: targetAccount.ID,
+		URI:             requestingAccount.URI + "/follow/01FJ1S8DX3STJJ6CEYPMZ1M0R3",
+	}
+	suite.NoError(suite.db.Put(context.Background(), fr))
+
+	recorder := suite.denyFollowRequest(requestingAccount.ID)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := ioutil.ReadAll(recorder.Body)
+	suite.NoError(err)
+
+	relationship := &model.Relationship{}
+	suite.NoError(json.Unmarshal(b, relationship))
+	suite.Equal(requestingAccount.ID, relationship.ID)
+	suite.False(relationship.FollowedBy)
+
+	// the follow request should be gone now
+	err = suite.db.GetByID(context.Background(), fr.ID, &gtsmodel.FollowRequest{})
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *FollowRequestDenyTestSuite) TestDenyNonExistentFollowRequest() {
+	recorder := suite.denyFollowRequest(suite.testAccounts["local_account_2"].ID)
+	suite.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func TestFollowRequestDenyTestSuite(t *testing.T) {
+	suite.Run(t, &FollowRequestDenyTestSuite{})
+}
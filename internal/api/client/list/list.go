@@ -29,8 +29,23 @@ import (
 )
 
 const (
+	// IDKey is for list UUIDs
+	IDKey = "id"
 	// BasePath is the base path for serving the lists API
 	BasePath = "/api/v1/lists"
+	// BasePathWithID is the base path with the ID key in it, for operations on an existing list.
+	BasePathWithID = BasePath + "/:" + IDKey
+	// AccountsPath is for viewing, adding, and removing the accounts in a list.
+	AccountsPath = BasePathWithID + "/accounts"
+
+	// MaxIDKey is the url query for setting a max ID to return
+	MaxIDKey = "max_id"
+	// SinceIDKey is the url query for returning results newer than the given ID
+	SinceIDKey = "since_id"
+	// MinIDKey is the url query for returning results immediately newer than the given ID
+	MinIDKey = "min_id"
+	// LimitKey is for specifying maximum number of results to return.
+	LimitKey = "limit"
 )
 
 // Module implements the ClientAPIModule interface for everything related to lists
@@ -52,5 +67,12 @@ func New(config *config.Config, processor processing.Processor, log *logrus.Logg
 // Route attaches all routes from this module to the given router
 func (m *Module) Route(r router.Router) error {
 	r.AttachHandler(http.MethodGet, BasePath, m.ListsGETHandler)
+	r.AttachHandler(http.MethodPost, BasePath, m.ListCreatePOSTHandler)
+	r.AttachHandler(http.MethodGet, BasePathWithID, m.ListGETHandler)
+	r.AttachHandler(http.MethodPut, BasePathWithID, m.ListUpdatePUTHandler)
+	r.AttachHandler(http.MethodDelete, BasePathWithID, m.ListDELETEHandler)
+	r.AttachHandler(http.MethodGet, AccountsPath, m.ListAccountsGETHandler)
+	r.AttachHandler(http.MethodPost, AccountsPath, m.ListAccountsPOSTHandler)
+	r.AttachHandler(http.MethodDelete, AccountsPath, m.ListAccountsDELETEHandler)
 	return nil
 }
@@ -0,0 +1,211 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package list_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type ListAccountsTestSuite struct {
+	ListStandardTestSuite
+}
+
+func (suite *ListAccountsTestSuite) listAccountsPath(listID string) string {
+	return "/api/v1/lists/" + listID + "/accounts"
+}
+
+func (suite *ListAccountsTestSuite) getListAccounts(listID string) []string {
+	recorder := httptest.NewRecorder()
+	suite.listModule.ListAccountsGETHandler(suite.newContext(recorder, http.MethodGet, suite.listAccountsPath(listID), "local_account_1", listID, url.Values{}))
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	accounts := []*model.Account{}
+	suite.NoError(json.NewDecoder(recorder.Body).Decode(&accounts))
+
+	accountIDs := []string{}
+	for _, a := range accounts {
+		accountIDs = append(accountIDs, a.ID)
+	}
+	return accountIDs
+}
+
+func (suite *ListAccountsTestSuite) addListAccounts(accountKey string, listID string, accountIDs ...string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	form := url.Values{"account_ids[]": accountIDs}
+	suite.listModule.ListAccountsPOSTHandler(suite.newContext(recorder, http.MethodPost, suite.listAccountsPath(listID), accountKey, listID, form))
+	return recorder
+}
+
+func (suite *ListAccountsTestSuite) removeListAccounts(accountKey string, listID string, accountIDs ...string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	form := url.Values{"account_ids[]": accountIDs}
+	suite.listModule.ListAccountsDELETEHandler(suite.newContext(recorder, http.MethodDelete, suite.listAccountsPath(listID), accountKey, listID, form))
+	return recorder
+}
+
+func (suite *ListAccountsTestSuite) TestGetListAccounts() {
+	accountIDs := suite.getListAccounts(suite.testLists["local_account_1_list_1"].ID)
+	suite.Equal([]string{suite.testAccounts["local_account_2"].ID}, accountIDs)
+}
+
+func (suite *ListAccountsTestSuite) TestAddFollowedAccount() {
+	listID := suite.testLists["local_account_1_list_1"].ID
+
+	recorder := suite.addListAccounts("local_account_1", listID, suite.testAccounts["admin_account"].ID)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	accountIDs := suite.getListAccounts(listID)
+	suite.Len(accountIDs, 2)
+	suite.Contains(accountIDs, suite.testAccounts["admin_account"].ID)
+	suite.Contains(accountIDs, suite.testAccounts["local_account_2"].ID)
+}
+
+func (suite *ListAccountsTestSuite) TestAddNotFollowedAccount() {
+	listID := suite.testLists["local_account_1_list_1"].ID
+
+	// local_account_1 doesn't follow remote_account_1, so it can't go in the list
+	recorder := suite.addListAccounts("local_account_1", listID, suite.testAccounts["remote_account_1"].ID)
+	suite.Equal(http.StatusNotFound, recorder.Code)
+
+	suite.Equal([]string{suite.testAccounts["local_account_2"].ID}, suite.getListAccounts(listID))
+}
+
+func (suite *ListAccountsTestSuite) TestAddNotFollowedAccountWithFollowedAccount() {
+	listID := suite.testLists["local_account_1_list_1"].ID
+
+	// if any of the accounts can't be added, none of them should be
+	recorder := suite.addListAccounts("local_account_1", listID, suite.testAccounts["admin_account"].ID, suite.testAccounts["remote_account_1"].ID)
+	suite.Equal(http.StatusNotFound, recorder.Code)
+
+	suite.Equal([]string{suite.testAccounts["local_account_2"].ID}, suite.getListAccounts(listID))
+}
+
+func (suite *ListAccountsTestSuite) TestAddAlreadyListedAccount() {
+	listID := suite.testLists["local_account_1_list_1"].ID
+
+	recorder := suite.addListAccounts("local_account_1", listID, suite.testAccounts["local_account_2"].ID)
+	suite.Equal(http.StatusUnprocessableEntity, recorder.Code)
+
+	suite.Equal([]string{suite.testAccounts["local_account_2"].ID}, suite.getListAccounts(listID))
+}
+
+func (suite *ListAccountsTestSuite) TestAddAccountToListNotOwned() {
+	listID := suite.testLists["local_account_1_list_1"].ID
+
+	recorder := suite.addListAccounts("local_account_2", listID, suite.testAccounts["local_account_1"].ID)
+	suite.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func (suite *ListAccountsTestSuite) TestAddNoAccounts() {
+	recorder := suite.addListAccounts("local_account_1", suite.testLists["local_account_1_list_1"].ID)
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func (suite *ListAccountsTestSuite) TestRemoveAccount() {
+	listID := suite.testLists["local_account_1_list_1"].ID
+
+	recorder := suite.removeListAccounts("local_account_1", listID, suite.testAccounts["local_account_2"].ID)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	suite.Empty(suite.getListAccounts(listID))
+
+	// removing the account only takes it out of the list; it's still followed
+	following, err := suite.db.IsFollowing(context.Background(), suite.testAccounts["local_account_1"], suite.testAccounts["local_account_2"])
+	suite.NoError(err)
+	suite.True(following)
+}
+
+func (suite *ListAccountsTestSuite) TestRemoveAccountFromListNotOwned() {
+	listID := suite.testLists["local_account_1_list_1"].ID
+
+	recorder := suite.removeListAccounts("local_account_2", listID, suite.testAccounts["local_account_2"].ID)
+	suite.Equal(http.StatusNotFound, recorder.Code)
+
+	suite.Equal([]string{suite.testAccounts["local_account_2"].ID}, suite.getListAccounts(listID))
+}
+
+func (suite *ListAccountsTestSuite) TestMemberStatusLandsInListTimeline() {
+	ctx := context.Background()
+	listID := suite.testLists["local_account_1_list_1"].ID
+	owner := &oauth.Auth{
+		Application: suite.testApplications["application_1"],
+		User:        suite.testUsers["local_account_1"],
+		Account:     suite.testAccounts["local_account_1"],
+	}
+
+	// new statuses are put into timelines by the processor in the background, so it needs to be running
+	suite.NoError(suite.processor.Start(ctx))
+	defer func() {
+		suite.NoError(suite.processor.Stop())
+	}()
+
+	// get the list timeline once so that it's already indexed when the new statuses come in
+	_, errWithCode := suite.processor.ListTimelineGet(ctx, owner, listID, "", "", "", 20)
+	suite.NoError(errWithCode)
+
+	postStatus := func(accountKey string, text string) string {
+		status, err := suite.processor.StatusCreate(ctx, &oauth.Auth{
+			Application: suite.testApplications["application_1"],
+			User:        suite.testUsers[accountKey],
+			Account:     suite.testAccounts[accountKey],
+		}, &model.AdvancedStatusCreateForm{
+			StatusCreateRequest: model.StatusCreateRequest{
+				Status:     text,
+				Visibility: model.VisibilityPublic,
+			},
+		})
+		suite.NoError(err)
+		return status.ID
+	}
+
+	// admin_account is followed by the list owner, but isn't in the list
+	notListedID := postStatus("admin_account", "this shouldn't be in the list timeline")
+	listedID := postStatus("local_account_2", "this should be in the list timeline")
+
+	var statusIDs []string
+	for i := 0; i < 50; i++ {
+		resp, errWithCode := suite.processor.ListTimelineGet(ctx, owner, listID, "", "", "", 20)
+		suite.NoError(errWithCode)
+
+		statusIDs = []string{}
+		for _, s := range resp.Statuses {
+			statusIDs = append(statusIDs, s.ID)
+		}
+		if len(statusIDs) != 0 && statusIDs[0] == listedID {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	suite.Contains(statusIDs, listedID)
+	suite.NotContains(statusIDs, notListedID)
+}
+
+func TestListAccountsTestSuite(t *testing.T) {
+	suite.Run(t, new(ListAccountsTestSuite))
+}
@@ -0,0 +1,89 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package list_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/list"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type ListCreateTestSuite struct {
+	ListStandardTestSuite
+}
+
+func (suite *ListCreateTestSuite) postList(form url.Values) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	suite.listModule.ListCreatePOSTHandler(suite.newContext(recorder, http.MethodPost, list.BasePath, "local_account_1", "", form))
+	return recorder
+}
+
+func (suite *ListCreateTestSuite) TestCreateList() {
+	recorder := suite.postList(url.Values{
+		"title":          {"cool remote people"},
+		"replies_policy": {"list"},
+	})
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	apiList := &model.List{}
+	suite.NoError(json.NewDecoder(recorder.Body).Decode(apiList))
+	suite.NotEmpty(apiList.ID)
+	suite.Equal("cool remote people", apiList.Title)
+	suite.Equal("list", apiList.RepliesPolicy)
+
+	// the new list should be there when the owner fetches it
+	recorder = suite.getList("local_account_1", apiList.ID)
+	suite.Equal(http.StatusOK, recorder.Code)
+}
+
+func (suite *ListCreateTestSuite) TestCreateListDefaultRepliesPolicy() {
+	recorder := suite.postList(url.Values{
+		"title": {"cool remote people"},
+	})
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	apiList := &model.List{}
+	suite.NoError(json.NewDecoder(recorder.Body).Decode(apiList))
+	suite.Equal("followed", apiList.RepliesPolicy)
+}
+
+func (suite *ListCreateTestSuite) TestCreateListNoTitle() {
+	recorder := suite.postList(url.Values{
+		"replies_policy": {"list"},
+	})
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func (suite *ListCreateTestSuite) TestCreateListBadRepliesPolicy() {
+	recorder := suite.postList(url.Values{
+		"title":          {"cool remote people"},
+		"replies_policy": {"everyone"},
+	})
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func TestListCreateTestSuite(t *testing.T) {
+	suite.Run(t, new(ListCreateTestSuite))
+}
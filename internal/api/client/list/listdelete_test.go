@@ -0,0 +1,68 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package list_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+)
+
+type ListDeleteTestSuite struct {
+	ListStandardTestSuite
+}
+
+func (suite *ListDeleteTestSuite) deleteList(accountKey string, listID string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	suite.listModule.ListDELETEHandler(suite.newContext(recorder, http.MethodDelete, "/api/v1/lists/"+listID, accountKey, listID, url.Values{}))
+	return recorder
+}
+
+func (suite *ListDeleteTestSuite) TestDeleteList() {
+	testList := suite.testLists["local_account_1_list_1"]
+
+	recorder := suite.deleteList("local_account_1", testList.ID)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	recorder = suite.getList("local_account_1", testList.ID)
+	suite.Equal(http.StatusNotFound, recorder.Code)
+
+	_, err := suite.db.GetListByID(context.Background(), testList.ID)
+	suite.Equal(db.ErrNoEntries, err)
+}
+
+func (suite *ListDeleteTestSuite) TestDeleteListNotOwned() {
+	testList := suite.testLists["local_account_1_list_1"]
+
+	recorder := suite.deleteList("local_account_2", testList.ID)
+	suite.Equal(http.StatusNotFound, recorder.Code)
+
+	// the list should still be there for its owner
+	recorder = suite.getList("local_account_1", testList.ID)
+	suite.Equal(http.StatusOK, recorder.Code)
+}
+
+func TestListDeleteTestSuite(t *testing.T) {
+	suite.Run(t, new(ListDeleteTestSuite))
+}
@@ -0,0 +1,106 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package list_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type ListUpdateTestSuite struct {
+	ListStandardTestSuite
+}
+
+func (suite *ListUpdateTestSuite) putList(accountKey string, listID string, form url.Values) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	suite.listModule.ListUpdatePUTHandler(suite.newContext(recorder, http.MethodPut, "/api/v1/lists/"+listID, accountKey, listID, form))
+	return recorder
+}
+
+func (suite *ListUpdateTestSuite) TestUpdateListTitle() {
+	testList := suite.testLists["local_account_1_list_1"]
+
+	recorder := suite.putList("local_account_1", testList.ID, url.Values{
+		"title": {"even cooler posters"},
+	})
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	apiList := &model.List{}
+	suite.NoError(json.NewDecoder(recorder.Body).Decode(apiList))
+	suite.Equal(testList.ID, apiList.ID)
+	suite.Equal("even cooler posters", apiList.Title)
+	// fields that weren't given should be left alone
+	suite.Equal(string(testList.RepliesPolicy), apiList.RepliesPolicy)
+}
+
+func (suite *ListUpdateTestSuite) TestUpdateListRepliesPolicy() {
+	testList := suite.testLists["local_account_1_list_1"]
+
+	recorder := suite.putList("local_account_1", testList.ID, url.Values{
+		"replies_policy": {"none"},
+	})
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	apiList := &model.List{}
+	suite.NoError(json.NewDecoder(recorder.Body).Decode(apiList))
+	suite.Equal(testList.Title, apiList.Title)
+	suite.Equal("none", apiList.RepliesPolicy)
+}
+
+func (suite *ListUpdateTestSuite) TestUpdateListEmptyTitle() {
+	recorder := suite.putList("local_account_1", suite.testLists["local_account_1_list_1"].ID, url.Values{
+		"title": {""},
+	})
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func (suite *ListUpdateTestSuite) TestUpdateListNotOwned() {
+	testList := suite.testLists["local_account_1_list_1"]
+
+	recorder := suite.putList("local_account_2", testList.ID, url.Values{
+		"title": {"mine now"},
+	})
+	suite.Equal(http.StatusNotFound, recorder.Code)
+
+	// the list should be untouched
+	recorder = suite.getList("local_account_1", testList.ID)
+	suite.Equal(http.StatusOK, recorder.Code)
+	apiList := &model.List{}
+	suite.NoError(json.NewDecoder(recorder.Body).Decode(apiList))
+	suite.Equal(testList.Title, apiList.Title)
+}
+
+func (suite *ListUpdateTestSuite) TestGetListNotOwned() {
+	// another account's list shouldn't be distinguishable from one that doesn't exist
+	recorder := suite.getList("local_account_2", suite.testLists["local_account_1_list_1"].ID)
+	suite.Equal(http.StatusNotFound, recorder.Code)
+
+	recorder = suite.getList("local_account_2", "01FXKN6R3SWQ2PCE4ABK5AWCWT")
+	suite.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func TestListUpdateTestSuite(t *testing.T) {
+	suite.Run(t, new(ListUpdateTestSuite))
+}
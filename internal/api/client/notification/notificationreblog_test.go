@@ -0,0 +1,148 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package notification_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/notification"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type NotificationReblogTestSuite struct {
+	NotificationStandardTestSuite
+}
+
+func (suite *NotificationReblogTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testStatuses = testrig.NewTestStatuses()
+	suite.testNotifications = testrig.NewTestNotifications()
+}
+
+func (suite *NotificationReblogTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.storage = testrig.NewTestStorage()
+	suite.log = testrig.NewTestLog()
+	suite.federator = testrig.NewTestFederator(suite.db, testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db), suite.storage)
+	suite.processor = testrig.NewTestProcessor(suite.db, suite.storage, suite.federator)
+	suite.notificationModule = notification.New(suite.config, suite.processor, suite.log).(*notification.Module)
+	testrig.StandardDBSetup(suite.db, nil)
+	testrig.StandardStorageSetup(suite.storage, "../../../../testrig/media")
+
+	// boost notifications are created by the processor in the background, so it needs to be running
+	if err := suite.processor.Start(context.Background()); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *NotificationReblogTestSuite) TearDownTest() {
+	if err := suite.processor.Stop(); err != nil {
+		suite.FailNow(err.Error())
+	}
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StandardStorageTeardown(suite.storage)
+}
+
+// auth returns the oauth details of the given test account.
+func (suite *NotificationReblogTestSuite) auth(accountKey string) *oauth.Auth {
+	return &oauth.Auth{
+		Application: suite.testApplications["application_1"],
+		User:        suite.testUsers[accountKey],
+		Account:     suite.testAccounts[accountKey],
+	}
+}
+
+// boost boosts the given test status as the given test account, and returns the id of the boost.
+func (suite *NotificationReblogTestSuite) boost(accountKey string, statusKey string) string {
+	boost, errWithCode := suite.processor.StatusBoost(context.Background(), suite.auth(accountKey), suite.testStatuses[statusKey].ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	return boost.ID
+}
+
+// reblogNotified returns whether there's a reblog notification about the given boost.
+func (suite *NotificationReblogTestSuite) reblogNotified(boostID string) bool {
+	err := suite.db.GetWhere(context.Background(), []db.Where{
+		{Key: "notification_type", Value: gtsmodel.NotificationReblog},
+		{Key: "status_id", Value: boostID},
+	}, &gtsmodel.Notification{})
+	return err == nil
+}
+
+// waitForReblogNotified waits a little while for a reblog notification about the given boost to turn up,
+// and returns whether it did.
+func (suite *NotificationReblogTestSuite) waitForReblogNotified(boostID string) bool {
+	for i := 0; i < 50; i++ {
+		if suite.reblogNotified(boostID) {
+			return true
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return false
+}
+
+func (suite *NotificationReblogTestSuite) TestReblogNotification() {
+	boostID := suite.boost("local_account_2", "local_account_1_status_1")
+	suite.True(suite.waitForReblogNotified(boostID))
+}
+
+func (suite *NotificationReblogTestSuite) TestReblogNotificationMuted() {
+	notifications := true
+	_, errWithCode := suite.processor.AccountMuteCreate(context.Background(), suite.auth("local_account_1"), &model.AccountMuteRequest{
+		ID:            suite.testAccounts["local_account_2"].ID,
+		Notifications: &notifications,
+	})
+	suite.NoError(errWithCode)
+
+	mutedBoostID := suite.boost("local_account_2", "local_account_1_status_1")
+
+	// admin_account isn't muted, so once its boost has been notified, the muted boost has been dealt with too
+	boostID := suite.boost("admin_account", "local_account_1_status_1")
+	suite.True(suite.waitForReblogNotified(boostID))
+	suite.False(suite.reblogNotified(mutedBoostID))
+}
+
+func (suite *NotificationReblogTestSuite) TestReblogNotificationThreadMuted() {
+	_, errWithCode := suite.processor.StatusMute(context.Background(), suite.auth("local_account_1"), suite.testStatuses["local_account_1_status_1"].ID)
+	suite.NoError(errWithCode)
+
+	mutedBoostID := suite.boost("local_account_2", "local_account_1_status_1")
+
+	// local_account_1_status_2 isn't in the muted thread, so once its boost has been notified, the muted boost has been dealt with too
+	boostID := suite.boost("local_account_2", "local_account_1_status_2")
+	suite.True(suite.waitForReblogNotified(boostID))
+	suite.False(suite.reblogNotified(mutedBoostID))
+}
+
+func TestNotificationReblogTestSuite(t *testing.T) {
+	suite.Run(t, new(NotificationReblogTestSuite))
+}
@@ -0,0 +1,145 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package notification_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/notification"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type NotificationsGetTestSuite struct {
+	NotificationStandardTestSuite
+}
+
+func (suite *NotificationsGetTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testStatuses = testrig.NewTestStatuses()
+	suite.testNotifications = testrig.NewTestNotifications()
+}
+
+func (suite *NotificationsGetTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.storage = testrig.NewTestStorage()
+	suite.log = testrig.NewTestLog()
+	suite.federator = testrig.NewTestFederator(suite.db, testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db), suite.storage)
+	suite.processor = testrig.NewTestProcessor(suite.db, suite.storage, suite.federator)
+	suite.notificationModule = notification.New(suite.config, suite.processor, suite.log).(*notification.Module)
+	testrig.StandardDBSetup(suite.db, nil)
+	testrig.StandardStorageSetup(suite.storage, "../../../../testrig/media")
+
+	// local_account_2 mentions local_account_1 in this status
+	mention := &gtsmodel.Notification{
+		ID:               "01G1TRFMGQJ1ZK2N7A6Q9WB1XS",
+		NotificationType: gtsmodel.NotificationMention,
+		CreatedAt:        time.Now().Add(-1 * time.Minute),
+		TargetAccountID:  suite.testAccounts["local_account_1"].ID,
+		OriginAccountID:  suite.testAccounts["local_account_2"].ID,
+		StatusID:         suite.testStatuses["local_account_2_status_5"].ID,
+	}
+	if err := suite.db.Put(context.Background(), mention); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *NotificationsGetTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StandardStorageTeardown(suite.storage)
+}
+
+func (suite *NotificationsGetTestSuite) getNotifications() []string {
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodGet, "http://localhost:8080"+notification.BasePath, nil) // the endpoint we're hitting
+	suite.notificationModule.NotificationsGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	notifs := []*model.Notification{}
+	suite.NoError(json.NewDecoder(recorder.Body).Decode(&notifs))
+
+	notifIDs := []string{}
+	for _, n := range notifs {
+		notifIDs = append(notifIDs, n.ID)
+	}
+	return notifIDs
+}
+
+func (suite *NotificationsGetTestSuite) putFilter(filter *gtsmodel.Filter) {
+	filter.AccountID = suite.testAccounts["local_account_1"].ID
+	filter.CreatedAt = time.Now()
+	filter.UpdatedAt = time.Now()
+	filter.Irreversible = true
+	suite.NoError(suite.db.Put(context.Background(), filter))
+}
+
+func (suite *NotificationsGetTestSuite) TestGetNotifications() {
+	notifIDs := suite.getNotifications()
+	suite.Len(notifIDs, 2)
+	suite.Contains(notifIDs, "01G1TRFMGQJ1ZK2N7A6Q9WB1XS")
+	suite.Contains(notifIDs, suite.testNotifications["local_account_1_like"].ID)
+}
+
+func (suite *NotificationsGetTestSuite) TestGetNotificationsFiltered() {
+	// the mention says "hi zork!"
+	suite.putFilter(&gtsmodel.Filter{
+		ID:                   "01G1TRG5N1QK1CRY2HCE4JXQAM",
+		Phrase:               "zork",
+		ContextNotifications: true,
+		WholeWord:            true,
+	})
+
+	// the like is of a status by local_account_1, and people's own statuses are never filtered
+	suite.Equal([]string{suite.testNotifications["local_account_1_like"].ID}, suite.getNotifications())
+}
+
+func (suite *NotificationsGetTestSuite) TestGetNotificationsFilteredOtherContext() {
+	suite.putFilter(&gtsmodel.Filter{
+		ID:          "01G1TRG5N1QK1CRY2HCE4JXQAM",
+		Phrase:      "zork",
+		ContextHome: true,
+		WholeWord:   true,
+	})
+
+	// the filter doesn't apply to notifications, so nothing should be dropped
+	suite.Len(suite.getNotifications(), 2)
+}
+
+func TestNotificationsGetTestSuite(t *testing.T) {
+	suite.Run(t, new(NotificationsGetTestSuite))
+}
@@ -0,0 +1,161 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package preferences_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/preferences"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type PreferencesGetTestSuite struct {
+	PreferencesStandardTestSuite
+}
+
+func (suite *PreferencesGetTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+}
+
+func (suite *PreferencesGetTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.storage = testrig.NewTestStorage()
+	suite.log = testrig.NewTestLog()
+	suite.federator = testrig.NewTestFederator(suite.db, testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db), suite.storage)
+	suite.processor = testrig.NewTestProcessor(suite.db, suite.storage, suite.federator)
+	suite.preferencesModule = preferences.New(suite.config, suite.processor, suite.log).(*preferences.Module)
+	testrig.StandardDBSetup(suite.db, nil)
+	testrig.StandardStorageSetup(suite.storage, "../../../../testrig/media")
+}
+
+func (suite *PreferencesGetTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StandardStorageTeardown(suite.storage)
+}
+
+func (suite *PreferencesGetTestSuite) getPreferences(account *gtsmodel.Account, user *gtsmodel.User) *model.Preferences {
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Set(oauth.SessionAuthorizedAccount, account)
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, user)
+	ctx.Request = httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:8080%s", preferences.BasePath), nil) // the endpoint we're hitting
+	suite.preferencesModule.PreferencesGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := ioutil.ReadAll(recorder.Body)
+	suite.NoError(err)
+
+	prefs := &model.Preferences{}
+	suite.NoError(json.Unmarshal(b, prefs))
+	return prefs
+}
+
+func (suite *PreferencesGetTestSuite) TestGetDefaultPreferences() {
+	prefs := suite.getPreferences(suite.testAccounts["local_account_1"], suite.testUsers["local_account_1"])
+	suite.Equal("public", prefs.PostingDefaultVisibility)
+	suite.False(prefs.PostingDefaultSensitive)
+	suite.Equal("en", prefs.PostingDefaultLanguage)
+	suite.Equal("default", prefs.ReadingExpandMedia)
+	suite.False(prefs.ReadingExpandSpoilers)
+	suite.False(prefs.NotificationsEmailFollow)
+	suite.False(prefs.NotificationsEmailFollowRequest)
+	suite.False(prefs.NotificationsEmailMention)
+	suite.False(prefs.NotificationsEmailDirect)
+}
+
+func (suite *PreferencesGetTestSuite) TestGetUpdatedPreferences() {
+	privacy := "private"
+	sensitive := true
+	language := "de"
+	expandMedia := "show_all"
+	expandSpoilers := true
+	emailNotifyMention := true
+	emailNotifyDirect := true
+
+	authed := &oauth.Auth{
+		Account: suite.testAccounts["local_account_1"],
+		User:    suite.testUsers["local_account_1"],
+	}
+	_, err := suite.processor.AccountUpdate(context.Background(), authed, &model.UpdateCredentialsRequest{
+		Source: &model.UpdateSource{
+			Privacy:        &privacy,
+			Sensitive:      &sensitive,
+			Language:       &language,
+			ExpandMedia:    &expandMedia,
+			ExpandSpoilers: &expandSpoilers,
+
+			EmailNotifyMention: &emailNotifyMention,
+			EmailNotifyDirect:  &emailNotifyDirect,
+		},
+	})
+	suite.NoError(err)
+
+	// the authed models would normally be fetched fresh from the db for each request
+	account, err := suite.db.GetAccountByID(context.Background(), authed.Account.ID)
+	suite.NoError(err)
+	user := &gtsmodel.User{}
+	suite.NoError(suite.db.GetByID(context.Background(), authed.User.ID, user))
+
+	prefs := suite.getPreferences(account, user)
+	suite.Equal("private", prefs.PostingDefaultVisibility)
+	suite.True(prefs.PostingDefaultSensitive)
+	suite.Equal("de", prefs.PostingDefaultLanguage)
+	suite.Equal("show_all", prefs.ReadingExpandMedia)
+	suite.True(prefs.ReadingExpandSpoilers)
+	suite.False(prefs.NotificationsEmailFollow)
+	suite.False(prefs.NotificationsEmailFollowRequest)
+	suite.True(prefs.NotificationsEmailMention)
+	suite.True(prefs.NotificationsEmailDirect)
+}
+
+func (suite *PreferencesGetTestSuite) TestUpdateInvalidExpandMedia() {
+	expandMedia := "show_some"
+	authed := &oauth.Auth{
+		Account: suite.testAccounts["local_account_1"],
+		User:    suite.testUsers["local_account_1"],
+	}
+	_, err := suite.processor.AccountUpdate(context.Background(), authed, &model.UpdateCredentialsRequest{
+		Source: &model.UpdateSource{
+			ExpandMedia: &expandMedia,
+		},
+	})
+	suite.Error(err)
+}
+
+func TestPreferencesGetTestSuite(t *testing.T) {
+	suite.Run(t, &PreferencesGetTestSuite{})
+}
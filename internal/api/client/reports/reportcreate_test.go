@@ -0,0 +1,140 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package reports_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/reports"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ReportCreateTestSuite struct {
+	ReportsStandardTestSuite
+}
+
+func (suite *ReportCreateTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testStatuses = testrig.NewTestStatuses()
+}
+
+func (suite *ReportCreateTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.storage = testrig.NewTestStorage()
+	suite.log = testrig.NewTestLog()
+	suite.federator = testrig.NewTestFederator(suite.db, testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db), suite.storage)
+	suite.processor = testrig.NewTestProcessor(suite.db, suite.storage, suite.federator)
+	suite.reportsModule = reports.New(suite.config, suite.processor, suite.log).(*reports.Module)
+	testrig.StandardDBSetup(suite.db, nil)
+	testrig.StandardStorageSetup(suite.storage, "../../../../testrig/media")
+}
+
+func (suite *ReportCreateTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StandardStorageTeardown(suite.storage)
+}
+
+func (suite *ReportCreateTestSuite) postReport(form url.Values) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodPost, "http://localhost:8080"+reports.BasePath, nil) // the endpoint we're hitting
+	ctx.Request.Form = form
+	suite.reportsModule.ReportPOSTHandler(ctx)
+	return recorder
+}
+
+func (suite *ReportCreateTestSuite) TestReportAccount() {
+	targetAccount := suite.testAccounts["local_account_2"]
+	targetStatus := suite.testStatuses["local_account_2_status_1"]
+
+	recorder := suite.postReport(url.Values{
+		"account_id": {targetAccount.ID},
+		"status_ids": {targetStatus.ID},
+		"comment":    {"this account posts spam"},
+		"forward":    {"true"},
+	})
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	apiReport := &model.Report{}
+	err = json.Unmarshal(b, apiReport)
+	suite.NoError(err)
+	suite.False(apiReport.ActionTaken)
+	suite.Nil(apiReport.ActionTakenAt)
+	suite.Equal("this account posts spam", apiReport.Comment)
+	// the target is local so there's nowhere to forward the report to
+	suite.False(apiReport.Forwarded)
+	suite.Equal([]string{targetStatus.ID}, apiReport.StatusIDs)
+	suite.Equal(targetAccount.ID, apiReport.TargetAccount.ID)
+
+	// the report should be waiting in the moderation queue
+	report, err := suite.db.GetReportByID(context.Background(), apiReport.ID)
+	suite.NoError(err)
+	suite.Equal(suite.testAccounts["local_account_1"].ID, report.AccountID)
+	suite.False(report.Resolved())
+}
+
+func (suite *ReportCreateTestSuite) TestReportStatusOfOtherAccount() {
+	recorder := suite.postReport(url.Values{
+		"account_id": {suite.testAccounts["local_account_2"].ID},
+		"status_ids": {suite.testStatuses["admin_account_status_1"].ID},
+	})
+	suite.EqualValues(http.StatusBadRequest, recorder.Code)
+}
+
+func (suite *ReportCreateTestSuite) TestReportSelf() {
+	recorder := suite.postReport(url.Values{
+		"account_id": {suite.testAccounts["local_account_1"].ID},
+	})
+	suite.EqualValues(http.StatusBadRequest, recorder.Code)
+}
+
+func (suite *ReportCreateTestSuite) TestReportUnknownAccount() {
+	recorder := suite.postReport(url.Values{
+		"account_id": {"01FY1B0S6JX0JJ5WTK4NGBZNHF"},
+	})
+	suite.EqualValues(http.StatusNotFound, recorder.Code)
+}
+
+func TestReportCreateTestSuite(t *testing.T) {
+	suite.Run(t, new(ReportCreateTestSuite))
+}
@@ -0,0 +1,72 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package scheduledstatuses
+
+import (
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/router"
+)
+
+const (
+	// IDKey is for scheduled status UUIDs
+	IDKey = "id"
+	// BasePath is the base path for serving the scheduled statuses API
+	BasePath = "/api/v1/scheduled_statuses"
+	// BasePathWithID is the base path with the ID key in it, for operations on an existing scheduled status.
+	BasePathWithID = BasePath + "/:" + IDKey
+
+	// MaxIDKey is the url query for setting a max scheduled status ID to return
+	MaxIDKey = "max_id"
+	// SinceIDKey is the url query for returning results newer than the given scheduled status ID
+	SinceIDKey = "since_id"
+	// MinIDKey is the url query for returning results immediately newer than the given scheduled status ID
+	MinIDKey = "min_id"
+	// LimitKey is for specifying maximum number of results to return.
+	LimitKey = "limit"
+)
+
+// Module implements the ClientAPIModule interface for everything relating to scheduled statuses
+type Module struct {
+	config    *config.Config
+	processor processing.Processor
+	log       *logrus.Logger
+}
+
+// New returns a new scheduled statuses module
+func New(config *config.Config, processor processing.Processor, log *logrus.Logger) api.ClientModule {
+	return &Module{
+		config:    config,
+		processor: processor,
+		log:       log,
+	}
+}
+
+// Route attaches all routes from this module to the given router
+func (m *Module) Route(r router.Router) error {
+	r.AttachHandler(http.MethodGet, BasePath, m.ScheduledStatusesGETHandler)
+	r.AttachHandler(http.MethodGet, BasePathWithID, m.ScheduledStatusGETHandler)
+	r.AttachHandler(http.MethodPut, BasePathWithID, m.ScheduledStatusUpdatePUTHandler)
+	r.AttachHandler(http.MethodDelete, BasePathWithID, m.ScheduledStatusDELETEHandler)
+	return nil
+}
@@ -0,0 +1,96 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package scheduledstatuses_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type ScheduledStatusPublishTestSuite struct {
+	ScheduledStatusesStandardTestSuite
+}
+
+// newScheduledStatus puts a scheduled status by local_account_1 with the given text in the database.
+func (suite *ScheduledStatusPublishTestSuite) newScheduledStatus(text string, scheduledAt time.Time) *gtsmodel.ScheduledStatus {
+	scheduledStatusID, err := id.NewULID()
+	suite.NoError(err)
+
+	scheduledStatus := &gtsmodel.ScheduledStatus{
+		ID:            scheduledStatusID,
+		ScheduledAt:   scheduledAt,
+		AccountID:     suite.testAccounts["local_account_1"].ID,
+		ApplicationID: suite.testApplications["application_1"].ID,
+		Text:          text,
+		Visibility:    gtsmodel.VisibilityPublic,
+	}
+	suite.NoError(suite.db.PutScheduledStatus(context.Background(), scheduledStatus))
+	return scheduledStatus
+}
+
+func (suite *ScheduledStatusPublishTestSuite) TestPublishFailureKeepsScheduledStatus() {
+	ctx := context.Background()
+	attachment := suite.testAttachments["local_account_1_unattached_1"]
+
+	// this one replies to a status that doesn't exist, so creating the status will fail
+	failing := suite.newScheduledStatus("this can't be posted", time.Now().Add(-time.Minute))
+	failing.InReplyToID = "01FN3VJGFH10KR7S2PB0GFJZYG"
+	failing.MediaIDs = []string{attachment.ID}
+	suite.NoError(suite.db.UpdateScheduledStatus(ctx, failing))
+	attachment.ScheduledStatusID = failing.ID
+	suite.NoError(suite.db.UpdateByID(ctx, attachment.ID, attachment))
+
+	// this one is due after the failing one, so once it's been posted the failing one has been tried too
+	posted := suite.newScheduledStatus("this should be posted", time.Now().Add(2*time.Second))
+
+	// scheduled statuses are loaded and posted by the processor, so it needs to be running
+	suite.NoError(suite.processor.Start(ctx))
+	defer func() {
+		suite.NoError(suite.processor.Stop())
+	}()
+
+	var err error
+	for i := 0; i < 50; i++ {
+		if _, err = suite.db.GetScheduledStatusByID(ctx, posted.ID); err == db.ErrNoEntries {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	suite.Equal(db.ErrNoEntries, err)
+
+	// the failing scheduled status should still be there, and still hold on to its attachment
+	dbScheduledStatus, err := suite.db.GetScheduledStatusByID(ctx, failing.ID)
+	suite.NoError(err)
+	suite.Equal(failing.Text, dbScheduledStatus.Text)
+
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	suite.NoError(err)
+	suite.Equal(failing.ID, dbAttachment.ScheduledStatusID)
+	suite.Empty(dbAttachment.StatusID)
+}
+
+func TestScheduledStatusPublishTestSuite(t *testing.T) {
+	suite.Run(t, new(ScheduledStatusPublishTestSuite))
+}
@@ -41,6 +41,10 @@ const (
 
 	// ContextPath is used for fetching context of posts
 	ContextPath = BasePathWithID + "/context"
+	// HistoryPath is used for fetching the edit history of posts
+	HistoryPath = BasePathWithID + "/history"
+	// SourcePath is used for fetching the plain-text source of posts, for editing them
+	SourcePath = BasePathWithID + "/source"
 
 	// FavouritedPath is for seeing who's faved a given status
 	FavouritedPath = BasePathWithID + "/favourited_by"
@@ -92,6 +96,7 @@ func New(config *config.Config, processor processing.Processor, log *logrus.Logg
 func (m *Module) Route(r router.Router) error {
 	r.AttachHandler(http.MethodPost, BasePath, m.StatusCreatePOSTHandler)
 	r.AttachHandler(http.MethodDelete, BasePathWithID, m.StatusDELETEHandler)
+	r.AttachHandler(http.MethodPut, BasePathWithID, m.StatusEditPUTHandler)
 
 	r.AttachHandler(http.MethodPost, FavouritePath, m.StatusFavePOSTHandler)
 	r.AttachHandler(http.MethodPost, UnfavouritePath, m.StatusUnfavePOSTHandler)
@@ -101,7 +106,18 @@ func (m *Module) Route(r router.Router) error {
 	r.AttachHandler(http.MethodPost, UnreblogPath, m.StatusUnboostPOSTHandler)
 	r.AttachHandler(http.MethodGet, RebloggedPath, m.StatusBoostedByGETHandler)
 
+	r.AttachHandler(http.MethodPost, BookmarkPath, m.StatusBookmarkPOSTHandler)
+	r.AttachHandler(http.MethodPost, UnbookmarkPath, m.StatusUnbookmarkPOSTHandler)
+
+	r.AttachHandler(http.MethodPost, MutePath, m.StatusMutePOSTHandler)
+	r.AttachHandler(http.MethodPost, UnmutePath, m.StatusUnmutePOSTHandler)
+
+	r.AttachHandler(http.MethodPost, PinPath, m.StatusPinPOSTHandler)
+	r.AttachHandler(http.MethodPost, UnpinPath, m.StatusUnpinPOSTHandler)
+
 	r.AttachHandler(http.MethodGet, ContextPath, m.StatusContextGETHandler)
+	r.AttachHandler(http.MethodGet, HistoryPath, m.StatusHistoryGETHandler)
+	r.AttachHandler(http.MethodGet, SourcePath, m.StatusSourceGETHandler)
 
 	r.AttachHandler(http.MethodGet, BasePathWithID, m.muxHandler)
 	return nil
@@ -0,0 +1,144 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package status_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/status"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type StatusContextTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusContextTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testAttachments = testrig.NewTestAttachments()
+	suite.testStatuses = testrig.NewTestStatuses()
+}
+
+func (suite *StatusContextTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.storage = testrig.NewTestStorage()
+	suite.log = testrig.NewTestLog()
+	suite.federator = testrig.NewTestFederator(suite.db, testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db), suite.storage)
+	suite.processor = testrig.NewTestProcessor(suite.db, suite.storage, suite.federator)
+	suite.statusModule = status.New(suite.config, suite.processor, suite.log).(*status.Module)
+	testrig.StandardDBSetup(suite.db, nil)
+	testrig.StandardStorageSetup(suite.storage, "../../../../testrig/media")
+}
+
+func (suite *StatusContextTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StandardStorageTeardown(suite.storage)
+}
+
+func (suite *StatusContextTestSuite) getContext(accountKey string, statusID string) *model.Context {
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[accountKey]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[accountKey])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[accountKey])
+	ctx.Request = httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:8080/api/v1/statuses/%s/context", statusID), nil) // the endpoint we're hitting
+	ctx.Params = gin.Params{gin.Param{Key: status.IDKey, Value: statusID}}
+	suite.statusModule.StatusContextGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	statusContext := &model.Context{}
+	suite.NoError(json.NewDecoder(recorder.Body).Decode(statusContext))
+	return statusContext
+}
+
+func (suite *StatusContextTestSuite) putFilter(accountKey string, filter *gtsmodel.Filter) {
+	filter.AccountID = suite.testAccounts[accountKey].ID
+	filter.CreatedAt = time.Now()
+	filter.UpdatedAt = time.Now()
+	filter.Irreversible = true
+	suite.NoError(suite.db.Put(context.Background(), filter))
+}
+
+func (suite *StatusContextTestSuite) TestGetContext() {
+	// local_account_2_status_5 is a reply to local_account_1_status_1
+	statusContext := suite.getContext("local_account_1", suite.testStatuses["local_account_1_status_1"].ID)
+	suite.Empty(statusContext.Ancestors)
+	suite.Len(statusContext.Descendants, 1)
+	suite.Equal(suite.testStatuses["local_account_2_status_5"].ID, statusContext.Descendants[0].ID)
+}
+
+func (suite *StatusContextTestSuite) TestGetContextDescendantFiltered() {
+	// the reply says "hi zork!"
+	suite.putFilter("local_account_1", &gtsmodel.Filter{
+		ID:            "01G1TRKZ4S6G5NKXBMTDJZ0D8D",
+		Phrase:        "zork",
+		ContextThread: true,
+		WholeWord:     true,
+	})
+
+	statusContext := suite.getContext("local_account_1", suite.testStatuses["local_account_1_status_1"].ID)
+	suite.Empty(statusContext.Descendants)
+}
+
+func (suite *StatusContextTestSuite) TestGetContextAncestorFiltered() {
+	// the original post says "hello everyone!"
+	suite.putFilter("local_account_2", &gtsmodel.Filter{
+		ID:            "01G1TRKZ4S6G5NKXBMTDJZ0D8D",
+		Phrase:        "everyone",
+		ContextThread: true,
+		WholeWord:     true,
+	})
+
+	statusContext := suite.getContext("local_account_2", suite.testStatuses["local_account_2_status_5"].ID)
+	suite.Empty(statusContext.Ancestors)
+}
+
+func (suite *StatusContextTestSuite) TestGetContextFilteredOtherContext() {
+	suite.putFilter("local_account_1", &gtsmodel.Filter{
+		ID:          "01G1TRKZ4S6G5NKXBMTDJZ0D8D",
+		Phrase:      "zork",
+		ContextHome: true,
+		WholeWord:   true,
+	})
+
+	// the filter isn't for threads, so the reply should still be there
+	statusContext := suite.getContext("local_account_1", suite.testStatuses["local_account_1_status_1"].ID)
+	suite.Len(statusContext.Descendants, 1)
+}
+
+func TestStatusContextTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusContextTestSuite))
+}
@@ -22,10 +22,12 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
@@ -55,13 +57,17 @@ import (
 //
 // responses:
 //   '200':
-//     description: "The newly created status."
+//     description: |-
+//       The newly created status.
+//       If scheduled_at was given, a scheduledStatus is returned instead.
 //     schema:
 //       "$ref": "#/definitions/status"
 //   '401':
 //      description: unauthorized
 //   '400':
 //      description: bad request
+//   '403':
+//      description: forbidden, eg., because the account has moved
 //   '404':
 //      description: not found
 //   '500':
@@ -91,6 +97,11 @@ func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "missing one or more required form values"})
 		return
 	}
+	if form.Poll == nil {
+		// poll options given as url-encoded form values
+		// won't be bound to the form by gin, so check them here
+		form.Poll = parsePollForm(c)
+	}
 	l.Debugf("handling status request form: %+v", form)
 
 	// Give the fields on the request form a first pass to make sure the request is superficially valid.
@@ -101,9 +112,27 @@ func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if form.ScheduledAt != "" {
+		// the status should be posted later, so return a scheduled status instead
+		scheduledStatus, errWithCode := m.processor.ScheduledStatusCreate(c.Request.Context(), authed, form)
+		if errWithCode != nil {
+			l.Debugf("error processing scheduled status create: %s", errWithCode)
+			c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+			return
+		}
+
+		c.JSON(http.StatusOK, scheduledStatus)
+		return
+	}
+
 	mastoStatus, err := m.processor.StatusCreate(c.Request.Context(), authed, form)
 	if err != nil {
 		l.Debugf("error processing status create: %s", err)
+		if errWithCode, ok := err.(gtserror.WithCode); ok && errWithCode.Code() == http.StatusForbidden {
+			// the account isn't allowed to post, eg., because it has moved
+			c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": "bad request"})
 		return
 	}
@@ -111,6 +140,13 @@ func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, mastoStatus)
 }
 
+const (
+	// pollMinExpiresIn is the shortest duration, in seconds, that a poll can be open for (5 minutes).
+	pollMinExpiresIn = 300
+	// pollMaxExpiresIn is the longest duration, in seconds, that a poll can be open for (1 month).
+	pollMaxExpiresIn = 2629746
+)
+
 func validateCreateStatus(form *model.AdvancedStatusCreateForm, config *config.StatusesConfig) error {
 	// validate that, structurally, we have a valid status/post
 	if form.Status == "" && form.MediaIDs == nil && form.Poll == nil {
@@ -138,6 +174,9 @@ func validateCreateStatus(form *model.AdvancedStatusCreateForm, config *config.S
 		if form.Poll.Options == nil {
 			return errors.New("poll with no options")
 		}
+		if len(form.Poll.Options) < 2 {
+			return errors.New("poll must have at least 2 options")
+		}
 		if len(form.Poll.Options) > config.PollMaxOptions {
 			return fmt.Errorf("too many poll options provided, %d provided but limit is %d", len(form.Poll.Options), config.PollMaxOptions)
 		}
@@ -146,6 +185,9 @@ func validateCreateStatus(form *model.AdvancedStatusCreateForm, config *config.S
 				return fmt.Errorf("poll option too long, %d characters provided but limit is %d", len(p), config.PollOptionMaxChars)
 			}
 		}
+		if form.Poll.ExpiresIn < pollMinExpiresIn || form.Poll.ExpiresIn > pollMaxExpiresIn {
+			return fmt.Errorf("poll expires_in must be between %d and %d seconds, but %d was provided", pollMinExpiresIn, pollMaxExpiresIn, form.Poll.ExpiresIn)
+		}
 	}
 
 	// validate spoiler text/cw
@@ -164,3 +206,30 @@ func validateCreateStatus(form *model.AdvancedStatusCreateForm, config *config.S
 
 	return nil
 }
+
+// parsePollForm parses poll[options][], poll[expires_in], poll[multiple], and poll[hide_totals] from
+// the request form, returning nil if no poll options were given.
+func parsePollForm(c *gin.Context) *model.PollRequest {
+	options := c.PostFormArray("poll[options][]")
+	if len(options) == 0 {
+		return nil
+	}
+
+	poll := &model.PollRequest{
+		Options: options,
+	}
+
+	if expiresIn, err := strconv.Atoi(c.PostForm("poll[expires_in]")); err == nil {
+		poll.ExpiresIn = expiresIn
+	}
+
+	if multiple, err := strconv.ParseBool(c.PostForm("poll[multiple]")); err == nil {
+		poll.Multiple = multiple
+	}
+
+	if hideTotals, err := strconv.ParseBool(c.PostForm("poll[hide_totals]")); err == nil {
+		poll.HideTotals = hideTotals
+	}
+
+	return poll
+}
@@ -337,6 +337,32 @@ func (suite *StatusCreateTestSuite) TestAttachNewMediaSuccess() {
 	assert.Equal(suite.T(), statusResponse.ID, gtsAttachment.StatusID)
 }
 
+// Try to post from an account that has moved
+func (suite *StatusCreateTestSuite) TestPostFromMovedAccount() {
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+
+	movedAccount := &gtsmodel.Account{}
+	*movedAccount = *suite.testAccounts["local_account_1"]
+	movedAccount.MovedToAccountID = suite.testAccounts["local_account_2"].ID
+
+	// setup
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, movedAccount)
+	ctx.Request = httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080/%s", status.BasePath), nil) // the endpoint we're hitting
+	ctx.Request.Form = url.Values{
+		"status": {"posting from my old account"},
+	}
+	suite.statusModule.StatusCreatePOSTHandler(ctx)
+
+	// check response
+	suite.EqualValues(http.StatusForbidden, recorder.Code)
+}
+
 func TestStatusCreateTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusCreateTestSuite))
 }
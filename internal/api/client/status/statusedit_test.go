@@ -0,0 +1,172 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package status_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/status"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type StatusEditTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusEditTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testAttachments = testrig.NewTestAttachments()
+	suite.testStatuses = testrig.NewTestStatuses()
+}
+
+func (suite *StatusEditTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.storage = testrig.NewTestStorage()
+	suite.log = testrig.NewTestLog()
+	suite.federator = testrig.NewTestFederator(suite.db, testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db), suite.storage)
+	suite.processor = testrig.NewTestProcessor(suite.db, suite.storage, suite.federator)
+	suite.statusModule = status.New(suite.config, suite.processor, suite.log).(*status.Module)
+	testrig.StandardDBSetup(suite.db, nil)
+	testrig.StandardStorageSetup(suite.storage, "../../../../testrig/media")
+}
+
+func (suite *StatusEditTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StandardStorageTeardown(suite.storage)
+}
+
+func (suite *StatusEditTestSuite) newContext(recorder *httptest.ResponseRecorder, method string, path string, targetStatusID string, accountKey string) *gin.Context {
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[accountKey]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[accountKey])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[accountKey])
+	ctx.Request = httptest.NewRequest(method, fmt.Sprintf("http://localhost:8080%s", strings.Replace(path, ":id", targetStatusID, 1)), nil) // the endpoint we're hitting
+
+	// normally the router would populate these params from the path values,
+	// but because we're calling the function directly, we need to set them manually.
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   status.IDKey,
+			Value: targetStatusID,
+		},
+	}
+	return ctx
+}
+
+func (suite *StatusEditTestSuite) TestEditStatus() {
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	// edit the status
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPut, status.BasePathWithID, targetStatus.ID, "local_account_1")
+	ctx.Request.Form = url.Values{
+		"status":       {"hello everyone! (edited)"},
+		"spoiler_text": {"introduction post"},
+		"sensitive":    {"true"},
+	}
+	suite.statusModule.StatusEditPUTHandler(ctx)
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	statusReply := &model.Status{}
+	err = json.Unmarshal(b, statusReply)
+	suite.NoError(err)
+	suite.Equal(targetStatus.ID, statusReply.ID)
+	suite.Equal("<p>hello everyone! (edited)</p>", statusReply.Content)
+	suite.Equal("introduction post", statusReply.SpoilerText)
+	suite.NotEmpty(statusReply.EditedAt)
+
+	// the history should now contain the original and the edited version
+	recorder = httptest.NewRecorder()
+	ctx = suite.newContext(recorder, http.MethodGet, status.HistoryPath, targetStatus.ID, "local_account_1")
+	suite.statusModule.StatusHistoryGETHandler(ctx)
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result = recorder.Result()
+	defer result.Body.Close()
+	b, err = ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	history := []*model.StatusEdit{}
+	err = json.Unmarshal(b, &history)
+	suite.NoError(err)
+	suite.Len(history, 2)
+	suite.Equal(targetStatus.Content, history[0].Content)
+	suite.Equal("<p>hello everyone! (edited)</p>", history[1].Content)
+
+	// and the source should be the new text
+	recorder = httptest.NewRecorder()
+	ctx = suite.newContext(recorder, http.MethodGet, status.SourcePath, targetStatus.ID, "local_account_1")
+	suite.statusModule.StatusSourceGETHandler(ctx)
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result = recorder.Result()
+	defer result.Body.Close()
+	b, err = ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	source := &model.StatusSource{}
+	err = json.Unmarshal(b, source)
+	suite.NoError(err)
+	suite.Equal("hello everyone! (edited)", source.Text)
+	suite.Equal("introduction post", source.SpoilerText)
+}
+
+func (suite *StatusEditTestSuite) TestEditOtherAccountStatus() {
+	targetStatus := suite.testStatuses["local_account_2_status_1"]
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPut, status.BasePathWithID, targetStatus.ID, "local_account_1")
+	ctx.Request.Form = url.Values{
+		"status": {"this isn't my status"},
+	}
+	suite.statusModule.StatusEditPUTHandler(ctx)
+	suite.EqualValues(http.StatusForbidden, recorder.Code)
+
+	// nobody but the author can see the source of a status either
+	recorder = httptest.NewRecorder()
+	ctx = suite.newContext(recorder, http.MethodGet, status.SourcePath, targetStatus.ID, "local_account_1")
+	suite.statusModule.StatusSourceGETHandler(ctx)
+	suite.EqualValues(http.StatusNotFound, recorder.Code)
+}
+
+func TestStatusEditTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusEditTestSuite))
+}
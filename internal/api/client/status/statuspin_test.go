@@ -0,0 +1,144 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package status_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/status"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type StatusPinTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusPinTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testAttachments = testrig.NewTestAttachments()
+	suite.testStatuses = testrig.NewTestStatuses()
+}
+
+func (suite *StatusPinTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.storage = testrig.NewTestStorage()
+	suite.log = testrig.NewTestLog()
+	suite.federator = testrig.NewTestFederator(suite.db, testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db), suite.storage)
+	suite.processor = testrig.NewTestProcessor(suite.db, suite.storage, suite.federator)
+	suite.statusModule = status.New(suite.config, suite.processor, suite.log).(*status.Module)
+	testrig.StandardDBSetup(suite.db, nil)
+	testrig.StandardStorageSetup(suite.storage, "../../../../testrig/media")
+}
+
+func (suite *StatusPinTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StandardStorageTeardown(suite.storage)
+}
+
+func (suite *StatusPinTestSuite) newContext(recorder *httptest.ResponseRecorder, path string, targetStatusID string, accountKey string) *gin.Context {
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[accountKey]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[accountKey])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[accountKey])
+	ctx.Request = httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080%s", strings.Replace(path, ":id", targetStatusID, 1)), nil) // the endpoint we're hitting
+
+	// normally the router would populate these params from the path values,
+	// but because we're calling the function directly, we need to set them manually.
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   status.IDKey,
+			Value: targetStatusID,
+		},
+	}
+	return ctx
+}
+
+func (suite *StatusPinTestSuite) TestPinAndUnpinStatus() {
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	// pin the status
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, status.PinPath, targetStatus.ID, "local_account_1")
+	suite.statusModule.StatusPinPOSTHandler(ctx)
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	statusReply := &model.Status{}
+	err = json.Unmarshal(b, statusReply)
+	suite.NoError(err)
+	suite.Equal(targetStatus.ID, statusReply.ID)
+	suite.True(statusReply.Pinned)
+
+	pinned, err := suite.db.CountAccountPinned(ctx, targetStatus.AccountID)
+	suite.NoError(err)
+	suite.Equal(1, pinned)
+
+	// now unpin it again
+	recorder = httptest.NewRecorder()
+	ctx = suite.newContext(recorder, status.UnpinPath, targetStatus.ID, "local_account_1")
+	suite.statusModule.StatusUnpinPOSTHandler(ctx)
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result = recorder.Result()
+	defer result.Body.Close()
+	b, err = ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	statusReply = &model.Status{}
+	err = json.Unmarshal(b, statusReply)
+	suite.NoError(err)
+	suite.False(statusReply.Pinned)
+
+	pinned, err = suite.db.CountAccountPinned(ctx, targetStatus.AccountID)
+	suite.NoError(err)
+	suite.Equal(0, pinned)
+}
+
+func (suite *StatusPinTestSuite) TestPinOtherAccountStatus() {
+	targetStatus := suite.testStatuses["local_account_2_status_1"]
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, status.PinPath, targetStatus.ID, "local_account_1")
+	suite.statusModule.StatusPinPOSTHandler(ctx)
+	suite.EqualValues(http.StatusForbidden, recorder.Code)
+}
+
+func TestStatusPinTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusPinTestSuite))
+}
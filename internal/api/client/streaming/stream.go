@@ -83,12 +83,14 @@ import (
 //             `update`: a new status has been received.
 //             `notification`: a new notification has been received.
 //             `delete`: a status has been deleted.
+//             `conversation`: a direct message conversation has been updated (only sent to the `direct` stream).
 //             `filters_changed`: not implemented.
 //           type: string
 //           enum:
 //           - update
 //           - notification
 //           - delete
+//           - conversation
 //           - filters_changed
 //         payload:
 //           description: |-
@@ -100,6 +102,7 @@ import (
 //             If `event` = `update`, then the payload will be a JSON string of a status.
 //             If `event` = `notification`, then the payload will be a JSON string of a notification.
 //             If `event` = `delete`, then the payload will be a status ID.
+//             If `event` = `conversation`, then the payload will be a JSON string of a conversation.
 //           type: string
 //           example: "{\"id\":\"01FC3TZ5CFG6H65GCKCJRKA669\",\"created_at\":\"2021-08-02T16:25:52Z\",\"sensitive\":false,\"spoiler_text\":\"\",\"visibility\":\"public\",\"language\":\"en\",\"uri\":\"https://gts.superseriousbusiness.org/users/dumpsterqueer/statuses/01FC3TZ5CFG6H65GCKCJRKA669\",\"url\":\"https://gts.superseriousbusiness.org/@dumpsterqueer/statuses/01FC3TZ5CFG6H65GCKCJRKA669\",\"replies_count\":0,\"reblogs_count\":0,\"favourites_count\":0,\"favourited\":false,\"reblogged\":false,\"muted\":false,\"bookmarked\":fals…//gts.superseriousbusiness.org/fileserver/01JNN207W98SGG3CBJ76R5MVDN/header/original/019036W043D8FXPJKSKCX7G965.png\",\"header_static\":\"https://gts.superseriousbusiness.org/fileserver/01JNN207W98SGG3CBJ76R5MVDN/header/small/019036W043D8FXPJKSKCX7G965.png\",\"followers_count\":33,\"following_count\":28,\"statuses_count\":126,\"last_status_at\":\"2021-08-02T16:25:52Z\",\"emojis\":[],\"fields\":[]},\"media_attachments\":[],\"mentions\":[],\"tags\":[],\"emojis\":[],\"card\":null,\"poll\":null,\"text\":\"a\"}"
 //   '401':
@@ -147,7 +150,7 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 	defer conn.Close() // whatever happens, when we leave this function we want to close the websocket connection
 
 	// inform the processor that we have a new connection and want a stream for it
-	stream, errWithCode := m.processor.OpenStreamForAccount(c.Request.Context(), account, streamType)
+	stream, errWithCode := m.processor.OpenStreamForAccount(c.Request.Context(), account, streamType, accessToken)
 	if errWithCode != nil {
 		c.JSON(errWithCode.Code(), errWithCode.Safe())
 		return
@@ -178,6 +181,14 @@ sendLoop:
 				break sendLoop
 			}
 			l.Trace("wrote ping message into websocket connection")
+		case <-stream.Closed:
+			// the server wants us gone, most likely because the token was revoked, so let the client know why before hanging up
+			l.Debug("stream was closed by the server")
+			msg := websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "access token was revoked")
+			if err := conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(5*time.Second)); err != nil {
+				l.Debugf("error writing close message to websocket connection: %s", err)
+			}
+			break sendLoop
 		}
 	}
 
@@ -0,0 +1,209 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package suggestions_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/suggestions"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type SuggestionsGetTestSuite struct {
+	SuggestionsStandardTestSuite
+}
+
+func (suite *SuggestionsGetTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+}
+
+func (suite *SuggestionsGetTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.storage = testrig.NewTestStorage()
+	suite.log = testrig.NewTestLog()
+	suite.federator = testrig.NewTestFederator(suite.db, testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db), suite.storage)
+	suite.processor = testrig.NewTestProcessor(suite.db, suite.storage, suite.federator)
+	suite.suggestionsModule = suggestions.New(suite.config, suite.processor, suite.log).(*suggestions.Module)
+	testrig.StandardDBSetup(suite.db, nil)
+	testrig.StandardStorageSetup(suite.storage, "../../../../testrig/media")
+
+	// local_account_1 already follows local_account_2 and the admin account, so only remote_account_1 should be shown
+	account := suite.testAccounts["local_account_1"]
+	suggestions := []*gtsmodel.Suggestion{}
+	for i, targetAccountName := range []string{"local_account_2", "remote_account_1", "admin_account"} {
+		suggestions = append(suggestions, &gtsmodel.Suggestion{
+			ID:              fmt.Sprintf("01FY2N0000000000000000000%d", i),
+			AccountID:       account.ID,
+			TargetAccountID: suite.testAccounts[targetAccountName].ID,
+			Source:          gtsmodel.SuggestionSourceFriendsOfFriends,
+			Rank:            i,
+		})
+	}
+	if err := suite.db.ReplaceSuggestionsForAccount(context.Background(), account.ID, suggestions); err != nil {
+		panic(err)
+	}
+}
+
+func (suite *SuggestionsGetTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StandardStorageTeardown(suite.storage)
+}
+
+func (suite *SuggestionsGetTestSuite) newContext(recorder *httptest.ResponseRecorder, method string, path string, targetAccountID string) *gin.Context {
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(method, fmt.Sprintf("http://localhost:8080%s", strings.Replace(path, ":"+suggestions.AccountIDKey, targetAccountID, 1)), nil) // the endpoint we're hitting
+
+	// normally the router would populate these params from the path values,
+	// but because we're calling the function directly, we need to set them manually.
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   suggestions.AccountIDKey,
+			Value: targetAccountID,
+		},
+	}
+	return ctx
+}
+
+func (suite *SuggestionsGetTestSuite) getSuggestions() []*model.Account {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, suggestions.BasePath, "")
+	suite.suggestionsModule.SuggestionsGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := ioutil.ReadAll(recorder.Body)
+	suite.NoError(err)
+
+	accounts := []*model.Account{}
+	suite.NoError(json.Unmarshal(b, &accounts))
+	return accounts
+}
+
+func (suite *SuggestionsGetTestSuite) TestGetSuggestions() {
+	accounts := suite.getSuggestions()
+	suite.Len(accounts, 1)
+	suite.Equal(suite.testAccounts["remote_account_1"].ID, accounts[0].ID)
+}
+
+func (suite *SuggestionsGetTestSuite) TestGetSuggestionsMuted() {
+	suite.NoError(suite.db.Put(context.Background(), &gtsmodel.UserMute{
+		ID:              "01G1TS0D1T4XWZ1FAM7VN0XHQ3",
+		AccountID:       suite.testAccounts["local_account_1"].ID,
+		TargetAccountID: suite.testAccounts["remote_account_1"].ID,
+	}))
+
+	suite.Empty(suite.getSuggestions())
+}
+
+func (suite *SuggestionsGetTestSuite) TestComputeSuggestionsDiscoverable() {
+	ctx := context.Background()
+
+	// remote_account_1 becomes a friend of a friend of local_account_1, but it hasn't opted in to being discovered
+	remoteAccount := suite.testAccounts["remote_account_1"]
+	remoteAccount.Discoverable = false
+	_, err := suite.db.UpdateAccount(ctx, remoteAccount)
+	suite.NoError(err)
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.Follow{
+		ID:              "01G1TS0NH6B0DMZ9N1Z6A0VEXH",
+		AccountID:       suite.testAccounts["admin_account"].ID,
+		TargetAccountID: remoteAccount.ID,
+		URI:             "http://localhost:8080/users/admin/follow/01G1TS0NH6B0DMZ9N1Z6A0VEXH",
+	}))
+
+	// unconfirmed_account isn't discoverable either, but accounts featured by an admin are suggested anyway
+	featuredAccount := suite.testAccounts["unconfirmed_account"]
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.FeaturedAccount{
+		ID:                 "01G1TS0WQ4CTXZ4Q7K6FH3YW5Z",
+		AccountID:          featuredAccount.ID,
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}))
+
+	// suggestions are computed in the background when the processor starts
+	suite.NoError(suite.processor.Start(ctx))
+	defer func() {
+		suite.NoError(suite.processor.Stop())
+	}()
+
+	var accountIDs []string
+	for i := 0; i < 50; i++ {
+		accountIDs = []string{}
+		for _, a := range suite.getSuggestions() {
+			accountIDs = append(accountIDs, a.ID)
+		}
+		if len(accountIDs) != 0 && accountIDs[0] == featuredAccount.ID {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	suite.Equal([]string{featuredAccount.ID}, accountIDs)
+}
+
+func (suite *SuggestionsGetTestSuite) TestDeleteSuggestion() {
+	targetAccount := suite.testAccounts["remote_account_1"]
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodDelete, suggestions.BasePathWithAccountID, targetAccount.ID)
+	suite.suggestionsModule.SuggestionDELETEHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	suite.Empty(suite.getSuggestions())
+
+	// the dismissal should be remembered for next time suggestions are computed
+	dismissedIDs, err := suite.db.GetDismissedSuggestionIDs(context.Background(), suite.testAccounts["local_account_1"].ID)
+	suite.NoError(err)
+	suite.Equal([]string{targetAccount.ID}, dismissedIDs)
+
+	// dismissing again is fine
+	recorder = httptest.NewRecorder()
+	ctx = suite.newContext(recorder, http.MethodDelete, suggestions.BasePathWithAccountID, targetAccount.ID)
+	suite.suggestionsModule.SuggestionDELETEHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+}
+
+func (suite *SuggestionsGetTestSuite) TestDeleteSuggestionUnknownAccount() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodDelete, suggestions.BasePathWithAccountID, "01FY2NZZZZZZZZZZZZZZZZZZZZ")
+	suite.suggestionsModule.SuggestionDELETEHandler(ctx)
+	suite.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func TestSuggestionsGetTestSuite(t *testing.T) {
+	suite.Run(t, &SuggestionsGetTestSuite{})
+}
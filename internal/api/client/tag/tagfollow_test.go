@@ -0,0 +1,159 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package tag_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/tag"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type TagFollowTestSuite struct {
+	TagStandardTestSuite
+}
+
+func (suite *TagFollowTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testTags = testrig.NewTestTags()
+}
+
+func (suite *TagFollowTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.storage = testrig.NewTestStorage()
+	suite.log = testrig.NewTestLog()
+	suite.federator = testrig.NewTestFederator(suite.db, testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db), suite.storage)
+	suite.processor = testrig.NewTestProcessor(suite.db, suite.storage, suite.federator)
+	suite.tagModule = tag.New(suite.config, suite.processor, suite.log).(*tag.Module)
+	testrig.StandardDBSetup(suite.db, nil)
+	testrig.StandardStorageSetup(suite.storage, "../../../../testrig/media")
+}
+
+func (suite *TagFollowTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StandardStorageTeardown(suite.storage)
+}
+
+func (suite *TagFollowTestSuite) newContext(recorder *httptest.ResponseRecorder, method string, path string, tagName string) *gin.Context {
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(method, fmt.Sprintf("http://localhost:8080%s", strings.Replace(path, ":name", tagName, 1)), nil) // the endpoint we're hitting
+
+	// normally the router would populate these params from the path values,
+	// but because we're calling the function directly, we need to set them manually.
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   tag.NameKey,
+			Value: tagName,
+		},
+	}
+	return ctx
+}
+
+func (suite *TagFollowTestSuite) getTag(recorder *httptest.ResponseRecorder) *model.Tag {
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	apiTag := &model.Tag{}
+	err = json.Unmarshal(b, apiTag)
+	suite.NoError(err)
+	return apiTag
+}
+
+func (suite *TagFollowTestSuite) TestFollowAndUnfollowTag() {
+	// follow a tag that nobody has used yet
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, tag.FollowPath, "NewTag")
+	suite.tagModule.TagFollowPOSTHandler(ctx)
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	apiTag := suite.getTag(recorder)
+	suite.Equal("newtag", apiTag.Name)
+	suite.True(apiTag.Following)
+
+	// it should now show up in our followed tags
+	recorder = httptest.NewRecorder()
+	ctx = suite.newContext(recorder, http.MethodGet, tag.FollowedTagsPath, "")
+	suite.tagModule.FollowedTagsGETHandler(ctx)
+	suite.EqualValues(http.StatusOK, recorder.Code)
+	suite.NotEmpty(recorder.Header().Get("Link"))
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	followedTags := []*model.Tag{}
+	err = json.Unmarshal(b, &followedTags)
+	suite.NoError(err)
+	suite.Len(followedTags, 1)
+	suite.Equal("newtag", followedTags[0].Name)
+
+	// unfollow it again
+	recorder = httptest.NewRecorder()
+	ctx = suite.newContext(recorder, http.MethodPost, tag.UnfollowPath, "newtag")
+	suite.tagModule.TagUnfollowPOSTHandler(ctx)
+	suite.EqualValues(http.StatusOK, recorder.Code)
+	suite.False(suite.getTag(recorder).Following)
+
+	// the tag should still exist, we just don't follow it anymore
+	recorder = httptest.NewRecorder()
+	ctx = suite.newContext(recorder, http.MethodGet, tag.BasePathWithName, "newtag")
+	suite.tagModule.TagGETHandler(ctx)
+	suite.EqualValues(http.StatusOK, recorder.Code)
+	suite.False(suite.getTag(recorder).Following)
+}
+
+func (suite *TagFollowTestSuite) TestFollowInvalidTag() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, tag.FollowPath, "not-a-tag")
+	suite.tagModule.TagFollowPOSTHandler(ctx)
+	suite.EqualValues(http.StatusBadRequest, recorder.Code)
+}
+
+func (suite *TagFollowTestSuite) TestGetUnknownTag() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, tag.BasePathWithName, "doesnotexist")
+	suite.tagModule.TagGETHandler(ctx)
+	suite.EqualValues(http.StatusNotFound, recorder.Code)
+}
+
+func TestTagFollowTestSuite(t *testing.T) {
+	suite.Run(t, new(TagFollowTestSuite))
+}
@@ -35,6 +35,14 @@ const (
 	HomeTimeline = BasePath + "/home"
 	// PublicTimeline is the path for the public (and public local) timeline
 	PublicTimeline = BasePath + "/public"
+	// ListTimeline is the path for the timeline of one list
+	ListTimeline = BasePath + "/list/:" + IDKey
+	// TagTimeline is the path for the timeline of one hashtag
+	TagTimeline = BasePath + "/tag/:" + HashtagKey
+	// IDKey is the key to use for retrieving list IDs from the path
+	IDKey = "id"
+	// HashtagKey is the key to use for retrieving hashtag names from the path
+	HashtagKey = "hashtag"
 	// MaxIDKey is the url query for setting a max status ID to return
 	MaxIDKey = "max_id"
 	// SinceIDKey is the url query for returning results newer than the given ID
@@ -67,5 +75,7 @@ func New(config *config.Config, processor processing.Processor, log *logrus.Logg
 func (m *Module) Route(r router.Router) error {
 	r.AttachHandler(http.MethodGet, HomeTimeline, m.HomeTimelineGETHandler)
 	r.AttachHandler(http.MethodGet, PublicTimeline, m.PublicTimelineGETHandler)
+	r.AttachHandler(http.MethodGet, ListTimeline, m.ListTimelineGETHandler)
+	r.AttachHandler(http.MethodGet, TagTimeline, m.TagTimelineGETHandler)
 	return nil
 }
@@ -0,0 +1,261 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package user
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// TwoFactorSetupPOSTHandler swagger:operation POST /api/v1/user/two_factor/setup twoFactorSetup
+//
+// Generate a new secret for two-factor authentication.
+//
+// The secret should be added to an authenticator app, either by typing it in or by scanning a QR code of the provisioning URI.
+// Two-factor authentication isn't required at sign in until it's been enabled with a code from the app.
+// Calling this again before enabling replaces the secret.
+//
+// ---
+// tags:
+// - user
+//
+// produces:
+// - application/json
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:accounts
+//
+// responses:
+//   '200':
+//     description: The new secret.
+//     schema:
+//       "$ref": "#/definitions/twoFactorSetup"
+//   '401':
+//      description: unauthorized
+//   '422':
+//      description: two-factor authentication is already enabled
+func (m *Module) TwoFactorSetupPOSTHandler(c *gin.Context) {
+	l := m.log.WithField("func", "TwoFactorSetupPOSTHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	setup, errWithCode := m.processor.UserTwoFactorSetup(c.Request.Context(), authed)
+	if errWithCode != nil {
+		l.Debugf("error from processor UserTwoFactorSetup: %s", errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, setup)
+}
+
+// TwoFactorEnablePOSTHandler swagger:operation POST /api/v1/user/two_factor/enable twoFactorEnable
+//
+// Enable two-factor authentication, using a code from the secret generated by the setup endpoint.
+//
+// From now on, a code will be needed to sign in. A set of one-time backup codes is returned,
+// which can be used in place of a code if the authenticator app is lost. They won't be shown again.
+//
+// The parameters can also be given in the body of the request, as JSON, if the content-type is set to 'application/json'.
+// The parameters can also be given in the body of the request, as XML, if the content-type is set to 'application/xml'.
+//
+// ---
+// tags:
+// - user
+//
+// consumes:
+// - application/json
+// - application/xml
+// - application/x-www-form-urlencoded
+//
+// produces:
+// - application/json
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:accounts
+//
+// responses:
+//   '200':
+//     description: The backup codes.
+//     schema:
+//       "$ref": "#/definitions/twoFactorBackupCodes"
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+//   '422':
+//      description: the code was not valid, or two-factor authentication is already enabled or hasn't been set up
+func (m *Module) TwoFactorEnablePOSTHandler(c *gin.Context) {
+	l := m.log.WithField("func", "TwoFactorEnablePOSTHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	form := &model.TwoFactorCodeRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	backupCodes, errWithCode := m.processor.UserTwoFactorEnable(c.Request.Context(), authed, form.Code)
+	if errWithCode != nil {
+		l.Debugf("error from processor UserTwoFactorEnable: %s", errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, backupCodes)
+}
+
+// TwoFactorDisablePOSTHandler swagger:operation POST /api/v1/user/two_factor/disable twoFactorDisable
+//
+// Disable two-factor authentication.
+//
+// The parameters can also be given in the body of the request, as JSON, if the content-type is set to 'application/json'.
+// The parameters can also be given in the body of the request, as XML, if the content-type is set to 'application/xml'.
+//
+// ---
+// tags:
+// - user
+//
+// consumes:
+// - application/json
+// - application/xml
+// - application/x-www-form-urlencoded
+//
+// produces:
+// - application/json
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:accounts
+//
+// responses:
+//   '200':
+//     description: Two-factor authentication was disabled.
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+//   '403':
+//      description: the code was not valid
+//   '422':
+//      description: two-factor authentication is not enabled
+func (m *Module) TwoFactorDisablePOSTHandler(c *gin.Context) {
+	l := m.log.WithField("func", "TwoFactorDisablePOSTHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	form := &model.TwoFactorCodeRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if errWithCode := m.processor.UserTwoFactorDisable(c.Request.Context(), authed, form.Code); errWithCode != nil {
+		l.Debugf("error from processor UserTwoFactorDisable: %s", errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// TwoFactorBackupCodesPOSTHandler swagger:operation POST /api/v1/user/two_factor/backup_codes twoFactorBackupCodes
+//
+// Replace the backup codes for two-factor authentication with a fresh set.
+//
+// Any backup codes that haven't been used yet will stop working.
+//
+// The parameters can also be given in the body of the request, as JSON, if the content-type is set to 'application/json'.
+// The parameters can also be given in the body of the request, as XML, if the content-type is set to 'application/xml'.
+//
+// ---
+// tags:
+// - user
+//
+// consumes:
+// - application/json
+// - application/xml
+// - application/x-www-form-urlencoded
+//
+// produces:
+// - application/json
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:accounts
+//
+// responses:
+//   '200':
+//     description: The new backup codes.
+//     schema:
+//       "$ref": "#/definitions/twoFactorBackupCodes"
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+//   '403':
+//      description: the code was not valid
+//   '422':
+//      description: two-factor authentication is not enabled
+func (m *Module) TwoFactorBackupCodesPOSTHandler(c *gin.Context) {
+	l := m.log.WithField("func", "TwoFactorBackupCodesPOSTHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	form := &model.TwoFactorCodeRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	backupCodes, errWithCode := m.processor.UserTwoFactorBackupCodes(c.Request.Context(), authed, form.Code)
+	if errWithCode != nil {
+		l.Debugf("error from processor UserTwoFactorBackupCodes: %s", errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, backupCodes)
+}
@@ -0,0 +1,144 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package user_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/user"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/otp"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type TwoFactorTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *TwoFactorTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testAccounts = testrig.NewTestAccounts()
+}
+
+func (suite *TwoFactorTestSuite) SetupTest() {
+	// the handlers modify the authed user, so get fresh ones for each test
+	suite.testUsers = testrig.NewTestUsers()
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.storage = testrig.NewTestStorage()
+	suite.log = testrig.NewTestLog()
+	suite.federator = testrig.NewTestFederator(suite.db, testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db), suite.storage)
+	suite.processor = testrig.NewTestProcessor(suite.db, suite.storage, suite.federator)
+	suite.userModule = user.New(suite.config, suite.processor, suite.log).(*user.Module)
+	testrig.StandardDBSetup(suite.db, nil)
+	testrig.StandardStorageSetup(suite.storage, "../../../../testrig/media")
+}
+
+func (suite *TwoFactorTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StandardStorageTeardown(suite.storage)
+}
+
+func (suite *TwoFactorTestSuite) newContext(recorder *httptest.ResponseRecorder, path string, code string) *gin.Context {
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080%s", path), nil) // the endpoint we're hitting
+	if code != "" {
+		ctx.Request.Form = url.Values{
+			"code": {code},
+		}
+	}
+	return ctx
+}
+
+func (suite *TwoFactorTestSuite) TestEnableAndDisableTwoFactor() {
+	// get a secret
+	recorder := httptest.NewRecorder()
+	suite.userModule.TwoFactorSetupPOSTHandler(suite.newContext(recorder, user.TwoFactorSetupPath, ""))
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := ioutil.ReadAll(recorder.Body)
+	suite.NoError(err)
+	setup := &model.TwoFactorSetup{}
+	suite.NoError(json.Unmarshal(b, setup))
+	suite.NotEmpty(setup.Secret)
+	suite.Contains(setup.ProvisioningURI, "secret="+setup.Secret)
+
+	// enabling needs a code
+	recorder = httptest.NewRecorder()
+	suite.userModule.TwoFactorEnablePOSTHandler(suite.newContext(recorder, user.TwoFactorEnablePath, ""))
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+
+	code, err := otp.GenerateCode(setup.Secret, time.Now())
+	suite.NoError(err)
+	recorder = httptest.NewRecorder()
+	suite.userModule.TwoFactorEnablePOSTHandler(suite.newContext(recorder, user.TwoFactorEnablePath, code))
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err = ioutil.ReadAll(recorder.Body)
+	suite.NoError(err)
+	backupCodes := &model.TwoFactorBackupCodes{}
+	suite.NoError(json.Unmarshal(b, backupCodes))
+	suite.Len(backupCodes.BackupCodes, otp.BackupCodeCount)
+
+	dbUser := &gtsmodel.User{}
+	suite.NoError(suite.db.GetByID(context.Background(), suite.testUsers["local_account_1"].ID, dbUser))
+	suite.True(dbUser.OTPRequiredForLogin)
+
+	// disabling with a wrong code is forbidden
+	recorder = httptest.NewRecorder()
+	suite.userModule.TwoFactorDisablePOSTHandler(suite.newContext(recorder, user.TwoFactorDisablePath, "notacode12"))
+	suite.Equal(http.StatusForbidden, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	suite.userModule.TwoFactorDisablePOSTHandler(suite.newContext(recorder, user.TwoFactorDisablePath, backupCodes.BackupCodes[0]))
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	dbUser = &gtsmodel.User{}
+	suite.NoError(suite.db.GetByID(context.Background(), suite.testUsers["local_account_1"].ID, dbUser))
+	suite.False(dbUser.OTPRequiredForLogin)
+	suite.Empty(dbUser.EncryptedOTPSecret)
+}
+
+func (suite *TwoFactorTestSuite) TestBackupCodesNotEnabled() {
+	recorder := httptest.NewRecorder()
+	suite.userModule.TwoFactorBackupCodesPOSTHandler(suite.newContext(recorder, user.TwoFactorBackupCodesPath, "123456"))
+	suite.Equal(http.StatusUnprocessableEntity, recorder.Code)
+}
+
+func TestTwoFactorTestSuite(t *testing.T) {
+	suite.Run(t, new(TwoFactorTestSuite))
+}
@@ -0,0 +1,86 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package user
+
+import (
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/router"
+)
+
+const (
+	// BasePath is the base URI path for serving user-level settings of the requesting account
+	BasePath = "/api/v1/user"
+	// TwoFactorPath is the base path for managing two-factor authentication
+	TwoFactorPath = BasePath + "/two_factor"
+	// TwoFactorSetupPath is for generating a new OTP secret
+	TwoFactorSetupPath = TwoFactorPath + "/setup"
+	// TwoFactorEnablePath is for turning on two-factor authentication with a code from the new secret
+	TwoFactorEnablePath = TwoFactorPath + "/enable"
+	// TwoFactorDisablePath is for turning off two-factor authentication
+	TwoFactorDisablePath = TwoFactorPath + "/disable"
+	// TwoFactorBackupCodesPath is for regenerating backup codes
+	TwoFactorBackupCodesPath = TwoFactorPath + "/backup_codes"
+
+	// IDKey is for specifying the id of a webauthn credential in the path
+	IDKey = "id"
+	// WebauthnPath is the base path for managing security keys and passkeys
+	WebauthnPath = BasePath + "/webauthn"
+	// WebauthnRegisterBeginPath is for getting the options to register a new credential with
+	WebauthnRegisterBeginPath = WebauthnPath + "/register/begin"
+	// WebauthnRegisterFinishPath is for sending the newly created credential back
+	WebauthnRegisterFinishPath = WebauthnPath + "/register/finish"
+	// WebauthnCredentialsPath is for listing registered credentials
+	WebauthnCredentialsPath = WebauthnPath + "/credentials"
+	// WebauthnCredentialsPathWithID is for revoking one registered credential
+	WebauthnCredentialsPathWithID = WebauthnCredentialsPath + "/:" + IDKey
+)
+
+// Module implements the ClientAPIModule interface for everything relating to user-level settings
+type Module struct {
+	config    *config.Config
+	processor processing.Processor
+	log       *logrus.Logger
+}
+
+// New returns a new user module
+func New(config *config.Config, processor processing.Processor, log *logrus.Logger) api.ClientModule {
+	return &Module{
+		config:    config,
+		processor: processor,
+		log:       log,
+	}
+}
+
+// Route attaches all routes from this module to the given router
+func (m *Module) Route(r router.Router) error {
+	r.AttachHandler(http.MethodPost, TwoFactorSetupPath, m.TwoFactorSetupPOSTHandler)
+	r.AttachHandler(http.MethodPost, TwoFactorEnablePath, m.TwoFactorEnablePOSTHandler)
+	r.AttachHandler(http.MethodPost, TwoFactorDisablePath, m.TwoFactorDisablePOSTHandler)
+	r.AttachHandler(http.MethodPost, TwoFactorBackupCodesPath, m.TwoFactorBackupCodesPOSTHandler)
+	r.AttachHandler(http.MethodPost, WebauthnRegisterBeginPath, m.WebauthnRegisterBeginPOSTHandler)
+	r.AttachHandler(http.MethodPost, WebauthnRegisterFinishPath, m.WebauthnRegisterFinishPOSTHandler)
+	r.AttachHandler(http.MethodGet, WebauthnCredentialsPath, m.WebauthnCredentialsGETHandler)
+	r.AttachHandler(http.MethodDelete, WebauthnCredentialsPathWithID, m.WebauthnCredentialDELETEHandler)
+	return nil
+}
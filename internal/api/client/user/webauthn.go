@@ -0,0 +1,236 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package user
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// WebauthnRegisterBeginPOSTHandler swagger:operation POST /api/v1/user/webauthn/register/begin webauthnRegisterBegin
+//
+// Start registering a new security key or passkey for signing in.
+//
+// The response should be passed to navigator.credentials.create() in the browser, after base64url decoding
+// publicKey.challenge, publicKey.user.id, and the id of each entry in publicKey.excludeCredentials.
+// This must be done on a page served by this instance, since credentials are tied to the instance's host name.
+// The registration must be finished within five minutes.
+//
+// ---
+// tags:
+// - user
+//
+// produces:
+// - application/json
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:accounts
+//
+// responses:
+//   '200':
+//     description: PublicKeyCredentialCreationOptions, wrapped in an object under the key publicKey.
+//   '401':
+//      description: unauthorized
+func (m *Module) WebauthnRegisterBeginPOSTHandler(c *gin.Context) {
+	l := m.log.WithField("func", "WebauthnRegisterBeginPOSTHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	options, errWithCode := m.processor.UserWebauthnRegisterBegin(c.Request.Context(), authed)
+	if errWithCode != nil {
+		l.Debugf("error from processor UserWebauthnRegisterBegin: %s", errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, options)
+}
+
+// WebauthnRegisterFinishPOSTHandler swagger:operation POST /api/v1/user/webauthn/register/finish webauthnRegisterFinish
+//
+// Finish registering a new security key or passkey, by sending back the credential created by navigator.credentials.create().
+//
+// Once registered, the credential can be used on the sign in page, either after giving a password or instead of one.
+//
+// ---
+// tags:
+// - user
+//
+// consumes:
+// - application/json
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: body
+//   in: body
+//   required: true
+//   schema:
+//     "$ref": "#/definitions/webauthnRegistrationRequest"
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:accounts
+//
+// responses:
+//   '200':
+//     description: The newly registered credential.
+//     schema:
+//       "$ref": "#/definitions/webauthnCredential"
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+//   '422':
+//      description: the credential could not be verified, or no registration was in progress
+func (m *Module) WebauthnRegisterFinishPOSTHandler(c *gin.Context) {
+	l := m.log.WithField("func", "WebauthnRegisterFinishPOSTHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	form := &model.WebauthnRegistrationRequest{}
+	if err := c.ShouldBindJSON(form); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	credential, errWithCode := m.processor.UserWebauthnRegisterFinish(c.Request.Context(), authed, form)
+	if errWithCode != nil {
+		l.Debugf("error from processor UserWebauthnRegisterFinish: %s", errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, credential)
+}
+
+// WebauthnCredentialsGETHandler swagger:operation GET /api/v1/user/webauthn/credentials webauthnCredentialsGet
+//
+// List the security keys and passkeys registered for signing in, oldest first.
+//
+// ---
+// tags:
+// - user
+//
+// produces:
+// - application/json
+//
+// security:
+// - OAuth2 Bearer:
+//   - read:accounts
+//
+// responses:
+//   '200':
+//     description: The registered credentials.
+//     schema:
+//       type: array
+//       items:
+//         "$ref": "#/definitions/webauthnCredential"
+//   '401':
+//      description: unauthorized
+func (m *Module) WebauthnCredentialsGETHandler(c *gin.Context) {
+	l := m.log.WithField("func", "WebauthnCredentialsGETHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	credentials, errWithCode := m.processor.UserWebauthnCredentialsGet(c.Request.Context(), authed)
+	if errWithCode != nil {
+		l.Debugf("error from processor UserWebauthnCredentialsGet: %s", errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, credentials)
+}
+
+// WebauthnCredentialDELETEHandler swagger:operation DELETE /api/v1/user/webauthn/credentials/{id} webauthnCredentialDelete
+//
+// Revoke a security key or passkey, so that it can't be used to sign in anymore.
+//
+// ---
+// tags:
+// - user
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: The id of the credential.
+//   in: path
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:accounts
+//
+// responses:
+//   '200':
+//     description: The credential was revoked.
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) WebauthnCredentialDELETEHandler(c *gin.Context) {
+	l := m.log.WithField("func", "WebauthnCredentialDELETEHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("error authing: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	credentialID := c.Param(IDKey)
+	if credentialID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no credential id provided"})
+		return
+	}
+
+	if errWithCode := m.processor.UserWebauthnCredentialDelete(c.Request.Context(), authed, credentialID); errWithCode != nil {
+		l.Debugf("error from processor UserWebauthnCredentialDelete: %s", errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
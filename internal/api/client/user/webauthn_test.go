@@ -0,0 +1,153 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package user_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/user"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/webauthn"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type WebauthnTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *WebauthnTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testAccounts = testrig.NewTestAccounts()
+}
+
+func (suite *WebauthnTestSuite) SetupTest() {
+	// the handlers modify the authed user, so get fresh ones for each test
+	suite.testUsers = testrig.NewTestUsers()
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.storage = testrig.NewTestStorage()
+	suite.log = testrig.NewTestLog()
+	suite.federator = testrig.NewTestFederator(suite.db, testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db), suite.storage)
+	suite.processor = testrig.NewTestProcessor(suite.db, suite.storage, suite.federator)
+	suite.userModule = user.New(suite.config, suite.processor, suite.log).(*user.Module)
+	testrig.StandardDBSetup(suite.db, nil)
+	testrig.StandardStorageSetup(suite.storage, "../../../../testrig/media")
+}
+
+func (suite *WebauthnTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StandardStorageTeardown(suite.storage)
+}
+
+func (suite *WebauthnTestSuite) newContext(recorder *httptest.ResponseRecorder, method string, path string, body io.Reader) *gin.Context {
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(method, fmt.Sprintf("http://localhost:8080%s", path), body) // the endpoint we're hitting
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	return ctx
+}
+
+func (suite *WebauthnTestSuite) TestRegisterListRevoke() {
+	authenticator := testrig.NewWebauthnAuthenticator("http://localhost:8080")
+
+	// get registration options
+	recorder := httptest.NewRecorder()
+	suite.userModule.WebauthnRegisterBeginPOSTHandler(suite.newContext(recorder, http.MethodPost, user.WebauthnRegisterBeginPath, nil))
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	options := &webauthn.CredentialCreationOptions{}
+	suite.NoError(json.NewDecoder(recorder.Body).Decode(options))
+
+	// make a credential and send it back
+	resp, err := authenticator.Create(options)
+	suite.NoError(err)
+	credential, err := json.Marshal(resp)
+	suite.NoError(err)
+	body, err := json.Marshal(&model.WebauthnRegistrationRequest{
+		Name:       "yubikey",
+		Credential: credential,
+	})
+	suite.NoError(err)
+
+	recorder = httptest.NewRecorder()
+	suite.userModule.WebauthnRegisterFinishPOSTHandler(suite.newContext(recorder, http.MethodPost, user.WebauthnRegisterFinishPath, bytes.NewReader(body)))
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	registered := &model.WebauthnCredential{}
+	suite.NoError(json.NewDecoder(recorder.Body).Decode(registered))
+	suite.NotEmpty(registered.ID)
+	suite.Equal("yubikey", registered.Name)
+
+	// it should be listed
+	recorder = httptest.NewRecorder()
+	suite.userModule.WebauthnCredentialsGETHandler(suite.newContext(recorder, http.MethodGet, user.WebauthnCredentialsPath, nil))
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	credentials := []*model.WebauthnCredential{}
+	suite.NoError(json.NewDecoder(recorder.Body).Decode(&credentials))
+	suite.Len(credentials, 1)
+	suite.Equal(registered.ID, credentials[0].ID)
+
+	// revoke it
+	recorder = httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodDelete, user.WebauthnCredentialsPath+"/"+registered.ID, nil)
+	ctx.Params = gin.Params{gin.Param{Key: user.IDKey, Value: registered.ID}}
+	suite.userModule.WebauthnCredentialDELETEHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	suite.userModule.WebauthnCredentialsGETHandler(suite.newContext(recorder, http.MethodGet, user.WebauthnCredentialsPath, nil))
+	suite.Equal(http.StatusOK, recorder.Code)
+	b, err := ioutil.ReadAll(recorder.Body)
+	suite.NoError(err)
+	suite.Equal("[]", string(b))
+}
+
+func (suite *WebauthnTestSuite) TestRegisterFinishBadJSON() {
+	recorder := httptest.NewRecorder()
+	suite.userModule.WebauthnRegisterFinishPOSTHandler(suite.newContext(recorder, http.MethodPost, user.WebauthnRegisterFinishPath, bytes.NewReader([]byte(`{"name":"yubikey"}`))))
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func (suite *WebauthnTestSuite) TestRevokeNotFound() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodDelete, user.WebauthnCredentialsPath+"/01F8MH1H7YV1Z7D2C8K2730QBF", nil)
+	ctx.Params = gin.Params{gin.Param{Key: user.IDKey, Value: "01F8MH1H7YV1Z7D2C8K2730QBF"}}
+	suite.userModule.WebauthnCredentialDELETEHandler(ctx)
+	suite.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func TestWebauthnTestSuite(t *testing.T) {
+	suite.Run(t, new(WebauthnTestSuite))
+}
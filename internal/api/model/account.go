@@ -85,6 +85,12 @@ type Account struct {
 	Fields []Field `json:"fields"`
 	// Account has been suspended by our instance.
 	Suspended bool `json:"suspended,omitempty"`
+	// If this account has moved, the account that it has moved to.
+	Moved *Account `json:"moved,omitempty"`
+	// Hashtags that this account features on its profile.
+	FeaturedTags []FeaturedTag `json:"featured_tags,omitempty"`
+	// Accounts that this account endorses (features) on its profile.
+	Endorsements []Account `json:"endorsements,omitempty"`
 	// If this account has been muted, when will the mute expire (ISO 8601 Datetime).
 	// example: 2021-07-30T09:20:25+00:00
 	MuteExpiresAt string `json:"mute_expires_at,omitempty"`
@@ -151,6 +157,8 @@ type UpdateCredentialsRequest struct {
 	Source *UpdateSource `form:"source" json:"source" xml:"source"`
 	// Profile metadata name and value
 	FieldsAttributes *[]UpdateField `form:"fields_attributes" json:"fields_attributes" xml:"fields_attributes"`
+	// URIs of other accounts that this account is also known as, eg., accounts that will be moved to this one.
+	AlsoKnownAs *[]string `form:"also_known_as" json:"also_known_as" xml:"also_known_as"`
 }
 
 // UpdateSource is to be used specifically in an UpdateCredentialsRequest.
@@ -163,6 +171,18 @@ type UpdateSource struct {
 	Sensitive *bool `form:"sensitive" json:"sensitive" xml:"sensitive"`
 	// Default language to use for authored statuses. (ISO 6391)
 	Language *string `form:"language" json:"language" xml:"language"`
+	// How media attachments should be shown by default: default, show_all, or hide_all.
+	ExpandMedia *string `form:"expand_media" json:"expand_media" xml:"expand_media"`
+	// Expand content warnings by default.
+	ExpandSpoilers *bool `form:"expand_spoilers" json:"expand_spoilers" xml:"expand_spoilers"`
+	// Include new followers in notification digest emails.
+	EmailNotifyFollow *bool `form:"email_notify_follow" json:"email_notify_follow" xml:"email_notify_follow"`
+	// Include new follow requests in notification digest emails.
+	EmailNotifyFollowRequest *bool `form:"email_notify_follow_request" json:"email_notify_follow_request" xml:"email_notify_follow_request"`
+	// Include mentions in notification digest emails.
+	EmailNotifyMention *bool `form:"email_notify_mention" json:"email_notify_mention" xml:"email_notify_mention"`
+	// Include direct messages in notification digest emails.
+	EmailNotifyDirect *bool `form:"email_notify_direct" json:"email_notify_direct" xml:"email_notify_direct"`
 }
 
 // UpdateField is to be used specifically in an UpdateCredentialsRequest.
@@ -187,3 +207,33 @@ type AccountFollowRequest struct {
 	// Notify when this account posts.
 	Notify *bool `form:"notify" json:"notify" xml:"notify"`
 }
+
+// AccountMuteRequest models a request to mute an account.
+//
+// swagger:ignore
+type AccountMuteRequest struct {
+	// The id of the account to mute.
+	ID string `form:"-" json:"-" xml:"-"`
+	// Mute notifications from this account as well as statuses.
+	Notifications *bool `form:"notifications" json:"notifications" xml:"notifications"`
+	// Number of seconds from now that the mute should expire. 0 or empty means the mute doesn't expire.
+	Duration *int `form:"duration" json:"duration" xml:"duration"`
+}
+
+// AccountDeleteRequest models a request to delete an account.
+//
+// swagger:ignore
+type AccountDeleteRequest struct {
+	// Password of the account's user, for confirmation.
+	Password string `form:"password" json:"password" xml:"password"`
+}
+
+// AccountMoveRequest models a request to move an account to another account.
+//
+// swagger:ignore
+type AccountMoveRequest struct {
+	// ActivityPub URI of the account to move to. The account must already list this account in its aliases.
+	Target string `form:"target" json:"target" xml:"target"`
+	// Password of the account's user, for confirmation.
+	Password string `form:"password" json:"password" xml:"password"`
+}
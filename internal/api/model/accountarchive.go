@@ -0,0 +1,59 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import "mime/multipart"
+
+// AccountArchive represents a zip archive of the requesting account's data, either generated for download, or uploaded to be imported.
+//
+// swagger:model accountArchive
+type AccountArchive struct {
+	// The ID of the archive.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// Whether the archive is an export of the account's data, or an import into the account: export or import.
+	// example: export
+	Type string `json:"type"`
+	// Is the work on this archive done?
+	// example: true
+	Finished bool `json:"finished"`
+	// When the archive was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Size of the zip file in bytes.
+	// example: 204800
+	Size int `json:"size"`
+	// Number of statuses exported into or imported from the archive so far.
+	// example: 125
+	StatusesCount int `json:"statuses_count"`
+	// Why the archive couldn't be processed, if it couldn't.
+	// example: archive contained no outbox.json
+	Error string `json:"error,omitempty"`
+	// Where to download the archive from, once a successful export has finished.
+	// example: https://example.org/api/v1/accounts/archives/01FBVD42CQ3ZEEVMW180SBX03B/download
+	URL string `json:"url,omitempty"`
+}
+
+// AccountArchiveImportRequest is the form submitted as a POST to /api/v1/accounts/archives/import to import an archive.
+//
+// swagger:ignore
+type AccountArchiveImportRequest struct {
+	// The zip archive to import, in the format produced by exporting an archive.
+	Data *multipart.FileHeader `form:"data" json:"data" xml:"data"`
+}
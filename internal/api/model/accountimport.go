@@ -0,0 +1,81 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import "mime/multipart"
+
+// AccountImport represents a CSV file of follows, blocks, mutes, or domain blocks that is being imported into the requesting account.
+//
+// swagger:model accountImport
+type AccountImport struct {
+	// The ID of the import.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// What is being imported: following, blocks, mutes, or domain_blocks.
+	// example: following
+	Type string `json:"type"`
+	// Has every entry of the import been processed?
+	// example: false
+	Finished bool `json:"finished"`
+	// When the import was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Number of entries in the import.
+	// example: 20
+	Total int `json:"total"`
+	// Number of entries processed so far.
+	// example: 15
+	Processed int `json:"processed"`
+	// Number of entries imported successfully.
+	// example: 14
+	Succeeded int `json:"succeeded"`
+	// Number of entries that couldn't be imported.
+	// example: 1
+	Failed int `json:"failed"`
+	// The result of each processed entry of the import, in the order they appeared in the file.
+	Results []AccountImportResult `json:"results"`
+}
+
+// AccountImportResult represents the result of importing one line of an account import file.
+//
+// swagger:model accountImportResult
+type AccountImportResult struct {
+	// Line of the file that this result is for, starting at 1.
+	// example: 3
+	Line int `json:"line"`
+	// Address of the account on this line of the file, or the domain for domain block imports.
+	// example: someone@example.org
+	Account string `json:"account"`
+	// Result of importing this line: succeeded or failed.
+	// example: failed
+	Result string `json:"result"`
+	// Why this line couldn't be imported, if it failed.
+	// example: account could not be found
+	Error string `json:"error,omitempty"`
+}
+
+// AccountImportRequest is the form submitted as a POST to /api/v1/accounts/imports to import a CSV file.
+//
+// swagger:ignore
+type AccountImportRequest struct {
+	// What is being imported: following, blocks, mutes, or domain_blocks.
+	Type string `form:"type" json:"type" xml:"type"`
+	// The CSV file to import, in the format produced by /api/v1/accounts/exports.
+	Data *multipart.FileHeader `form:"data" json:"data" xml:"data"`
+}
@@ -79,3 +79,12 @@ type AdminReportInfo struct {
 	// Statuses attached to the report, for context.
 	Statuses []Status `json:"statuses"`
 }
+
+// AdminFeaturedAccountCreateRequest is the form submitted as a POST to /api/v1/admin/featured_accounts
+// to feature an account as a follow suggestion for everyone on the instance.
+//
+// swagger:model adminFeaturedAccountCreateRequest
+type AdminFeaturedAccountCreateRequest struct {
+	// ID of the account to feature.
+	AccountID string `form:"account_id" json:"account_id" xml:"account_id" binding:"required"`
+}
@@ -69,3 +69,24 @@ type ApplicationCreateRequest struct {
 	// in: formData
 	Website string `form:"website" json:"website" xml:"website"`
 }
+
+// AuthorizedApplication models an application that a user has allowed to use their account.
+//
+// swagger:model authorizedApplication
+type AuthorizedApplication struct {
+	// The ID of the application.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// The name of the application.
+	// example: Tusky
+	Name string `json:"name"`
+	// The website associated with the application (url)
+	// example: https://tusky.app
+	Website string `json:"website,omitempty"`
+	// Scopes that the application has been given, across all of its tokens.
+	// example: ["read","write"]
+	Scopes []string `json:"scopes"`
+	// When the application was first authorized (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+}
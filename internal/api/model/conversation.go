@@ -19,6 +19,8 @@
 package model
 
 // Conversation represents a conversation with "direct message" visibility. See https://docs.joinmastodon.org/entities/conversation/
+//
+// swagger:model conversation
 type Conversation struct {
 	// REQUIRED
 
@@ -34,3 +36,10 @@ type Conversation struct {
 	// The last status in the conversation, to be used for optional display.
 	LastStatus *Status `json:"last_status"`
 }
+
+// ConversationsResponse wraps a slice of conversations, ready to be serialized, along with the Link
+// header for the previous and next queries, to be returned to the client.
+type ConversationsResponse struct {
+	Conversations []*Conversation
+	LinkHeader    string
+}
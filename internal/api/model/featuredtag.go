@@ -18,16 +18,34 @@
 
 package model
 
-// FeaturedTag represents a hashtag that is featured on a profile. See https://docs.joinmastodon.org/entities/featuredtag/
+// FeaturedTag represents a hashtag that is featured on an account's profile.
+//
+// swagger:model featuredTag
 type FeaturedTag struct {
-	// The internal ID of the featured tag in the database.
+	// The id of the featured tag.
+	// example: 01FBW9XGEP7G6K88VY4S9MPE1R
 	ID string `json:"id"`
-	// The name of the hashtag being featured.
+	// The value of the hashtag after the # sign.
+	// example: helloworld
 	Name string `json:"name"`
-	// A link to all statuses by a user that contain this hashtag.
+	// Web link to the hashtag.
+	// example: https://example.org/tags/helloworld
 	URL string `json:"url"`
-	// The number of authored statuses containing this hashtag.
+	// Number of public or unlisted statuses by the account that use this hashtag.
 	StatusesCount int `json:"statuses_count"`
-	// The timestamp of the last authored status containing this hashtag. (ISO 8601 Datetime)
-	LastStatusAt string `json:"last_status_at"`
+	// When the account last used this hashtag in a public or unlisted status (ISO 8601 Datetime).
+	// Null if the hashtag hasn't been used by the account yet.
+	// example: 2021-07-30T09:20:25+00:00
+	LastStatusAt *string `json:"last_status_at"`
+}
+
+// FeaturedTagCreateRequest models a request to feature a hashtag on the requesting account's profile.
+//
+// swagger:parameters featuredTagCreate
+type FeaturedTagCreateRequest struct {
+	// The hashtag to be featured, without the # symbol.
+	// example: helloworld
+	// in: formData
+	// required: true
+	Name string `form:"name" json:"name" xml:"name" binding:"required"`
 }
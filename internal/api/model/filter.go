@@ -25,6 +25,8 @@ package model
 // If the phrase starts with a word character, and if the previous character before matched range is a word character, its matched range should be treated to not match.
 // If the phrase ends with a word character, and if the next character after matched range is a word character, its matched range should be treated to not match.
 // Please check app/javascript/mastodon/selectors/index.js and app/lib/feed_manager.rb in the Mastodon source code for more details.
+//
+// swagger:model filter
 type Filter struct {
 	// The ID of the filter in the database.
 	ID string `json:"id"`
@@ -44,3 +46,29 @@ type Filter struct {
 	// Should matching entities in home and notifications be dropped by the server?
 	Irreversible bool `json:"irreversible"`
 }
+
+// FilterCreateUpdateRequest represents a form submitted to create a new filter, or to replace an existing one.
+//
+// swagger:model filterCreateUpdateRequest
+type FilterCreateUpdateRequest struct {
+	// The text to be filtered.
+	// example: fnord
+	// in: formData
+	// required: true
+	Phrase string `form:"phrase" json:"phrase" xml:"phrase"`
+	// The contexts in which the filter should be applied: one or more of home, notifications, public, thread.
+	// in: formData
+	// required: true
+	Context []string `form:"context[]" json:"context" xml:"context"`
+	// Should matching entities in home and notifications be dropped by the server?
+	// in: formData
+	// default: false
+	Irreversible *bool `form:"irreversible" json:"irreversible" xml:"irreversible"`
+	// Should the filter consider word boundaries?
+	// in: formData
+	// default: false
+	WholeWord *bool `form:"whole_word" json:"whole_word" xml:"whole_word"`
+	// Number of seconds from now that the filter should expire. Leave empty or 0 for a filter that never expires.
+	// in: formData
+	ExpiresIn *int `form:"expires_in" json:"expires_in" xml:"expires_in"`
+}
@@ -19,6 +19,8 @@
 package model
 
 // List represents a list of some users that the authenticated user follows. See https://docs.joinmastodon.org/entities/list/
+//
+// swagger:model list
 type List struct {
 	// The internal database ID of the list.
 	ID string `json:"id"`
@@ -29,3 +31,57 @@ type List struct {
 	//	none = Show replies to no one
 	RepliesPolicy string `json:"replies_policy"`
 }
+
+// ListCreateRequest represents a form submitted to create a new list.
+//
+// swagger:model listCreateRequest
+type ListCreateRequest struct {
+	// Title of this list.
+	// example: Cool People
+	// in: formData
+	// required: true
+	Title string `form:"title" json:"title" xml:"title"`
+	// RepliesPolicy for this list.
+	//	followed = Show replies to any followed user
+	//	list = Show replies to members of the list
+	//	none = Show replies to no one
+	// example: list
+	// default: followed
+	// in: formData
+	RepliesPolicy string `form:"replies_policy" json:"replies_policy" xml:"replies_policy"`
+}
+
+// ListUpdateRequest represents a form submitted to update an existing list.
+//
+// swagger:model listUpdateRequest
+type ListUpdateRequest struct {
+	// Title of this list.
+	// example: Cool People
+	// in: formData
+	Title *string `form:"title" json:"title" xml:"title"`
+	// RepliesPolicy for this list.
+	//	followed = Show replies to any followed user
+	//	list = Show replies to members of the list
+	//	none = Show replies to no one
+	// example: list
+	// in: formData
+	RepliesPolicy *string `form:"replies_policy" json:"replies_policy" xml:"replies_policy"`
+}
+
+// ListAccountsChangeRequest represents a form submitted to add or remove accounts to or from a list.
+//
+// swagger:model listAccountsChangeRequest
+type ListAccountsChangeRequest struct {
+	// Array of accountIDs to modify.
+	// Each accountID must correspond to an account that the requesting account follows.
+	// in: formData
+	// required: true
+	AccountIDs []string `form:"account_ids[]" json:"account_ids" xml:"account_ids"`
+}
+
+// ListAccountsResponse wraps a slice of accounts, ready to be serialized, along with the Link
+// header for the previous and next queries, to be returned to the client.
+type ListAccountsResponse struct {
+	Accounts   []*Account
+	LinkHeader string
+}
@@ -72,3 +72,11 @@ type PollRequest struct {
 	// Hide vote counts until the poll ends.
 	HideTotals bool `form:"hide_totals" json:"hide_totals" xml:"hide_totals"`
 }
+
+// PollVoteRequest models a request to vote in a poll.
+//
+// swagger:ignore
+type PollVoteRequest struct {
+	// Array of own votes containing index for each option (starting from 0).
+	Choices []int `form:"choices[]" json:"choices" xml:"choices"`
+}
@@ -19,6 +19,8 @@
 package model
 
 // Preferences represents a user's preferences. See https://docs.joinmastodon.org/entities/preferences/
+//
+// swagger:model preferences
 type Preferences struct {
 	// Default visibility for new posts.
 	// 	public = Public post
@@ -37,4 +39,12 @@ type Preferences struct {
 	ReadingExpandMedia string `json:"reading:expand:media"`
 	// Whether CWs should be expanded by default.
 	ReadingExpandSpoilers bool `json:"reading:expand:spoilers"`
+	// Whether new followers are included in notification digest emails.
+	NotificationsEmailFollow bool `json:"notifications:email:follow"`
+	// Whether new follow requests are included in notification digest emails.
+	NotificationsEmailFollowRequest bool `json:"notifications:email:follow_request"`
+	// Whether mentions are included in notification digest emails.
+	NotificationsEmailMention bool `json:"notifications:email:mention"`
+	// Whether direct messages are included in notification digest emails.
+	NotificationsEmailDirect bool `json:"notifications:email:direct"`
 }
@@ -0,0 +1,121 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+// Report represents a report of an account, as seen by the account that made it. See https://docs.joinmastodon.org/entities/report/
+//
+// swagger:model report
+type Report struct {
+	// The ID of the report.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// Has a moderator resolved this report yet?
+	// example: false
+	ActionTaken bool `json:"action_taken"`
+	// When the report was resolved (ISO 8601 Datetime), or null if it hasn't been.
+	// example: 2021-07-30T09:20:25+00:00
+	ActionTakenAt *string `json:"action_taken_at"`
+	// Reason given for the report.
+	// example: this account posts spam
+	Comment string `json:"comment"`
+	// Was the report forwarded to the instance of the target account?
+	// example: true
+	Forwarded bool `json:"forwarded"`
+	// When the report was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// IDs of the statuses attached to this report.
+	StatusIDs []string `json:"status_ids"`
+	// The account that was reported.
+	TargetAccount *Account `json:"target_account"`
+}
+
+// AdminReport represents a report of an account, as seen by an admin of this instance. See https://docs.joinmastodon.org/entities/admin-report/
+//
+// swagger:model adminReport
+type AdminReport struct {
+	// The ID of the report.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// Has a moderator resolved this report yet?
+	// example: false
+	ActionTaken bool `json:"action_taken"`
+	// When the report was resolved (ISO 8601 Datetime), or null if it hasn't been.
+	// example: 2021-07-30T09:20:25+00:00
+	ActionTakenAt *string `json:"action_taken_at"`
+	// What was done to the target account when the report was resolved: none, sensitive, silence, or suspend.
+	// example: silence
+	ActionTakenType string `json:"action_taken_type,omitempty"`
+	// The resolving moderator's note about the action taken.
+	// example: told them to stop
+	ActionTakenComment string `json:"action_taken_comment,omitempty"`
+	// Reason given for the report.
+	// example: this account posts spam
+	Comment string `json:"comment"`
+	// Was the report forwarded to the instance of the target account?
+	// example: true
+	Forwarded bool `json:"forwarded"`
+	// When the report was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// When the report was last updated (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	UpdatedAt string `json:"updated_at"`
+	// The account that made the report.
+	Account *Account `json:"account"`
+	// The account that was reported.
+	TargetAccount *Account `json:"target_account"`
+	// The moderator assigned to this report, if any.
+	AssignedAccount *Account `json:"assigned_account"`
+	// The moderator who resolved this report, if any.
+	ActionTakenByAccount *Account `json:"action_taken_by_account"`
+	// The statuses attached to this report.
+	Statuses []*Status `json:"statuses"`
+}
+
+// ReportCreateRequest is the form submitted as a POST to /api/v1/reports to report an account.
+//
+// swagger:model reportCreateRequest
+type ReportCreateRequest struct {
+	// ID of the account to report.
+	AccountID string `form:"account_id" json:"account_id" xml:"account_id"`
+	// IDs of statuses of the reported account to attach to the report.
+	StatusIDs []string `form:"status_ids" json:"status_ids" xml:"status_ids"`
+	// Reason for the report. Maximum 1000 characters.
+	Comment string `form:"comment" json:"comment" xml:"comment"`
+	// If the reported account is on another instance, should the report be forwarded to that instance too?
+	Forward bool `form:"forward" json:"forward" xml:"forward"`
+}
+
+// AdminReportActionRequest is the form submitted as a POST to /api/v1/admin/reports/:id/action to act on a report.
+//
+// swagger:model adminReportActionRequest
+type AdminReportActionRequest struct {
+	// What to do to the reported account: none, sensitive, silence, or suspend.
+	Type string `form:"type" json:"type" xml:"type"`
+	// Note about the action taken, visible to admins only.
+	Text string `form:"text" json:"text" xml:"text"`
+}
+
+// AdminReportsResponse wraps a slice of admin reports, ready to be serialized, along with the Link
+// header for the previous and next queries, to be returned to the client.
+type AdminReportsResponse struct {
+	Reports    []*AdminReport
+	LinkHeader string
+}
@@ -19,6 +19,8 @@
 package model
 
 // ScheduledStatus represents a status that will be published at a future scheduled date. See https://docs.joinmastodon.org/entities/scheduledstatus/
+//
+// swagger:model scheduledStatus
 type ScheduledStatus struct {
 	ID               string        `json:"id"`
 	ScheduledAt      string        `json:"scheduled_at"`
@@ -27,13 +29,32 @@ type ScheduledStatus struct {
 }
 
 // StatusParams represents parameters for a scheduled status. See https://docs.joinmastodon.org/entities/scheduledstatus/
+//
+// swagger:model statusParams
 type StatusParams struct {
-	Text          string   `json:"text"`
-	InReplyToID   string   `json:"in_reply_to_id,omitempty"`
-	MediaIDs      []string `json:"media_ids,omitempty"`
-	Sensitive     bool     `json:"sensitive,omitempty"`
-	SpoilerText   string   `json:"spoiler_text,omitempty"`
-	Visibility    string   `json:"visibility"`
-	ScheduledAt   string   `json:"scheduled_at,omitempty"`
-	ApplicationID string   `json:"application_id"`
+	Text          string       `json:"text"`
+	InReplyToID   string       `json:"in_reply_to_id,omitempty"`
+	MediaIDs      []string     `json:"media_ids,omitempty"`
+	Sensitive     bool         `json:"sensitive,omitempty"`
+	SpoilerText   string       `json:"spoiler_text,omitempty"`
+	Visibility    string       `json:"visibility"`
+	Language      string       `json:"language,omitempty"`
+	Poll          *PollRequest `json:"poll,omitempty"`
+	ScheduledAt   string       `json:"scheduled_at,omitempty"`
+	ApplicationID string       `json:"application_id"`
+}
+
+// ScheduledStatusUpdateRequest models a request to change the time at which a scheduled status will be posted.
+//
+// swagger:ignore
+type ScheduledStatusUpdateRequest struct {
+	// ISO 8601 Datetime at which the status will be published. Must be at least 5 minutes in the future.
+	ScheduledAt string `form:"scheduled_at" json:"scheduled_at" xml:"scheduled_at"`
+}
+
+// ScheduledStatusesResponse wraps a slice of scheduled statuses, ready to be serialized, along with the Link
+// header for the previous and next queries, to be returned to the client.
+type ScheduledStatusesResponse struct {
+	ScheduledStatuses []*ScheduledStatus
+	LinkHeader        string
 }
@@ -38,4 +38,7 @@ type Source struct {
 	Fields []Field `json:"fields"`
 	// The number of pending follow requests.
 	FollowRequestsCount int `json:"follow_requests_count,omitempty"`
+	// URIs of other accounts that this account is also known as.
+	// Accounts in this list are allowed to move to this account.
+	AlsoKnownAs []string `json:"also_known_as,omitempty"`
 }
@@ -0,0 +1,77 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+// StatusEdit represents a revision of a status that has been edited.
+//
+// swagger:model statusEdit
+type StatusEdit struct {
+	// The content of this revision of the status.
+	// example: <p>Hey this is a status!</p>
+	Content string `json:"content"`
+	// Subject, summary, or content warning for this revision of the status.
+	// example: warning nsfw
+	SpoilerText string `json:"spoiler_text"`
+	// Was this revision of the status marked sensitive?
+	// example: false
+	Sensitive bool `json:"sensitive"`
+	// The date when this revision of the status was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// The account that authored this status.
+	Account *Account `json:"account"`
+	// Media that was attached to this revision of the status.
+	MediaAttachments []Attachment `json:"media_attachments"`
+	// Custom emoji to be used when rendering this revision of the status.
+	Emojis []Emoji `json:"emojis"`
+}
+
+// StatusSource represents the plain-text source of a status, for use when editing it.
+//
+// swagger:model statusSource
+type StatusSource struct {
+	// ID of the status.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// Plain-text source of the status.
+	// example: Hey this is a status!
+	Text string `json:"text"`
+	// Plain-text source of the status's content warning.
+	// example: warning nsfw
+	SpoilerText string `json:"spoiler_text"`
+}
+
+// StatusEditRequest models status edit parameters.
+//
+// swagger:ignore
+type StatusEditRequest struct {
+	// Text content of the status.
+	// If media_ids is provided, this becomes optional.
+	Status string `form:"status" json:"status" xml:"status"`
+	// Array of Attachment ids to be attached as media.
+	MediaIDs []string `form:"media_ids" json:"media_ids" xml:"media_ids"`
+	// Status and attached media should be marked as sensitive.
+	Sensitive bool `form:"sensitive" json:"sensitive" xml:"sensitive"`
+	// Text to be shown as a warning or subject before the actual content.
+	// Statuses are generally collapsed behind this field.
+	SpoilerText string `form:"spoiler_text" json:"spoiler_text" xml:"spoiler_text"`
+	// ISO 639 language code for this status.
+	// If empty, the language of the status is left as it was.
+	Language string `form:"language" json:"language" xml:"language"`
+}
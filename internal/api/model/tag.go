@@ -28,4 +28,14 @@ type Tag struct {
 	// Web link to the hashtag.
 	// example: https://example.org/tags/helloworld
 	URL string `json:"url"`
+	// Whether the requesting account follows this hashtag.
+	// Only set when viewing a hashtag directly, or when viewing followed hashtags.
+	Following bool `json:"following,omitempty"`
+}
+
+// FollowedTagsResponse wraps a slice of followed tags, ready to be serialized, along with the Link
+// header for the previous and next queries, to be returned to the client.
+type FollowedTagsResponse struct {
+	Tags       []*Tag
+	LinkHeader string
 }
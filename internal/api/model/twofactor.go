@@ -0,0 +1,53 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+// TwoFactorSetup contains the secret that the user should add to their authenticator app
+// in order to set up two-factor authentication.
+//
+// swagger:model twoFactorSetup
+type TwoFactorSetup struct {
+	// The base32 encoded secret, for typing into an authenticator app by hand.
+	// example: JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP
+	Secret string `json:"secret"`
+	// otpauth:// URI of the secret, for showing as a QR code that authenticator apps can scan.
+	// example: otpauth://totp/example.org:some_user?algorithm=SHA1&digits=6&issuer=example.org&period=30&secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// TwoFactorBackupCodes contains a set of freshly generated one-time backup codes, which the user
+// can use to sign in if they lose access to their authenticator app. They are only shown once.
+//
+// swagger:model twoFactorBackupCodes
+type TwoFactorBackupCodes struct {
+	// The backup codes. Each one can be used only once.
+	BackupCodes []string `json:"backup_codes"`
+}
+
+// TwoFactorCodeRequest models a request that must be confirmed with a code from the user's authenticator app.
+//
+// swagger:parameters twoFactorEnable twoFactorDisable twoFactorBackupCodes
+type TwoFactorCodeRequest struct {
+	// A code from the user's authenticator app. When disabling two-factor authentication or
+	// regenerating backup codes, an unused backup code is also accepted.
+	// example: 123456
+	// in: formData
+	// required: true
+	Code string `form:"code" json:"code" xml:"code" binding:"required"`
+}
@@ -0,0 +1,34 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+// UserDomainBlocksResponse wraps a slice of domains blocked by an account, ready to be serialized,
+// along with the Link header for the previous and next queries, to be returned to the client.
+type UserDomainBlocksResponse struct {
+	Domains    []string
+	LinkHeader string
+}
+
+// UserDomainBlockRequest is the form submitted as a POST or DELETE to /api/v1/domain_blocks to block or unblock a domain.
+//
+// swagger:ignore
+type UserDomainBlockRequest struct {
+	// The domain to block or unblock.
+	Domain string `form:"domain" json:"domain" xml:"domain"`
+}
@@ -0,0 +1,51 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import "encoding/json"
+
+// WebauthnCredential represents a security key or passkey that the user has registered for signing in.
+//
+// swagger:model webauthnCredential
+type WebauthnCredential struct {
+	// The id of the credential.
+	// example: 01FBW9XGEP7G6K88VY4S9MPE1R
+	ID string `json:"id"`
+	// The name given to the credential when it was registered.
+	// example: yubikey
+	Name string `json:"name"`
+	// When the credential was registered (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// When the credential was last used to sign in (ISO 8601 Datetime). Null if it hasn't been used yet.
+	// example: 2021-07-30T09:20:25+00:00
+	LastUsedAt *string `json:"last_used_at"`
+}
+
+// WebauthnRegistrationRequest models a request to finish registering a new security key or passkey.
+//
+// swagger:model webauthnRegistrationRequest
+type WebauthnRegistrationRequest struct {
+	// A name for the credential, so that it can be told apart from others.
+	// example: yubikey
+	Name string `json:"name" xml:"name" binding:"required"`
+	// The PublicKeyCredential returned by navigator.credentials.create(), with id, type, and
+	// response.clientDataJSON and response.attestationObject. Binary values must be base64url encoded.
+	Credential json.RawMessage `json:"credential" xml:"credential" binding:"required"`
+}
@@ -55,6 +55,8 @@ const (
 	UsersFollowersPath = UsersBasePathWithUsername + "/" + util.FollowersPath
 	// UsersFollowingPath is for serving GET request's to a user's following list, with the given username key.
 	UsersFollowingPath = UsersBasePathWithUsername + "/" + util.FollowingPath
+	// UsersFeaturedPath is for serving GET requests to a user's featured collection, with the given username key.
+	UsersFeaturedPath = UsersBasePathWithUsername + "/" + util.CollectionsPath + "/" + util.FeaturedPath
 	// UsersStatusPath is for serving GET requests to a particular status by a user, with the given username key and status ID
 	UsersStatusPath = UsersBasePathWithUsername + "/" + util.StatusesPath + "/:" + StatusIDKey
 	// UsersStatusRepliesPath is for serving the replies collection of a status.
@@ -90,6 +92,7 @@ func (m *Module) Route(s router.Router) error {
 	s.AttachHandler(http.MethodPost, UsersInboxPath, m.InboxPOSTHandler)
 	s.AttachHandler(http.MethodGet, UsersFollowersPath, m.FollowersGETHandler)
 	s.AttachHandler(http.MethodGet, UsersFollowingPath, m.FollowingGETHandler)
+	s.AttachHandler(http.MethodGet, UsersFeaturedPath, m.FeaturedCollectionGETHandler)
 	s.AttachHandler(http.MethodGet, UsersStatusPath, m.StatusGETHandler)
 	s.AttachHandler(http.MethodGet, UsersPublicKeyPath, m.PublicKeyGETHandler)
 	s.AttachHandler(http.MethodGet, UsersStatusRepliesPath, m.StatusRepliesGETHandler)
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-fed/activity/streams"
@@ -15,6 +16,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/api/s2s/user"
 	"github.com/superseriousbusiness/gotosocial/internal/api/security"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -110,6 +112,43 @@ func (suite *UserGetTestSuite) TestGetUser() {
 	assert.EqualValues(suite.T(), targetAccount.Username, a.Username)
 }
 
+func (suite *UserGetTestSuite) TestGetDeletedUser() {
+	// the dereference we're gonna use
+	derefRequests := testrig.NewTestDereferenceRequests(suite.testAccounts)
+	signedRequest := derefRequests["foss_satan_dereference_zork"]
+	targetAccount := suite.testAccounts["local_account_1"]
+
+	// mark the account as deleted
+	deletedAccount := &gtsmodel.Account{}
+	*deletedAccount = *targetAccount
+	deletedAccount.SuspendedAt = time.Now()
+	deletedAccount.SuspensionOrigin = targetAccount.ID
+	_, err := suite.db.UpdateAccount(context.Background(), deletedAccount)
+	suite.NoError(err)
+
+	// setup request
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, targetAccount.URI, nil) // the endpoint we're hitting
+	ctx.Request.Header.Set("Signature", signedRequest.SignatureHeader)
+	ctx.Request.Header.Set("Date", signedRequest.DateHeader)
+
+	// we need to pass the context through signature check first to set appropriate values on it
+	suite.securityModule.SignatureCheck(ctx)
+
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   user.UsernameKey,
+			Value: targetAccount.Username,
+		},
+	}
+
+	suite.userModule.UsersGETHandler(ctx)
+
+	// the account is gone, so we should get a 410
+	suite.EqualValues(http.StatusGone, recorder.Code)
+}
+
 func TestUserGetTestSuite(t *testing.T) {
 	suite.Run(t, new(UserGetTestSuite))
 }
@@ -153,5 +153,6 @@ func copyAccount(account *gtsmodel.Account) *gtsmodel.Account {
 		SuspendedAt:             account.SuspendedAt,
 		HideCollections:         account.HideCollections,
 		SuspensionOrigin:        account.SuspensionOrigin,
+		DeletionRequestedAt:     account.DeletionRequestedAt,
 	}
 }
@@ -126,6 +126,7 @@ func copyStatus(status *gtsmodel.Status) *gtsmodel.Status {
 		Emojis:                   nil,
 		CreatedAt:                status.CreatedAt,
 		UpdatedAt:                status.UpdatedAt,
+		EditedAt:                 status.EditedAt,
 		Local:                    status.Local,
 		AccountID:                status.AccountID,
 		Account:                  nil,
@@ -148,5 +149,7 @@ func copyStatus(status *gtsmodel.Status) *gtsmodel.Status {
 		ActivityStreamsType:      status.ActivityStreamsType,
 		Text:                     status.Text,
 		Pinned:                   status.Pinned,
+		PollID:                   status.PollID,
+		Poll:                     nil,
 	}
 }
@@ -105,6 +105,10 @@ var Confirm cliactions.GTSAction = func(ctx context.Context, c *config.Config, l
 		return err
 	}
 
+	if err := dbConn.DeleteWhere(ctx, []db.Where{{Key: "user_id", Value: u.ID}}, &[]*gtsmodel.WebauthnCredential{}); err != nil && err != db.ErrNoEntries {
+		return err
+	}
+
 	return dbConn.Stop(ctx)
 }
 
@@ -137,6 +141,10 @@ var Promote cliactions.GTSAction = func(ctx context.Context, c *config.Config, l
 		return err
 	}
 
+	if err := dbConn.DeleteWhere(ctx, []db.Where{{Key: "user_id", Value: u.ID}}, &[]*gtsmodel.WebauthnCredential{}); err != nil && err != db.ErrNoEntries {
+		return err
+	}
+
 	return dbConn.Stop(ctx)
 }
 
@@ -169,6 +177,10 @@ var Demote cliactions.GTSAction = func(ctx context.Context, c *config.Config, lo
 		return err
 	}
 
+	if err := dbConn.DeleteWhere(ctx, []db.Where{{Key: "user_id", Value: u.ID}}, &[]*gtsmodel.WebauthnCredential{}); err != nil && err != db.ErrNoEntries {
+		return err
+	}
+
 	return dbConn.Stop(ctx)
 }
 
@@ -201,6 +213,10 @@ var Disable cliactions.GTSAction = func(ctx context.Context, c *config.Config, l
 		return err
 	}
 
+	if err := dbConn.DeleteWhere(ctx, []db.Where{{Key: "user_id", Value: u.ID}}, &[]*gtsmodel.WebauthnCredential{}); err != nil && err != db.ErrNoEntries {
+		return err
+	}
+
 	return dbConn.Stop(ctx)
 }
 
@@ -210,6 +226,57 @@ var Suspend cliactions.GTSAction = func(ctx context.Context, c *config.Config, l
 	return nil
 }
 
+// Delete queues the target account for deletion. The running server will pick it up and remove all of the account's statuses,
+// media, follows, etc, tell other servers that the account is gone, and leave a tombstone in place of the account.
+var Delete cliactions.GTSAction = func(ctx context.Context, c *config.Config, log *logrus.Logger) error {
+	dbConn, err := bundb.NewBunDBService(ctx, c, log)
+	if err != nil {
+		return fmt.Errorf("error creating dbservice: %s", err)
+	}
+
+	username, ok := c.AccountCLIFlags[config.UsernameFlag]
+	if !ok {
+		return errors.New("no username set")
+	}
+	if err := util.ValidateUsername(username); err != nil {
+		return err
+	}
+
+	a, err := dbConn.GetLocalAccountByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	if !a.SuspendedAt.IsZero() {
+		return fmt.Errorf("account %s has already been deleted or suspended", username)
+	}
+
+	// the deletion is attributed to the instance account since there's no admin account involved here
+	instanceAccount, err := dbConn.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	u := &gtsmodel.User{}
+	if err := dbConn.GetWhere(ctx, []db.Where{{Key: "account_id", Value: a.ID}}, u); err != nil {
+		return err
+	}
+	u.Disabled = true
+	if err := dbConn.UpdateByID(ctx, u.ID, u); err != nil {
+		return err
+	}
+
+	a.DeletionRequestedAt = time.Now()
+	a.SuspensionOrigin = instanceAccount.ID
+	if _, err := dbConn.UpdateAccount(ctx, a); err != nil {
+		return err
+	}
+
+	log.Infof("account %s queued for deletion; the deletion will be carried out by the running server", username)
+
+	return dbConn.Stop(ctx)
+}
+
 // Password sets the password of target account.
 var Password cliactions.GTSAction = func(ctx context.Context, c *config.Config, log *logrus.Logger) error {
 	dbConn, err := bundb.NewBunDBService(ctx, c, log)
@@ -256,3 +323,44 @@ var Password cliactions.GTSAction = func(ctx context.Context, c *config.Config,
 
 	return nil
 }
+
+// DisableTwoFactor turns off two-factor authentication for the target account, for users who have lost
+// access to both their authenticator app and their backup codes. Security keys and passkeys are removed too.
+var DisableTwoFactor cliactions.GTSAction = func(ctx context.Context, c *config.Config, log *logrus.Logger) error {
+	dbConn, err := bundb.NewBunDBService(ctx, c, log)
+	if err != nil {
+		return fmt.Errorf("error creating dbservice: %s", err)
+	}
+
+	username, ok := c.AccountCLIFlags[config.UsernameFlag]
+	if !ok {
+		return errors.New("no username set")
+	}
+	if err := util.ValidateUsername(username); err != nil {
+		return err
+	}
+
+	a, err := dbConn.GetLocalAccountByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	u := &gtsmodel.User{}
+	if err := dbConn.GetWhere(ctx, []db.Where{{Key: "account_id", Value: a.ID}}, u); err != nil {
+		return err
+	}
+	u.EncryptedOTPSecret = ""
+	u.EncryptedOTPSecretIv = ""
+	u.EncryptedOTPSecretSalt = ""
+	u.OTPRequiredForLogin = false
+	u.OTPBackupCodes = nil
+	if err := dbConn.UpdateByID(ctx, u.ID, u); err != nil {
+		return err
+	}
+
+	if err := dbConn.DeleteWhere(ctx, []db.Where{{Key: "user_id", Value: u.ID}}, &[]*gtsmodel.WebauthnCredential{}); err != nil && err != db.ErrNoEntries {
+		return err
+	}
+
+	return dbConn.Stop(ctx)
+}
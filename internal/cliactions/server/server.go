@@ -15,19 +15,32 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/app"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/auth"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/blocks"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/bookmarks"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/conversations"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/emoji"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/domainblocks"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/endorsements"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/favourites"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/featuredtags"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/fileserver"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/filter"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/followrequest"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/instance"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/list"
 	mediaModule "github.com/superseriousbusiness/gotosocial/internal/api/client/media"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/mutes"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/notification"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/polls"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/preferences"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/reports"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/scheduledstatuses"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/search"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/status"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/streaming"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/suggestions"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/tag"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/timeline"
+	clientuser "github.com/superseriousbusiness/gotosocial/internal/api/client/user"
 	"github.com/superseriousbusiness/gotosocial/internal/api/s2s/nodeinfo"
 	"github.com/superseriousbusiness/gotosocial/internal/api/s2s/user"
 	"github.com/superseriousbusiness/gotosocial/internal/api/s2s/webfinger"
@@ -36,6 +49,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/cliactions"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
 	"github.com/superseriousbusiness/gotosocial/internal/federation/federatingdb"
 	"github.com/superseriousbusiness/gotosocial/internal/gotosocial"
@@ -57,8 +71,11 @@ var models []interface{} = []interface{}{
 	&gtsmodel.Block{},
 	&gtsmodel.DomainBlock{},
 	&gtsmodel.EmailDomainBlock{},
+	&gtsmodel.Filter{},
 	&gtsmodel.Follow{},
 	&gtsmodel.FollowRequest{},
+	&gtsmodel.List{},
+	&gtsmodel.ListEntry{},
 	&gtsmodel.MediaAttachment{},
 	&gtsmodel.Mention{},
 	&gtsmodel.Status{},
@@ -69,6 +86,25 @@ var models []interface{} = []interface{}{
 	&gtsmodel.StatusMute{},
 	&gtsmodel.Tag{},
 	&gtsmodel.User{},
+	&gtsmodel.UserMute{},
+	&gtsmodel.UserDomainBlock{},
+	&gtsmodel.Poll{},
+	&gtsmodel.PollVote{},
+	&gtsmodel.ScheduledStatus{},
+	&gtsmodel.StatusEdit{},
+	&gtsmodel.TagFollow{},
+	&gtsmodel.Conversation{},
+	&gtsmodel.ConversationToStatus{},
+	&gtsmodel.Report{},
+	&gtsmodel.AccountImport{},
+	&gtsmodel.AccountArchive{},
+	&gtsmodel.FeaturedTag{},
+	&gtsmodel.Endorsement{},
+	&gtsmodel.Suggestion{},
+	&gtsmodel.SuggestionDismissal{},
+	&gtsmodel.FeaturedAccount{},
+	&gtsmodel.QueuedNotificationEmail{},
+	&gtsmodel.WebauthnCredential{},
 	&gtsmodel.Emoji{},
 	&gtsmodel.Instance{},
 	&gtsmodel.Notification{},
@@ -119,7 +155,24 @@ var Start cliactions.GTSAction = func(ctx context.Context, c *config.Config, log
 	oauthServer := oauth.New(dbService, log)
 	transportController := transport.NewController(c, dbService, &federation.Clock{}, http.DefaultClient, log)
 	federator := federation.NewFederator(dbService, federatingDB, transportController, c, log, typeConverter, mediaHandler)
-	processor := processing.NewProcessor(c, typeConverter, federator, oauthServer, mediaHandler, storageBackend, timelineManager, dbService, log)
+
+	// build an email sender, falling back to a no-op sender if smtp isn't configured
+	var emailSender email.Sender
+	if c.SMTPConfig.Host != "" {
+		emailSender, err = email.NewSender(c)
+		if err != nil {
+			return fmt.Errorf("error creating email sender: %s", err)
+		}
+	} else {
+		emailSender, err = email.NewNoopSender(c.TemplateConfig.BaseDir, func(toAddress string, message string) {
+			log.Tracef("smtp host not set, NOT sending email to %s with contents: %s", toAddress, message)
+		})
+		if err != nil {
+			return fmt.Errorf("error creating noop email sender: %s", err)
+		}
+	}
+
+	processor := processing.NewProcessor(c, typeConverter, federator, oauthServer, mediaHandler, storageBackend, timelineManager, dbService, emailSender, log)
 	if err := processor.Start(ctx); err != nil {
 		return fmt.Errorf("error starting processor: %s", err)
 	}
@@ -130,7 +183,7 @@ var Start cliactions.GTSAction = func(ctx context.Context, c *config.Config, log
 	}
 
 	// build client api modules
-	authModule := auth.New(c, dbService, oauthServer, idp, log)
+	authModule := auth.New(c, dbService, oauthServer, idp, processor, log)
 	accountModule := account.New(c, processor, log)
 	instanceModule := instance.New(c, processor, log)
 	appsModule := app.New(c, processor, log)
@@ -153,6 +206,19 @@ var Start cliactions.GTSAction = func(ctx context.Context, c *config.Config, log
 	streamingModule := streaming.New(c, processor, log)
 	favouritesModule := favourites.New(c, processor, log)
 	blocksModule := blocks.New(c, processor, log)
+	bookmarksModule := bookmarks.New(c, processor, log)
+	pollsModule := polls.New(c, processor, log)
+	scheduledStatusesModule := scheduledstatuses.New(c, processor, log)
+	mutesModule := mutes.New(c, processor, log)
+	domainBlocksModule := domainblocks.New(c, processor, log)
+	tagsModule := tag.New(c, processor, log)
+	featuredTagsModule := featuredtags.New(c, processor, log)
+	endorsementsModule := endorsements.New(c, processor, log)
+	preferencesModule := preferences.New(c, processor, log)
+	suggestionsModule := suggestions.New(c, processor, log)
+	conversationsModule := conversations.New(c, processor, log)
+	reportsModule := reports.New(c, processor, log)
+	clientUserModule := clientuser.New(c, processor, log)
 
 	apis := []api.ClientModule{
 		// modules with middleware go first
@@ -181,6 +247,19 @@ var Start cliactions.GTSAction = func(ctx context.Context, c *config.Config, log
 		streamingModule,
 		favouritesModule,
 		blocksModule,
+		mutesModule,
+		domainBlocksModule,
+		bookmarksModule,
+		pollsModule,
+		scheduledStatusesModule,
+		tagsModule,
+		featuredTagsModule,
+		endorsementsModule,
+		preferencesModule,
+		suggestionsModule,
+		conversationsModule,
+		reportsModule,
+		clientUserModule,
 	}
 
 	for _, m := range apis {
@@ -17,19 +17,32 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/app"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/auth"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/blocks"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/bookmarks"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/conversations"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/emoji"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/domainblocks"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/endorsements"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/favourites"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/featuredtags"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/fileserver"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/filter"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/followrequest"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/instance"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/list"
 	mediaModule "github.com/superseriousbusiness/gotosocial/internal/api/client/media"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/mutes"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/notification"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/polls"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/preferences"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/reports"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/scheduledstatuses"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/search"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/status"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/streaming"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/suggestions"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/tag"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/timeline"
+	clientuser "github.com/superseriousbusiness/gotosocial/internal/api/client/user"
 	"github.com/superseriousbusiness/gotosocial/internal/api/s2s/nodeinfo"
 	"github.com/superseriousbusiness/gotosocial/internal/api/s2s/user"
 	"github.com/superseriousbusiness/gotosocial/internal/api/s2s/webfinger"
@@ -73,7 +86,7 @@ var Start cliactions.GTSAction = func(ctx context.Context, _ *config.Config, log
 	}
 
 	// build client api modules
-	authModule := auth.New(c, dbService, oauthServer, idp, log)
+	authModule := auth.New(c, dbService, oauthServer, idp, processor, log)
 	accountModule := account.New(c, processor, log)
 	instanceModule := instance.New(c, processor, log)
 	appsModule := app.New(c, processor, log)
@@ -96,6 +109,19 @@ var Start cliactions.GTSAction = func(ctx context.Context, _ *config.Config, log
 	streamingModule := streaming.New(c, processor, log)
 	favouritesModule := favourites.New(c, processor, log)
 	blocksModule := blocks.New(c, processor, log)
+	bookmarksModule := bookmarks.New(c, processor, log)
+	pollsModule := polls.New(c, processor, log)
+	scheduledStatusesModule := scheduledstatuses.New(c, processor, log)
+	mutesModule := mutes.New(c, processor, log)
+	domainBlocksModule := domainblocks.New(c, processor, log)
+	tagsModule := tag.New(c, processor, log)
+	featuredTagsModule := featuredtags.New(c, processor, log)
+	endorsementsModule := endorsements.New(c, processor, log)
+	preferencesModule := preferences.New(c, processor, log)
+	suggestionsModule := suggestions.New(c, processor, log)
+	conversationsModule := conversations.New(c, processor, log)
+	reportsModule := reports.New(c, processor, log)
+	clientUserModule := clientuser.New(c, processor, log)
 
 	apis := []api.ClientModule{
 		// modules with middleware go first
@@ -124,6 +150,19 @@ var Start cliactions.GTSAction = func(ctx context.Context, _ *config.Config, log
 		streamingModule,
 		favouritesModule,
 		blocksModule,
+		mutesModule,
+		domainBlocksModule,
+		bookmarksModule,
+		pollsModule,
+		scheduledStatusesModule,
+		tagsModule,
+		featuredTagsModule,
+		endorsementsModule,
+		preferencesModule,
+		suggestionsModule,
+		conversationsModule,
+		reportsModule,
+		clientUserModule,
 	}
 
 	for _, m := range apis {
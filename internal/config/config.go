@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"gopkg.in/yaml.v2"
 )
@@ -60,6 +61,7 @@ type Config struct {
 	StatusesConfig    *StatusesConfig    `yaml:"statuses"`
 	LetsEncryptConfig *LetsEncryptConfig `yaml:"letsEncrypt"`
 	OIDCConfig        *OIDCConfig        `yaml:"oidc"`
+	SMTPConfig        *SMTPConfig        `yaml:"smtp"`
 
 	/*
 		Not parsed from .yaml configuration file.
@@ -91,6 +93,7 @@ func Empty() *Config {
 		StatusesConfig:    &StatusesConfig{},
 		LetsEncryptConfig: &LetsEncryptConfig{},
 		OIDCConfig:        &OIDCConfig{},
+		SMTPConfig:        &SMTPConfig{},
 		AccountCLIFlags:   make(map[string]string),
 	}
 }
@@ -313,6 +316,31 @@ func (c *Config) ParseCLIFlags(f KeyedFlags, version string) error {
 		c.OIDCConfig.Scopes = f.StringSlice(fn.OIDCScopes)
 	}
 
+	// smtp flags
+	if c.SMTPConfig.Host == "" || f.IsSet(fn.SMTPHost) {
+		c.SMTPConfig.Host = f.String(fn.SMTPHost)
+	}
+
+	if c.SMTPConfig.Port == 0 || f.IsSet(fn.SMTPPort) {
+		c.SMTPConfig.Port = f.Int(fn.SMTPPort)
+	}
+
+	if c.SMTPConfig.Username == "" || f.IsSet(fn.SMTPUsername) {
+		c.SMTPConfig.Username = f.String(fn.SMTPUsername)
+	}
+
+	if c.SMTPConfig.Password == "" || f.IsSet(fn.SMTPPassword) {
+		c.SMTPConfig.Password = f.String(fn.SMTPPassword)
+	}
+
+	if c.SMTPConfig.From == "" || f.IsSet(fn.SMTPFrom) {
+		c.SMTPConfig.From = f.String(fn.SMTPFrom)
+	}
+
+	if c.SMTPConfig.NotificationDigestInterval == 0 || f.IsSet(fn.SMTPNotificationDigestInterval) {
+		c.SMTPConfig.NotificationDigestInterval = f.Duration(fn.SMTPNotificationDigestInterval)
+	}
+
 	// command-specific flags
 
 	// admin account CLI flags
@@ -331,6 +359,7 @@ type KeyedFlags interface {
 	String(k string) string
 	StringSlice(k string) []string
 	Int(k string) int
+	Duration(k string) time.Duration
 	IsSet(k string) bool
 }
 
@@ -391,6 +420,14 @@ type Flags struct {
 	OIDCClientID         string
 	OIDCClientSecret     string
 	OIDCScopes           string
+
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+
+	SMTPNotificationDigestInterval string
 }
 
 // Defaults contains all the default values for a gotosocial config
@@ -450,6 +487,14 @@ type Defaults struct {
 	OIDCClientID         string
 	OIDCClientSecret     string
 	OIDCScopes           []string
+
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+
+	SMTPNotificationDigestInterval time.Duration
 }
 
 // GetFlagNames returns a struct containing the names of the various flags used for
@@ -510,6 +555,14 @@ func GetFlagNames() Flags {
 		OIDCClientID:         "oidc-client-id",
 		OIDCClientSecret:     "oidc-client-secret",
 		OIDCScopes:           "oidc-scopes",
+
+		SMTPHost:     "smtp-host",
+		SMTPPort:     "smtp-port",
+		SMTPUsername: "smtp-username",
+		SMTPPassword: "smtp-password",
+		SMTPFrom:     "smtp-from",
+
+		SMTPNotificationDigestInterval: "smtp-notification-digest-interval",
 	}
 }
 
@@ -571,5 +624,13 @@ func GetEnvNames() Flags {
 		OIDCClientID:         "GTS_OIDC_CLIENT_ID",
 		OIDCClientSecret:     "GTS_OIDC_CLIENT_SECRET",
 		OIDCScopes:           "GTS_OIDC_SCOPES",
+
+		SMTPHost:     "GTS_SMTP_HOST",
+		SMTPPort:     "GTS_SMTP_PORT",
+		SMTPUsername: "GTS_SMTP_USERNAME",
+		SMTPPassword: "GTS_SMTP_PASSWORD",
+		SMTPFrom:     "GTS_SMTP_FROM",
+
+		SMTPNotificationDigestInterval: "GTS_SMTP_NOTIFICATION_DIGEST_INTERVAL",
 	}
 }
@@ -1,6 +1,10 @@
 package config
 
-import "github.com/coreos/go-oidc/v3/oidc"
+import (
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+)
 
 // TestDefault returns a default config for testing
 func TestDefault() *Config {
@@ -66,6 +70,14 @@ func TestDefault() *Config {
 			ClientSecret:     defaults.OIDCClientSecret,
 			Scopes:           defaults.OIDCScopes,
 		},
+		SMTPConfig: &SMTPConfig{
+			Host:                       defaults.SMTPHost,
+			Port:                       defaults.SMTPPort,
+			Username:                   defaults.SMTPUsername,
+			Password:                   defaults.SMTPPassword,
+			From:                       defaults.SMTPFrom,
+			NotificationDigestInterval: defaults.SMTPNotificationDigestInterval,
+		},
 	}
 }
 
@@ -133,6 +145,14 @@ func Default() *Config {
 			ClientSecret:     defaults.OIDCClientSecret,
 			Scopes:           defaults.OIDCScopes,
 		},
+		SMTPConfig: &SMTPConfig{
+			Host:                       defaults.SMTPHost,
+			Port:                       defaults.SMTPPort,
+			Username:                   defaults.SMTPUsername,
+			Password:                   defaults.SMTPPassword,
+			From:                       defaults.SMTPFrom,
+			NotificationDigestInterval: defaults.SMTPNotificationDigestInterval,
+		},
 	}
 }
 
@@ -194,6 +214,14 @@ func GetDefaults() Defaults {
 		OIDCClientID:         "",
 		OIDCClientSecret:     "",
 		OIDCScopes:           []string{oidc.ScopeOpenID, "profile", "email", "groups"},
+
+		SMTPHost:     "",
+		SMTPPort:     587,
+		SMTPUsername: "",
+		SMTPPassword: "",
+		SMTPFrom:     "",
+
+		SMTPNotificationDigestInterval: time.Hour,
 	}
 }
 
@@ -252,5 +280,13 @@ func GetTestDefaults() Defaults {
 		OIDCClientID:         "",
 		OIDCClientSecret:     "",
 		OIDCScopes:           []string{oidc.ScopeOpenID, "profile", "email", "groups"},
+
+		SMTPHost:     "",
+		SMTPPort:     0,
+		SMTPUsername: "",
+		SMTPPassword: "",
+		SMTPFrom:     "",
+
+		SMTPNotificationDigestInterval: time.Hour,
 	}
 }
@@ -48,6 +48,9 @@ type Account interface {
 	// GetAccountStatusesCount is a shortcut for the common action of counting statuses produced by accountID.
 	CountAccountStatuses(ctx context.Context, accountID string) (int, Error)
 
+	// CountAccountPinned returns the number of statuses that accountID currently has pinned to their profile.
+	CountAccountPinned(ctx context.Context, accountID string) (int, Error)
+
 	// GetAccountStatuses is a shortcut for getting the most recent statuses. accountID is optional, if not provided
 	// then all statuses will be returned. If limit is set to 0, the size of the returned slice will not be limited. This can
 	// be very memory intensive so you probably shouldn't do this!
@@ -67,4 +70,10 @@ type Account interface {
 	// GetInstanceAccount returns the instance account for the given domain.
 	// If domain is empty, this instance account will be returned.
 	GetInstanceAccount(ctx context.Context, domain string) (*gtsmodel.Account, Error)
+
+	// GetAccountsPendingDeletion returns local accounts whose deletion has been requested, but not yet carried out.
+	GetAccountsPendingDeletion(ctx context.Context) ([]*gtsmodel.Account, Error)
+
+	// GetRemoteInboxURIs returns the inbox URIs of all remote accounts that we know about and that haven't been suspended.
+	GetRemoteInboxURIs(ctx context.Context) ([]string, Error)
 }
@@ -0,0 +1,47 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// AccountArchive contains functions for creating, getting, updating, and deleting account archive exports and imports.
+type AccountArchive interface {
+	// GetAccountArchiveByID returns one account archive with the given ID, or an error if something goes wrong.
+	GetAccountArchiveByID(ctx context.Context, id string) (*gtsmodel.AccountArchive, Error)
+
+	// GetAccountArchives returns all account archives of the given type belonging to the given account, newest first.
+	GetAccountArchives(ctx context.Context, accountID string, archiveType gtsmodel.AccountArchiveType) ([]*gtsmodel.AccountArchive, Error)
+
+	// GetUnfinishedAccountArchives returns all account archives that haven't been finished yet, oldest first.
+	GetUnfinishedAccountArchives(ctx context.Context) ([]*gtsmodel.AccountArchive, Error)
+
+	// PutAccountArchive puts a new account archive in the database.
+	PutAccountArchive(ctx context.Context, archive *gtsmodel.AccountArchive) Error
+
+	// UpdateAccountArchive updates the given account archive in the database.
+	UpdateAccountArchive(ctx context.Context, archive *gtsmodel.AccountArchive) Error
+
+	// DeleteAccountArchiveByID deletes one account archive with the given ID from the database.
+	// It doesn't remove the archive's file from storage.
+	DeleteAccountArchiveByID(ctx context.Context, id string) Error
+}
@@ -0,0 +1,40 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// AccountImport contains functions for creating, getting, and updating CSV imports of follows, blocks, and mutes.
+type AccountImport interface {
+	// GetAccountImportByID returns one account import with the given ID, or an error if something goes wrong.
+	GetAccountImportByID(ctx context.Context, id string) (*gtsmodel.AccountImport, Error)
+
+	// GetUnfinishedAccountImports returns all account imports that haven't been finished yet, oldest first.
+	GetUnfinishedAccountImports(ctx context.Context) ([]*gtsmodel.AccountImport, Error)
+
+	// PutAccountImport puts a new account import in the database.
+	PutAccountImport(ctx context.Context, accountImport *gtsmodel.AccountImport) Error
+
+	// UpdateAccountImport updates the given account import in the database.
+	UpdateAccountImport(ctx context.Context, accountImport *gtsmodel.AccountImport) Error
+}
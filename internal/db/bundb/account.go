@@ -130,13 +130,14 @@ func (a *accountDB) GetInstanceAccount(ctx context.Context, domain string) (*gts
 	q := a.newAccountQ(account)
 
 	if domain == "" {
+		// the instance account of this instance is named after our host, and has no domain
 		q = q.
-			Where("account.username = ?", domain).
-			Where("account.domain = ?", domain)
+			Where("account.username = ?", a.config.Host).
+			WhereGroup(" AND ", whereEmptyOrNull("domain"))
 	} else {
 		q = q.
 			Where("account.username = ?", domain).
-			WhereGroup(" AND ", whereEmptyOrNull("domain"))
+			Where("account.domain = ?", domain)
 	}
 
 	err := q.Scan(ctx)
@@ -233,6 +234,15 @@ func (a *accountDB) CountAccountStatuses(ctx context.Context, accountID string)
 		Count(ctx)
 }
 
+func (a *accountDB) CountAccountPinned(ctx context.Context, accountID string) (int, db.Error) {
+	return a.conn.
+		NewSelect().
+		Model(&gtsmodel.Status{}).
+		Where("account_id = ?", accountID).
+		Where("pinned = ?", true).
+		Count(ctx)
+}
+
 func (a *accountDB) GetAccountStatuses(ctx context.Context, accountID string, limit int, excludeReplies bool, maxID string, pinnedOnly bool, mediaOnly bool) ([]*gtsmodel.Status, db.Error) {
 	statuses := []*gtsmodel.Status{}
 
@@ -320,3 +330,37 @@ func (a *accountDB) GetAccountBlocks(ctx context.Context, accountID string, maxI
 	prevMinID := blocks[0].ID
 	return accounts, nextMaxID, prevMinID, nil
 }
+
+func (a *accountDB) GetAccountsPendingDeletion(ctx context.Context) ([]*gtsmodel.Account, db.Error) {
+	accounts := []*gtsmodel.Account{}
+
+	q := a.conn.
+		NewSelect().
+		Model(&accounts).
+		Where("deletion_requested_at IS NOT NULL").
+		Where("suspended_at IS NULL").
+		WhereGroup(" AND ", whereEmptyOrNull("domain"))
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+	return accounts, nil
+}
+
+func (a *accountDB) GetRemoteInboxURIs(ctx context.Context) ([]string, db.Error) {
+	inboxURIs := []string{}
+
+	q := a.conn.
+		NewSelect().
+		Model((*gtsmodel.Account)(nil)).
+		ColumnExpr("DISTINCT ?", bun.Ident("inbox_uri")).
+		Where("domain IS NOT NULL").
+		Where("domain != ''").
+		Where("inbox_uri IS NOT NULL").
+		Where("suspended_at IS NULL")
+
+	if err := q.Scan(ctx, &inboxURIs); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+	return inboxURIs, nil
+}
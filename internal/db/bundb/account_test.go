@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -81,6 +82,64 @@ func (suite *AccountTestSuite) TestUpdateAccount() {
 	suite.WithinDuration(time.Now(), updated.UpdatedAt, 5*time.Second)
 }
 
+func (suite *AccountTestSuite) TestGetAccountsPendingDeletion() {
+	pending, err := suite.db.GetAccountsPendingDeletion(context.Background())
+	suite.NoError(err)
+	suite.Empty(pending)
+
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_2"]
+	testAccount.DeletionRequestedAt = time.Now()
+	testAccount.SuspensionOrigin = testAccount.ID
+	_, err = suite.db.UpdateAccount(context.Background(), testAccount)
+	suite.NoError(err)
+
+	pending, err = suite.db.GetAccountsPendingDeletion(context.Background())
+	suite.NoError(err)
+	suite.Len(pending, 1)
+	suite.Equal(testAccount.ID, pending[0].ID)
+
+	// once the account has been deleted it's no longer pending
+	testAccount.SuspendedAt = time.Now()
+	_, err = suite.db.UpdateAccount(context.Background(), testAccount)
+	suite.NoError(err)
+
+	pending, err = suite.db.GetAccountsPendingDeletion(context.Background())
+	suite.NoError(err)
+	suite.Empty(pending)
+}
+
+func (suite *AccountTestSuite) TestGetRemoteInboxURIs() {
+	inboxes, err := suite.db.GetRemoteInboxURIs(context.Background())
+	suite.NoError(err)
+	suite.NotEmpty(inboxes)
+	suite.Contains(inboxes, suite.testAccounts["remote_account_1"].InboxURI)
+	suite.NotContains(inboxes, suite.testAccounts["local_account_1"].InboxURI)
+}
+
+func (suite *AccountTestSuite) TestDeletedUsernameNotAvailable() {
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_2"]
+
+	available, err := suite.db.IsUsernameAvailable(context.Background(), testAccount.Username)
+	suite.NoError(err)
+	suite.False(available)
+
+	// a deleted account leaves its entry in place, so the username still can't be taken
+	testAccount.SuspendedAt = time.Now()
+	testAccount.SuspensionOrigin = testAccount.ID
+	_, err = suite.db.UpdateAccount(context.Background(), testAccount)
+	suite.NoError(err)
+
+	available, err = suite.db.IsUsernameAvailable(context.Background(), testAccount.Username)
+	suite.NoError(err)
+	suite.False(available)
+
+	available, err = suite.db.IsUsernameAvailable(context.Background(), "some_brand_new_username")
+	suite.NoError(err)
+	suite.True(available)
+}
+
 func TestAccountTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountTestSuite))
 }
@@ -0,0 +1,116 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type accountArchiveDB struct {
+	config *config.Config
+	conn   *DBConn
+}
+
+func (a *accountArchiveDB) GetAccountArchiveByID(ctx context.Context, id string) (*gtsmodel.AccountArchive, db.Error) {
+	archive := &gtsmodel.AccountArchive{}
+
+	err := a.conn.
+		NewSelect().
+		Model(archive).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+	return archive, nil
+}
+
+func (a *accountArchiveDB) GetAccountArchives(ctx context.Context, accountID string, archiveType gtsmodel.AccountArchiveType) ([]*gtsmodel.AccountArchive, db.Error) {
+	archives := []*gtsmodel.AccountArchive{}
+
+	err := a.conn.
+		NewSelect().
+		Model(&archives).
+		Where("account_id = ?", accountID).
+		Where("type = ?", archiveType).
+		Order("id DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	if len(archives) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	return archives, nil
+}
+
+func (a *accountArchiveDB) GetUnfinishedAccountArchives(ctx context.Context) ([]*gtsmodel.AccountArchive, db.Error) {
+	archives := []*gtsmodel.AccountArchive{}
+
+	err := a.conn.
+		NewSelect().
+		Model(&archives).
+		Where("finished_at IS NULL").
+		Order("id ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	if len(archives) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	return archives, nil
+}
+
+func (a *accountArchiveDB) PutAccountArchive(ctx context.Context, archive *gtsmodel.AccountArchive) db.Error {
+	_, err := a.conn.
+		NewInsert().
+		Model(archive).
+		Exec(ctx)
+	return a.conn.ProcessError(err)
+}
+
+func (a *accountArchiveDB) UpdateAccountArchive(ctx context.Context, archive *gtsmodel.AccountArchive) db.Error {
+	archive.UpdatedAt = time.Now()
+
+	_, err := a.conn.
+		NewUpdate().
+		Model(archive).
+		WherePK().
+		Exec(ctx)
+	return a.conn.ProcessError(err)
+}
+
+func (a *accountArchiveDB) DeleteAccountArchiveByID(ctx context.Context, id string) db.Error {
+	_, err := a.conn.
+		NewDelete().
+		Model(&gtsmodel.AccountArchive{}).
+		Where("id = ?", id).
+		Exec(ctx)
+	return a.conn.ProcessError(err)
+}
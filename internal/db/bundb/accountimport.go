@@ -0,0 +1,86 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type accountImportDB struct {
+	config *config.Config
+	conn   *DBConn
+}
+
+func (a *accountImportDB) GetAccountImportByID(ctx context.Context, id string) (*gtsmodel.AccountImport, db.Error) {
+	accountImport := &gtsmodel.AccountImport{}
+
+	err := a.conn.
+		NewSelect().
+		Model(accountImport).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+	return accountImport, nil
+}
+
+func (a *accountImportDB) GetUnfinishedAccountImports(ctx context.Context) ([]*gtsmodel.AccountImport, db.Error) {
+	accountImports := []*gtsmodel.AccountImport{}
+
+	err := a.conn.
+		NewSelect().
+		Model(&accountImports).
+		Where("finished_at IS NULL").
+		Order("id ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	if len(accountImports) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	return accountImports, nil
+}
+
+func (a *accountImportDB) PutAccountImport(ctx context.Context, accountImport *gtsmodel.AccountImport) db.Error {
+	_, err := a.conn.
+		NewInsert().
+		Model(accountImport).
+		Exec(ctx)
+	return a.conn.ProcessError(err)
+}
+
+func (a *accountImportDB) UpdateAccountImport(ctx context.Context, accountImport *gtsmodel.AccountImport) db.Error {
+	accountImport.UpdatedAt = time.Now()
+
+	_, err := a.conn.
+		NewUpdate().
+		Model(accountImport).
+		WherePK().
+		Exec(ctx)
+	return a.conn.ProcessError(err)
+}
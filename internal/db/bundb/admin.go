@@ -47,7 +47,7 @@ func (a *adminDB) IsUsernameAvailable(ctx context.Context, username string) (boo
 		NewSelect().
 		Model(&gtsmodel.Account{}).
 		Where("username = ?", username).
-		Where("domain = ?", nil)
+		WhereGroup(" AND ", whereEmptyOrNull("domain"))
 
 	return a.conn.NotExists(ctx, q)
 }
@@ -119,7 +119,7 @@ func (b *basicDB) UpdateOneByID(ctx context.Context, id string, key string, valu
 	q := b.conn.NewUpdate().
 		Model(i).
 		Set("? = ?", bun.Safe(key), value).
-		WherePK()
+		Where("? = ?", bun.Ident("id"), id)
 
 	_, err := q.Exec(ctx)
 	return b.conn.ProcessError(err)
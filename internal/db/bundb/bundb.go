@@ -58,16 +58,26 @@ var registerTables []interface{} = []interface{}{
 // bunDBService satisfies the DB interface
 type bunDBService struct {
 	db.Account
+	db.AccountArchive
+	db.AccountImport
 	db.Admin
 	db.Basic
+	db.Conversation
 	db.Domain
+	db.Filter
 	db.Instance
+	db.List
 	db.Media
 	db.Mention
 	db.Notification
+	db.Poll
 	db.Relationship
+	db.Report
+	db.ScheduledStatus
 	db.Session
 	db.Status
+	db.Suggestion
+	db.Tag
 	db.Timeline
 	config *config.Config
 	conn   *DBConn
@@ -134,6 +144,14 @@ func NewBunDBService(ctx context.Context, c *config.Config, log *logrus.Logger)
 
 	ps := &bunDBService{
 		Account: accounts,
+		AccountArchive: &accountArchiveDB{
+			config: c,
+			conn:   conn,
+		},
+		AccountImport: &accountImportDB{
+			config: c,
+			conn:   conn,
+		},
 		Admin: &adminDB{
 			config: c,
 			conn:   conn,
@@ -142,14 +160,26 @@ func NewBunDBService(ctx context.Context, c *config.Config, log *logrus.Logger)
 			config: c,
 			conn:   conn,
 		},
+		Conversation: &conversationDB{
+			config: c,
+			conn:   conn,
+		},
 		Domain: &domainDB{
 			config: c,
 			conn:   conn,
 		},
+		Filter: &filterDB{
+			config: c,
+			conn:   conn,
+		},
 		Instance: &instanceDB{
 			config: c,
 			conn:   conn,
 		},
+		List: &listDB{
+			config: c,
+			conn:   conn,
+		},
 		Media: &mediaDB{
 			config: c,
 			conn:   conn,
@@ -164,10 +194,22 @@ func NewBunDBService(ctx context.Context, c *config.Config, log *logrus.Logger)
 			conn:   conn,
 			cache:  ttlcache.NewCache(),
 		},
+		Poll: &pollDB{
+			config: c,
+			conn:   conn,
+		},
 		Relationship: &relationshipDB{
 			config: c,
 			conn:   conn,
 		},
+		Report: &reportDB{
+			config: c,
+			conn:   conn,
+		},
+		ScheduledStatus: &scheduledStatusDB{
+			config: c,
+			conn:   conn,
+		},
 		Session: &sessionDB{
 			config: c,
 			conn:   conn,
@@ -178,6 +220,14 @@ func NewBunDBService(ctx context.Context, c *config.Config, log *logrus.Logger)
 			cache:    cache.NewStatusCache(),
 			accounts: accounts,
 		},
+		Suggestion: &suggestionDB{
+			config: c,
+			conn:   conn,
+		},
+		Tag: &tagDB{
+			config: c,
+			conn:   conn,
+		},
 		Timeline: &timelineDB{
 			config: c,
 			conn:   conn,
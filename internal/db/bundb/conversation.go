@@ -0,0 +1,213 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type conversationDB struct {
+	config *config.Config
+	conn   *DBConn
+}
+
+func (c *conversationDB) GetConversationByID(ctx context.Context, id string) (*gtsmodel.Conversation, db.Error) {
+	conversation := &gtsmodel.Conversation{}
+
+	err := c.conn.
+		NewSelect().
+		Model(conversation).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, c.conn.ProcessError(err)
+	}
+	return conversation, nil
+}
+
+func (c *conversationDB) GetConversationByThreadAndAccounts(ctx context.Context, accountID string, threadID string, otherAccountsKey string) (*gtsmodel.Conversation, db.Error) {
+	conversation := &gtsmodel.Conversation{}
+
+	err := c.conn.
+		NewSelect().
+		Model(conversation).
+		Where("account_id = ?", accountID).
+		Where("thread_id = ?", threadID).
+		Where("other_accounts_key = ?", otherAccountsKey).
+		Scan(ctx)
+	if err != nil {
+		return nil, c.conn.ProcessError(err)
+	}
+	return conversation, nil
+}
+
+func (c *conversationDB) GetConversationForStatusID(ctx context.Context, accountID string, statusID string) (*gtsmodel.Conversation, db.Error) {
+	conversation := &gtsmodel.Conversation{}
+
+	err := c.conn.
+		NewSelect().
+		Model(conversation).
+		Join("JOIN conversation_to_statuses AS cts ON cts.conversation_id = conversation.id").
+		Where("conversation.account_id = ?", accountID).
+		Where("cts.status_id = ?", statusID).
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		return nil, c.conn.ProcessError(err)
+	}
+	return conversation, nil
+}
+
+func (c *conversationDB) GetConversationsForStatusID(ctx context.Context, statusID string) ([]*gtsmodel.Conversation, db.Error) {
+	conversations := []*gtsmodel.Conversation{}
+
+	err := c.conn.
+		NewSelect().
+		Model(&conversations).
+		Join("JOIN conversation_to_statuses AS cts ON cts.conversation_id = conversation.id").
+		Where("cts.status_id = ?", statusID).
+		Scan(ctx)
+	if err != nil {
+		return nil, c.conn.ProcessError(err)
+	}
+	return conversations, nil
+}
+
+func (c *conversationDB) GetConversationsForAccountID(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.Conversation, db.Error) {
+	// Ensure reasonable
+	if limit < 0 {
+		limit = 0
+	}
+
+	// Make educated guess for slice size
+	conversations := make([]*gtsmodel.Conversation, 0, limit)
+
+	q := c.conn.
+		NewSelect().
+		Model(&conversations).
+		Where("account_id = ?", accountID).
+		Order("last_status_id DESC")
+
+	if maxID != "" {
+		q = q.Where("last_status_id < ?", maxID)
+	}
+
+	if sinceID != "" {
+		q = q.Where("last_status_id > ?", sinceID)
+	}
+
+	if minID != "" {
+		q = q.Where("last_status_id > ?", minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	err := q.Scan(ctx)
+	if err != nil {
+		return nil, c.conn.ProcessError(err)
+	}
+
+	if len(conversations) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	return conversations, nil
+}
+
+func (c *conversationDB) GetConversationLastStatusID(ctx context.Context, conversationID string) (string, db.Error) {
+	var statusID string
+
+	err := c.conn.
+		NewSelect().
+		Model((*gtsmodel.ConversationToStatus)(nil)).
+		Column("status_id").
+		Where("conversation_id = ?", conversationID).
+		Order("status_id DESC").
+		Limit(1).
+		Scan(ctx, &statusID)
+	if err != nil {
+		return "", c.conn.ProcessError(err)
+	}
+	return statusID, nil
+}
+
+func (c *conversationDB) PutConversation(ctx context.Context, conversation *gtsmodel.Conversation) db.Error {
+	_, err := c.conn.
+		NewInsert().
+		Model(conversation).
+		Exec(ctx)
+	return c.conn.ProcessError(err)
+}
+
+func (c *conversationDB) UpdateConversation(ctx context.Context, conversation *gtsmodel.Conversation) db.Error {
+	_, err := c.conn.
+		NewUpdate().
+		Model(conversation).
+		WherePK().
+		Exec(ctx)
+	return c.conn.ProcessError(err)
+}
+
+func (c *conversationDB) PutConversationToStatus(ctx context.Context, conversationID string, statusID string) db.Error {
+	_, err := c.conn.
+		NewInsert().
+		Model(&gtsmodel.ConversationToStatus{
+			ConversationID: conversationID,
+			StatusID:       statusID,
+		}).
+		Exec(ctx)
+	return c.conn.ProcessError(err)
+}
+
+func (c *conversationDB) DeleteConversationByID(ctx context.Context, id string) db.Error {
+	return c.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		// delete the record of which statuses belong to this conversation
+		if _, err := tx.
+			NewDelete().
+			Model(&[]*gtsmodel.ConversationToStatus{}).
+			Where("conversation_id = ?", id).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		// delete the conversation itself
+		_, err := tx.
+			NewDelete().
+			Model(&gtsmodel.Conversation{}).
+			Where("id = ?", id).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (c *conversationDB) DeleteStatusFromConversations(ctx context.Context, statusID string) db.Error {
+	_, err := c.conn.
+		NewDelete().
+		Model(&[]*gtsmodel.ConversationToStatus{}).
+		Where("status_id = ?", statusID).
+		Exec(ctx)
+	return c.conn.ProcessError(err)
+}
@@ -0,0 +1,144 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ConversationTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *ConversationTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testAttachments = testrig.NewTestAttachments()
+	suite.testStatuses = testrig.NewTestStatuses()
+	suite.testTags = testrig.NewTestTags()
+	suite.testMentions = testrig.NewTestMentions()
+}
+
+func (suite *ConversationTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.log = testrig.NewTestLog()
+
+	testrig.StandardDBSetup(suite.db, suite.testAccounts)
+}
+
+func (suite *ConversationTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+}
+
+func (suite *ConversationTestSuite) TestConversations() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+	otherAccount := suite.testAccounts["local_account_2"]
+	olderStatus := suite.testStatuses["local_account_1_status_1"]
+	newerStatus := suite.testStatuses["local_account_2_status_1"]
+
+	older := &gtsmodel.Conversation{
+		ID:               "01FY0R6KTBWKQ8J4KZXKRXZ1GK",
+		AccountID:        account.ID,
+		ThreadID:         olderStatus.ID,
+		OtherAccountIDs:  []string{otherAccount.ID},
+		OtherAccountsKey: otherAccount.ID,
+		LastStatusID:     olderStatus.ID,
+	}
+	suite.NoError(suite.db.PutConversation(ctx, older))
+	suite.NoError(suite.db.PutConversationToStatus(ctx, older.ID, olderStatus.ID))
+
+	newer := &gtsmodel.Conversation{
+		ID:               "01FY0R6KTBN2QZ1T3D0JC4W4MC",
+		AccountID:        account.ID,
+		ThreadID:         newerStatus.ID,
+		OtherAccountIDs:  []string{otherAccount.ID},
+		OtherAccountsKey: otherAccount.ID,
+		LastStatusID:     newerStatus.ID,
+	}
+	suite.NoError(suite.db.PutConversation(ctx, newer))
+	suite.NoError(suite.db.PutConversationToStatus(ctx, newer.ID, newerStatus.ID))
+
+	// the same status can't be added to a conversation twice
+	suite.ErrorIs(suite.db.PutConversationToStatus(ctx, newer.ID, newerStatus.ID), db.ErrAlreadyExists)
+
+	// conversations should be ordered by their last status, newest first
+	conversations, err := suite.db.GetConversationsForAccountID(ctx, account.ID, "", "", "", 20)
+	suite.NoError(err)
+	suite.Len(conversations, 2)
+	suite.Equal(newer.ID, conversations[0].ID)
+	suite.Equal(older.ID, conversations[1].ID)
+	suite.Equal([]string{otherAccount.ID}, conversations[0].OtherAccountIDs)
+
+	// paging refers to the last status of each conversation
+	conversations, err = suite.db.GetConversationsForAccountID(ctx, account.ID, newerStatus.ID, "", "", 20)
+	suite.NoError(err)
+	suite.Len(conversations, 1)
+	suite.Equal(older.ID, conversations[0].ID)
+
+	_, err = suite.db.GetConversationsForAccountID(ctx, otherAccount.ID, "", "", "", 20)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	conversation, err := suite.db.GetConversationByThreadAndAccounts(ctx, account.ID, olderStatus.ID, otherAccount.ID)
+	suite.NoError(err)
+	suite.Equal(older.ID, conversation.ID)
+
+	conversation, err = suite.db.GetConversationForStatusID(ctx, account.ID, newerStatus.ID)
+	suite.NoError(err)
+	suite.Equal(newer.ID, conversation.ID)
+
+	_, err = suite.db.GetConversationForStatusID(ctx, otherAccount.ID, newerStatus.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// add the older status to the newer conversation too, then take the newer status out of it
+	suite.NoError(suite.db.PutConversationToStatus(ctx, newer.ID, olderStatus.ID))
+
+	conversations, err = suite.db.GetConversationsForStatusID(ctx, olderStatus.ID)
+	suite.NoError(err)
+	suite.Len(conversations, 2)
+
+	suite.NoError(suite.db.DeleteStatusFromConversations(ctx, newerStatus.ID))
+
+	lastStatusID, err := suite.db.GetConversationLastStatusID(ctx, newer.ID)
+	suite.NoError(err)
+	suite.Equal(olderStatus.ID, lastStatusID)
+
+	// deleting a conversation removes its statuses from it too
+	suite.NoError(suite.db.DeleteConversationByID(ctx, newer.ID))
+
+	_, err = suite.db.GetConversationByID(ctx, newer.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	_, err = suite.db.GetConversationLastStatusID(ctx, newer.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestConversationTestSuite(t *testing.T) {
+	suite.Run(t, new(ConversationTestSuite))
+}
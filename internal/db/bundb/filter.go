@@ -0,0 +1,126 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type filterDB struct {
+	config *config.Config
+	conn   *DBConn
+}
+
+func (f *filterDB) GetFilterByID(ctx context.Context, id string) (*gtsmodel.Filter, db.Error) {
+	filter := &gtsmodel.Filter{}
+
+	err := f.conn.
+		NewSelect().
+		Model(filter).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, f.conn.ProcessError(err)
+	}
+	return filter, nil
+}
+
+func (f *filterDB) GetFiltersForAccountID(ctx context.Context, accountID string) ([]*gtsmodel.Filter, db.Error) {
+	filters := []*gtsmodel.Filter{}
+
+	err := f.conn.
+		NewSelect().
+		Model(&filters).
+		Where("account_id = ?", accountID).
+		Order("id ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, f.conn.ProcessError(err)
+	}
+	return filters, nil
+}
+
+func (f *filterDB) GetActiveFiltersForAccountID(ctx context.Context, accountID string, filterContext gtsmodel.FilterContext, irreversibleOnly bool) ([]*gtsmodel.Filter, db.Error) {
+	filters := []*gtsmodel.Filter{}
+
+	q := f.conn.
+		NewSelect().
+		Model(&filters).
+		Where("account_id = ?", accountID).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				WhereOr("expires_at IS NULL").
+				WhereOr("expires_at > ?", time.Now())
+		})
+
+	switch filterContext {
+	case gtsmodel.FilterContextHome:
+		q = q.Where("context_home = ?", true)
+	case gtsmodel.FilterContextNotifications:
+		q = q.Where("context_notifications = ?", true)
+	case gtsmodel.FilterContextPublic:
+		q = q.Where("context_public = ?", true)
+	case gtsmodel.FilterContextThread:
+		q = q.Where("context_thread = ?", true)
+	}
+
+	if irreversibleOnly {
+		q = q.Where("irreversible = ?", true)
+	}
+
+	err := q.Scan(ctx)
+	if err != nil {
+		return nil, f.conn.ProcessError(err)
+	}
+	return filters, nil
+}
+
+func (f *filterDB) PutFilter(ctx context.Context, filter *gtsmodel.Filter) db.Error {
+	_, err := f.conn.
+		NewInsert().
+		Model(filter).
+		Exec(ctx)
+	return f.conn.ProcessError(err)
+}
+
+func (f *filterDB) UpdateFilter(ctx context.Context, filter *gtsmodel.Filter) db.Error {
+	filter.UpdatedAt = time.Now()
+
+	_, err := f.conn.
+		NewUpdate().
+		Model(filter).
+		WherePK().
+		Exec(ctx)
+	return f.conn.ProcessError(err)
+}
+
+func (f *filterDB) DeleteFilterByID(ctx context.Context, id string) db.Error {
+	_, err := f.conn.
+		NewDelete().
+		Model(&gtsmodel.Filter{}).
+		Where("id = ?", id).
+		Exec(ctx)
+	return f.conn.ProcessError(err)
+}
@@ -0,0 +1,185 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type listDB struct {
+	config *config.Config
+	conn   *DBConn
+}
+
+func (l *listDB) GetListByID(ctx context.Context, id string) (*gtsmodel.List, db.Error) {
+	list := &gtsmodel.List{}
+
+	err := l.conn.
+		NewSelect().
+		Model(list).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, l.conn.ProcessError(err)
+	}
+	return list, nil
+}
+
+func (l *listDB) GetListsForAccountID(ctx context.Context, accountID string) ([]*gtsmodel.List, db.Error) {
+	lists := []*gtsmodel.List{}
+
+	err := l.conn.
+		NewSelect().
+		Model(&lists).
+		Where("account_id = ?", accountID).
+		Order("id ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, l.conn.ProcessError(err)
+	}
+	return lists, nil
+}
+
+func (l *listDB) PutList(ctx context.Context, list *gtsmodel.List) db.Error {
+	_, err := l.conn.
+		NewInsert().
+		Model(list).
+		Exec(ctx)
+	return l.conn.ProcessError(err)
+}
+
+func (l *listDB) UpdateList(ctx context.Context, list *gtsmodel.List) db.Error {
+	list.UpdatedAt = time.Now()
+
+	_, err := l.conn.
+		NewUpdate().
+		Model(list).
+		WherePK().
+		Exec(ctx)
+	return l.conn.ProcessError(err)
+}
+
+func (l *listDB) DeleteListByID(ctx context.Context, id string) db.Error {
+	return l.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		// delete all entries belonging to this list
+		if _, err := tx.
+			NewDelete().
+			Model(&[]*gtsmodel.ListEntry{}).
+			Where("list_id = ?", id).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		// delete the list itself
+		_, err := tx.
+			NewDelete().
+			Model(&gtsmodel.List{}).
+			Where("id = ?", id).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (l *listDB) GetListEntries(ctx context.Context, listID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.ListEntry, db.Error) {
+	// Ensure reasonable
+	if limit < 0 {
+		limit = 0
+	}
+
+	// Make educated guess for slice size
+	entries := make([]*gtsmodel.ListEntry, 0, limit)
+
+	q := l.conn.
+		NewSelect().
+		Model(&entries).
+		Relation("Follow").
+		Where("list_entry.list_id = ?", listID).
+		Order("list_entry.id DESC")
+
+	if maxID != "" {
+		q = q.Where("list_entry.id < ?", maxID)
+	}
+
+	if sinceID != "" {
+		q = q.Where("list_entry.id > ?", sinceID)
+	}
+
+	if minID != "" {
+		q = q.Where("list_entry.id > ?", minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	err := q.Scan(ctx)
+	if err != nil {
+		return nil, l.conn.ProcessError(err)
+	}
+	return entries, nil
+}
+
+func (l *listDB) GetListEntriesForFollowID(ctx context.Context, followID string) ([]*gtsmodel.ListEntry, db.Error) {
+	entries := []*gtsmodel.ListEntry{}
+
+	err := l.conn.
+		NewSelect().
+		Model(&entries).
+		Where("follow_id = ?", followID).
+		Scan(ctx)
+	if err != nil {
+		return nil, l.conn.ProcessError(err)
+	}
+	return entries, nil
+}
+
+func (l *listDB) PutListEntries(ctx context.Context, entries []*gtsmodel.ListEntry) db.Error {
+	return l.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		for _, e := range entries {
+			if _, err := tx.NewInsert().Model(e).Exec(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (l *listDB) DeleteListEntry(ctx context.Context, id string) db.Error {
+	_, err := l.conn.
+		NewDelete().
+		Model(&gtsmodel.ListEntry{}).
+		Where("id = ?", id).
+		Exec(ctx)
+	return l.conn.ProcessError(err)
+}
+
+func (l *listDB) DeleteListEntriesForFollowID(ctx context.Context, followID string) db.Error {
+	_, err := l.conn.
+		NewDelete().
+		Model(&[]*gtsmodel.ListEntry{}).
+		Where("follow_id = ?", followID).
+		Exec(ctx)
+	return l.conn.ProcessError(err)
+}
@@ -0,0 +1,145 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type pollDB struct {
+	config *config.Config
+	conn   *DBConn
+}
+
+func (p *pollDB) GetPollByID(ctx context.Context, id string) (*gtsmodel.Poll, db.Error) {
+	poll := &gtsmodel.Poll{}
+
+	err := p.conn.
+		NewSelect().
+		Model(poll).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, p.conn.ProcessError(err)
+	}
+	return poll, nil
+}
+
+func (p *pollDB) GetPollByStatusID(ctx context.Context, statusID string) (*gtsmodel.Poll, db.Error) {
+	poll := &gtsmodel.Poll{}
+
+	err := p.conn.
+		NewSelect().
+		Model(poll).
+		Where("status_id = ?", statusID).
+		Scan(ctx)
+	if err != nil {
+		return nil, p.conn.ProcessError(err)
+	}
+	return poll, nil
+}
+
+func (p *pollDB) GetExpiredPolls(ctx context.Context) ([]*gtsmodel.Poll, db.Error) {
+	polls := []*gtsmodel.Poll{}
+
+	err := p.conn.
+		NewSelect().
+		Model(&polls).
+		Where("closed_at IS NULL").
+		Where("expires_at IS NOT NULL").
+		Where("expires_at <= ?", time.Now()).
+		Scan(ctx)
+	if err != nil {
+		return nil, p.conn.ProcessError(err)
+	}
+	return polls, nil
+}
+
+func (p *pollDB) PutPoll(ctx context.Context, poll *gtsmodel.Poll) db.Error {
+	_, err := p.conn.
+		NewInsert().
+		Model(poll).
+		Exec(ctx)
+	return p.conn.ProcessError(err)
+}
+
+func (p *pollDB) UpdatePoll(ctx context.Context, poll *gtsmodel.Poll) db.Error {
+	poll.UpdatedAt = time.Now()
+
+	_, err := p.conn.
+		NewUpdate().
+		Model(poll).
+		WherePK().
+		Exec(ctx)
+	return p.conn.ProcessError(err)
+}
+
+func (p *pollDB) GetPollVotes(ctx context.Context, pollID string) ([]*gtsmodel.PollVote, db.Error) {
+	votes := []*gtsmodel.PollVote{}
+
+	err := p.conn.
+		NewSelect().
+		Model(&votes).
+		Where("poll_id = ?", pollID).
+		Order("id ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, p.conn.ProcessError(err)
+	}
+	return votes, nil
+}
+
+func (p *pollDB) GetPollVoteByAccountID(ctx context.Context, pollID string, accountID string) (*gtsmodel.PollVote, db.Error) {
+	vote := &gtsmodel.PollVote{}
+
+	err := p.conn.
+		NewSelect().
+		Model(vote).
+		Where("poll_id = ?", pollID).
+		Where("account_id = ?", accountID).
+		Scan(ctx)
+	if err != nil {
+		return nil, p.conn.ProcessError(err)
+	}
+	return vote, nil
+}
+
+func (p *pollDB) PutPollVote(ctx context.Context, vote *gtsmodel.PollVote) db.Error {
+	_, err := p.conn.
+		NewInsert().
+		Model(vote).
+		Exec(ctx)
+	return p.conn.ProcessError(err)
+}
+
+func (p *pollDB) UpdatePollVote(ctx context.Context, vote *gtsmodel.PollVote) db.Error {
+	vote.UpdatedAt = time.Now()
+
+	_, err := p.conn.
+		NewUpdate().
+		Model(vote).
+		WherePK().
+		Exec(ctx)
+	return p.conn.ProcessError(err)
+}
@@ -0,0 +1,148 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type PollTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *PollTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testAttachments = testrig.NewTestAttachments()
+	suite.testStatuses = testrig.NewTestStatuses()
+	suite.testTags = testrig.NewTestTags()
+	suite.testMentions = testrig.NewTestMentions()
+}
+
+func (suite *PollTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.log = testrig.NewTestLog()
+
+	testrig.StandardDBSetup(suite.db, suite.testAccounts)
+}
+
+func (suite *PollTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+}
+
+// putPollStatus puts a new status with a poll attached in the database, owned by the given account
+func (suite *PollTestSuite) putPollStatus(account *gtsmodel.Account, expiresAt time.Time) *gtsmodel.Status {
+	status := &gtsmodel.Status{
+		ID:                  "01FE4JSD6G2J4HSNQVTW3BNJSF",
+		URI:                 "http://localhost:8080/users/the_mighty_zork/statuses/01FE4JSD6G2J4HSNQVTW3BNJSF",
+		URL:                 "http://localhost:8080/@the_mighty_zork/statuses/01FE4JSD6G2J4HSNQVTW3BNJSF",
+		Content:             "which is better?",
+		CreatedAt:           time.Now(),
+		UpdatedAt:           time.Now(),
+		Local:               true,
+		AccountID:           account.ID,
+		AccountURI:          account.URI,
+		Visibility:          gtsmodel.VisibilityPublic,
+		ActivityStreamsType: gtsmodel.ActivityStreamsNote,
+		Poll: &gtsmodel.Poll{
+			ExpiresAt: expiresAt,
+			Options:   []string{"tabs", "spaces"},
+			Votes:     []int{0, 0},
+		},
+	}
+
+	err := suite.db.PutStatus(context.Background(), status)
+	suite.NoError(err)
+	return status
+}
+
+func (suite *PollTestSuite) TestPutStatusWithPoll() {
+	account := suite.testAccounts["local_account_1"]
+	status := suite.putPollStatus(account, time.Now().Add(1*time.Hour))
+	suite.NotEmpty(status.PollID)
+
+	dbStatus, err := suite.db.GetStatusByID(context.Background(), status.ID)
+	suite.NoError(err)
+	suite.Equal(status.PollID, dbStatus.PollID)
+
+	poll, err := suite.db.GetPollByStatusID(context.Background(), status.ID)
+	suite.NoError(err)
+	suite.Equal(status.PollID, poll.ID)
+	suite.Equal(account.ID, poll.AccountID)
+	suite.Equal([]string{"tabs", "spaces"}, poll.Options)
+	suite.Equal([]int{0, 0}, poll.Votes)
+	suite.False(poll.Expired())
+}
+
+func (suite *PollTestSuite) TestPutAndGetPollVote() {
+	status := suite.putPollStatus(suite.testAccounts["local_account_1"], time.Now().Add(1*time.Hour))
+	voter := suite.testAccounts["local_account_2"]
+
+	_, err := suite.db.GetPollVoteByAccountID(context.Background(), status.PollID, voter.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	err = suite.db.PutPollVote(context.Background(), &gtsmodel.PollVote{
+		ID:        "01FE4K3KD8QBA6DVXYDBRBDV5A",
+		PollID:    status.PollID,
+		AccountID: voter.ID,
+		Choices:   []int{1},
+	})
+	suite.NoError(err)
+
+	vote, err := suite.db.GetPollVoteByAccountID(context.Background(), status.PollID, voter.ID)
+	suite.NoError(err)
+	suite.Equal([]int{1}, vote.Choices)
+
+	votes, err := suite.db.GetPollVotes(context.Background(), status.PollID)
+	suite.NoError(err)
+	suite.Len(votes, 1)
+}
+
+func (suite *PollTestSuite) TestGetExpiredPolls() {
+	status := suite.putPollStatus(suite.testAccounts["local_account_1"], time.Now().Add(-1*time.Minute))
+
+	polls, err := suite.db.GetExpiredPolls(context.Background())
+	suite.NoError(err)
+	suite.Len(polls, 1)
+	suite.Equal(status.PollID, polls[0].ID)
+
+	// once the poll is closed it shouldn't be returned any more
+	polls[0].ClosedAt = time.Now()
+	err = suite.db.UpdatePoll(context.Background(), polls[0])
+	suite.NoError(err)
+
+	polls, err = suite.db.GetExpiredPolls(context.Background())
+	suite.NoError(err)
+	suite.Empty(polls)
+}
+
+func TestPollTestSuite(t *testing.T) {
+	suite.Run(t, new(PollTestSuite))
+}
@@ -22,6 +22,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -81,6 +83,170 @@ func (r *relationshipDB) GetBlock(ctx context.Context, account1 string, account2
 	return block, nil
 }
 
+// whereMuteActive adds a clause to the given mute query so that expired mutes are excluded.
+func whereMuteActive(q *bun.SelectQuery) *bun.SelectQuery {
+	return q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.
+			WhereOr("user_mute.expires_at IS NULL").
+			WhereOr("user_mute.expires_at > ?", time.Now())
+	})
+}
+
+func (r *relationshipDB) IsMuted(ctx context.Context, account1 string, account2 string) (bool, db.Error) {
+	q := r.conn.
+		NewSelect().
+		Model(&gtsmodel.UserMute{}).
+		Where("user_mute.account_id = ?", account1).
+		Where("user_mute.target_account_id = ?", account2)
+
+	return r.conn.Exists(ctx, whereMuteActive(q).Limit(1))
+}
+
+func (r *relationshipDB) GetMute(ctx context.Context, account1 string, account2 string) (*gtsmodel.UserMute, db.Error) {
+	mute := &gtsmodel.UserMute{}
+
+	q := r.conn.
+		NewSelect().
+		Model(mute).
+		Where("user_mute.account_id = ?", account1).
+		Where("user_mute.target_account_id = ?", account2)
+
+	err := whereMuteActive(q).Scan(ctx)
+	if err != nil {
+		return nil, r.conn.ProcessError(err)
+	}
+	return mute, nil
+}
+
+func (r *relationshipDB) GetAccountMutes(ctx context.Context, accountID string, maxID string, sinceID string, limit int) ([]*gtsmodel.UserMute, db.Error) {
+	mutes := []*gtsmodel.UserMute{}
+
+	q := r.conn.
+		NewSelect().
+		Model(&mutes).
+		Relation("TargetAccount").
+		Where("user_mute.account_id = ?", accountID).
+		Order("user_mute.id DESC")
+	q = whereMuteActive(q)
+
+	if maxID != "" {
+		q = q.Where("user_mute.id < ?", maxID)
+	}
+
+	if sinceID != "" {
+		q = q.Where("user_mute.id > ?", sinceID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	err := q.Scan(ctx)
+	if err != nil {
+		return nil, r.conn.ProcessError(err)
+	}
+
+	if len(mutes) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	return mutes, nil
+}
+
+func (r *relationshipDB) IsDomainBlockedByAccount(ctx context.Context, accountID string, domain string) (bool, db.Error) {
+	if domain == "" {
+		// local accounts can't be domain blocked
+		return false, nil
+	}
+
+	q := r.conn.
+		NewSelect().
+		Model(&gtsmodel.UserDomainBlock{}).
+		Where("user_domain_block.account_id = ?", accountID).
+		Where("user_domain_block.domain = ?", strings.ToLower(domain)).
+		Limit(1)
+
+	return r.conn.Exists(ctx, q)
+}
+
+func (r *relationshipDB) GetAccountDomainBlocks(ctx context.Context, accountID string, maxID string, sinceID string, limit int) ([]*gtsmodel.UserDomainBlock, db.Error) {
+	blocks := []*gtsmodel.UserDomainBlock{}
+
+	q := r.conn.
+		NewSelect().
+		Model(&blocks).
+		Where("user_domain_block.account_id = ?", accountID).
+		Order("user_domain_block.id DESC")
+
+	if maxID != "" {
+		q = q.Where("user_domain_block.id < ?", maxID)
+	}
+
+	if sinceID != "" {
+		q = q.Where("user_domain_block.id > ?", sinceID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	err := q.Scan(ctx)
+	if err != nil {
+		return nil, r.conn.ProcessError(err)
+	}
+
+	if len(blocks) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	return blocks, nil
+}
+
+func (r *relationshipDB) IsEndorsed(ctx context.Context, account1 string, account2 string) (bool, db.Error) {
+	q := r.conn.
+		NewSelect().
+		Model(&gtsmodel.Endorsement{}).
+		Where("account_id = ?", account1).
+		Where("target_account_id = ?", account2).
+		Limit(1)
+
+	return r.conn.Exists(ctx, q)
+}
+
+func (r *relationshipDB) GetAccountEndorsements(ctx context.Context, accountID string, maxID string, sinceID string, limit int) ([]*gtsmodel.Endorsement, db.Error) {
+	endorsements := []*gtsmodel.Endorsement{}
+
+	q := r.conn.
+		NewSelect().
+		Model(&endorsements).
+		Relation("TargetAccount").
+		Where("endorsement.account_id = ?", accountID).
+		Order("endorsement.id DESC")
+
+	if maxID != "" {
+		q = q.Where("endorsement.id < ?", maxID)
+	}
+
+	if sinceID != "" {
+		q = q.Where("endorsement.id > ?", sinceID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	err := q.Scan(ctx)
+	if err != nil {
+		return nil, r.conn.ProcessError(err)
+	}
+
+	if len(endorsements) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	return endorsements, nil
+}
+
 func (r *relationshipDB) GetRelationship(ctx context.Context, requestingAccount string, targetAccount string) (*gtsmodel.Relationship, db.Error) {
 	rel := &gtsmodel.Relationship{
 		ID: targetAccount,
@@ -148,6 +314,36 @@ func (r *relationshipDB) GetRelationship(ctx context.Context, requestingAccount
 	}
 	rel.BlockedBy = count > 0
 
+	// check if the requesting account mutes the target account
+	mute, err := r.GetMute(ctx, requestingAccount, targetAccount)
+	if err != nil {
+		if err != db.ErrNoEntries {
+			return nil, fmt.Errorf("getrelationship: error checking muting existence: %s", err)
+		}
+		rel.Muting = false
+		rel.MutingNotifications = false
+	} else {
+		rel.Muting = true
+		rel.MutingNotifications = mute.Notifications
+	}
+
+	// check if the requesting account blocks the domain of the target account
+	domainBlockQ := r.conn.
+		NewSelect().
+		Model(&gtsmodel.UserDomainBlock{}).
+		Where("user_domain_block.account_id = ?", requestingAccount).
+		Where("user_domain_block.domain = (?)", r.conn.
+			NewSelect().
+			Model(&gtsmodel.Account{}).
+			Column("account.domain").
+			Where("account.id = ?", targetAccount)).
+		Limit(1)
+	domainBlocked, err := r.conn.Exists(ctx, domainBlockQ)
+	if err != nil {
+		return nil, fmt.Errorf("getrelationship: error checking domain block existence: %s", err)
+	}
+	rel.DomainBlocking = domainBlocked
+
 	// check if there's a pending following request from requesting account to target account
 	count, err = r.conn.
 		NewSelect().
@@ -161,6 +357,13 @@ func (r *relationshipDB) GetRelationship(ctx context.Context, requestingAccount
 	}
 	rel.Requested = count > 0
 
+	// check if the requesting account endorses the target account
+	endorsed, err := r.IsEndorsed(ctx, requestingAccount, targetAccount)
+	if err != nil {
+		return nil, fmt.Errorf("getrelationship: error checking endorsed existence: %s", err)
+	}
+	rel.Endorsed = endorsed
+
 	return rel, nil
 }
 
@@ -319,3 +522,56 @@ func (r *relationshipDB) CountAccountFollowedBy(ctx context.Context, accountID s
 		Where("target_account_id = ?", accountID).
 		Count(ctx)
 }
+
+func (r *relationshipDB) GetFollowsOfFollows(ctx context.Context, accountID string, limit int) ([]string, db.Error) {
+	accountIDs := []string{}
+
+	// accounts that accountID already follows shouldn't be returned
+	alreadyFollowing := r.conn.
+		NewSelect().
+		Model((*gtsmodel.Follow)(nil)).
+		Column("target_account_id").
+		Where("account_id = ?", accountID)
+
+	q := r.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("follows"), bun.Ident("fof")).
+		ColumnExpr("? AS ?", bun.Ident("fof.target_account_id"), bun.Ident("target_account_id")).
+		Join("JOIN ? AS ? ON ? = ?", bun.Ident("follows"), bun.Ident("f"), bun.Ident("f.target_account_id"), bun.Ident("fof.account_id")).
+		Where("? = ?", bun.Ident("f.account_id"), accountID).
+		Where("? != ?", bun.Ident("fof.target_account_id"), accountID).
+		Where("? NOT IN (?)", bun.Ident("fof.target_account_id"), alreadyFollowing).
+		Group("fof.target_account_id").
+		OrderExpr("COUNT(*) DESC")
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &accountIDs); err != nil {
+		return nil, r.conn.ProcessError(err)
+	}
+	return accountIDs, nil
+}
+
+func (r *relationshipDB) GetMostFollowedByLocalAccounts(ctx context.Context, limit int) ([]string, db.Error) {
+	accountIDs := []string{}
+
+	q := r.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("follows"), bun.Ident("follow")).
+		ColumnExpr("? AS ?", bun.Ident("follow.target_account_id"), bun.Ident("target_account_id")).
+		Join("JOIN ? AS ? ON ? = ?", bun.Ident("accounts"), bun.Ident("a"), bun.Ident("a.id"), bun.Ident("follow.account_id")).
+		Where("? IS NULL OR ? = ''", bun.Ident("a.domain"), bun.Ident("a.domain")).
+		Group("follow.target_account_id").
+		OrderExpr("COUNT(*) DESC")
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &accountIDs); err != nil {
+		return nil, r.conn.ProcessError(err)
+	}
+	return accountIDs, nil
+}
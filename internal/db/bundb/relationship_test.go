@@ -22,9 +22,11 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -64,6 +66,144 @@ func (suite *RelationshipTestSuite) TestGetBlock() {
 	suite.Suite.T().Skip("TODO: implement")
 }
 
+func (suite *RelationshipTestSuite) TestIsMuted() {
+	ctx := context.Background()
+	account1 := suite.testAccounts["local_account_1"]
+	account2 := suite.testAccounts["local_account_2"]
+
+	muted, err := suite.db.IsMuted(ctx, account1.ID, account2.ID)
+	suite.NoError(err)
+	suite.False(muted)
+
+	// an expired mute shouldn't count
+	mute := &gtsmodel.UserMute{
+		ID:              "01FXR5Y5RF8MCYQ7AQ1PJ8Y4T2",
+		ExpiresAt:       time.Now().Add(-1 * time.Minute),
+		AccountID:       account1.ID,
+		TargetAccountID: account2.ID,
+		Notifications:   true,
+	}
+	suite.NoError(suite.db.Put(ctx, mute))
+
+	muted, err = suite.db.IsMuted(ctx, account1.ID, account2.ID)
+	suite.NoError(err)
+	suite.False(muted)
+
+	mute.ExpiresAt = time.Now().Add(1 * time.Hour)
+	suite.NoError(suite.db.UpdateByID(ctx, mute.ID, mute))
+
+	muted, err = suite.db.IsMuted(ctx, account1.ID, account2.ID)
+	suite.NoError(err)
+	suite.True(muted)
+
+	// mutes only go in one direction
+	muted, err = suite.db.IsMuted(ctx, account2.ID, account1.ID)
+	suite.NoError(err)
+	suite.False(muted)
+
+	rel, err := suite.db.GetRelationship(ctx, account1.ID, account2.ID)
+	suite.NoError(err)
+	suite.True(rel.Muting)
+	suite.True(rel.MutingNotifications)
+
+	mutes, err := suite.db.GetAccountMutes(ctx, account1.ID, "", "", 20)
+	suite.NoError(err)
+	suite.Len(mutes, 1)
+	suite.Equal(account2.ID, mutes[0].TargetAccount.ID)
+}
+
+func (suite *RelationshipTestSuite) TestIsEndorsed() {
+	ctx := context.Background()
+	account1 := suite.testAccounts["local_account_1"]
+	account2 := suite.testAccounts["local_account_2"]
+
+	endorsed, err := suite.db.IsEndorsed(ctx, account1.ID, account2.ID)
+	suite.NoError(err)
+	suite.False(endorsed)
+
+	_, err = suite.db.GetAccountEndorsements(ctx, account1.ID, "", "", 20)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.Endorsement{
+		ID:              "01FY0T5E0KN2QGHM3D9V7B4M1E",
+		AccountID:       account1.ID,
+		TargetAccountID: account2.ID,
+	}))
+
+	endorsed, err = suite.db.IsEndorsed(ctx, account1.ID, account2.ID)
+	suite.NoError(err)
+	suite.True(endorsed)
+
+	// endorsements only go in one direction
+	endorsed, err = suite.db.IsEndorsed(ctx, account2.ID, account1.ID)
+	suite.NoError(err)
+	suite.False(endorsed)
+
+	rel, err := suite.db.GetRelationship(ctx, account1.ID, account2.ID)
+	suite.NoError(err)
+	suite.True(rel.Endorsed)
+
+	endorsements, err := suite.db.GetAccountEndorsements(ctx, account1.ID, "", "", 20)
+	suite.NoError(err)
+	suite.Len(endorsements, 1)
+	suite.Equal(account2.ID, endorsements[0].TargetAccount.ID)
+}
+
+func (suite *RelationshipTestSuite) TestGetFollowsOfFollows() {
+	ctx := context.Background()
+	account1 := suite.testAccounts["local_account_1"]
+	account2 := suite.testAccounts["local_account_2"]
+	remoteAccount := suite.testAccounts["remote_account_1"]
+
+	// local_account_1 follows local_account_2 and the admin account, but they don't follow anyone
+	fofIDs, err := suite.db.GetFollowsOfFollows(ctx, account1.ID, 20)
+	suite.NoError(err)
+	suite.Empty(fofIDs)
+
+	// local_account_2 follows remote_account_1 and local_account_1 back
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.Follow{
+		ID:              "01FY2K7Q5GX7QXKJ1DKJ8HZAEF",
+		URI:             "http://localhost:8080/users/1happyturtle/follow/01FY2K7Q5GX7QXKJ1DKJ8HZAEF",
+		AccountID:       account2.ID,
+		TargetAccountID: remoteAccount.ID,
+	}))
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.Follow{
+		ID:              "01FY2K81JVPXEHV8FMMQ8B9D0A",
+		URI:             "http://localhost:8080/users/1happyturtle/follow/01FY2K81JVPXEHV8FMMQ8B9D0A",
+		AccountID:       account2.ID,
+		TargetAccountID: account1.ID,
+	}))
+
+	// local_account_1 itself shouldn't be included, only remote_account_1
+	fofIDs, err = suite.db.GetFollowsOfFollows(ctx, account1.ID, 20)
+	suite.NoError(err)
+	suite.Equal([]string{remoteAccount.ID}, fofIDs)
+}
+
+func (suite *RelationshipTestSuite) TestGetMostFollowedByLocalAccounts() {
+	ctx := context.Background()
+	account1 := suite.testAccounts["local_account_1"]
+	admin := suite.testAccounts["admin_account"]
+
+	// local_account_2 follows the admin account too, so now it's the most followed
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.Follow{
+		ID:              "01FY2KA0V1QW0HZ9XPK8WX2XJ6",
+		URI:             "http://localhost:8080/users/1happyturtle/follow/01FY2KA0V1QW0HZ9XPK8WX2XJ6",
+		AccountID:       suite.testAccounts["local_account_2"].ID,
+		TargetAccountID: admin.ID,
+	}))
+
+	accountIDs, err := suite.db.GetMostFollowedByLocalAccounts(ctx, 20)
+	suite.NoError(err)
+	suite.Len(accountIDs, 2)
+	suite.Equal(admin.ID, accountIDs[0])
+	suite.NotContains(accountIDs, account1.ID)
+
+	accountIDs, err = suite.db.GetMostFollowedByLocalAccounts(ctx, 1)
+	suite.NoError(err)
+	suite.Equal([]string{admin.ID}, accountIDs)
+}
+
 func (suite *RelationshipTestSuite) TestGetRelationship() {
 	suite.Suite.T().Skip("TODO: implement")
 }
@@ -0,0 +1,133 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type reportDB struct {
+	config *config.Config
+	conn   *DBConn
+}
+
+func (r *reportDB) GetReportByID(ctx context.Context, id string) (*gtsmodel.Report, db.Error) {
+	report := &gtsmodel.Report{}
+
+	err := r.conn.
+		NewSelect().
+		Model(report).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, r.conn.ProcessError(err)
+	}
+	return report, nil
+}
+
+func (r *reportDB) GetReportByURI(ctx context.Context, uri string) (*gtsmodel.Report, db.Error) {
+	report := &gtsmodel.Report{}
+
+	err := r.conn.
+		NewSelect().
+		Model(report).
+		Where("uri = ?", uri).
+		Scan(ctx)
+	if err != nil {
+		return nil, r.conn.ProcessError(err)
+	}
+	return report, nil
+}
+
+func (r *reportDB) GetReports(ctx context.Context, resolved bool, accountID string, targetAccountID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.Report, db.Error) {
+	// Ensure reasonable
+	if limit < 0 {
+		limit = 0
+	}
+
+	// Make educated guess for slice size
+	reports := make([]*gtsmodel.Report, 0, limit)
+
+	q := r.conn.
+		NewSelect().
+		Model(&reports).
+		Order("id DESC")
+
+	if resolved {
+		q = q.Where("action_taken_at IS NOT NULL")
+	} else {
+		q = q.Where("action_taken_at IS NULL")
+	}
+
+	if accountID != "" {
+		q = q.Where("account_id = ?", accountID)
+	}
+
+	if targetAccountID != "" {
+		q = q.Where("target_account_id = ?", targetAccountID)
+	}
+
+	if maxID != "" {
+		q = q.Where("id < ?", maxID)
+	}
+
+	if sinceID != "" {
+		q = q.Where("id > ?", sinceID)
+	}
+
+	if minID != "" {
+		q = q.Where("id > ?", minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	err := q.Scan(ctx)
+	if err != nil {
+		return nil, r.conn.ProcessError(err)
+	}
+
+	if len(reports) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	return reports, nil
+}
+
+func (r *reportDB) PutReport(ctx context.Context, report *gtsmodel.Report) db.Error {
+	_, err := r.conn.
+		NewInsert().
+		Model(report).
+		Exec(ctx)
+	return r.conn.ProcessError(err)
+}
+
+func (r *reportDB) UpdateReport(ctx context.Context, report *gtsmodel.Report) db.Error {
+	_, err := r.conn.
+		NewUpdate().
+		Model(report).
+		WherePK().
+		Exec(ctx)
+	return r.conn.ProcessError(err)
+}
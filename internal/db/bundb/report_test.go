@@ -0,0 +1,132 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ReportTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *ReportTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testAttachments = testrig.NewTestAttachments()
+	suite.testStatuses = testrig.NewTestStatuses()
+	suite.testTags = testrig.NewTestTags()
+	suite.testMentions = testrig.NewTestMentions()
+}
+
+func (suite *ReportTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.log = testrig.NewTestLog()
+
+	testrig.StandardDBSetup(suite.db, suite.testAccounts)
+}
+
+func (suite *ReportTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+}
+
+func (suite *ReportTestSuite) TestReports() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+	targetAccount := suite.testAccounts["local_account_2"]
+	remoteAccount := suite.testAccounts["remote_account_1"]
+	status := suite.testStatuses["local_account_2_status_1"]
+
+	older := &gtsmodel.Report{
+		ID:              "01FY0R6KTBN2QZ1T3D0JC4W4MC",
+		URI:             "http://localhost:8080/users/localhost:8080/reports/01FY0R6KTBN2QZ1T3D0JC4W4MC",
+		AccountID:       account.ID,
+		TargetAccountID: targetAccount.ID,
+		StatusIDs:       []string{status.ID},
+		Comment:         "this is spam",
+	}
+	suite.NoError(suite.db.PutReport(ctx, older))
+
+	newer := &gtsmodel.Report{
+		ID:              "01FY0R6KTBWKQ8J4KZXKRXZ1GK",
+		URI:             "http://fossbros-anonymous.io/reports/1",
+		AccountID:       remoteAccount.ID,
+		TargetAccountID: targetAccount.ID,
+	}
+	suite.NoError(suite.db.PutReport(ctx, newer))
+
+	report, err := suite.db.GetReportByURI(ctx, older.URI)
+	suite.NoError(err)
+	suite.Equal(older.ID, report.ID)
+	suite.Equal([]string{status.ID}, report.StatusIDs)
+	suite.Equal(targetAccount.ID, report.TargetAccountID)
+	suite.False(report.Resolved())
+
+	// reports should be ordered newest first
+	reports, err := suite.db.GetReports(ctx, false, "", targetAccount.ID, "", "", "", 20)
+	suite.NoError(err)
+	suite.Len(reports, 2)
+	suite.Equal(newer.ID, reports[0].ID)
+	suite.Equal(older.ID, reports[1].ID)
+
+	reports, err = suite.db.GetReports(ctx, false, account.ID, "", "", "", "", 20)
+	suite.NoError(err)
+	suite.Len(reports, 1)
+	suite.Equal(older.ID, reports[0].ID)
+
+	reports, err = suite.db.GetReports(ctx, false, "", "", newer.ID, "", "", 20)
+	suite.NoError(err)
+	suite.Len(reports, 1)
+	suite.Equal(older.ID, reports[0].ID)
+
+	_, err = suite.db.GetReports(ctx, true, "", "", "", "", "", 20)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// resolving a report moves it to the resolved queue
+	report.ActionTakenAt = time.Now()
+	report.ActionTakenByAccountID = suite.testAccounts["admin_account"].ID
+	report.ActionTaken = gtsmodel.ReportActionNone
+	suite.NoError(suite.db.UpdateReport(ctx, report))
+
+	reports, err = suite.db.GetReports(ctx, true, "", "", "", "", "", 20)
+	suite.NoError(err)
+	suite.Len(reports, 1)
+	suite.Equal(older.ID, reports[0].ID)
+	suite.True(reports[0].Resolved())
+
+	reports, err = suite.db.GetReports(ctx, false, "", "", "", "", "", 20)
+	suite.NoError(err)
+	suite.Len(reports, 1)
+	suite.Equal(newer.ID, reports[0].ID)
+}
+
+func TestReportTestSuite(t *testing.T) {
+	suite.Run(t, new(ReportTestSuite))
+}
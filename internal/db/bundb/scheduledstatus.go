@@ -0,0 +1,156 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type scheduledStatusDB struct {
+	config *config.Config
+	conn   *DBConn
+}
+
+func (s *scheduledStatusDB) GetScheduledStatusByID(ctx context.Context, id string) (*gtsmodel.ScheduledStatus, db.Error) {
+	scheduledStatus := &gtsmodel.ScheduledStatus{}
+
+	err := s.conn.
+		NewSelect().
+		Model(scheduledStatus).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+	return scheduledStatus, nil
+}
+
+func (s *scheduledStatusDB) GetScheduledStatusesForAccountID(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.ScheduledStatus, db.Error) {
+	scheduledStatuses := []*gtsmodel.ScheduledStatus{}
+
+	q := s.conn.
+		NewSelect().
+		Model(&scheduledStatuses).
+		Where("account_id = ?", accountID)
+
+	if maxID != "" {
+		q = q.Where("id < ?", maxID)
+	}
+
+	if sinceID != "" {
+		q = q.Where("id > ?", sinceID)
+	}
+
+	if minID != "" {
+		// when paging upwards from minID, take the entries immediately newer than it
+		q = q.Where("id > ?", minID).Order("id ASC")
+	} else {
+		q = q.Order("id DESC")
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	err := q.Scan(ctx)
+	if err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+
+	if len(scheduledStatuses) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	if minID != "" {
+		// put the results back in newest first order
+		for i, j := 0, len(scheduledStatuses)-1; i < j; i, j = i+1, j-1 {
+			scheduledStatuses[i], scheduledStatuses[j] = scheduledStatuses[j], scheduledStatuses[i]
+		}
+	}
+
+	return scheduledStatuses, nil
+}
+
+func (s *scheduledStatusDB) CountScheduledStatusesForAccountID(ctx context.Context, accountID string, from time.Time, to time.Time) (int, db.Error) {
+	q := s.conn.
+		NewSelect().
+		Model(&gtsmodel.ScheduledStatus{}).
+		Where("account_id = ?", accountID)
+
+	if !from.IsZero() {
+		q = q.Where("scheduled_at >= ?", from)
+	}
+
+	if !to.IsZero() {
+		q = q.Where("scheduled_at < ?", to)
+	}
+
+	count, err := q.Count(ctx)
+	if err != nil {
+		return 0, s.conn.ProcessError(err)
+	}
+	return count, nil
+}
+
+func (s *scheduledStatusDB) GetAllScheduledStatuses(ctx context.Context) ([]*gtsmodel.ScheduledStatus, db.Error) {
+	scheduledStatuses := []*gtsmodel.ScheduledStatus{}
+
+	err := s.conn.
+		NewSelect().
+		Model(&scheduledStatuses).
+		Order("scheduled_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+	return scheduledStatuses, nil
+}
+
+func (s *scheduledStatusDB) PutScheduledStatus(ctx context.Context, scheduledStatus *gtsmodel.ScheduledStatus) db.Error {
+	_, err := s.conn.
+		NewInsert().
+		Model(scheduledStatus).
+		Exec(ctx)
+	return s.conn.ProcessError(err)
+}
+
+func (s *scheduledStatusDB) UpdateScheduledStatus(ctx context.Context, scheduledStatus *gtsmodel.ScheduledStatus) db.Error {
+	scheduledStatus.UpdatedAt = time.Now()
+
+	_, err := s.conn.
+		NewUpdate().
+		Model(scheduledStatus).
+		WherePK().
+		Exec(ctx)
+	return s.conn.ProcessError(err)
+}
+
+func (s *scheduledStatusDB) DeleteScheduledStatusByID(ctx context.Context, id string) db.Error {
+	_, err := s.conn.
+		NewDelete().
+		Model(&gtsmodel.ScheduledStatus{}).
+		Where("id = ?", id).
+		Exec(ctx)
+	return s.conn.ProcessError(err)
+}
@@ -0,0 +1,159 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ScheduledStatusTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *ScheduledStatusTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testAttachments = testrig.NewTestAttachments()
+	suite.testStatuses = testrig.NewTestStatuses()
+	suite.testTags = testrig.NewTestTags()
+	suite.testMentions = testrig.NewTestMentions()
+}
+
+func (suite *ScheduledStatusTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.log = testrig.NewTestLog()
+
+	testrig.StandardDBSetup(suite.db, suite.testAccounts)
+}
+
+func (suite *ScheduledStatusTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+}
+
+// putScheduledStatuses puts three scheduled statuses for the given account in the database, one day apart
+func (suite *ScheduledStatusTestSuite) putScheduledStatuses(accountID string) []*gtsmodel.ScheduledStatus {
+	scheduledStatuses := []*gtsmodel.ScheduledStatus{
+		{
+			ID:          "01FFZ1S3B0TN0XF0Y7Q6N6QJ5M",
+			ScheduledAt: time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC),
+			AccountID:   accountID,
+			Text:        "happy new year from the past!",
+			Visibility:  gtsmodel.VisibilityPublic,
+			MediaIDs:    []string{},
+		},
+		{
+			ID:          "01FFZ1SWQ2Z9RHPZ3M5D0YPQNK",
+			ScheduledAt: time.Date(2030, 1, 2, 12, 0, 0, 0, time.UTC),
+			AccountID:   accountID,
+			Text:        "still here",
+			PollOptions: []string{"yes", "no"},
+		},
+		{
+			ID:          "01FFZ1TB6B8BRHK9MWY2G0KGVE",
+			ScheduledAt: time.Date(2030, 1, 3, 12, 0, 0, 0, time.UTC),
+			AccountID:   accountID,
+			Text:        "one more",
+		},
+	}
+
+	for _, s := range scheduledStatuses {
+		err := suite.db.PutScheduledStatus(context.Background(), s)
+		suite.NoError(err)
+	}
+
+	return scheduledStatuses
+}
+
+func (suite *ScheduledStatusTestSuite) TestGetScheduledStatusesForAccountID() {
+	accountID := suite.testAccounts["local_account_1"].ID
+	scheduledStatuses := suite.putScheduledStatuses(accountID)
+
+	all, err := suite.db.GetScheduledStatusesForAccountID(context.Background(), accountID, "", "", "", 20)
+	suite.NoError(err)
+	suite.Len(all, 3)
+	// newest first
+	suite.Equal(scheduledStatuses[2].ID, all[0].ID)
+	suite.Equal([]string{"yes", "no"}, all[1].PollOptions)
+
+	older, err := suite.db.GetScheduledStatusesForAccountID(context.Background(), accountID, scheduledStatuses[2].ID, "", "", 1)
+	suite.NoError(err)
+	suite.Len(older, 1)
+	suite.Equal(scheduledStatuses[1].ID, older[0].ID)
+
+	newer, err := suite.db.GetScheduledStatusesForAccountID(context.Background(), accountID, "", "", scheduledStatuses[0].ID, 1)
+	suite.NoError(err)
+	suite.Len(newer, 1)
+	suite.Equal(scheduledStatuses[1].ID, newer[0].ID)
+
+	_, err = suite.db.GetScheduledStatusesForAccountID(context.Background(), suite.testAccounts["local_account_2"].ID, "", "", "", 20)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *ScheduledStatusTestSuite) TestCountScheduledStatusesForAccountID() {
+	accountID := suite.testAccounts["local_account_1"].ID
+	suite.putScheduledStatuses(accountID)
+
+	total, err := suite.db.CountScheduledStatusesForAccountID(context.Background(), accountID, time.Time{}, time.Time{})
+	suite.NoError(err)
+	suite.Equal(3, total)
+
+	dayStart := time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC)
+	daily, err := suite.db.CountScheduledStatusesForAccountID(context.Background(), accountID, dayStart, dayStart.Add(24*time.Hour))
+	suite.NoError(err)
+	suite.Equal(1, daily)
+}
+
+func (suite *ScheduledStatusTestSuite) TestUpdateAndDeleteScheduledStatus() {
+	accountID := suite.testAccounts["local_account_1"].ID
+	scheduledStatus := suite.putScheduledStatuses(accountID)[0]
+
+	newTime := time.Date(2031, 1, 1, 12, 0, 0, 0, time.UTC)
+	scheduledStatus.ScheduledAt = newTime
+	err := suite.db.UpdateScheduledStatus(context.Background(), scheduledStatus)
+	suite.NoError(err)
+
+	dbScheduledStatus, err := suite.db.GetScheduledStatusByID(context.Background(), scheduledStatus.ID)
+	suite.NoError(err)
+	suite.True(newTime.Equal(dbScheduledStatus.ScheduledAt))
+
+	err = suite.db.DeleteScheduledStatusByID(context.Background(), scheduledStatus.ID)
+	suite.NoError(err)
+
+	_, err = suite.db.GetScheduledStatusByID(context.Background(), scheduledStatus.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	all, err := suite.db.GetAllScheduledStatuses(context.Background())
+	suite.NoError(err)
+	suite.Len(all, 2)
+}
+
+func TestScheduledStatusTestSuite(t *testing.T) {
+	suite.Run(t, new(ScheduledStatusTestSuite))
+}
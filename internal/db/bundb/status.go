@@ -27,6 +27,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/uptrace/bun"
 )
 
@@ -169,12 +170,107 @@ func (s *statusDB) PutStatus(ctx context.Context, status *gtsmodel.Status) db.Er
 			}
 		}
 
+		// insert the poll attached to this status, if there is one
+		if status.Poll != nil {
+			if status.Poll.ID == "" {
+				pollID, err := id.NewULIDFromTime(status.CreatedAt)
+				if err != nil {
+					return err
+				}
+				status.Poll.ID = pollID
+			}
+			status.Poll.StatusID = status.ID
+			status.Poll.AccountID = status.AccountID
+			status.PollID = status.Poll.ID
+			if _, err := tx.NewInsert().Model(status.Poll).Exec(ctx); err != nil {
+				return err
+			}
+		}
+
 		// Finally, insert the status
 		_, err := tx.NewInsert().Model(status).Exec(ctx)
 		return err
 	})
 }
 
+func (s *statusDB) UpdateStatus(ctx context.Context, status *gtsmodel.Status) db.Error {
+	err := s.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		// drop any existing links between this status and emojis, and recreate them
+		if _, err := tx.NewDelete().
+			Model(&gtsmodel.StatusToEmoji{}).
+			Where("status_id = ?", status.ID).
+			Exec(ctx); err != nil {
+			return err
+		}
+		for _, i := range status.EmojiIDs {
+			if _, err := tx.NewInsert().Model(&gtsmodel.StatusToEmoji{
+				StatusID: status.ID,
+				EmojiID:  i,
+			}).Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		// drop any existing links between this status and tags, and recreate them
+		if _, err := tx.NewDelete().
+			Model(&gtsmodel.StatusToTag{}).
+			Where("status_id = ?", status.ID).
+			Exec(ctx); err != nil {
+			return err
+		}
+		for _, i := range status.TagIDs {
+			if _, err := tx.NewInsert().Model(&gtsmodel.StatusToTag{
+				StatusID: status.ID,
+				TagID:    i,
+			}).Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		// make sure any newly attached media attachments point to this status
+		for _, a := range status.Attachments {
+			a.StatusID = status.ID
+			a.UpdatedAt = time.Now()
+			if _, err := tx.NewUpdate().Model(a).
+				Where("id = ?", a.ID).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		// Finally, update the status itself
+		_, err := tx.NewUpdate().Model(status).WherePK().Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return s.conn.ProcessError(err)
+	}
+
+	// Replace the cached version of the status
+	s.cache.Put(status)
+	return nil
+}
+
+func (s *statusDB) PutStatusEdit(ctx context.Context, edit *gtsmodel.StatusEdit) db.Error {
+	_, err := s.conn.NewInsert().Model(edit).Exec(ctx)
+	return s.conn.ProcessError(err)
+}
+
+func (s *statusDB) GetStatusEdits(ctx context.Context, statusID string) ([]*gtsmodel.StatusEdit, db.Error) {
+	edits := []*gtsmodel.StatusEdit{}
+
+	q := s.conn.
+		NewSelect().
+		Model(&edits).
+		Where("status_id = ?", statusID).
+		Order("created_at ASC")
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+	return edits, nil
+}
+
 func (s *statusDB) GetStatusParents(ctx context.Context, status *gtsmodel.Status, onlyDirect bool) ([]*gtsmodel.Status, db.Error) {
 	parents := []*gtsmodel.Status{}
 	s.statusParent(ctx, status, &parents, onlyDirect)
@@ -187,9 +283,11 @@ func (s *statusDB) statusParent(ctx context.Context, status *gtsmodel.Status, fo
 	}
 
 	parentStatus, err := s.GetStatusByID(ctx, status.InReplyToID)
-	if err == nil {
-		*foundStatuses = append(*foundStatuses, parentStatus)
+	if err != nil {
+		// we don't have the parent (or there's been an error), so we can't go any higher
+		return
 	}
+	*foundStatuses = append(*foundStatuses, parentStatus)
 
 	if onlyDirect {
 		return
@@ -281,10 +379,20 @@ func (s *statusDB) IsStatusRebloggedBy(ctx context.Context, status *gtsmodel.Sta
 }
 
 func (s *statusDB) IsStatusMutedBy(ctx context.Context, status *gtsmodel.Status, accountID string) (bool, db.Error) {
+	// a mute covers the whole thread, so check the status itself and everything above it
+	threadIDs := []string{status.ID}
+	parents, err := s.GetStatusParents(ctx, status, false)
+	if err != nil {
+		return false, err
+	}
+	for _, parent := range parents {
+		threadIDs = append(threadIDs, parent.ID)
+	}
+
 	q := s.conn.
 		NewSelect().
 		Model(&gtsmodel.StatusMute{}).
-		Where("status_id = ?", status.ID).
+		Where("status_id IN (?)", bun.In(threadIDs)).
 		Where("account_id = ?", accountID)
 
 	return s.conn.Exists(ctx, q)
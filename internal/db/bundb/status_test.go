@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -129,6 +130,79 @@ func (suite *StatusTestSuite) TestGetStatusTwice() {
 	suite.Less(duration2, duration1)
 }
 
+func (suite *StatusTestSuite) TestIsStatusMutedByThread() {
+	ctx := context.Background()
+	rootStatus := suite.testStatuses["local_account_1_status_1"]
+	replyStatus := suite.testStatuses["local_account_2_status_5"]
+	mutingAccount := suite.testAccounts["local_account_1"]
+
+	muted, err := suite.db.IsStatusMutedBy(ctx, replyStatus, mutingAccount.ID)
+	suite.NoError(err)
+	suite.False(muted)
+
+	// mute the root of the thread
+	err = suite.db.Put(ctx, &gtsmodel.StatusMute{
+		ID:              "01FFKZD54DZ4JW1KN3XDTQGCJQ",
+		AccountID:       mutingAccount.ID,
+		TargetAccountID: rootStatus.AccountID,
+		StatusID:        rootStatus.ID,
+	})
+	suite.NoError(err)
+
+	// both the root and the reply should now be muted
+	muted, err = suite.db.IsStatusMutedBy(ctx, rootStatus, mutingAccount.ID)
+	suite.NoError(err)
+	suite.True(muted)
+
+	muted, err = suite.db.IsStatusMutedBy(ctx, replyStatus, mutingAccount.ID)
+	suite.NoError(err)
+	suite.True(muted)
+
+	// but not for other accounts
+	muted, err = suite.db.IsStatusMutedBy(ctx, replyStatus, suite.testAccounts["local_account_2"].ID)
+	suite.NoError(err)
+	suite.False(muted)
+}
+
+func (suite *StatusTestSuite) TestUpdateStatusWithEdit() {
+	ctx := context.Background()
+	status, err := suite.db.GetStatusByID(ctx, suite.testStatuses["local_account_1_status_1"].ID)
+	suite.NoError(err)
+
+	edit := &gtsmodel.StatusEdit{
+		ID:             "01FGRKZ6QBE7FMQ9BGWJB4FZVH",
+		CreatedAt:      status.CreatedAt,
+		StatusID:       status.ID,
+		AccountID:      status.AccountID,
+		Content:        status.Content,
+		ContentWarning: status.ContentWarning,
+		Text:           status.Text,
+		AttachmentIDs:  status.AttachmentIDs,
+	}
+	suite.NoError(suite.db.PutStatusEdit(ctx, edit))
+
+	status.Content = "<p>this status has been edited</p>"
+	status.EditedAt = time.Now()
+	suite.NoError(suite.db.UpdateStatus(ctx, status))
+
+	// the status we get back should be the edited version
+	updated, err := suite.db.GetStatusByID(ctx, status.ID)
+	suite.NoError(err)
+	suite.Equal("<p>this status has been edited</p>", updated.Content)
+	suite.False(updated.EditedAt.IsZero())
+
+	// and the previous version should be in the history
+	edits, err := suite.db.GetStatusEdits(ctx, status.ID)
+	suite.NoError(err)
+	suite.Len(edits, 1)
+	suite.Equal(suite.testStatuses["local_account_1_status_1"].Content, edits[0].Content)
+
+	// a status that's never been edited has no history
+	edits, err = suite.db.GetStatusEdits(ctx, suite.testStatuses["local_account_1_status_2"].ID)
+	suite.NoError(err)
+	suite.Empty(edits)
+}
+
 func TestStatusTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusTestSuite))
 }
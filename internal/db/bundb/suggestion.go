@@ -0,0 +1,157 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type suggestionDB struct {
+	config *config.Config
+	conn   *DBConn
+}
+
+func (s *suggestionDB) GetSuggestionsForAccount(ctx context.Context, accountID string, limit int) ([]*gtsmodel.Suggestion, db.Error) {
+	suggestions := []*gtsmodel.Suggestion{}
+
+	q := s.conn.
+		NewSelect().
+		Model(&suggestions).
+		Relation("TargetAccount").
+		Where("suggestion.account_id = ?", accountID).
+		Order("suggestion.rank ASC")
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+	return suggestions, nil
+}
+
+func (s *suggestionDB) ReplaceSuggestionsForAccount(ctx context.Context, accountID string, suggestions []*gtsmodel.Suggestion) db.Error {
+	return s.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		// clear out the old suggestions
+		if _, err := tx.
+			NewDelete().
+			Model(&[]*gtsmodel.Suggestion{}).
+			Where("account_id = ?", accountID).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		if len(suggestions) == 0 {
+			return nil
+		}
+
+		// put the new ones in their place
+		_, err := tx.
+			NewInsert().
+			Model(&suggestions).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (s *suggestionDB) DeleteSuggestion(ctx context.Context, accountID string, targetAccountID string) db.Error {
+	_, err := s.conn.
+		NewDelete().
+		Model(&[]*gtsmodel.Suggestion{}).
+		Where("account_id = ?", accountID).
+		Where("target_account_id = ?", targetAccountID).
+		Exec(ctx)
+	return s.conn.ProcessError(err)
+}
+
+func (s *suggestionDB) PutSuggestionDismissal(ctx context.Context, dismissal *gtsmodel.SuggestionDismissal) db.Error {
+	_, err := s.conn.
+		NewInsert().
+		Model(dismissal).
+		Exec(ctx)
+	return s.conn.ProcessError(err)
+}
+
+func (s *suggestionDB) GetDismissedSuggestionIDs(ctx context.Context, accountID string) ([]string, db.Error) {
+	targetAccountIDs := []string{}
+
+	err := s.conn.
+		NewSelect().
+		Model((*gtsmodel.SuggestionDismissal)(nil)).
+		Column("target_account_id").
+		Where("account_id = ?", accountID).
+		Scan(ctx, &targetAccountIDs)
+	if err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+	return targetAccountIDs, nil
+}
+
+func (s *suggestionDB) GetFeaturedAccounts(ctx context.Context) ([]*gtsmodel.FeaturedAccount, db.Error) {
+	featuredAccounts := []*gtsmodel.FeaturedAccount{}
+
+	err := s.conn.
+		NewSelect().
+		Model(&featuredAccounts).
+		Relation("Account").
+		Order("featured_account.id ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+	return featuredAccounts, nil
+}
+
+func (s *suggestionDB) GetFeaturedAccount(ctx context.Context, accountID string) (*gtsmodel.FeaturedAccount, db.Error) {
+	featuredAccount := &gtsmodel.FeaturedAccount{}
+
+	err := s.conn.
+		NewSelect().
+		Model(featuredAccount).
+		Relation("Account").
+		Where("featured_account.account_id = ?", accountID).
+		Scan(ctx)
+	if err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+	return featuredAccount, nil
+}
+
+func (s *suggestionDB) PutFeaturedAccount(ctx context.Context, featuredAccount *gtsmodel.FeaturedAccount) db.Error {
+	_, err := s.conn.
+		NewInsert().
+		Model(featuredAccount).
+		Exec(ctx)
+	return s.conn.ProcessError(err)
+}
+
+func (s *suggestionDB) DeleteFeaturedAccount(ctx context.Context, accountID string) db.Error {
+	_, err := s.conn.
+		NewDelete().
+		Model(&[]*gtsmodel.FeaturedAccount{}).
+		Where("account_id = ?", accountID).
+		Exec(ctx)
+	return s.conn.ProcessError(err)
+}
@@ -0,0 +1,179 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type SuggestionTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *SuggestionTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testAttachments = testrig.NewTestAttachments()
+	suite.testStatuses = testrig.NewTestStatuses()
+	suite.testTags = testrig.NewTestTags()
+	suite.testMentions = testrig.NewTestMentions()
+}
+
+func (suite *SuggestionTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.log = testrig.NewTestLog()
+
+	testrig.StandardDBSetup(suite.db, suite.testAccounts)
+}
+
+func (suite *SuggestionTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+}
+
+func (suite *SuggestionTestSuite) TestReplaceSuggestions() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+
+	suggestions, err := suite.db.GetSuggestionsForAccount(ctx, account.ID, 0)
+	suite.NoError(err)
+	suite.Empty(suggestions)
+
+	suite.NoError(suite.db.ReplaceSuggestionsForAccount(ctx, account.ID, []*gtsmodel.Suggestion{
+		{
+			ID:              "01FY2M3MJ2Y2HTXSR3JV4TZ9DF",
+			AccountID:       account.ID,
+			TargetAccountID: suite.testAccounts["remote_account_1"].ID,
+			Source:          gtsmodel.SuggestionSourceFriendsOfFriends,
+			Rank:            1,
+		},
+		{
+			ID:              "01FY2M3VA7Q0V7N6W1ST0CJ2FE",
+			AccountID:       account.ID,
+			TargetAccountID: suite.testAccounts["local_account_2"].ID,
+			Source:          gtsmodel.SuggestionSourceFeatured,
+			Rank:            0,
+		},
+	}))
+
+	// suggestions should come back in order of rank, with target accounts populated
+	suggestions, err = suite.db.GetSuggestionsForAccount(ctx, account.ID, 0)
+	suite.NoError(err)
+	suite.Len(suggestions, 2)
+	suite.Equal(suite.testAccounts["local_account_2"].ID, suggestions[0].TargetAccount.ID)
+	suite.Equal(suite.testAccounts["remote_account_1"].ID, suggestions[1].TargetAccount.ID)
+
+	suggestions, err = suite.db.GetSuggestionsForAccount(ctx, account.ID, 1)
+	suite.NoError(err)
+	suite.Len(suggestions, 1)
+
+	// replacing should get rid of the old ones
+	suite.NoError(suite.db.ReplaceSuggestionsForAccount(ctx, account.ID, []*gtsmodel.Suggestion{
+		{
+			ID:              "01FY2M5BJ9T40R7JJ0F2PK5Q6D",
+			AccountID:       account.ID,
+			TargetAccountID: suite.testAccounts["remote_account_1"].ID,
+			Source:          gtsmodel.SuggestionSourcePopular,
+			Rank:            0,
+		},
+	}))
+
+	suggestions, err = suite.db.GetSuggestionsForAccount(ctx, account.ID, 0)
+	suite.NoError(err)
+	suite.Len(suggestions, 1)
+	suite.Equal(gtsmodel.SuggestionSourcePopular, suggestions[0].Source)
+
+	suite.NoError(suite.db.DeleteSuggestion(ctx, account.ID, suite.testAccounts["remote_account_1"].ID))
+	suggestions, err = suite.db.GetSuggestionsForAccount(ctx, account.ID, 0)
+	suite.NoError(err)
+	suite.Empty(suggestions)
+}
+
+func (suite *SuggestionTestSuite) TestSuggestionDismissals() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+	targetAccount := suite.testAccounts["remote_account_1"]
+
+	dismissedIDs, err := suite.db.GetDismissedSuggestionIDs(ctx, account.ID)
+	suite.NoError(err)
+	suite.Empty(dismissedIDs)
+
+	suite.NoError(suite.db.PutSuggestionDismissal(ctx, &gtsmodel.SuggestionDismissal{
+		ID:              "01FY2M7ZC3W1DAF7B9YQ4G2M8P",
+		AccountID:       account.ID,
+		TargetAccountID: targetAccount.ID,
+	}))
+
+	// dismissing the same account twice should fail
+	err = suite.db.PutSuggestionDismissal(ctx, &gtsmodel.SuggestionDismissal{
+		ID:              "01FY2M8A3MY0GD7CDB2RJ3XG1K",
+		AccountID:       account.ID,
+		TargetAccountID: targetAccount.ID,
+	})
+	suite.ErrorIs(err, db.ErrAlreadyExists)
+
+	dismissedIDs, err = suite.db.GetDismissedSuggestionIDs(ctx, account.ID)
+	suite.NoError(err)
+	suite.Equal([]string{targetAccount.ID}, dismissedIDs)
+}
+
+func (suite *SuggestionTestSuite) TestFeaturedAccounts() {
+	ctx := context.Background()
+	admin := suite.testAccounts["admin_account"]
+	featured := suite.testAccounts["local_account_2"]
+
+	featuredAccounts, err := suite.db.GetFeaturedAccounts(ctx)
+	suite.NoError(err)
+	suite.Empty(featuredAccounts)
+
+	_, err = suite.db.GetFeaturedAccount(ctx, featured.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	suite.NoError(suite.db.PutFeaturedAccount(ctx, &gtsmodel.FeaturedAccount{
+		ID:                 "01FY2MB5Q0E8S8QF4P4ZBE2T1V",
+		AccountID:          featured.ID,
+		CreatedByAccountID: admin.ID,
+	}))
+
+	featuredAccounts, err = suite.db.GetFeaturedAccounts(ctx)
+	suite.NoError(err)
+	suite.Len(featuredAccounts, 1)
+	suite.Equal(featured.ID, featuredAccounts[0].Account.ID)
+
+	featuredAccount, err := suite.db.GetFeaturedAccount(ctx, featured.ID)
+	suite.NoError(err)
+	suite.Equal(admin.ID, featuredAccount.CreatedByAccountID)
+
+	suite.NoError(suite.db.DeleteFeaturedAccount(ctx, featured.ID))
+	_, err = suite.db.GetFeaturedAccount(ctx, featured.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestSuggestionTestSuite(t *testing.T) {
+	suite.Run(t, new(SuggestionTestSuite))
+}
@@ -0,0 +1,270 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type tagDB struct {
+	config *config.Config
+	conn   *DBConn
+}
+
+func (t *tagDB) GetTagByName(ctx context.Context, name string) (*gtsmodel.Tag, db.Error) {
+	tag := &gtsmodel.Tag{}
+
+	err := t.conn.
+		NewSelect().
+		Model(tag).
+		Where("LOWER(?) = LOWER(?)", bun.Ident("name"), name).
+		Scan(ctx)
+	if err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+	return tag, nil
+}
+
+func (t *tagDB) GetTagFollow(ctx context.Context, accountID string, tagID string) (*gtsmodel.TagFollow, db.Error) {
+	tagFollow := &gtsmodel.TagFollow{}
+
+	err := t.conn.
+		NewSelect().
+		Model(tagFollow).
+		Where("account_id = ?", accountID).
+		Where("tag_id = ?", tagID).
+		Scan(ctx)
+	if err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+	return tagFollow, nil
+}
+
+func (t *tagDB) GetTagFollowsForAccountID(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.TagFollow, db.Error) {
+	// Ensure reasonable
+	if limit < 0 {
+		limit = 0
+	}
+
+	// Make educated guess for slice size
+	tagFollows := make([]*gtsmodel.TagFollow, 0, limit)
+
+	q := t.conn.
+		NewSelect().
+		Model(&tagFollows).
+		Relation("Tag").
+		Where("tag_follow.account_id = ?", accountID).
+		Order("tag_follow.id DESC")
+
+	if maxID != "" {
+		q = q.Where("tag_follow.id < ?", maxID)
+	}
+
+	if sinceID != "" {
+		q = q.Where("tag_follow.id > ?", sinceID)
+	}
+
+	if minID != "" {
+		q = q.Where("tag_follow.id > ?", minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	err := q.Scan(ctx)
+	if err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+
+	if len(tagFollows) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	return tagFollows, nil
+}
+
+func (t *tagDB) GetTagFollowsForTagIDs(ctx context.Context, tagIDs []string) ([]*gtsmodel.TagFollow, db.Error) {
+	tagFollows := []*gtsmodel.TagFollow{}
+
+	if len(tagIDs) == 0 {
+		return tagFollows, nil
+	}
+
+	err := t.conn.
+		NewSelect().
+		Model(&tagFollows).
+		Where("tag_id IN (?)", bun.In(tagIDs)).
+		Scan(ctx)
+	if err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+	return tagFollows, nil
+}
+
+func (t *tagDB) PutTagFollow(ctx context.Context, tagFollow *gtsmodel.TagFollow) db.Error {
+	_, err := t.conn.
+		NewInsert().
+		Model(tagFollow).
+		Exec(ctx)
+	return t.conn.ProcessError(err)
+}
+
+func (t *tagDB) DeleteTagFollow(ctx context.Context, accountID string, tagID string) db.Error {
+	_, err := t.conn.
+		NewDelete().
+		Model(&gtsmodel.TagFollow{}).
+		Where("account_id = ?", accountID).
+		Where("tag_id = ?", tagID).
+		Exec(ctx)
+	return t.conn.ProcessError(err)
+}
+
+func (t *tagDB) GetFeaturedTagByID(ctx context.Context, id string) (*gtsmodel.FeaturedTag, db.Error) {
+	featuredTag := &gtsmodel.FeaturedTag{}
+
+	err := t.conn.
+		NewSelect().
+		Model(featuredTag).
+		Relation("Tag").
+		Where("featured_tag.id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+	return featuredTag, nil
+}
+
+func (t *tagDB) GetFeaturedTag(ctx context.Context, accountID string, tagID string) (*gtsmodel.FeaturedTag, db.Error) {
+	featuredTag := &gtsmodel.FeaturedTag{}
+
+	err := t.conn.
+		NewSelect().
+		Model(featuredTag).
+		Where("account_id = ?", accountID).
+		Where("tag_id = ?", tagID).
+		Scan(ctx)
+	if err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+	return featuredTag, nil
+}
+
+func (t *tagDB) GetFeaturedTagsForAccountID(ctx context.Context, accountID string) ([]*gtsmodel.FeaturedTag, db.Error) {
+	featuredTags := []*gtsmodel.FeaturedTag{}
+
+	err := t.conn.
+		NewSelect().
+		Model(&featuredTags).
+		Relation("Tag").
+		Where("featured_tag.account_id = ?", accountID).
+		Order("featured_tag.id ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+	return featuredTags, nil
+}
+
+func (t *tagDB) PutFeaturedTag(ctx context.Context, featuredTag *gtsmodel.FeaturedTag) db.Error {
+	_, err := t.conn.
+		NewInsert().
+		Model(featuredTag).
+		Exec(ctx)
+	return t.conn.ProcessError(err)
+}
+
+func (t *tagDB) DeleteFeaturedTagByID(ctx context.Context, id string) db.Error {
+	_, err := t.conn.
+		NewDelete().
+		Model(&gtsmodel.FeaturedTag{}).
+		Where("id = ?", id).
+		Exec(ctx)
+	return t.conn.ProcessError(err)
+}
+
+// accountTagStatusesQuery selects the public and unlisted statuses of the given accountID that use the given tagID.
+func (t *tagDB) accountTagStatusesQuery(statuses *[]*gtsmodel.Status, accountID string, tagID string) *bun.SelectQuery {
+	return t.conn.
+		NewSelect().
+		Model(statuses).
+		Join("JOIN status_to_tags AS stt ON stt.status_id = status.id").
+		Where("stt.tag_id = ?", tagID).
+		Where("status.account_id = ?", accountID).
+		Where("status.visibility IN (?)", bun.In([]gtsmodel.Visibility{gtsmodel.VisibilityPublic, gtsmodel.VisibilityUnlocked})).
+		WhereGroup(" AND ", whereEmptyOrNull("status.boost_of_id"))
+}
+
+func (t *tagDB) CountAccountTagStatuses(ctx context.Context, accountID string, tagID string) (int, time.Time, db.Error) {
+	count, err := t.accountTagStatusesQuery(&[]*gtsmodel.Status{}, accountID, tagID).Count(ctx)
+	if err != nil {
+		return 0, time.Time{}, t.conn.ProcessError(err)
+	}
+
+	if count == 0 {
+		return 0, time.Time{}, nil
+	}
+
+	latest := []*gtsmodel.Status{}
+	err = t.accountTagStatusesQuery(&latest, accountID, tagID).
+		Column("status.created_at").
+		Order("status.created_at DESC").
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		return 0, time.Time{}, t.conn.ProcessError(err)
+	}
+
+	if len(latest) == 0 {
+		return count, time.Time{}, nil
+	}
+	return count, latest[0].CreatedAt, nil
+}
+
+func (t *tagDB) GetAccountMostUsedTags(ctx context.Context, accountID string, limit int) ([]*gtsmodel.Tag, db.Error) {
+	tags := []*gtsmodel.Tag{}
+
+	q := t.conn.
+		NewSelect().
+		Model(&tags).
+		ColumnExpr("tag.*").
+		// Find the public and unlisted statuses of this account that use each tag.
+		Join("JOIN status_to_tags AS stt ON stt.tag_id = tag.id").
+		Join("JOIN statuses AS status ON status.id = stt.status_id").
+		Where("status.account_id = ?", accountID).
+		Where("status.visibility IN (?)", bun.In([]gtsmodel.Visibility{gtsmodel.VisibilityPublic, gtsmodel.VisibilityUnlocked})).
+		WhereGroup(" AND ", whereEmptyOrNull("status.boost_of_id")).
+		Group("tag.id").
+		OrderExpr("COUNT(stt.status_id) DESC")
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+	return tags, nil
+}
@@ -0,0 +1,166 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type TagTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *TagTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testAttachments = testrig.NewTestAttachments()
+	suite.testStatuses = testrig.NewTestStatuses()
+	suite.testTags = testrig.NewTestTags()
+	suite.testMentions = testrig.NewTestMentions()
+}
+
+func (suite *TagTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.log = testrig.NewTestLog()
+
+	testrig.StandardDBSetup(suite.db, suite.testAccounts)
+}
+
+func (suite *TagTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+}
+
+func (suite *TagTestSuite) TestGetTagByName() {
+	// tag names should be matched case-insensitively
+	tag, err := suite.db.GetTagByName(context.Background(), "WELCOME")
+	suite.NoError(err)
+	suite.Equal(suite.testTags["welcome"].ID, tag.ID)
+
+	_, err = suite.db.GetTagByName(context.Background(), "doesnotexist")
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *TagTestSuite) TestTagFollows() {
+	account := suite.testAccounts["local_account_1"]
+	tag := suite.testTags["welcome"]
+
+	err := suite.db.PutTagFollow(context.Background(), &gtsmodel.TagFollow{
+		ID:        "01FXZ3B8A9YJ8Q5NN2Y9J8T4ZT",
+		AccountID: account.ID,
+		TagID:     tag.ID,
+	})
+	suite.NoError(err)
+
+	tagFollow, err := suite.db.GetTagFollow(context.Background(), account.ID, tag.ID)
+	suite.NoError(err)
+	suite.Equal("01FXZ3B8A9YJ8Q5NN2Y9J8T4ZT", tagFollow.ID)
+
+	tagFollows, err := suite.db.GetTagFollowsForAccountID(context.Background(), account.ID, "", "", "", 0)
+	suite.NoError(err)
+	suite.Len(tagFollows, 1)
+	suite.NotNil(tagFollows[0].Tag)
+	suite.Equal(tag.Name, tagFollows[0].Tag.Name)
+
+	tagFollows, err = suite.db.GetTagFollowsForTagIDs(context.Background(), []string{tag.ID, suite.testTags["Hashtag"].ID})
+	suite.NoError(err)
+	suite.Len(tagFollows, 1)
+	suite.Equal(account.ID, tagFollows[0].AccountID)
+
+	err = suite.db.DeleteTagFollow(context.Background(), account.ID, tag.ID)
+	suite.NoError(err)
+
+	_, err = suite.db.GetTagFollow(context.Background(), account.ID, tag.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *TagTestSuite) TestFeaturedTags() {
+	account := suite.testAccounts["local_account_1"]
+	tag := suite.testTags["welcome"]
+
+	featuredTags, err := suite.db.GetFeaturedTagsForAccountID(context.Background(), account.ID)
+	suite.NoError(err)
+	suite.Empty(featuredTags)
+
+	err = suite.db.PutFeaturedTag(context.Background(), &gtsmodel.FeaturedTag{
+		ID:        "01FY0SQ5Y3W1XJ4R9E4GJ5D7QK",
+		AccountID: account.ID,
+		TagID:     tag.ID,
+	})
+	suite.NoError(err)
+
+	featuredTag, err := suite.db.GetFeaturedTag(context.Background(), account.ID, tag.ID)
+	suite.NoError(err)
+	suite.Equal("01FY0SQ5Y3W1XJ4R9E4GJ5D7QK", featuredTag.ID)
+
+	featuredTag, err = suite.db.GetFeaturedTagByID(context.Background(), "01FY0SQ5Y3W1XJ4R9E4GJ5D7QK")
+	suite.NoError(err)
+	suite.NotNil(featuredTag.Tag)
+	suite.Equal(tag.Name, featuredTag.Tag.Name)
+
+	featuredTags, err = suite.db.GetFeaturedTagsForAccountID(context.Background(), account.ID)
+	suite.NoError(err)
+	suite.Len(featuredTags, 1)
+	suite.Equal(tag.Name, featuredTags[0].Tag.Name)
+
+	err = suite.db.DeleteFeaturedTagByID(context.Background(), featuredTag.ID)
+	suite.NoError(err)
+
+	_, err = suite.db.GetFeaturedTag(context.Background(), account.ID, tag.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *TagTestSuite) TestAccountTagUsage() {
+	account := suite.testAccounts["admin_account"]
+	tag := suite.testTags["welcome"]
+
+	// the admin account's first status uses the welcome tag
+	count, lastStatusAt, err := suite.db.CountAccountTagStatuses(context.Background(), account.ID, tag.ID)
+	suite.NoError(err)
+	suite.Equal(1, count)
+	suite.WithinDuration(suite.testStatuses["admin_account_status_1"].CreatedAt, lastStatusAt, time.Second)
+
+	count, lastStatusAt, err = suite.db.CountAccountTagStatuses(context.Background(), account.ID, suite.testTags["Hashtag"].ID)
+	suite.NoError(err)
+	suite.Zero(count)
+	suite.True(lastStatusAt.IsZero())
+
+	tags, err := suite.db.GetAccountMostUsedTags(context.Background(), account.ID, 10)
+	suite.NoError(err)
+	suite.Len(tags, 1)
+	suite.Equal(tag.ID, tags[0].ID)
+
+	tags, err = suite.db.GetAccountMostUsedTags(context.Background(), suite.testAccounts["local_account_2"].ID, 10)
+	suite.NoError(err)
+	suite.Empty(tags)
+}
+
+func TestTagTestSuite(t *testing.T) {
+	suite.Run(t, new(TagTestSuite))
+}
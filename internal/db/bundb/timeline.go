@@ -98,6 +98,54 @@ func (t *timelineDB) GetHomeTimeline(ctx context.Context, accountID string, maxI
 	return statuses, nil
 }
 
+func (t *timelineDB) GetListTimeline(ctx context.Context, listID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.Status, db.Error) {
+	// Ensure reasonable
+	if limit < 0 {
+		limit = 0
+	}
+
+	// Make educated guess for slice size
+	statuses := make([]*gtsmodel.Status, 0, limit)
+
+	q := t.conn.
+		NewSelect().
+		Model(&statuses)
+
+	q = q.ColumnExpr("status.*").
+		// Find the follows that are entries in this list.
+		Join("JOIN follows AS f ON f.target_account_id = status.account_id").
+		Join("JOIN list_entries AS le ON le.follow_id = f.id").
+		Where("le.list_id = ?", listID).
+		// Sort by highest ID (newest) to lowest ID (oldest)
+		Order("status.id DESC")
+
+	if maxID != "" {
+		// return only statuses LOWER (ie., older) than maxID
+		q = q.Where("status.id < ?", maxID)
+	}
+
+	if sinceID != "" {
+		// return only statuses HIGHER (ie., newer) than sinceID
+		q = q.Where("status.id > ?", sinceID)
+	}
+
+	if minID != "" {
+		// return only statuses HIGHER (ie., newer) than minID
+		q = q.Where("status.id > ?", minID)
+	}
+
+	if limit > 0 {
+		// limit amount of statuses returned
+		q = q.Limit(limit)
+	}
+
+	err := q.Scan(ctx)
+	if err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+	return statuses, nil
+}
+
 func (t *timelineDB) GetPublicTimeline(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int, local bool) ([]*gtsmodel.Status, db.Error) {
 	// Ensure reasonable
 	if limit < 0 {
@@ -143,6 +191,55 @@ func (t *timelineDB) GetPublicTimeline(ctx context.Context, accountID string, ma
 	return statuses, nil
 }
 
+func (t *timelineDB) GetTagTimeline(ctx context.Context, tagID string, maxID string, sinceID string, minID string, limit int, local bool) ([]*gtsmodel.Status, db.Error) {
+	// Ensure reasonable
+	if limit < 0 {
+		limit = 0
+	}
+
+	// Make educated guess for slice size
+	statuses := make([]*gtsmodel.Status, 0, limit)
+
+	q := t.conn.
+		NewSelect().
+		Model(&statuses).
+		ColumnExpr("status.*").
+		// Find the statuses that use this tag.
+		Join("JOIN status_to_tags AS stt ON stt.status_id = status.id").
+		Where("stt.tag_id = ?", tagID).
+		Where("status.visibility = ?", gtsmodel.VisibilityPublic).
+		WhereGroup(" AND ", whereEmptyOrNull("status.in_reply_to_id")).
+		WhereGroup(" AND ", whereEmptyOrNull("status.in_reply_to_uri")).
+		WhereGroup(" AND ", whereEmptyOrNull("status.boost_of_id")).
+		Order("status.id DESC")
+
+	if maxID != "" {
+		q = q.Where("status.id < ?", maxID)
+	}
+
+	if sinceID != "" {
+		q = q.Where("status.id > ?", sinceID)
+	}
+
+	if minID != "" {
+		q = q.Where("status.id > ?", minID)
+	}
+
+	if local {
+		q = q.Where("status.local = ?", local)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	err := q.Scan(ctx)
+	if err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+	return statuses, nil
+}
+
 // TODO optimize this query and the logic here, because it's slow as balls -- it takes like a literal second to return with a limit of 20!
 // It might be worth serving it through a timeline instead of raw DB queries, like we do for Home feeds.
 func (t *timelineDB) GetFavedTimeline(ctx context.Context, accountID string, maxID string, minID string, limit int) ([]*gtsmodel.Status, string, string, db.Error) {
@@ -218,3 +315,74 @@ func (t *timelineDB) GetFavedTimeline(ctx context.Context, accountID string, max
 	prevMinID := faves[0].ID
 	return statuses, nextMaxID, prevMinID, nil
 }
+
+func (t *timelineDB) GetBookmarkedTimeline(ctx context.Context, accountID string, maxID string, minID string, limit int) ([]*gtsmodel.Status, string, string, db.Error) {
+	// Ensure reasonable
+	if limit < 0 {
+		limit = 0
+	}
+
+	// Make educated guess for slice size
+	bookmarks := make([]*gtsmodel.StatusBookmark, 0, limit)
+
+	bq := t.conn.
+		NewSelect().
+		Model(&bookmarks).
+		Where("account_id = ?", accountID).
+		Order("id DESC")
+
+	if maxID != "" {
+		bq = bq.Where("id < ?", maxID)
+	}
+
+	if minID != "" {
+		bq = bq.Where("id > ?", minID)
+	}
+
+	if limit > 0 {
+		bq = bq.Limit(limit)
+	}
+
+	err := bq.Scan(ctx)
+	if err != nil {
+		return nil, "", "", t.conn.ProcessError(err)
+	}
+
+	if len(bookmarks) == 0 {
+		return nil, "", "", db.ErrNoEntries
+	}
+
+	// map[statusID]bookmarkID -- we need this to sort statuses by bookmark ID rather than status ID
+	statusesBookmarksMap := make(map[string]string, len(bookmarks))
+	statusIDs := make([]string, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		statusesBookmarksMap[b.StatusID] = b.ID
+		statusIDs = append(statusIDs, b.StatusID)
+	}
+
+	statuses := make([]*gtsmodel.Status, 0, len(statusIDs))
+
+	err = t.conn.
+		NewSelect().
+		Model(&statuses).
+		Where("id IN (?)", bun.In(statusIDs)).
+		Scan(ctx)
+	if err != nil {
+		return nil, "", "", t.conn.ProcessError(err)
+	}
+
+	if len(statuses) == 0 {
+		return nil, "", "", db.ErrNoEntries
+	}
+
+	// arrange statuses by bookmark ID, newest bookmark first
+	sort.Slice(statuses, func(i int, j int) bool {
+		statusI := statuses[i]
+		statusJ := statuses[j]
+		return statusesBookmarksMap[statusI.ID] > statusesBookmarksMap[statusJ.ID]
+	})
+
+	nextMaxID := bookmarks[len(bookmarks)-1].ID
+	prevMinID := bookmarks[0].ID
+	return statuses, nextMaxID, prevMinID, nil
+}
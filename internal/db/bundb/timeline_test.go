@@ -63,6 +63,40 @@ func (suite *TimelineTestSuite) TestGetPublicTimeline() {
 	suite.Len(s, 6)
 }
 
+func (suite *TimelineTestSuite) TestGetListTimeline() {
+	list := testrig.NewTestLists()["local_account_1_list_1"]
+
+	s, err := suite.db.GetListTimeline(context.Background(), list.ID, "", "", "", 20)
+	suite.NoError(err)
+
+	// local_account_1_list_1 contains only local_account_2, who has 5 statuses
+	suite.Len(s, 5)
+	for _, status := range s {
+		suite.Equal(suite.testAccounts["local_account_2"].ID, status.AccountID)
+	}
+}
+
+func (suite *TimelineTestSuite) TestGetTagTimeline() {
+	tag := suite.testTags["welcome"]
+
+	s, err := suite.db.GetTagTimeline(context.Background(), tag.ID, "", "", "", 20, false)
+	suite.NoError(err)
+
+	// only admin_account_status_1 uses the welcome tag
+	suite.Len(s, 1)
+	suite.Equal(suite.testStatuses["admin_account_status_1"].ID, s[0].ID)
+
+	// admin_account_status_1 is local, so it should turn up in the local tag timeline too
+	s, err = suite.db.GetTagTimeline(context.Background(), tag.ID, "", "", "", 20, true)
+	suite.NoError(err)
+	suite.Len(s, 1)
+
+	// nothing uses the other tag
+	s, err = suite.db.GetTagTimeline(context.Background(), suite.testTags["Hashtag"].ID, "", "", "", 20, false)
+	suite.NoError(err)
+	suite.Empty(s)
+}
+
 func TestTimelineTestSuite(t *testing.T) {
 	suite.Run(t, new(TimelineTestSuite))
 }
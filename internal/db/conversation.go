@@ -0,0 +1,67 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Conversation contains functions for creating, getting, and removing direct message conversations.
+type Conversation interface {
+	// GetConversationByID returns one conversation with the given ID, or an error if something goes wrong.
+	GetConversationByID(ctx context.Context, id string) (*gtsmodel.Conversation, Error)
+
+	// GetConversationByThreadAndAccounts returns the conversation owned by the given accountID, for the given threadID
+	// and set of other participants, or an error if something goes wrong.
+	GetConversationByThreadAndAccounts(ctx context.Context, accountID string, threadID string, otherAccountsKey string) (*gtsmodel.Conversation, Error)
+
+	// GetConversationForStatusID returns the conversation owned by the given accountID which contains the given statusID,
+	// or an error if something goes wrong.
+	GetConversationForStatusID(ctx context.Context, accountID string, statusID string) (*gtsmodel.Conversation, Error)
+
+	// GetConversationsForStatusID returns all conversations, belonging to any account, which contain the given statusID.
+	GetConversationsForStatusID(ctx context.Context, statusID string) ([]*gtsmodel.Conversation, Error)
+
+	// GetConversationsForAccountID returns a slice of conversations owned by the given accountID.
+	// Conversations are returned in descending order of their last status (most recently active first),
+	// and the paging parameters refer to the ID of that last status.
+	GetConversationsForAccountID(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.Conversation, Error)
+
+	// GetConversationLastStatusID returns the ID of the newest status still belonging to the given conversationID,
+	// or ErrNoEntries if the conversation doesn't contain any statuses anymore.
+	GetConversationLastStatusID(ctx context.Context, conversationID string) (string, Error)
+
+	// PutConversation puts a new conversation in the database.
+	PutConversation(ctx context.Context, conversation *gtsmodel.Conversation) Error
+
+	// UpdateConversation updates the given conversation in the database.
+	UpdateConversation(ctx context.Context, conversation *gtsmodel.Conversation) Error
+
+	// PutConversationToStatus records that the given statusID belongs to the given conversationID.
+	PutConversationToStatus(ctx context.Context, conversationID string, statusID string) Error
+
+	// DeleteConversationByID deletes one conversation with the given ID, and its record of which statuses belong to it.
+	DeleteConversationByID(ctx context.Context, id string) Error
+
+	// DeleteStatusFromConversations removes the given statusID from any conversations it belongs to.
+	// The conversations themselves are left in place.
+	DeleteStatusFromConversations(ctx context.Context, statusID string) Error
+}
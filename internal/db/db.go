@@ -32,16 +32,26 @@ const (
 // DB provides methods for interacting with an underlying database or other storage mechanism.
 type DB interface {
 	Account
+	AccountArchive
+	AccountImport
 	Admin
 	Basic
+	Conversation
 	Domain
+	Filter
 	Instance
+	List
 	Media
 	Mention
 	Notification
+	Poll
 	Relationship
+	Report
+	ScheduledStatus
 	Session
 	Status
+	Suggestion
+	Tag
 	Timeline
 
 	/*
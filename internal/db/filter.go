@@ -0,0 +1,48 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Filter contains functions for creating, getting, and removing user-defined keyword filters.
+type Filter interface {
+	// GetFilterByID returns one filter with the given ID, or an error if something goes wrong.
+	GetFilterByID(ctx context.Context, id string) (*gtsmodel.Filter, Error)
+
+	// GetFiltersForAccountID returns all filters owned by the given accountID, including expired ones.
+	GetFiltersForAccountID(ctx context.Context, accountID string) ([]*gtsmodel.Filter, Error)
+
+	// GetActiveFiltersForAccountID returns all filters owned by the given accountID that apply in the given
+	// context and which haven't expired yet. If irreversibleOnly is true, then only filters that have irreversible
+	// set to true will be returned.
+	GetActiveFiltersForAccountID(ctx context.Context, accountID string, filterContext gtsmodel.FilterContext, irreversibleOnly bool) ([]*gtsmodel.Filter, Error)
+
+	// PutFilter puts a new filter in the database.
+	PutFilter(ctx context.Context, filter *gtsmodel.Filter) Error
+
+	// UpdateFilter updates the given filter in the database.
+	UpdateFilter(ctx context.Context, filter *gtsmodel.Filter) Error
+
+	// DeleteFilterByID deletes one filter with the given ID.
+	DeleteFilterByID(ctx context.Context, id string) Error
+}
@@ -0,0 +1,59 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// List contains functions for creating, getting, and removing lists and their entries.
+type List interface {
+	// GetListByID returns one list with the given ID, or an error if something goes wrong.
+	GetListByID(ctx context.Context, id string) (*gtsmodel.List, Error)
+
+	// GetListsForAccountID returns all lists owned by the given accountID.
+	GetListsForAccountID(ctx context.Context, accountID string) ([]*gtsmodel.List, Error)
+
+	// PutList puts a new list in the database.
+	PutList(ctx context.Context, list *gtsmodel.List) Error
+
+	// UpdateList updates the given list in the database.
+	UpdateList(ctx context.Context, list *gtsmodel.List) Error
+
+	// DeleteListByID deletes one list with the given ID, and all entries belonging to it.
+	DeleteListByID(ctx context.Context, id string) Error
+
+	// GetListEntries returns a slice of entries from the given list, with their follows populated.
+	// Entries are returned in descending order of when they were added to the list (newest first).
+	GetListEntries(ctx context.Context, listID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.ListEntry, Error)
+
+	// GetListEntriesForFollowID returns all list entries that reference the given followID.
+	GetListEntriesForFollowID(ctx context.Context, followID string) ([]*gtsmodel.ListEntry, Error)
+
+	// PutListEntries puts the given list entries in the database.
+	PutListEntries(ctx context.Context, entries []*gtsmodel.ListEntry) Error
+
+	// DeleteListEntry deletes one list entry with the given ID.
+	DeleteListEntry(ctx context.Context, id string) Error
+
+	// DeleteListEntriesForFollowID deletes all list entries that reference the given followID.
+	DeleteListEntriesForFollowID(ctx context.Context, followID string) Error
+}
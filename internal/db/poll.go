@@ -0,0 +1,56 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Poll contains functions for getting, creating, and updating polls and the votes cast in them.
+type Poll interface {
+	// GetPollByID returns one poll with the given ID, or an error if something goes wrong.
+	GetPollByID(ctx context.Context, id string) (*gtsmodel.Poll, Error)
+
+	// GetPollByStatusID returns the poll attached to the status with the given ID, or an error if something goes wrong.
+	GetPollByStatusID(ctx context.Context, statusID string) (*gtsmodel.Poll, Error)
+
+	// GetExpiredPolls returns all polls whose expiry time has passed, but which haven't been closed yet.
+	GetExpiredPolls(ctx context.Context) ([]*gtsmodel.Poll, Error)
+
+	// PutPoll puts a new poll in the database.
+	PutPoll(ctx context.Context, poll *gtsmodel.Poll) Error
+
+	// UpdatePoll updates the given poll in the database.
+	UpdatePoll(ctx context.Context, poll *gtsmodel.Poll) Error
+
+	// GetPollVotes returns all votes that have been cast in the poll with the given ID.
+	GetPollVotes(ctx context.Context, pollID string) ([]*gtsmodel.PollVote, Error)
+
+	// GetPollVoteByAccountID returns the vote cast by the given account in the given poll,
+	// or ErrNoEntries if the account hasn't voted in the poll.
+	GetPollVoteByAccountID(ctx context.Context, pollID string, accountID string) (*gtsmodel.PollVote, Error)
+
+	// PutPollVote puts a new poll vote in the database.
+	PutPollVote(ctx context.Context, vote *gtsmodel.PollVote) Error
+
+	// UpdatePollVote updates the given poll vote in the database.
+	UpdatePollVote(ctx context.Context, vote *gtsmodel.PollVote) Error
+}
@@ -36,6 +36,36 @@ type Relationship interface {
 	// not if you're just checking for the existence of a block.
 	GetBlock(ctx context.Context, account1 string, account2 string) (*gtsmodel.Block, Error)
 
+	// IsMuted checks whether account1 has an active (ie., unexpired) mute in place against account2.
+	IsMuted(ctx context.Context, account1 string, account2 string) (bool, Error)
+
+	// GetMute returns the active (ie., unexpired) mute from account1 targeting account2, if it exists, or an error if it doesn't.
+	GetMute(ctx context.Context, account1 string, account2 string) (*gtsmodel.UserMute, Error)
+
+	// GetAccountMutes returns active mutes created by the given accountID, with the target account of each mute populated.
+	// Mutes are returned in descending order of ID (newest first).
+	//
+	// In case of no entries, a 'no entries' error will be returned.
+	GetAccountMutes(ctx context.Context, accountID string, maxID string, sinceID string, limit int) ([]*gtsmodel.UserMute, Error)
+
+	// IsDomainBlockedByAccount checks whether the given accountID has blocked the given domain.
+	IsDomainBlockedByAccount(ctx context.Context, accountID string, domain string) (bool, Error)
+
+	// GetAccountDomainBlocks returns domain blocks created by the given accountID.
+	// Domain blocks are returned in descending order of ID (newest first).
+	//
+	// In case of no entries, a 'no entries' error will be returned.
+	GetAccountDomainBlocks(ctx context.Context, accountID string, maxID string, sinceID string, limit int) ([]*gtsmodel.UserDomainBlock, Error)
+
+	// IsEndorsed checks whether account1 has endorsed (featured on its profile) account2.
+	IsEndorsed(ctx context.Context, account1 string, account2 string) (bool, Error)
+
+	// GetAccountEndorsements returns endorsements created by the given accountID, with the target account of each endorsement populated.
+	// Endorsements are returned in descending order of ID (newest first).
+	//
+	// In case of no entries, a 'no entries' error will be returned.
+	GetAccountEndorsements(ctx context.Context, accountID string, maxID string, sinceID string, limit int) ([]*gtsmodel.Endorsement, Error)
+
 	// GetRelationship retrieves the relationship of the targetAccount to the requestingAccount.
 	GetRelationship(ctx context.Context, requestingAccount string, targetAccount string) (*gtsmodel.Relationship, Error)
 
@@ -72,4 +102,12 @@ type Relationship interface {
 
 	// CountAccountFollowedBy returns the amounts that the given ID is followed by.
 	CountAccountFollowedBy(ctx context.Context, accountID string, localOnly bool) (int, Error)
+
+	// GetFollowsOfFollows returns the IDs of up to limit accounts that are followed by the accounts that the given accountID follows,
+	// but which aren't followed by accountID itself. Accounts followed by more of accountID's follows come first.
+	GetFollowsOfFollows(ctx context.Context, accountID string, limit int) ([]string, Error)
+
+	// GetMostFollowedByLocalAccounts returns the IDs of up to limit accounts that are followed by the most accounts on this instance,
+	// most followed first.
+	GetMostFollowedByLocalAccounts(ctx context.Context, limit int) ([]string, Error)
 }
@@ -0,0 +1,45 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Report contains functions for creating, getting, and updating reports of accounts.
+type Report interface {
+	// GetReportByID returns one report with the given ID, or an error if something goes wrong.
+	GetReportByID(ctx context.Context, id string) (*gtsmodel.Report, Error)
+
+	// GetReportByURI returns one report with the given URI, or an error if something goes wrong.
+	GetReportByURI(ctx context.Context, uri string) (*gtsmodel.Report, Error)
+
+	// GetReports returns a slice of reports that are either resolved or unresolved, optionally filtered to those made by
+	// the given accountID and/or targeting the given targetAccountID. Pass an empty string for either to not filter on it.
+	// Reports are returned in descending order of when they were created (newest first).
+	GetReports(ctx context.Context, resolved bool, accountID string, targetAccountID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.Report, Error)
+
+	// PutReport puts a new report in the database.
+	PutReport(ctx context.Context, report *gtsmodel.Report) Error
+
+	// UpdateReport updates the given report in the database.
+	UpdateReport(ctx context.Context, report *gtsmodel.Report) Error
+}
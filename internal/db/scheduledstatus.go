@@ -0,0 +1,52 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// ScheduledStatus contains functions for creating, getting, and removing statuses that are scheduled to be posted in the future.
+type ScheduledStatus interface {
+	// GetScheduledStatusByID returns one scheduled status with the given ID, or an error if something goes wrong.
+	GetScheduledStatusByID(ctx context.Context, id string) (*gtsmodel.ScheduledStatus, Error)
+
+	// GetScheduledStatusesForAccountID returns scheduled statuses owned by the given accountID, newest first,
+	// paged using the given maxID, sinceID, minID and limit. If no entries are found, ErrNoEntries will be returned.
+	GetScheduledStatusesForAccountID(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.ScheduledStatus, Error)
+
+	// CountScheduledStatusesForAccountID returns the number of scheduled statuses owned by the given accountID that
+	// are scheduled to be posted between the given times. Zero times mean no limit in that direction.
+	CountScheduledStatusesForAccountID(ctx context.Context, accountID string, from time.Time, to time.Time) (int, Error)
+
+	// GetAllScheduledStatuses returns every scheduled status in the database, regardless of owner.
+	GetAllScheduledStatuses(ctx context.Context) ([]*gtsmodel.ScheduledStatus, Error)
+
+	// PutScheduledStatus puts a new scheduled status in the database.
+	PutScheduledStatus(ctx context.Context, scheduledStatus *gtsmodel.ScheduledStatus) Error
+
+	// UpdateScheduledStatus updates the given scheduled status in the database.
+	UpdateScheduledStatus(ctx context.Context, scheduledStatus *gtsmodel.ScheduledStatus) Error
+
+	// DeleteScheduledStatusByID deletes one scheduled status with the given ID.
+	DeleteScheduledStatusByID(ctx context.Context, id string) Error
+}
@@ -38,6 +38,16 @@ type Status interface {
 	// PutStatus stores one status in the database.
 	PutStatus(ctx context.Context, status *gtsmodel.Status) Error
 
+	// UpdateStatus updates one status in the database, relinking its emojis, tags and attachments as necessary.
+	UpdateStatus(ctx context.Context, status *gtsmodel.Status) Error
+
+	// PutStatusEdit stores one previous revision of a status in the database.
+	PutStatusEdit(ctx context.Context, edit *gtsmodel.StatusEdit) Error
+
+	// GetStatusEdits returns all stored previous revisions of the given status, oldest first.
+	// If the status has never been edited, an empty slice will be returned.
+	GetStatusEdits(ctx context.Context, statusID string) ([]*gtsmodel.StatusEdit, Error)
+
 	// CountStatusReplies returns the amount of replies recorded for a status, or an error if something goes wrong
 	CountStatusReplies(ctx context.Context, status *gtsmodel.Status) (int, Error)
 
@@ -63,7 +73,9 @@ type Status interface {
 	// IsStatusRebloggedBy checks if a given status has been reblogged/boosted by a given account ID
 	IsStatusRebloggedBy(ctx context.Context, status *gtsmodel.Status, accountID string) (bool, Error)
 
-	// IsStatusMutedBy checks if a given status has been muted by a given account ID
+	// IsStatusMutedBy checks if a given status has been muted by a given account ID.
+	//
+	// Since mutes apply to a whole thread, this will also return true if any of the status' parents has been muted.
 	IsStatusMutedBy(ctx context.Context, status *gtsmodel.Status, accountID string) (bool, Error)
 
 	// IsStatusBookmarkedBy checks if a given status has been bookmarked by a given account ID
@@ -0,0 +1,58 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Suggestion contains functions for storing and retrieving follow suggestions, dismissals of those suggestions,
+// and the accounts that admins have featured as suggestions for everyone.
+type Suggestion interface {
+	// GetSuggestionsForAccount returns up to limit of the suggestions currently stored for the given account, with target accounts populated,
+	// ordered by rank. If there are no suggestions, an empty slice will be returned rather than an error.
+	GetSuggestionsForAccount(ctx context.Context, accountID string, limit int) ([]*gtsmodel.Suggestion, Error)
+
+	// ReplaceSuggestionsForAccount removes all suggestions currently stored for the given account, and stores the given suggestions instead.
+	ReplaceSuggestionsForAccount(ctx context.Context, accountID string, suggestions []*gtsmodel.Suggestion) Error
+
+	// DeleteSuggestion removes the suggestion of targetAccountID from the suggestions stored for accountID, if there is one.
+	DeleteSuggestion(ctx context.Context, accountID string, targetAccountID string) Error
+
+	// PutSuggestionDismissal stores the given suggestion dismissal.
+	PutSuggestionDismissal(ctx context.Context, dismissal *gtsmodel.SuggestionDismissal) Error
+
+	// GetDismissedSuggestionIDs returns the IDs of all the accounts that the given account has dismissed as suggestions.
+	GetDismissedSuggestionIDs(ctx context.Context, accountID string) ([]string, Error)
+
+	// GetFeaturedAccounts returns all the accounts that have been featured by admins, with accounts populated, oldest first.
+	// If there are no featured accounts, an empty slice will be returned rather than an error.
+	GetFeaturedAccounts(ctx context.Context) ([]*gtsmodel.FeaturedAccount, Error)
+
+	// GetFeaturedAccount returns the featured account entry for the given account ID, or ErrNoEntries if it's not featured.
+	GetFeaturedAccount(ctx context.Context, accountID string) (*gtsmodel.FeaturedAccount, Error)
+
+	// PutFeaturedAccount stores the given featured account entry.
+	PutFeaturedAccount(ctx context.Context, featuredAccount *gtsmodel.FeaturedAccount) Error
+
+	// DeleteFeaturedAccount removes the featured account entry for the given account ID, if there is one.
+	DeleteFeaturedAccount(ctx context.Context, accountID string) Error
+}
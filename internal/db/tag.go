@@ -0,0 +1,76 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Tag contains functions for getting hashtags, and creating, getting, and removing follows and features of hashtags.
+type Tag interface {
+	// GetTagByName returns the tag with the given name, ignoring case, or an error if something goes wrong.
+	GetTagByName(ctx context.Context, name string) (*gtsmodel.Tag, Error)
+
+	// GetTagFollow returns the follow of the given tagID by the given accountID, or an error if something goes wrong.
+	GetTagFollow(ctx context.Context, accountID string, tagID string) (*gtsmodel.TagFollow, Error)
+
+	// GetTagFollowsForAccountID returns the tag follows of the given accountID, with their tags populated.
+	// Tag follows are returned in descending order of when they were created (newest first).
+	GetTagFollowsForAccountID(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.TagFollow, Error)
+
+	// GetTagFollowsForTagIDs returns all tag follows of any of the given tagIDs.
+	GetTagFollowsForTagIDs(ctx context.Context, tagIDs []string) ([]*gtsmodel.TagFollow, Error)
+
+	// PutTagFollow puts a new tag follow in the database.
+	PutTagFollow(ctx context.Context, tagFollow *gtsmodel.TagFollow) Error
+
+	// DeleteTagFollow deletes the follow of the given tagID by the given accountID, if it exists.
+	DeleteTagFollow(ctx context.Context, accountID string, tagID string) Error
+
+	// GetFeaturedTagByID returns the featured tag with the given id, with its tag populated.
+	GetFeaturedTagByID(ctx context.Context, id string) (*gtsmodel.FeaturedTag, Error)
+
+	// GetFeaturedTag returns the feature of the given tagID by the given accountID, or an error if something goes wrong.
+	GetFeaturedTag(ctx context.Context, accountID string, tagID string) (*gtsmodel.FeaturedTag, Error)
+
+	// GetFeaturedTagsForAccountID returns all tags featured by the given accountID, with their tags populated.
+	// Featured tags are returned in ascending order of when they were created (oldest first).
+	//
+	// If the account doesn't feature any tags, an empty slice will be returned rather than an error.
+	GetFeaturedTagsForAccountID(ctx context.Context, accountID string) ([]*gtsmodel.FeaturedTag, Error)
+
+	// PutFeaturedTag puts a new featured tag in the database.
+	PutFeaturedTag(ctx context.Context, featuredTag *gtsmodel.FeaturedTag) Error
+
+	// DeleteFeaturedTagByID deletes the featured tag with the given id, if it exists.
+	DeleteFeaturedTagByID(ctx context.Context, id string) Error
+
+	// CountAccountTagStatuses returns the amount of public and unlisted statuses by the given accountID that use the given tagID,
+	// along with the time that the most recent of those statuses was created (zero time if there are none).
+	CountAccountTagStatuses(ctx context.Context, accountID string, tagID string) (int, time.Time, Error)
+
+	// GetAccountMostUsedTags returns up to limit tags that the given accountID uses most often in its public and unlisted statuses.
+	// Tags are returned in descending order of use.
+	//
+	// If the account hasn't used any tags, an empty slice will be returned rather than an error.
+	GetAccountMostUsedTags(ctx context.Context, accountID string, limit int) ([]*gtsmodel.Tag, Error)
+}
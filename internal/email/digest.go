@@ -0,0 +1,60 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package email
+
+const (
+	digestTemplateHTML = "email_digest_html.tmpl"
+	digestTemplateText = "email_digest_text.tmpl"
+	digestSubject      = "%s: new notifications"
+)
+
+// DigestData represents data passed into the notification digest email templates.
+type DigestData struct {
+	// Username to be addressed.
+	Username string
+	// URL of the instance to present to the receiver.
+	InstanceURL string
+	// Name of the instance to present to the receiver.
+	InstanceName string
+	// Notifications to include in the digest, oldest first.
+	Notifications []DigestNotification
+}
+
+// DigestNotification is one notification included in a digest email.
+type DigestNotification struct {
+	// Type of the notification: one of follow, follow_request, mention, or direct.
+	Type string
+	// Name of the account that the notification came from, eg., @someone@example.org
+	Account string
+	// Link to the profile of the account that the notification came from.
+	AccountURL string
+	// Plaintext content of the status, for mentions and direct messages.
+	Text string
+	// Link to the status, for mentions and direct messages.
+	StatusURL string
+}
+
+func (s *sender) SendNotificationDigestEmail(toAddress string, data DigestData) error {
+	textBody, htmlBody, err := execute(s.textTemplate, s.htmlTemplate, digestTemplateText, digestTemplateHTML, data)
+	if err != nil {
+		return err
+	}
+
+	return s.send(toAddress, subject(digestSubject, data.InstanceName), textBody, htmlBody)
+}
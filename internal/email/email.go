@@ -18,3 +18,67 @@
 
 // Package email provides a service for interacting with an SMTP server
 package email
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"net/smtp"
+	ttemplate "text/template"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+// Sender contains functions for sending emails to instance users/new signups.
+type Sender interface {
+	// SendConfirmEmail sends a 'please confirm your email' style email to the given toAddress, with the given data.
+	SendConfirmEmail(toAddress string, data ConfirmData) error
+
+	// SendResetEmail sends a 'reset your password' style email to the given toAddress, with the given data.
+	SendResetEmail(toAddress string, data ResetData) error
+
+	// SendNotificationDigestEmail sends a digest of notifications to the given toAddress, with the given data.
+	SendNotificationDigestEmail(toAddress string, data DigestData) error
+}
+
+// NewSender returns a new email Sender interface with the given configuration, or an error if something goes wrong.
+func NewSender(cfg *config.Config) (Sender, error) {
+	if cfg.SMTPConfig.From == "" {
+		return nil, errors.New("smtp from address was not set")
+	}
+
+	htmlTemplate, textTemplate, err := loadTemplates(cfg.TemplateConfig.BaseDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sender{
+		hostAddress:  fmt.Sprintf("%s:%d", cfg.SMTPConfig.Host, cfg.SMTPConfig.Port),
+		from:         cfg.SMTPConfig.From,
+		auth:         smtp.PlainAuth("", cfg.SMTPConfig.Username, cfg.SMTPConfig.Password, cfg.SMTPConfig.Host),
+		htmlTemplate: htmlTemplate,
+		textTemplate: textTemplate,
+	}, nil
+}
+
+type sender struct {
+	hostAddress  string
+	from         string
+	auth         smtp.Auth
+	htmlTemplate *template.Template
+	textTemplate *ttemplate.Template
+}
+
+// send assembles a message with the given subject and bodies, and sends it to toAddress over smtp.
+func (s *sender) send(toAddress string, subject string, textBody string, htmlBody string) error {
+	msg, err := assembleMessage(subject, textBody, htmlBody, s.from, toAddress)
+	if err != nil {
+		return err
+	}
+
+	if err := smtp.SendMail(s.hostAddress, s.auth, s.from, []string{toAddress}, msg); err != nil {
+		return fmt.Errorf("error sending email to %s: %s", toAddress, err)
+	}
+
+	return nil
+}
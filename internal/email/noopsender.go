@@ -0,0 +1,91 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package email
+
+import (
+	"html/template"
+	ttemplate "text/template"
+)
+
+// NewNoopSender returns a no-op email sender that will just execute the given sendCallback
+// every time it would otherwise send an email to the given toAddress with the given message value.
+//
+// Passing a nil function is also acceptable, in which case the send functions will just return nil.
+//
+// Templates are still loaded and executed as normal, so this is useful for testing and for
+// instances that don't have an smtp server configured.
+func NewNoopSender(templateBaseDir string, sendCallback func(toAddress string, message string)) (Sender, error) {
+	htmlTemplate, textTemplate, err := loadTemplates(templateBaseDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &noopSender{
+		sendCallback: sendCallback,
+		htmlTemplate: htmlTemplate,
+		textTemplate: textTemplate,
+	}, nil
+}
+
+type noopSender struct {
+	sendCallback func(toAddress string, message string)
+	htmlTemplate *template.Template
+	textTemplate *ttemplate.Template
+}
+
+func (s *noopSender) SendConfirmEmail(toAddress string, data ConfirmData) error {
+	textBody, htmlBody, err := execute(s.textTemplate, s.htmlTemplate, confirmTemplateText, confirmTemplateHTML, data)
+	if err != nil {
+		return err
+	}
+
+	return s.send(toAddress, subject(confirmSubject, data.InstanceName), textBody, htmlBody)
+}
+
+func (s *noopSender) SendResetEmail(toAddress string, data ResetData) error {
+	textBody, htmlBody, err := execute(s.textTemplate, s.htmlTemplate, resetTemplateText, resetTemplateHTML, data)
+	if err != nil {
+		return err
+	}
+
+	return s.send(toAddress, subject(resetSubject, data.InstanceName), textBody, htmlBody)
+}
+
+func (s *noopSender) SendNotificationDigestEmail(toAddress string, data DigestData) error {
+	textBody, htmlBody, err := execute(s.textTemplate, s.htmlTemplate, digestTemplateText, digestTemplateHTML, data)
+	if err != nil {
+		return err
+	}
+
+	return s.send(toAddress, subject(digestSubject, data.InstanceName), textBody, htmlBody)
+}
+
+func (s *noopSender) send(toAddress string, subject string, textBody string, htmlBody string) error {
+	if s.sendCallback == nil {
+		return nil
+	}
+
+	msg, err := assembleMessage(subject, textBody, htmlBody, "", toAddress)
+	if err != nil {
+		return err
+	}
+
+	s.sendCallback(toAddress, string(msg))
+	return nil
+}
@@ -0,0 +1,134 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package email
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	ttemplate "text/template"
+	"time"
+)
+
+const (
+	htmlTemplateGlob = "email_*_html.tmpl"
+	textTemplateGlob = "email_*_text.tmpl"
+)
+
+// loadTemplates parses the html and plaintext email templates found in templateBaseDir.
+// A relative templateBaseDir is taken to be relative to the current working directory.
+func loadTemplates(templateBaseDir string) (*template.Template, *ttemplate.Template, error) {
+	if !filepath.IsAbs(templateBaseDir) {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting current working directory: %s", err)
+		}
+		templateBaseDir = filepath.Join(cwd, templateBaseDir)
+	}
+
+	htmlTemplate, err := template.ParseGlob(filepath.Join(templateBaseDir, htmlTemplateGlob))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading html email templates: %s", err)
+	}
+
+	textTemplate, err := ttemplate.ParseGlob(filepath.Join(templateBaseDir, textTemplateGlob))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading text email templates: %s", err)
+	}
+
+	return htmlTemplate, textTemplate, nil
+}
+
+// execute renders the named plaintext and html templates with the given data.
+func execute(textTemplate *ttemplate.Template, htmlTemplate *template.Template, textName string, htmlName string, data interface{}) (string, string, error) {
+	textBuf := &bytes.Buffer{}
+	if err := textTemplate.ExecuteTemplate(textBuf, textName, data); err != nil {
+		return "", "", fmt.Errorf("error executing template %s: %s", textName, err)
+	}
+
+	htmlBuf := &bytes.Buffer{}
+	if err := htmlTemplate.ExecuteTemplate(htmlBuf, htmlName, data); err != nil {
+		return "", "", fmt.Errorf("error executing template %s: %s", htmlName, err)
+	}
+
+	return textBuf.String(), htmlBuf.String(), nil
+}
+
+// subject formats an email subject line, falling back to a generic name if instanceName is empty.
+func subject(format string, instanceName string) string {
+	if instanceName == "" {
+		instanceName = "GoToSocial"
+	}
+	return fmt.Sprintf(format, instanceName)
+}
+
+// assembleMessage puts together a multipart/alternative email message with a plaintext and html part.
+func assembleMessage(mailSubject string, mailTextBody string, mailHTMLBody string, mailFrom string, mailTo string) ([]byte, error) {
+	if strings.ContainsAny(mailFrom, "\r\n") || strings.ContainsAny(mailTo, "\r\n") {
+		return nil, errors.New("email address must not contain newline characters")
+	}
+
+	msg := &bytes.Buffer{}
+	mw := multipart.NewWriter(msg)
+
+	fmt.Fprintf(msg, "To: %s\r\n", mailTo)
+	fmt.Fprintf(msg, "From: %s\r\n", mailFrom)
+	fmt.Fprintf(msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", mailSubject))
+	fmt.Fprintf(msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprint(msg, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(msg, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", mw.Boundary())
+
+	for _, part := range []struct {
+		contentType string
+		body        string
+	}{
+		{contentType: "text/plain", body: mailTextBody},
+		{contentType: "text/html", body: mailHTMLBody},
+	} {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType + "; charset=utf-8"},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error creating %s part: %s", part.contentType, err)
+		}
+
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.body)); err != nil {
+			return nil, fmt.Errorf("error writing %s part: %s", part.contentType, err)
+		}
+		if err := qp.Close(); err != nil {
+			return nil, fmt.Errorf("error closing %s part: %s", part.contentType, err)
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return nil, fmt.Errorf("error closing multipart writer: %s", err)
+	}
+
+	return msg.Bytes(), nil
+}
@@ -129,6 +129,9 @@ func (d *deref) GetRemoteAccount(ctx context.Context, username string, remoteAcc
 		}
 	}
 
+	// now the account is stored we can fetch the statuses it has pinned to its profile
+	d.fetchFeaturedCollection(ctx, username, gtsAccount)
+
 	return gtsAccount, new, nil
 }
 
@@ -0,0 +1,233 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dereferencing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// featuredCollectionLimit is the maximum number of items we'll dereference from a remote featured collection.
+const featuredCollectionLimit = 20
+
+// featuredItem is satisfied by iterators over both the items and orderedItems properties of a collection.
+type featuredItem interface {
+	IsIRI() bool
+	GetIRI() *url.URL
+	GetType() vocab.Type
+}
+
+// featuredItemURI returns the id of the given collection item, whether it's just an IRI or an embedded object.
+func featuredItemURI(item featuredItem) *url.URL {
+	if item.IsIRI() {
+		return item.GetIRI()
+	}
+
+	if t := item.GetType(); t != nil {
+		if idProp := t.GetJSONLDId(); idProp != nil && idProp.IsIRI() {
+			return idProp.GetIRI()
+		}
+	}
+
+	return nil
+}
+
+// featuredCtxKey is set on the context of a featured collection fetch, so that accounts we come across
+// while processing the collection don't go on to fetch their own featured collections in turn.
+type featuredCtxKey struct{}
+
+// fetchFeaturedCollection dereferences the featured collection of the given remote account in the background,
+// so that callers resolving the account--including inbox signature checks--don't have to wait for it.
+//
+// Nothing will be fetched for instance accounts, or for accounts that were themselves resolved while
+// processing another featured collection.
+func (d *deref) fetchFeaturedCollection(ctx context.Context, username string, account *gtsmodel.Account) {
+	if instanceAccount(account) || ctx.Value(featuredCtxKey{}) != nil {
+		return
+	}
+
+	// take a copy so the caller is free to go on using the account
+	featuredAccount := *account
+
+	go func() {
+		// the context of the caller may well be cancelled before we're done, so don't use it
+		ctx := context.WithValue(context.Background(), featuredCtxKey{}, true)
+		if err := d.dereferenceFeaturedCollection(ctx, username, &featuredAccount); err != nil {
+			// if this doesn't work, just skip it -- we can do it later
+			d.log.Debugf("fetchFeaturedCollection: error dereferencing featured collection of %s: %s", featuredAccount.URI, err)
+		}
+	}()
+}
+
+// dereferenceFeaturedCollection dereferences the featured collection of the given remote account, and makes
+// sure that the statuses in it--and only those statuses--are marked as pinned in our database.
+//
+// SIDE EFFECTS: remote statuses in the featured collection will be dereferenced and stored in the database if
+// we don't have them yet, and the pinned status of the account's other statuses will be updated as necessary.
+func (d *deref) dereferenceFeaturedCollection(ctx context.Context, username string, account *gtsmodel.Account) error {
+	if account.FeaturedCollectionURI == "" {
+		// nothing to do
+		return nil
+	}
+
+	featuredURIs, err := d.dereferenceFeaturedURIs(ctx, username, account)
+	if err != nil {
+		return err
+	}
+
+	featuredIDs := make(map[string]bool, len(featuredURIs))
+	for _, featuredURI := range featuredURIs {
+		status, _, _, err := d.GetRemoteStatus(ctx, username, featuredURI, false, false, false)
+		if err != nil {
+			d.log.Debugf("dereferenceFeaturedCollection: error dereferencing featured status %s: %s", featuredURI, err)
+			continue
+		}
+
+		// an account can only feature its own statuses
+		if status.AccountID != account.ID || status.BoostOfID != "" {
+			continue
+		}
+		featuredIDs[status.ID] = true
+
+		if status.Pinned {
+			continue
+		}
+
+		// get the fully populated status before updating it
+		status, err = d.db.GetStatusByID(ctx, status.ID)
+		if err != nil {
+			return fmt.Errorf("dereferenceFeaturedCollection: error getting status %s: %s", featuredURI, err)
+		}
+
+		status.Pinned = true
+		if err := d.db.UpdateStatus(ctx, status); err != nil {
+			return fmt.Errorf("dereferenceFeaturedCollection: error pinning status %s: %s", status.ID, err)
+		}
+	}
+
+	// unpin any statuses that are no longer featured
+	pinned, err := d.db.GetAccountStatuses(ctx, account.ID, 0, false, "", true, false)
+	if err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("dereferenceFeaturedCollection: error getting pinned statuses: %s", err)
+	}
+
+	for _, p := range pinned {
+		if featuredIDs[p.ID] {
+			continue
+		}
+
+		// get the fully populated status before updating it
+		status, err := d.db.GetStatusByID(ctx, p.ID)
+		if err != nil {
+			return fmt.Errorf("dereferenceFeaturedCollection: error getting status %s: %s", p.ID, err)
+		}
+
+		status.Pinned = false
+		if err := d.db.UpdateStatus(ctx, status); err != nil {
+			return fmt.Errorf("dereferenceFeaturedCollection: error unpinning status %s: %s", status.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// dereferenceFeaturedURIs calls the featured collection IRI of the given account with a GET request, and
+// returns the URIs of the items in it, up to featuredCollectionLimit.
+//
+// Items that aren't on the same host as the account are dropped, since an account can only feature its
+// own statuses, and we don't want to go dereferencing them from elsewhere just to find that out.
+//
+// Will work for Collection or OrderedCollection models.
+func (d *deref) dereferenceFeaturedURIs(ctx context.Context, username string, account *gtsmodel.Account) ([]*url.URL, error) {
+	accountIRI, err := url.Parse(account.URI)
+	if err != nil {
+		return nil, fmt.Errorf("dereferenceFeaturedURIs: couldn't parse account URI %s: %s", account.URI, err)
+	}
+
+	collectionIRI, err := url.Parse(account.FeaturedCollectionURI)
+	if err != nil {
+		return nil, fmt.Errorf("dereferenceFeaturedURIs: couldn't parse collection URI %s: %s", account.FeaturedCollectionURI, err)
+	}
+
+	if blocked, err := d.db.IsDomainBlocked(ctx, collectionIRI.Host); blocked || err != nil {
+		return nil, fmt.Errorf("dereferenceFeaturedURIs: domain %s is blocked", collectionIRI.Host)
+	}
+
+	transport, err := d.transportController.NewTransportForUsername(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("dereferenceFeaturedURIs: error creating transport: %s", err)
+	}
+
+	b, err := transport.Dereference(ctx, collectionIRI)
+	if err != nil {
+		return nil, fmt.Errorf("dereferenceFeaturedURIs: error deferencing %s: %s", collectionIRI.String(), err)
+	}
+
+	m := make(map[string]interface{})
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("dereferenceFeaturedURIs: error unmarshalling bytes into json: %s", err)
+	}
+
+	t, err := streams.ToType(ctx, m)
+	if err != nil {
+		return nil, fmt.Errorf("dereferenceFeaturedURIs: error resolving json into ap vocab type: %s", err)
+	}
+
+	uris := []*url.URL{}
+	appendURI := func(item featuredItem) {
+		if uri := featuredItemURI(item); uri != nil && uri.Host == accountIRI.Host && len(uris) < featuredCollectionLimit {
+			uris = append(uris, uri)
+		}
+	}
+
+	switch t.GetTypeName() {
+	case gtsmodel.ActivityStreamsOrderedCollection:
+		c, ok := t.(vocab.ActivityStreamsOrderedCollection)
+		if !ok {
+			return nil, errors.New("dereferenceFeaturedURIs: error resolving type as activitystreams ordered collection")
+		}
+		if items := c.GetActivityStreamsOrderedItems(); items != nil {
+			for iter := items.Begin(); iter != items.End(); iter = iter.Next() {
+				appendURI(iter)
+			}
+		}
+	case gtsmodel.ActivityStreamsCollection:
+		c, ok := t.(vocab.ActivityStreamsCollection)
+		if !ok {
+			return nil, errors.New("dereferenceFeaturedURIs: error resolving type as activitystreams collection")
+		}
+		if items := c.GetActivityStreamsItems(); items != nil {
+			for iter := items.Begin(); iter != items.End(); iter = iter.Next() {
+				appendURI(iter)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("dereferenceFeaturedURIs: type name %s not supported", t.GetTypeName())
+	}
+
+	return uris, nil
+}
@@ -0,0 +1,203 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dereferencing_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/federation/dereferencing"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+const (
+	brandNewPersonURI           = "https://unknown-instance.com/users/brand_new_person"
+	brandNewPersonFeaturedURI   = "https://unknown-instance.com/users/brand_new_person/collections/featured"
+	brandNewPersonStatusURI     = "https://unknown-instance.com/users/brand_new_person/statuses/01FE4NTHKWW7THT67EF10EB839"
+	anotherNewPersonFeaturedURI = "https://unknown-instance.com/users/another_new_person/collections/featured"
+	anotherNewPersonStatusURI   = "https://unknown-instance.com/users/another_new_person/statuses/01FGXBRWJ6JNSY0M4NA5PQ8WZW"
+	offHostStatusURI            = "https://some-other-instance.example.org/users/brand_new_person/statuses/01FGXC1ZB1F8Y2VGKKB1AF0V5Q"
+)
+
+type FeaturedTestSuite struct {
+	DereferencerStandardTestSuite
+
+	// featured collections we'll serve, keyed by uri
+	testFeaturedCollections map[string]string
+
+	// closed when requests for brand_new_person's featured collection may be answered
+	releaseFeatured chan struct{}
+
+	requestedMu sync.Mutex
+	requested   []string
+}
+
+// mockTransportController returns a transport controller that serves our remote people, notes,
+// and featured collections, and keeps a record of every url that was requested through it.
+func (suite *FeaturedTestSuite) mockTransportController() transport.Controller {
+	do := func(req *http.Request) (*http.Response, error) {
+		suite.requestedMu.Lock()
+		suite.requested = append(suite.requested, req.URL.String())
+		suite.requestedMu.Unlock()
+
+		responseBytes := []byte{}
+
+		if note, ok := suite.testRemoteStatuses[req.URL.String()]; ok {
+			noteI, err := streams.Serialize(note)
+			if err != nil {
+				panic(err)
+			}
+			responseBytes, err = json.Marshal(noteI)
+			if err != nil {
+				panic(err)
+			}
+		}
+
+		if person, ok := suite.testRemoteAccounts[req.URL.String()]; ok {
+			personI, err := streams.Serialize(person)
+			if err != nil {
+				panic(err)
+			}
+			responseBytes, err = json.Marshal(personI)
+			if err != nil {
+				panic(err)
+			}
+		}
+
+		if collection, ok := suite.testFeaturedCollections[req.URL.String()]; ok {
+			if req.URL.String() == brandNewPersonFeaturedURI {
+				<-suite.releaseFeatured
+			}
+			responseBytes = []byte(collection)
+		}
+
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader(responseBytes)),
+		}, nil
+	}
+	return testrig.NewTestTransportController(testrig.NewMockHTTPClient(do), suite.db)
+}
+
+// wasRequested returns true if the given url was requested through the mock transport.
+func (suite *FeaturedTestSuite) wasRequested(uri string) bool {
+	suite.requestedMu.Lock()
+	defer suite.requestedMu.Unlock()
+	for _, r := range suite.requested {
+		if r == uri {
+			return true
+		}
+	}
+	return false
+}
+
+func (suite *FeaturedTestSuite) SetupSuite() {
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testRemoteStatuses = testrig.NewTestFediStatuses()
+	suite.testRemoteAccounts = testrig.NewTestFediPeople()
+	suite.testFeaturedCollections = map[string]string{
+		// the status of another account and a status on another host should both be ignored
+		brandNewPersonFeaturedURI: `{
+			"@context": "https://www.w3.org/ns/activitystreams",
+			"id": "` + brandNewPersonFeaturedURI + `",
+			"type": "OrderedCollection",
+			"totalItems": 3,
+			"orderedItems": [
+				"` + offHostStatusURI + `",
+				"` + anotherNewPersonStatusURI + `",
+				"` + brandNewPersonStatusURI + `"
+			]
+		}`,
+		anotherNewPersonFeaturedURI: `{
+			"@context": "https://www.w3.org/ns/activitystreams",
+			"id": "` + anotherNewPersonFeaturedURI + `",
+			"type": "OrderedCollection",
+			"totalItems": 1,
+			"orderedItems": [
+				"` + anotherNewPersonStatusURI + `"
+			]
+		}`,
+	}
+}
+
+func (suite *FeaturedTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.log = testrig.NewTestLog()
+	suite.releaseFeatured = make(chan struct{})
+	suite.requested = []string{}
+	suite.dereferencer = dereferencing.NewDereferencer(suite.config,
+		suite.db,
+		testrig.NewTestTypeConverter(suite.db),
+		suite.mockTransportController(),
+		testrig.NewTestMediaHandler(suite.db, testrig.NewTestStorage()),
+		suite.log)
+	testrig.StandardDBSetup(suite.db, nil)
+}
+
+func (suite *FeaturedTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+}
+
+func (suite *FeaturedTestSuite) TestDereferenceFeaturedCollection() {
+	ctx := context.Background()
+	fetchingAccount := suite.testAccounts["local_account_1"]
+
+	// the featured collection is still blocked at this point, so we should get the account back without waiting for it
+	account, new, err := suite.dereferencer.GetRemoteAccount(ctx, fetchingAccount.Username, testrig.URLMustParse(brandNewPersonURI), false)
+	suite.NoError(err)
+	suite.True(new)
+	suite.Equal(brandNewPersonURI, account.URI)
+
+	_, err = suite.db.GetStatusByURI(ctx, brandNewPersonStatusURI)
+	suite.Error(err)
+
+	close(suite.releaseFeatured)
+
+	// the account's own status is last in the collection, so once it's pinned we're done
+	suite.Eventually(func() bool {
+		status, err := suite.db.GetStatusByURI(ctx, brandNewPersonStatusURI)
+		return err == nil && status.Pinned
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// the status from another account on the same host should be stored but not pinned
+	otherStatus, err := suite.db.GetStatusByURI(ctx, anotherNewPersonStatusURI)
+	suite.NoError(err)
+	suite.False(otherStatus.Pinned)
+
+	// the off-host status should never have been dereferenced
+	suite.False(suite.wasRequested(offHostStatusURI))
+
+	// and the other account, which we came across while processing the featured
+	// collection, shouldn't have had its own featured collection fetched
+	suite.False(suite.wasRequested(anotherNewPersonFeaturedURI))
+}
+
+func TestFeaturedTestSuite(t *testing.T) {
+	suite.Run(t, &FeaturedTestSuite{})
+}
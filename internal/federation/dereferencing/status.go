@@ -114,12 +114,22 @@ func (d *deref) GetRemoteStatus(ctx context.Context, username string, remoteStat
 		}
 	} else {
 		gtsStatus.ID = maybeStatus.ID
+		// the poll of an existing status is kept up to date by Update activities, so just keep the reference to it
+		gtsStatus.PollID = maybeStatus.PollID
+		gtsStatus.Poll = nil
+		// not every implementation sets 'updated' on edited statuses, so don't forget an edit we already know about
+		if gtsStatus.EditedAt.IsZero() {
+			gtsStatus.EditedAt = maybeStatus.EditedAt
+		}
+		// keep the mentions and attachments we already have, so they don't get created again
+		d.reuseStatusMentions(ctx, maybeStatus, gtsStatus)
+		d.reuseStatusAttachments(ctx, maybeStatus, gtsStatus)
 
 		if err := d.populateStatusFields(ctx, gtsStatus, username, includeParent, includeChilds); err != nil {
 			return nil, statusable, new, fmt.Errorf("GetRemoteStatus: error populating status fields: %s", err)
 		}
 
-		if err := d.db.UpdateByID(ctx, gtsStatus.ID, gtsStatus); err != nil {
+		if err := d.db.UpdateStatus(ctx, gtsStatus); err != nil {
 			return nil, statusable, new, fmt.Errorf("GetRemoteStatus: error updating status: %s", err)
 		}
 	}
@@ -208,6 +218,12 @@ func (d *deref) dereferenceStatusable(ctx context.Context, username string, remo
 			return nil, errors.New("DereferenceStatusable: error resolving type as ActivityStreamsProfile")
 		}
 		return p, nil
+	case gtsmodel.ActivityStreamsQuestion:
+		p, ok := t.(vocab.ActivityStreamsQuestion)
+		if !ok {
+			return nil, errors.New("DereferenceStatusable: error resolving type as ActivityStreamsQuestion")
+		}
+		return p, nil
 	}
 
 	return nil, fmt.Errorf("DereferenceStatusable: type name %s not supported", t.GetTypeName())
@@ -396,6 +412,14 @@ func (d *deref) populateStatusAttachments(ctx context.Context, status *gtsmodel.
 	attachments := []*gtsmodel.MediaAttachment{}
 
 	for _, a := range status.Attachments {
+		if a.ID != "" {
+			// we've already got this attachment, since it has an ID
+			l.Debug("populateStatusAttachments: attachment already populated")
+			attachmentIDs = append(attachmentIDs, a.ID)
+			attachments = append(attachments, a)
+			continue
+		}
+
 		aURL, err := url.Parse(a.RemoteURL)
 		if err != nil {
 			l.Errorf("populateStatusAttachments: couldn't parse attachment url %s: %s", a.RemoteURL, err)
@@ -418,6 +442,48 @@ func (d *deref) populateStatusAttachments(ctx context.Context, status *gtsmodel.
 	return nil
 }
 
+// reuseStatusMentions swaps any mentions on the freshly dereferenced newStatus for the mentions
+// we already have stored for existingStatus, where they target the same account.
+func (d *deref) reuseStatusMentions(ctx context.Context, existingStatus *gtsmodel.Status, newStatus *gtsmodel.Status) {
+	if len(existingStatus.MentionIDs) == 0 {
+		return
+	}
+
+	existing, err := d.db.GetMentions(ctx, existingStatus.MentionIDs)
+	if err != nil {
+		d.log.Debugf("reuseStatusMentions: error getting existing mentions: %s", err)
+		return
+	}
+
+	for i, m := range newStatus.Mentions {
+		for _, e := range existing {
+			if e.TargetAccountURI == m.TargetAccountURI {
+				newStatus.Mentions[i] = e
+				break
+			}
+		}
+	}
+}
+
+// reuseStatusAttachments swaps any attachments on the freshly dereferenced newStatus for the attachments
+// we already have stored for existingStatus, where they have the same remote URL.
+func (d *deref) reuseStatusAttachments(ctx context.Context, existingStatus *gtsmodel.Status, newStatus *gtsmodel.Status) {
+	for _, aID := range existingStatus.AttachmentIDs {
+		e, err := d.db.GetAttachmentByID(ctx, aID)
+		if err != nil {
+			d.log.Debugf("reuseStatusAttachments: error getting existing attachment %s: %s", aID, err)
+			continue
+		}
+
+		for i, a := range newStatus.Attachments {
+			if a.ID == "" && a.RemoteURL == e.RemoteURL {
+				newStatus.Attachments[i] = e
+				break
+			}
+		}
+	}
+}
+
 func (d *deref) populateStatusRepliedTo(ctx context.Context, status *gtsmodel.Status, requestingUsername string) error {
 	if status.InReplyToURI != "" && status.InReplyToID == "" {
 		statusURI, err := url.Parse(status.InReplyToURI)
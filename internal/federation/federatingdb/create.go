@@ -27,6 +27,7 @@ import (
 	"github.com/go-fed/activity/streams"
 	"github.com/go-fed/activity/streams/vocab"
 	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
@@ -100,6 +101,14 @@ func (f *federatingDB) Create(ctx context.Context, asType vocab.Type) error {
 			case gtsmodel.ActivityStreamsNote:
 				// CREATE A NOTE
 				note := objectIter.GetActivityStreamsNote()
+
+				// the note might be a vote in a poll owned by an account on this instance
+				if isVote, err := f.createPollVote(ctx, note, targetAcct, fromFederatorChan); err != nil {
+					return fmt.Errorf("CREATE: error handling poll vote: %s", err)
+				} else if isVote {
+					continue
+				}
+
 				status, err := f.typeConverter.ASStatusToStatus(ctx, note)
 				if err != nil {
 					return fmt.Errorf("CREATE: error converting note to status: %s", err)
@@ -122,6 +131,38 @@ func (f *federatingDB) Create(ctx context.Context, asType vocab.Type) error {
 					return fmt.Errorf("CREATE: database error inserting status: %s", err)
 				}
 
+				fromFederatorChan <- gtsmodel.FromFederator{
+					APObjectType:     gtsmodel.ActivityStreamsNote,
+					APActivityType:   gtsmodel.ActivityStreamsCreate,
+					GTSModel:         status,
+					ReceivingAccount: targetAcct,
+				}
+			case gtsmodel.ActivityStreamsQuestion:
+				// CREATE A QUESTION (a status with a poll attached)
+				question := objectIter.GetActivityStreamsQuestion()
+				status, err := f.typeConverter.ASStatusToStatus(ctx, question)
+				if err != nil {
+					return fmt.Errorf("CREATE: error converting question to status: %s", err)
+				}
+
+				// id the status based on the time it was created
+				statusID, err := id.NewULIDFromTime(status.CreatedAt)
+				if err != nil {
+					return err
+				}
+				status.ID = statusID
+
+				if err := f.db.PutStatus(ctx, status); err != nil {
+					if err == db.ErrAlreadyExists {
+						// the status already exists in the database, which means we've already handled everything else,
+						// so we can just return nil here and be done with it.
+						return nil
+					}
+					// an actual error has happened
+					return fmt.Errorf("CREATE: database error inserting status: %s", err)
+				}
+
+				// from here on a status with a poll is processed in exactly the same way as any other status
 				fromFederatorChan <- gtsmodel.FromFederator{
 					APObjectType:     gtsmodel.ActivityStreamsNote,
 					APActivityType:   gtsmodel.ActivityStreamsCreate,
@@ -217,3 +258,121 @@ func (f *federatingDB) Create(ctx context.Context, asType vocab.Type) error {
 	}
 	return nil
 }
+
+// createPollVote checks whether the given note is a vote in a poll owned by an account on this instance,
+// which other servers send as a note with a name (the chosen option), no content, and inReplyTo set to
+// the poll status. If it is, the vote is stored and true is returned; otherwise false is returned and
+// the note should be handled as a normal status.
+func (f *federatingDB) createPollVote(ctx context.Context, note vocab.ActivityStreamsNote, targetAcct *gtsmodel.Account, fromFederatorChan chan gtsmodel.FromFederator) (bool, error) {
+	name, err := ap.ExtractName(note)
+	if err != nil || name == "" {
+		return false, nil
+	}
+
+	if content, err := ap.ExtractContent(note); err == nil && content != "" {
+		return false, nil
+	}
+
+	inReplyToURI := ap.ExtractInReplyToURI(note)
+	if inReplyToURI == nil || inReplyToURI.Host != f.config.Host {
+		return false, nil
+	}
+
+	pollStatus, err := f.db.GetStatusByURI(ctx, inReplyToURI.String())
+	if err != nil || pollStatus.PollID == "" {
+		return false, nil
+	}
+
+	// from here on we know it's a vote
+	poll, err := f.db.GetPollByID(ctx, pollStatus.PollID)
+	if err != nil {
+		return true, fmt.Errorf("error getting poll %s: %s", pollStatus.PollID, err)
+	}
+
+	if poll.Expired() {
+		// too late, nothing to do
+		return true, nil
+	}
+
+	choice := -1
+	for i, o := range poll.Options {
+		if o == name {
+			choice = i
+			break
+		}
+	}
+	if choice == -1 {
+		return true, fmt.Errorf("vote %s does not match any option of poll %s", name, poll.ID)
+	}
+
+	voterURI, err := ap.ExtractAttributedTo(note)
+	if err != nil {
+		return true, fmt.Errorf("error extracting attributedTo: %s", err)
+	}
+
+	if requestingAcctI := ctx.Value(util.APRequestingAccount); requestingAcctI != nil {
+		if requestingAcct, ok := requestingAcctI.(*gtsmodel.Account); ok && requestingAcct.URI != voterURI.String() {
+			return true, fmt.Errorf("vote by %s was delivered by account %s, this is not valid", voterURI, requestingAcct.URI)
+		}
+	}
+
+	voter, err := f.db.GetAccountByURI(ctx, voterURI.String())
+	if err != nil {
+		return true, fmt.Errorf("error getting voter account %s: %s", voterURI, err)
+	}
+
+	if voter.ID == poll.AccountID {
+		return true, errors.New("poll owners can't vote in their own poll")
+	}
+
+	vote, err := f.db.GetPollVoteByAccountID(ctx, poll.ID, voter.ID)
+	switch err {
+	case nil:
+		// this account already voted, which is only fine if the poll allows multiple choices;
+		// remote servers send one note per choice so collect them all into the one vote
+		if !poll.Multiple {
+			return true, nil
+		}
+		for _, c := range vote.Choices {
+			if c == choice {
+				return true, nil
+			}
+		}
+		vote.Choices = append(vote.Choices, choice)
+		if err := f.db.UpdatePollVote(ctx, vote); err != nil {
+			return true, fmt.Errorf("error updating poll vote: %s", err)
+		}
+	case db.ErrNoEntries:
+		voteID, err := id.NewULID()
+		if err != nil {
+			return true, err
+		}
+
+		var voteURI string
+		if idProp := note.GetJSONLDId(); idProp != nil && idProp.IsIRI() {
+			voteURI = idProp.GetIRI().String()
+		}
+
+		vote = &gtsmodel.PollVote{
+			ID:        voteID,
+			URI:       voteURI,
+			PollID:    poll.ID,
+			AccountID: voter.ID,
+			Choices:   []int{choice},
+		}
+		if err := f.db.PutPollVote(ctx, vote); err != nil {
+			return true, fmt.Errorf("error putting poll vote: %s", err)
+		}
+	default:
+		return true, fmt.Errorf("error checking existing poll vote: %s", err)
+	}
+
+	fromFederatorChan <- gtsmodel.FromFederator{
+		APObjectType:     gtsmodel.ActivityStreamsQuestion,
+		APActivityType:   gtsmodel.ActivityStreamsCreate,
+		GTSModel:         vote,
+		ReceivingAccount: targetAcct,
+	}
+
+	return true, nil
+}
@@ -0,0 +1,117 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package federatingdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+func (f *federatingDB) Flag(ctx context.Context, flag vocab.ActivityStreamsFlag) error {
+	l := f.log.WithFields(
+		logrus.Fields{
+			"func": "Flag",
+		},
+	)
+	m, err := streams.Serialize(flag)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	l.Debugf("received FLAG %s", string(b))
+
+	targetAcctI := ctx.Value(util.APAccount)
+	if targetAcctI == nil {
+		// If the target account wasn't set on the context, that means this request didn't pass through the
+		// API, but came from inside GtS as the result of another activity on this instance. That being so,
+		// we can safely just ignore this activity, since we know we've already processed it elsewhere.
+		return nil
+	}
+	targetAcct, ok := targetAcctI.(*gtsmodel.Account)
+	if !ok {
+		l.Error("FLAG: target account was set on context but couldn't be parsed")
+		return nil
+	}
+
+	requestingAcctI := ctx.Value(util.APRequestingAccount)
+	if requestingAcctI == nil {
+		l.Error("FLAG: requesting account wasn't set on context")
+		return nil
+	}
+	requestingAcct, ok := requestingAcctI.(*gtsmodel.Account)
+	if !ok {
+		l.Error("FLAG: requesting account was set on context but couldn't be parsed")
+		return nil
+	}
+
+	fromFederatorChanI := ctx.Value(util.APFromFederatorChanKey)
+	if fromFederatorChanI == nil {
+		l.Error("FLAG: from federator channel wasn't set on context")
+		return nil
+	}
+	fromFederatorChan, ok := fromFederatorChanI.(chan gtsmodel.FromFederator)
+	if !ok {
+		l.Error("FLAG: from federator channel was set on context but couldn't be parsed")
+		return nil
+	}
+
+	// an account can only file reports in its own name, so the actor should be the account that sent us the flag
+	actorIRI, err := ap.ExtractActor(flag)
+	if err != nil {
+		return fmt.Errorf("FLAG: error extracting actor: %s", err)
+	}
+	if actorIRI.String() != requestingAcct.URI {
+		return fmt.Errorf("FLAG: flag by %s was delivered by account %s, this is not valid", actorIRI, requestingAcct.URI)
+	}
+
+	report, err := f.typeConverter.ASFlagToReport(ctx, flag)
+	if err != nil {
+		return fmt.Errorf("FLAG: error converting flag to report: %s", err)
+	}
+
+	if _, err := f.db.GetReportByURI(ctx, report.URI); err == nil {
+		// we already have this report, nothing to do
+		return nil
+	} else if err != db.ErrNoEntries {
+		return fmt.Errorf("FLAG: error checking for existing report with uri %s: %s", report.URI, err)
+	}
+
+	// it's a new report so pass it back to the processor async for storage
+	fromFederatorChan <- gtsmodel.FromFederator{
+		APObjectType:     gtsmodel.ActivityStreamsProfile,
+		APActivityType:   gtsmodel.ActivityStreamsFlag,
+		GTSModel:         report,
+		ReceivingAccount: targetAcct,
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package federatingdb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type FlagTestSuite struct {
+	FederatingDBTestSuite
+}
+
+func (suite *FlagTestSuite) SetupSuite() {
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testStatuses = testrig.NewTestStatuses()
+}
+
+func (suite *FlagTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.log = testrig.NewTestLog()
+	suite.federatingDB = testrig.NewTestFederatingDB(suite.db)
+	testrig.StandardDBSetup(suite.db, suite.testAccounts)
+}
+
+func (suite *FlagTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+}
+
+// newFlag returns a flag with the given actor, reporting the given local account and status.
+func (suite *FlagTestSuite) newFlag(actor *gtsmodel.Account, target *gtsmodel.Account, status *gtsmodel.Status) vocab.ActivityStreamsFlag {
+	flag := streams.NewActivityStreamsFlag()
+
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.Set(testrig.URLMustParse(actor.URI + "/flags/01FGP5A6N5X8DVDX3AZBTKQW2P"))
+	flag.SetJSONLDId(idProp)
+
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(testrig.URLMustParse(actor.URI))
+	flag.SetActivityStreamsActor(actorProp)
+
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendIRI(testrig.URLMustParse(target.URI))
+	objectProp.AppendIRI(testrig.URLMustParse(status.URI))
+	flag.SetActivityStreamsObject(objectProp)
+
+	contentProp := streams.NewActivityStreamsContentProperty()
+	contentProp.AppendXMLSchemaString("this is spam")
+	flag.SetActivityStreamsContent(contentProp)
+
+	return flag
+}
+
+// flagContext returns a context as it would be set up by the federating protocol for
+// an activity delivered by requestingAccount to the inbox of receivingAccount.
+func flagContext(receivingAccount *gtsmodel.Account, requestingAccount *gtsmodel.Account, fromFederatorChan chan gtsmodel.FromFederator) context.Context {
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, util.APAccount, receivingAccount)
+	ctx = context.WithValue(ctx, util.APRequestingAccount, requestingAccount)
+	ctx = context.WithValue(ctx, util.APFromFederatorChanKey, fromFederatorChan)
+	return ctx
+}
+
+func (suite *FlagTestSuite) TestFlag() {
+	remoteAccount := suite.testAccounts["remote_account_1"]
+	targetAccount := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	fromFederatorChan := make(chan gtsmodel.FromFederator, 10)
+	ctx := flagContext(targetAccount, remoteAccount, fromFederatorChan)
+
+	err := suite.federatingDB.Flag(ctx, suite.newFlag(remoteAccount, targetAccount, targetStatus))
+	suite.NoError(err)
+
+	suite.Len(fromFederatorChan, 1)
+	msg := <-fromFederatorChan
+	suite.Equal(gtsmodel.ActivityStreamsFlag, msg.APActivityType)
+	report, ok := msg.GTSModel.(*gtsmodel.Report)
+	suite.True(ok)
+	suite.Equal(remoteAccount.ID, report.AccountID)
+	suite.Equal(targetAccount.ID, report.TargetAccountID)
+	suite.Equal([]string{targetStatus.ID}, report.StatusIDs)
+	suite.Equal("this is spam", report.Comment)
+}
+
+func (suite *FlagTestSuite) TestFlagActorNotSender() {
+	remoteAccount := suite.testAccounts["remote_account_1"]
+	adminAccount := suite.testAccounts["admin_account"]
+	targetAccount := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	fromFederatorChan := make(chan gtsmodel.FromFederator, 10)
+	ctx := flagContext(targetAccount, remoteAccount, fromFederatorChan)
+
+	// the remote account tries to file a report in the name of our admin
+	err := suite.federatingDB.Flag(ctx, suite.newFlag(adminAccount, targetAccount, targetStatus))
+	suite.EqualError(err, "FLAG: flag by http://localhost:8080/users/admin was delivered by account http://fossbros-anonymous.io/users/foss_satan, this is not valid")
+
+	// nothing should have been passed on to the processor
+	suite.Empty(fromFederatorChan)
+}
+
+func TestFlagTestSuite(t *testing.T) {
+	suite.Run(t, &FlagTestSuite{})
+}
@@ -0,0 +1,117 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package federatingdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+func (f *federatingDB) Move(ctx context.Context, move vocab.ActivityStreamsMove) error {
+	l := f.log.WithFields(
+		logrus.Fields{
+			"func": "Move",
+		},
+	)
+	m, err := streams.Serialize(move)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	l.Debugf("received MOVE %s", string(b))
+
+	targetAcctI := ctx.Value(util.APAccount)
+	if targetAcctI == nil {
+		// If the target account wasn't set on the context, that means this request didn't pass through the
+		// API, but came from inside GtS as the result of another activity on this instance. That being so,
+		// we can safely just ignore this activity, since we know we've already processed it elsewhere.
+		return nil
+	}
+	targetAcct, ok := targetAcctI.(*gtsmodel.Account)
+	if !ok {
+		l.Error("MOVE: target account was set on context but couldn't be parsed")
+		return nil
+	}
+
+	requestingAcctI := ctx.Value(util.APRequestingAccount)
+	if requestingAcctI == nil {
+		l.Error("MOVE: requesting account wasn't set on context")
+		return nil
+	}
+	requestingAcct, ok := requestingAcctI.(*gtsmodel.Account)
+	if !ok {
+		l.Error("MOVE: requesting account was set on context but couldn't be parsed")
+		return nil
+	}
+
+	fromFederatorChanI := ctx.Value(util.APFromFederatorChanKey)
+	if fromFederatorChanI == nil {
+		l.Error("MOVE: from federator channel wasn't set on context")
+		return nil
+	}
+	fromFederatorChan, ok := fromFederatorChanI.(chan gtsmodel.FromFederator)
+	if !ok {
+		l.Error("MOVE: from federator channel was set on context but couldn't be parsed")
+		return nil
+	}
+
+	// an account can only move itself, so the actor and object should both be the account that sent us the move
+	actorIRI, err := ap.ExtractActor(move)
+	if err != nil {
+		return fmt.Errorf("MOVE: error extracting actor: %s", err)
+	}
+	objectIRI, err := ap.ExtractObject(move)
+	if err != nil {
+		return fmt.Errorf("MOVE: error extracting object: %s", err)
+	}
+	if actorIRI.String() != requestingAcct.URI || objectIRI.String() != requestingAcct.URI {
+		return fmt.Errorf("MOVE: move of %s by %s was delivered by account %s, this is not valid", objectIRI, actorIRI, requestingAcct.URI)
+	}
+
+	targetIRI, err := ap.ExtractTarget(move)
+	if err != nil {
+		return fmt.Errorf("MOVE: error extracting target: %s", err)
+	}
+	if targetIRI.String() == requestingAcct.URI {
+		return fmt.Errorf("MOVE: account %s tried to move to itself", requestingAcct.URI)
+	}
+
+	// checking that the target really is an alias of the account involves dereferencing it, so pass it back to the processor async
+	fromFederatorChan <- gtsmodel.FromFederator{
+		APObjectType:     gtsmodel.ActivityStreamsPerson,
+		APActivityType:   gtsmodel.ActivityStreamsMove,
+		GTSModel:         requestingAcct,
+		APIri:            targetIRI,
+		ReceivingAccount: targetAcct,
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package federatingdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+func (f *federatingDB) Reject(ctx context.Context, reject vocab.ActivityStreamsReject) error {
+	l := f.log.WithFields(
+		logrus.Fields{
+			"func":   "Reject",
+			"asType": reject.GetTypeName(),
+		},
+	)
+	m, err := streams.Serialize(reject)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	l.Debugf("received REJECT asType %s", string(b))
+
+	targetAcctI := ctx.Value(util.APAccount)
+	if targetAcctI == nil {
+		// If the target account wasn't set on the context, that means this request didn't pass through the
+		// API, but came from inside GtS as the result of another activity on this instance. That being so,
+		// we can safely just ignore this activity, since we know we've already processed it elsewhere.
+		return nil
+	}
+	targetAcct, ok := targetAcctI.(*gtsmodel.Account)
+	if !ok {
+		l.Error("REJECT: target account was set on context but couldn't be parsed")
+		return nil
+	}
+
+	requestingAcctI := ctx.Value(util.APRequestingAccount)
+	if requestingAcctI == nil {
+		l.Error("REJECT: requesting account wasn't set on context")
+		return nil
+	}
+	requestingAcct, ok := requestingAcctI.(*gtsmodel.Account)
+	if !ok {
+		l.Error("REJECT: requesting account was set on context but couldn't be parsed")
+		return nil
+	}
+
+	fromFederatorChanI := ctx.Value(util.APFromFederatorChanKey)
+	if fromFederatorChanI == nil {
+		l.Error("REJECT: from federator channel wasn't set on context")
+		return nil
+	}
+	fromFederatorChan, ok := fromFederatorChanI.(chan gtsmodel.FromFederator)
+	if !ok {
+		l.Error("REJECT: from federator channel was set on context but couldn't be parsed")
+		return nil
+	}
+
+	rejectObject := reject.GetActivityStreamsObject()
+	if rejectObject == nil {
+		return errors.New("REJECT: no object set on vocab.ActivityStreamsReject")
+	}
+
+	for iter := rejectObject.Begin(); iter != rejectObject.End(); iter = iter.Next() {
+		// check if the object is an IRI
+		if iter.IsIRI() {
+			// we have just the URI of whatever is being rejected, so we need to find out what it is
+			rejectedObjectIRI := iter.GetIRI()
+			if util.IsFollowPath(rejectedObjectIRI) {
+				// REJECT FOLLOW
+				// the follow may still be pending, or it may have been accepted already and now be revoked
+				var accountID string
+				var targetAccountID string
+				gtsFollowRequest := &gtsmodel.FollowRequest{}
+				gtsFollow := &gtsmodel.Follow{}
+				if err := f.db.GetWhere(ctx, []db.Where{{Key: "uri", Value: rejectedObjectIRI.String()}}, gtsFollowRequest); err == nil {
+					accountID = gtsFollowRequest.AccountID
+					targetAccountID = gtsFollowRequest.TargetAccountID
+				} else if err := f.db.GetWhere(ctx, []db.Where{{Key: "uri", Value: rejectedObjectIRI.String()}}, gtsFollow); err == nil {
+					accountID = gtsFollow.AccountID
+					targetAccountID = gtsFollow.TargetAccountID
+				} else {
+					return fmt.Errorf("REJECT: couldn't get follow or follow request with uri %s from the database: %s", rejectedObjectIRI.String(), err)
+				}
+
+				return f.rejectFollow(ctx, accountID, targetAccountID, targetAcct, requestingAcct, fromFederatorChan)
+			}
+		}
+
+		// check if iter is an AP object / type
+		if iter.GetType() == nil {
+			continue
+		}
+		switch iter.GetType().GetTypeName() {
+		// we have the whole object so we can figure out what we're rejecting
+		case string(gtsmodel.ActivityStreamsFollow):
+			// REJECT FOLLOW
+			asFollow, ok := iter.GetType().(vocab.ActivityStreamsFollow)
+			if !ok {
+				return errors.New("REJECT: couldn't parse follow into vocab.ActivityStreamsFollow")
+			}
+			// convert the follow to something we can understand
+			gtsFollow, err := f.typeConverter.ASFollowToFollow(ctx, asFollow)
+			if err != nil {
+				return fmt.Errorf("REJECT: error converting asfollow to gtsfollow: %s", err)
+			}
+
+			return f.rejectFollow(ctx, gtsFollow.AccountID, gtsFollow.TargetAccountID, targetAcct, requestingAcct, fromFederatorChan)
+		}
+	}
+
+	return nil
+}
+
+// rejectFollow removes any follow request or follow from accountID to targetAccountID, after making
+// sure that the follow was sent by the inbox owner to the account that's now rejecting it.
+// If a follow is removed, the processor is told about it so it can clean up the follower's timelines.
+func (f *federatingDB) rejectFollow(ctx context.Context, accountID string, targetAccountID string, inboxAcct *gtsmodel.Account, rejectingAcct *gtsmodel.Account, fromFederatorChan chan gtsmodel.FromFederator) error {
+	// make sure the addressee of the original follow is the same as whatever inbox this landed in
+	if accountID != inboxAcct.ID {
+		return errors.New("REJECT: follow object account and inbox account were not the same")
+	}
+
+	// make sure only the account that was followed can reject the follow
+	if targetAccountID != rejectingAcct.ID {
+		return errors.New("REJECT: follow object target account and rejecting account were not the same")
+	}
+
+	where := []db.Where{
+		{Key: "account_id", Value: accountID},
+		{Key: "target_account_id", Value: targetAccountID},
+	}
+
+	if err := f.db.DeleteWhere(ctx, where, &gtsmodel.FollowRequest{}); err != nil {
+		return fmt.Errorf("REJECT: error deleting follow request: %s", err)
+	}
+
+	follow := &gtsmodel.Follow{}
+	if err := f.db.GetWhere(ctx, where, follow); err != nil {
+		if err == db.ErrNoEntries {
+			// the follow was still pending, so there's nothing else to remove
+			return nil
+		}
+		return fmt.Errorf("REJECT: error getting follow: %s", err)
+	}
+
+	if err := f.db.DeleteByID(ctx, follow.ID, follow); err != nil {
+		return fmt.Errorf("REJECT: error deleting follow: %s", err)
+	}
+
+	if err := f.db.DeleteListEntriesForFollowID(ctx, follow.ID); err != nil {
+		return fmt.Errorf("REJECT: error deleting list entries: %s", err)
+	}
+
+	fromFederatorChan <- gtsmodel.FromFederator{
+		APObjectType:     gtsmodel.ActivityStreamsFollow,
+		APActivityType:   gtsmodel.ActivityStreamsReject,
+		GTSModel:         follow,
+		ReceivingAccount: inboxAcct,
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package federatingdb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type RejectTestSuite struct {
+	FederatingDBTestSuite
+}
+
+func (suite *RejectTestSuite) SetupSuite() {
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testStatuses = testrig.NewTestStatuses()
+}
+
+func (suite *RejectTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.log = testrig.NewTestLog()
+	suite.federatingDB = testrig.NewTestFederatingDB(suite.db)
+	testrig.StandardDBSetup(suite.db, suite.testAccounts)
+}
+
+func (suite *RejectTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+}
+
+// newReject returns a reject of the given follow URI by the given actor.
+func (suite *RejectTestSuite) newReject(actor *gtsmodel.Account, followURI string) vocab.ActivityStreamsReject {
+	reject := streams.NewActivityStreamsReject()
+
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.Set(testrig.URLMustParse(actor.URI + "/rejects/01FGP5A6N5X8DVDX3AZBTKQW2P"))
+	reject.SetJSONLDId(idProp)
+
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(testrig.URLMustParse(actor.URI))
+	reject.SetActivityStreamsActor(actorProp)
+
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendIRI(testrig.URLMustParse(followURI))
+	reject.SetActivityStreamsObject(objectProp)
+
+	return reject
+}
+
+func (suite *RejectTestSuite) TestRejectFollow() {
+	ctx := context.Background()
+	followingAccount := suite.testAccounts["local_account_1"]
+	rejectingAccount := suite.testAccounts["remote_account_1"]
+
+	// local_account_1 follows remote_account_1, and has put them in a list
+	follow := &gtsmodel.Follow{
+		ID:              "01FGRY72ASHBSET64353DPHK9T",
+		URI:             followingAccount.URI + "/follow/01FGRY72ASHBSET64353DPHK9T",
+		AccountID:       followingAccount.ID,
+		TargetAccountID: rejectingAccount.ID,
+	}
+	suite.NoError(suite.db.Put(ctx, follow))
+	suite.NoError(suite.db.PutListEntries(ctx, []*gtsmodel.ListEntry{{
+		ID:       "01FGRY9B4X4HZ03B1TZBX2ZCNQ",
+		ListID:   testrig.NewTestLists()["local_account_1_list_1"].ID,
+		FollowID: follow.ID,
+	}}))
+
+	fromFederatorChan := make(chan gtsmodel.FromFederator, 10)
+	err := suite.federatingDB.Reject(flagContext(followingAccount, rejectingAccount, fromFederatorChan), suite.newReject(rejectingAccount, follow.URI))
+	suite.NoError(err)
+
+	// the follow and its list entry should be gone
+	err = suite.db.GetByID(ctx, follow.ID, &gtsmodel.Follow{})
+	suite.Equal(db.ErrNoEntries, err)
+	entries, err := suite.db.GetListEntriesForFollowID(ctx, follow.ID)
+	suite.NoError(err)
+	suite.Empty(entries)
+
+	// the processor should be told so that it can clean up timelines
+	suite.Len(fromFederatorChan, 1)
+	msg := <-fromFederatorChan
+	suite.Equal(gtsmodel.ActivityStreamsReject, msg.APActivityType)
+	suite.Equal(gtsmodel.ActivityStreamsFollow, msg.APObjectType)
+	rejectedFollow, ok := msg.GTSModel.(*gtsmodel.Follow)
+	suite.True(ok)
+	suite.Equal(follow.ID, rejectedFollow.ID)
+}
+
+func TestRejectTestSuite(t *testing.T) {
+	suite.Run(t, new(RejectTestSuite))
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/go-fed/activity/streams/vocab"
 	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
@@ -166,5 +167,112 @@ func (f *federatingDB) Update(ctx context.Context, asType vocab.Type) error {
 
 	}
 
+	if typeName == gtsmodel.ActivityStreamsQuestion {
+		// it's an UPDATE to a status with a poll, most likely with new vote counts
+		l.Debug("got update for QUESTION")
+		question, ok := asType.(vocab.ActivityStreamsQuestion)
+		if !ok {
+			return errors.New("UPDATE: could not convert type to question")
+		}
+
+		questionID := question.GetJSONLDId()
+		if questionID == nil || !questionID.IsIRI() {
+			return errors.New("UPDATE: question had no id")
+		}
+
+		status, err := f.db.GetStatusByURI(ctx, questionID.GetIRI().String())
+		if err != nil {
+			if err == db.ErrNoEntries {
+				// we don't know this status so there's nothing to update
+				return nil
+			}
+			return fmt.Errorf("UPDATE: database error getting status %s: %s", questionID.GetIRI(), err)
+		}
+
+		if status.Local || status.PollID == "" {
+			// only remote polls are updated this way
+			return nil
+		}
+
+		if requestingAcct.ID != status.AccountID {
+			return fmt.Errorf("UPDATE: update for status %s was requested by account %s, this is not valid", status.URI, requestingAcct.URI)
+		}
+
+		poll, err := f.db.GetPollByID(ctx, status.PollID)
+		if err != nil {
+			return fmt.Errorf("UPDATE: database error getting poll %s: %s", status.PollID, err)
+		}
+
+		updatedPoll, err := ap.ExtractPoll(question)
+		if err != nil {
+			return fmt.Errorf("UPDATE: error extracting poll from question: %s", err)
+		}
+
+		if len(updatedPoll.Votes) == len(poll.Options) {
+			poll.Votes = updatedPoll.Votes
+		}
+		poll.VotersCount = updatedPoll.VotersCount
+
+		// closing the poll is left to the expiry job so that poll ended notifications get sent,
+		// so if the remote poll was closed early just bring the expiry time forward to match
+		if !updatedPoll.ClosedAt.IsZero() && (poll.ExpiresAt.IsZero() || updatedPoll.ClosedAt.Before(poll.ExpiresAt)) {
+			poll.ExpiresAt = updatedPoll.ClosedAt
+		}
+
+		if err := f.db.UpdatePoll(ctx, poll); err != nil {
+			return fmt.Errorf("UPDATE: database error updating poll: %s", err)
+		}
+
+		// the content of the status might also have been edited, in which case it needs dereferencing again
+		if updated, err := ap.ExtractUpdated(question); err == nil && updated.After(status.CreatedAt) && updated.After(status.EditedAt) {
+			fromFederatorChan <- gtsmodel.FromFederator{
+				APObjectType:     gtsmodel.ActivityStreamsNote,
+				APActivityType:   gtsmodel.ActivityStreamsUpdate,
+				GTSModel:         status,
+				ReceivingAccount: targetAcct,
+			}
+		}
+	}
+
+	if typeName == gtsmodel.ActivityStreamsNote {
+		// it's an UPDATE to a status, ie., the status has been edited
+		l.Debug("got update for NOTE")
+		note, ok := asType.(vocab.ActivityStreamsNote)
+		if !ok {
+			return errors.New("UPDATE: could not convert type to note")
+		}
+
+		noteID := note.GetJSONLDId()
+		if noteID == nil || !noteID.IsIRI() {
+			return errors.New("UPDATE: note had no id")
+		}
+
+		status, err := f.db.GetStatusByURI(ctx, noteID.GetIRI().String())
+		if err != nil {
+			if err == db.ErrNoEntries {
+				// we don't know this status so there's nothing to update
+				return nil
+			}
+			return fmt.Errorf("UPDATE: database error getting status %s: %s", noteID.GetIRI(), err)
+		}
+
+		if status.Local {
+			// no need to update local statuses
+			return nil
+		}
+
+		if requestingAcct.ID != status.AccountID {
+			return fmt.Errorf("UPDATE: update for status %s was requested by account %s, this is not valid", status.URI, requestingAcct.URI)
+		}
+
+		// the status will be dereferenced again and updated asynchronously
+		fromFederatorChan <- gtsmodel.FromFederator{
+			APObjectType:     gtsmodel.ActivityStreamsNote,
+			APActivityType:   gtsmodel.ActivityStreamsUpdate,
+			GTSModel:         status,
+			ReceivingAccount: targetAcct,
+		}
+	}
+
 	return nil
 }
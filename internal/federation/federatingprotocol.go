@@ -256,10 +256,22 @@ func (f *federator) FederatingCallbacks(ctx context.Context) (wrapped pub.Federa
 		func(ctx context.Context, accept vocab.ActivityStreamsAccept) error {
 			return f.FederatingDB().Accept(ctx, accept)
 		},
+		// override default reject behavior and drop the rejected follow (request)
+		func(ctx context.Context, reject vocab.ActivityStreamsReject) error {
+			return f.FederatingDB().Reject(ctx, reject)
+		},
 		// override default announce behavior and trigger our own side effects
 		func(ctx context.Context, announce vocab.ActivityStreamsAnnounce) error {
 			return f.FederatingDB().Announce(ctx, announce)
 		},
+		// store incoming flags as reports for our moderators to look at
+		func(ctx context.Context, flag vocab.ActivityStreamsFlag) error {
+			return f.FederatingDB().Flag(ctx, flag)
+		},
+		// follow the new account on behalf of local followers when an account moves
+		func(ctx context.Context, move vocab.ActivityStreamsMove) error {
+			return f.FederatingDB().Move(ctx, move)
+		},
 	}
 
 	return
@@ -41,6 +41,9 @@ type Federator interface {
 	FederatingActor() pub.FederatingActor
 	// FederatingDB returns the underlying FederatingDB interface.
 	FederatingDB() federatingdb.DB
+	// TransportController returns the underlying transport controller, which can be used to deliver activities directly to a set of inboxes
+	// without going through the recipient resolution of the FederatingActor.
+	TransportController() transport.Controller
 
 	// AuthenticateFederatedRequest can be used to check the authenticity of incoming http-signed requests for federating resources.
 	// The given username will be used to create a transport for making outgoing requests. See the implementation for more detailed comments.
@@ -114,3 +117,7 @@ func (f *federator) FederatingActor() pub.FederatingActor {
 func (f *federator) FederatingDB() federatingdb.DB {
 	return f.federatingDB
 }
+
+func (f *federator) TransportController() transport.Controller {
+	return f.transportController
+}
@@ -110,8 +110,9 @@ type Account struct {
 	FeaturedCollectionURI string `bun:",unique,nullzero"`
 	// What type of activitypub actor is this account?
 	ActorType string `bun:",nullzero"`
-	// This account is associated with x account id
-	AlsoKnownAs string `bun:",nullzero"`
+	// URIs of other accounts that this account is also known as, eg., accounts that it has moved from.
+	// A Move from another account to this one is only accepted if the other account's URI is in here.
+	AlsoKnownAs []string `bun:",array"`
 
 	/*
 		CRYPTO FIELDS
@@ -138,6 +139,8 @@ type Account struct {
 	HideCollections bool
 	// id of the database entry that caused this account to become suspended -- can be an account ID or a domain block ID
 	SuspensionOrigin string `bun:"type:CHAR(26),nullzero"`
+	// When was deletion of this account requested? Accounts with this set but SuspendedAt unset are still waiting to be deleted.
+	DeletionRequestedAt time.Time `bun:",nullzero"`
 }
 
 // Field represents a key value field on an account, for things like pronouns, website, etc.
@@ -0,0 +1,62 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// AccountArchive models a zip archive of an account's data, either generated for download by the account,
+// or uploaded by the account to recreate its statuses. The work is carried out in the background.
+type AccountArchive struct {
+	// id of this archive in the database
+	ID string `bun:"type:CHAR(26),pk,notnull,unique"`
+	// When was this archive created?
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// When was this archive last updated?
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// Account that the archive belongs to
+	AccountID string   `bun:"type:CHAR(26),notnull"`
+	Account   *Account `bun:"rel:belongs-to"`
+	// Is this archive being exported from or imported into the account?
+	Type AccountArchiveType `bun:",notnull"`
+	// Path of the zip file in storage
+	Path string `bun:",nullzero"`
+	// Size of the zip file in bytes
+	Size int
+	// Number of statuses exported into or imported from the archive so far
+	Statuses int
+	// If the archive couldn't be processed, why not?
+	Error string `bun:",nullzero"`
+	// When was the archive finished with? Archives with this unset are still being worked on.
+	FinishedAt time.Time `bun:",nullzero"`
+}
+
+// Finished returns true if the work on this archive is done, whether it succeeded or not.
+func (a *AccountArchive) Finished() bool {
+	return !a.FinishedAt.IsZero()
+}
+
+// AccountArchiveType describes whether an AccountArchive is an export or an import.
+type AccountArchiveType string
+
+const (
+	// AccountArchiveTypeExport means the archive is generated from the account's data, for the account to download.
+	AccountArchiveTypeExport AccountArchiveType = "export"
+	// AccountArchiveTypeImport means the archive was uploaded by the account, and its statuses are recreated in the account.
+	AccountArchiveTypeImport AccountArchiveType = "import"
+)
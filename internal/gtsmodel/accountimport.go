@@ -0,0 +1,88 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// AccountImport models a CSV file of follows, blocks, mutes, or domain blocks that an account has uploaded to be imported.
+// The import is carried out in the background, and the result of each entry is stored as it goes along.
+type AccountImport struct {
+	// id of this import in the database
+	ID string `bun:"type:CHAR(26),pk,notnull,unique"`
+	// When was this import created?
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// When was this import last updated?
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// Account that uploaded the import
+	AccountID string   `bun:"type:CHAR(26),notnull"`
+	Account   *Account `bun:"rel:belongs-to"`
+	// What kind of thing is being imported?
+	Type AccountImportType `bun:",notnull"`
+	// Entries parsed from the uploaded file, along with the result of importing each one
+	Entries []AccountImportEntry
+	// When was the last entry of this import processed? Imports with this unset are still being worked on.
+	FinishedAt time.Time `bun:",nullzero"`
+}
+
+// Finished returns true if every entry of this import has been processed.
+func (i *AccountImport) Finished() bool {
+	return !i.FinishedAt.IsZero()
+}
+
+// AccountImportEntry is one account or domain parsed from the file of an AccountImport.
+type AccountImportEntry struct {
+	// Line of the file this entry was parsed from, starting at 1 and not counting empty lines
+	Line int
+	// Address of the account in the form username@domain, or just the domain for domain blocks
+	Account string
+	// Show reblogs from this account? Only used for follows.
+	ShowReblogs bool
+	// Notify when this account posts? Only used for follows.
+	Notify bool
+	// Mute notifications from this account as well as statuses? Only used for mutes.
+	HideNotifications bool
+	// What happened when this entry was imported? Unset if it hasn't been processed yet.
+	Result AccountImportResult
+	// If the result is failed, why?
+	Error string
+}
+
+// AccountImportType describes what kind of thing is being imported by an AccountImport.
+type AccountImportType string
+
+const (
+	// AccountImportTypeFollowing means the imported accounts will be followed.
+	AccountImportTypeFollowing AccountImportType = "following"
+	// AccountImportTypeBlocks means the imported accounts will be blocked.
+	AccountImportTypeBlocks AccountImportType = "blocks"
+	// AccountImportTypeMutes means the imported accounts will be muted.
+	AccountImportTypeMutes AccountImportType = "mutes"
+	// AccountImportTypeDomainBlocks means the imported domains will be blocked.
+	AccountImportTypeDomainBlocks AccountImportType = "domain_blocks"
+)
+
+// AccountImportResult describes what happened when an entry of an AccountImport was processed.
+type AccountImportResult string
+
+const (
+	// AccountImportResultSucceeded means the entry was imported.
+	AccountImportResultSucceeded AccountImportResult = "succeeded"
+	// AccountImportResultFailed means the entry couldn't be imported.
+	AccountImportResultFailed AccountImportResult = "failed"
+)
@@ -47,6 +47,8 @@ const (
 	ActivityStreamsCollection = "Collection"
 	// ActivityStreamsCollectionPage https://www.w3.org/TR/activitystreams-vocabulary/#dfn-collectionpage
 	ActivityStreamsCollectionPage = "CollectionPage"
+	// ActivityStreamsOrderedCollection https://www.w3.org/TR/activitystreams-vocabulary/#dfn-orderedcollection
+	ActivityStreamsOrderedCollection = "OrderedCollection"
 )
 
 const (
@@ -0,0 +1,56 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// Conversation represents one local account's view of a thread of direct statuses between a certain set of participants.
+//
+// Each local participant in a direct thread has their own Conversation, so that read state and deletion are per account.
+type Conversation struct {
+	// id of this conversation in the database
+	ID string `bun:"type:CHAR(26),pk,notnull,unique"`
+	// When was this conversation created?
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// When was this conversation last updated?
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// Local account that owns this view of the conversation
+	AccountID string   `bun:"type:CHAR(26),unique:conversationthreadaccounts,notnull"`
+	Account   *Account `bun:"rel:belongs-to"`
+	// ID of the status that started the thread this conversation belongs to
+	ThreadID string `bun:"type:CHAR(26),unique:conversationthreadaccounts,notnull"`
+	// IDs of the participants in this conversation, other than the owning account
+	OtherAccountIDs []string   `bun:"other_account_ids,array"`
+	OtherAccounts   []*Account `bun:"-"`
+	// Sorted, comma-separated OtherAccountIDs, so that a conversation can be looked up by its participants
+	OtherAccountsKey string `bun:",unique:conversationthreadaccounts,notnull"`
+	// ID of the most recent status in this conversation
+	LastStatusID string  `bun:"type:CHAR(26),notnull"`
+	LastStatus   *Status `bun:"rel:belongs-to"`
+	// Has the owning account read the latest status in this conversation?
+	Read bool
+}
+
+// ConversationToStatus records which statuses belong to a conversation.
+type ConversationToStatus struct {
+	ConversationID string        `bun:"type:CHAR(26),unique:conversationstatus,notnull"`
+	Conversation   *Conversation `bun:"rel:belongs-to"`
+	StatusID       string        `bun:"type:CHAR(26),unique:conversationstatus,notnull"`
+	Status         *Status       `bun:"rel:belongs-to"`
+}
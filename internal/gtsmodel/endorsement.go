@@ -0,0 +1,37 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// Endorsement refers to one account featuring ('pinning') another account on its profile.
+type Endorsement struct {
+	// id of this endorsement in the database
+	ID string `bun:"type:CHAR(26),pk,notnull,unique"`
+	// When was this endorsement created?
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// When was this endorsement last updated?
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// id of the account that created ('did') the endorsement
+	AccountID string   `bun:"type:CHAR(26),unique:endorsementsrctarget,notnull"`
+	Account   *Account `bun:"rel:belongs-to"`
+	// id of the account that has been endorsed
+	TargetAccountID string   `bun:"type:CHAR(26),unique:endorsementsrctarget,notnull"`
+	TargetAccount   *Account `bun:"rel:belongs-to"`
+}
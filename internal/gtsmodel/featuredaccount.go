@@ -0,0 +1,34 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// FeaturedAccount is an account that an admin has picked to be suggested to every local account as one to follow.
+type FeaturedAccount struct {
+	// id of this featured account entry in the database
+	ID string `bun:"type:CHAR(26),pk,notnull,unique"`
+	// When was this featured account entry created?
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// id of the account that's being featured
+	AccountID string   `bun:"type:CHAR(26),unique,notnull"`
+	Account   *Account `bun:"rel:belongs-to"`
+	// id of the admin account that featured this account
+	CreatedByAccountID string `bun:"type:CHAR(26),notnull"`
+}
@@ -0,0 +1,64 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// Filter represents a user-defined filter for determining which statuses should not be shown to the user.
+type Filter struct {
+	// id of this filter in the database
+	ID string `bun:"type:CHAR(26),pk,notnull,unique"`
+	// When was this filter created?
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// When was this filter last updated?
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// When should this filter no longer be applied? Zero time means the filter doesn't expire.
+	ExpiresAt time.Time `bun:",nullzero"`
+	// Account that created/owns this filter
+	AccountID string   `bun:"type:CHAR(26),notnull"`
+	Account   *Account `bun:"rel:belongs-to"`
+	// The text to be filtered
+	Phrase string `bun:",nullzero,notnull"`
+	// Should the filter be applied in the home timeline and lists?
+	ContextHome bool
+	// Should the filter be applied in notifications?
+	ContextNotifications bool
+	// Should the filter be applied in public timelines?
+	ContextPublic bool
+	// Should the filter be applied in expanded threads of a status?
+	ContextThread bool
+	// Should the filter consider word boundaries?
+	WholeWord bool
+	// Should matching statuses be dropped by the server, rather than hidden by the client?
+	Irreversible bool
+}
+
+// FilterContext represents one of the contexts in which a filter can be applied.
+type FilterContext string
+
+const (
+	// FilterContextHome means the filter applies in the home timeline and lists.
+	FilterContextHome FilterContext = "home"
+	// FilterContextNotifications means the filter applies in notifications.
+	FilterContextNotifications FilterContext = "notifications"
+	// FilterContextPublic means the filter applies in public timelines.
+	FilterContextPublic FilterContext = "public"
+	// FilterContextThread means the filter applies in the expanded thread of a status.
+	FilterContextThread FilterContext = "thread"
+)
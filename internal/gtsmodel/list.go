@@ -0,0 +1,65 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// List refers to a list of follows for which the owning account wants to view a timeline of posts.
+type List struct {
+	// id of this list in the database
+	ID string `bun:"type:CHAR(26),pk,notnull,unique"`
+	// When was this list created?
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// When was this list last updated?
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// Title of this list
+	Title string `bun:",nullzero,notnull"`
+	// Account that created/owns this list
+	AccountID string   `bun:"type:CHAR(26),notnull"`
+	Account   *Account `bun:"rel:belongs-to"`
+	// Which replies should be shown in this list?
+	RepliesPolicy RepliesPolicy `bun:",nullzero,notnull,default:'followed'"`
+}
+
+// ListEntry refers to a single follow entry in a list.
+type ListEntry struct {
+	// id of this list entry in the database
+	ID string `bun:"type:CHAR(26),pk,notnull,unique"`
+	// When was this list entry created?
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// When was this list entry last updated?
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// ID of the list that this entry belongs to
+	ListID string `bun:"type:CHAR(26),unique:listfollow,notnull"`
+	// Follow that the account owning this entry wants to see posts from
+	FollowID string  `bun:"type:CHAR(26),unique:listfollow,notnull"`
+	Follow   *Follow `bun:"rel:belongs-to"`
+}
+
+// RepliesPolicy denotes which replies should be shown in the list.
+type RepliesPolicy string
+
+const (
+	// RepliesPolicyFollowed means replies to any followed account should be shown.
+	RepliesPolicyFollowed RepliesPolicy = "followed"
+	// RepliesPolicyList means replies to members of the list should be shown.
+	RepliesPolicyList RepliesPolicy = "list"
+	// RepliesPolicyNone means replies should not be shown at all.
+	RepliesPolicyNone RepliesPolicy = "none"
+)
@@ -18,6 +18,8 @@
 
 package gtsmodel
 
+import "net/url"
+
 // FromClientAPI wraps a message that travels from client API into the processor
 type FromClientAPI struct {
 	APObjectType   string
@@ -32,5 +34,6 @@ type FromFederator struct {
 	APObjectType     string
 	APActivityType   string
 	GTSModel         interface{}
+	APIri            *url.URL
 	ReceivingAccount *Account
 }
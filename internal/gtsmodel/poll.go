@@ -17,3 +17,72 @@
 */
 
 package gtsmodel
+
+import "time"
+
+// Poll represents a poll attached to a status, either local or remote.
+type Poll struct {
+	// id of this poll in the database
+	ID string `bun:"type:CHAR(26),pk,notnull,unique"`
+	// when was this poll created
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// when was this poll last updated (eg., when were the vote counts last changed)
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// id of the status this poll is attached to
+	StatusID string  `bun:"type:CHAR(26),notnull,unique"`
+	Status   *Status `bun:"rel:belongs-to"`
+	// id of the account that owns this poll
+	AccountID string   `bun:"type:CHAR(26),notnull"`
+	Account   *Account `bun:"rel:belongs-to"`
+	// when does this poll stop accepting votes? Zero if the poll does not expire.
+	ExpiresAt time.Time `bun:",nullzero"`
+	// when was this poll closed? Zero if the poll is still open.
+	ClosedAt time.Time `bun:",nullzero"`
+	// does this poll allow more than one option to be chosen?
+	Multiple bool
+	// should vote counts be hidden until the poll has closed?
+	HideTotals bool
+	// the text of each possible option in this poll, in order
+	Options []string `bun:",array"`
+	// number of votes received for each option, in the same order as Options
+	Votes []int `bun:",array"`
+	// number of unique accounts that have voted in this poll
+	VotersCount int
+}
+
+// Expired returns true if the poll has an expiry time and that time has passed, or if the poll has been closed.
+func (p *Poll) Expired() bool {
+	if !p.ClosedAt.IsZero() {
+		return true
+	}
+	return !p.ExpiresAt.IsZero() && time.Now().After(p.ExpiresAt)
+}
+
+// TotalVotes returns the sum of all votes across all options of the poll.
+func (p *Poll) TotalVotes() int {
+	total := 0
+	for _, v := range p.Votes {
+		total = total + v
+	}
+	return total
+}
+
+// PollVote represents the choices made by one account in a poll.
+type PollVote struct {
+	// id of this vote in the database
+	ID string `bun:"type:CHAR(26),pk,notnull,unique"`
+	// when was this vote created
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// when was this vote last updated (eg., when a remote account added another choice to a multiple-choice poll)
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// activitypub URI of this vote
+	URI string `bun:",nullzero"`
+	// id of the poll this vote was cast in
+	PollID string `bun:"type:CHAR(26),notnull,unique:pollaccount"`
+	Poll   *Poll  `bun:"rel:belongs-to"`
+	// id of the account that cast this vote
+	AccountID string   `bun:"type:CHAR(26),notnull,unique:pollaccount"`
+	Account   *Account `bun:"rel:belongs-to"`
+	// indexes of the options that were chosen, referring to the Options of the poll
+	Choices []int `bun:",array"`
+}
@@ -0,0 +1,34 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// QueuedNotificationEmail is a notification that's waiting to be sent to a local user as part of a notification digest email.
+// Queued notifications are batched up and flushed periodically in the background.
+type QueuedNotificationEmail struct {
+	// id of this queue entry in the database
+	ID string `bun:"type:CHAR(26),pk,notnull,unique"`
+	// When was this entry queued?
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// id of the user that the notification should be emailed to
+	UserID string `bun:"type:CHAR(26),notnull"`
+	// id of the notification to include in the digest
+	NotificationID string `bun:"type:CHAR(26),notnull,unique"`
+}
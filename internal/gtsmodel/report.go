@@ -0,0 +1,77 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// Report models a user-created report of an account, and optionally some of its statuses, for moderators to look at.
+type Report struct {
+	// id of this report in the database
+	ID string `bun:"type:CHAR(26),pk,notnull,unique"`
+	// When was this report created?
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// When was this report last updated?
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// activitypub URI of the Flag for this report
+	URI string `bun:",unique,nullzero,notnull"`
+	// Account that created the report. For reports received from another instance, this will usually be that instance's actor.
+	AccountID string   `bun:"type:CHAR(26),notnull"`
+	Account   *Account `bun:"rel:belongs-to"`
+	// Account being reported
+	TargetAccountID string   `bun:"type:CHAR(26),notnull"`
+	TargetAccount   *Account `bun:"rel:belongs-to"`
+	// IDs of any statuses of the target account that are referenced by this report
+	StatusIDs []string  `bun:"status_ids,array"`
+	Statuses  []*Status `bun:"-"`
+	// Comment given by the reporter about why they made this report
+	Comment string `bun:",nullzero"`
+	// Was this report forwarded to the instance of the target account?
+	Forwarded bool
+	// Moderator who has been assigned to deal with this report
+	AssignedAccountID string   `bun:"type:CHAR(26),nullzero"`
+	AssignedAccount   *Account `bun:"rel:belongs-to"`
+	// When was this report resolved? Unresolved reports have this unset.
+	ActionTakenAt time.Time `bun:",nullzero"`
+	// Moderator who resolved this report
+	ActionTakenByAccountID string   `bun:"type:CHAR(26),nullzero"`
+	ActionTakenByAccount   *Account `bun:"rel:belongs-to"`
+	// What was done to the target account when this report was resolved?
+	ActionTaken ReportAction `bun:",nullzero"`
+	// Moderator's note about the action taken
+	ActionTakenComment string `bun:",nullzero"`
+}
+
+// Resolved returns true if a moderator has resolved this report.
+func (r *Report) Resolved() bool {
+	return !r.ActionTakenAt.IsZero()
+}
+
+// ReportAction describes what a moderator did to the target account of a report.
+type ReportAction string
+
+const (
+	// ReportActionNone means the report was resolved without doing anything to the target account.
+	ReportActionNone ReportAction = "none"
+	// ReportActionSensitive means the target account's media was marked as sensitive.
+	ReportActionSensitive ReportAction = "sensitive"
+	// ReportActionSilence means the target account was silenced.
+	ReportActionSilence ReportAction = "silence"
+	// ReportActionSuspend means the target account was suspended.
+	ReportActionSuspend ReportAction = "suspend"
+)
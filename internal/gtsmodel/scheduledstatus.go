@@ -0,0 +1,68 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// ScheduledStatus represents a status that an account has asked to be posted at some point in the future.
+// It holds the parameters the status was submitted with, so that the status can be created as normal when the time comes.
+type ScheduledStatus struct {
+	// id of this scheduled status in the database
+	ID string `bun:"type:CHAR(26),pk,notnull,unique"`
+	// When was this scheduled status created?
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// When was this scheduled status last updated?
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// When should the status be posted?
+	ScheduledAt time.Time `bun:",nullzero,notnull"`
+	// Account that will post the status
+	AccountID string   `bun:"type:CHAR(26),notnull"`
+	Account   *Account `bun:"rel:belongs-to"`
+	// Application that was used to schedule the status
+	ApplicationID string `bun:"type:CHAR(26),nullzero"`
+	// Text content of the status, as submitted
+	Text string
+	// Content warning/spoiler text of the status
+	SpoilerText string
+	// Should the status and its media be marked as sensitive?
+	Sensitive bool
+	// Visibility of the status; empty means the account default at the time of posting
+	Visibility Visibility `bun:",nullzero"`
+	// Advanced visibility flags of the status, nil if not set
+	Federated *bool
+	Boostable *bool
+	Replyable *bool
+	Likeable  *bool
+	// id of the status this status replies to, if any
+	InReplyToID string `bun:"type:CHAR(26),nullzero"`
+	// Language code of the status; empty means the account default at the time of posting
+	Language string `bun:",nullzero"`
+	// Format the text of the status should be parsed with
+	Format string `bun:",nullzero"`
+	// ids of media attachments to attach to the status
+	MediaIDs []string `bun:",array"`
+	// Options of the poll to attach to the status, if any
+	PollOptions []string `bun:",array"`
+	// Number of seconds the poll should be open for, counted from when the status is posted
+	PollExpiresIn int
+	// Should the poll allow multiple choices?
+	PollMultiple bool
+	// Should the poll hide vote counts until it ends?
+	PollHideTotals bool
+}
@@ -48,6 +48,8 @@ type Status struct {
 	CreatedAt time.Time `bun:",notnull,nullzero,default:current_timestamp"`
 	// when was this status updated?
 	UpdatedAt time.Time `bun:",notnull,nullzero,default:current_timestamp"`
+	// when was the content of this status last edited by its author?
+	EditedAt time.Time `bun:",nullzero"`
 	// is this status from a local account?
 	Local bool
 	// which account posted this status?
@@ -89,6 +91,9 @@ type Status struct {
 	Text string `bun:",nullzero"`
 	// Has this status been pinned by its owner?
 	Pinned bool
+	// id of the poll attached to this status, if any
+	PollID string `bun:"type:CHAR(26),nullzero"`
+	Poll   *Poll  `bun:"-"`
 }
 
 // StatusToTag is an intermediate struct to facilitate the many2many relationship between a status and one or more tags.
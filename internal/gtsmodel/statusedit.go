@@ -0,0 +1,50 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// StatusEdit represents a previous revision of a status, stored when the status is edited by its author.
+// It contains the state of the status as it was before the edit was applied.
+type StatusEdit struct {
+	// id of this edit in the database
+	ID string `bun:"type:CHAR(26),pk,notnull,unique"`
+	// when was this revision of the status created (ie., when was the status posted or last edited before this)
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// id of the status this is a revision of
+	StatusID string  `bun:"type:CHAR(26),notnull"`
+	Status   *Status `bun:"rel:belongs-to"`
+	// id of the account that owns the status
+	AccountID string   `bun:"type:CHAR(26),notnull"`
+	Account   *Account `bun:"rel:belongs-to"`
+	// the html-formatted content of the status at this revision
+	Content string `bun:",nullzero"`
+	// cw string of the status at this revision
+	ContentWarning string `bun:",nullzero"`
+	// original text of the status at this revision, without formatting
+	Text string `bun:",nullzero"`
+	// was the status marked sensitive at this revision?
+	Sensitive bool
+	// language of the status at this revision
+	Language string `bun:",nullzero"`
+	// Database IDs of any media attachments on the status at this revision
+	AttachmentIDs []string `bun:"attachments,array"`
+	// Database IDs of any emojis used in the status at this revision
+	EmojiIDs []string `bun:"emojis,array"`
+}
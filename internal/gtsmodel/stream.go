@@ -17,10 +17,14 @@ type Stream struct {
 	ID string
 	// Type of this stream: user/public/etc
 	Type string
+	// Access token that the stream was opened with
+	AccessToken string
 	// Channel of messages for the client to read from
 	Messages chan *Message
 	// Channel to close when the client drops away
 	Hangup chan interface{}
+	// Channel that is closed when the server wants the client to go away, eg., because its token was revoked
+	Closed chan interface{}
 	// Only put messages in the stream when Connected
 	Connected bool
 	// Mutex to lock/unlock when inserting messages, hanging up, changing the connected state etc.
@@ -36,3 +40,6 @@ type Message struct {
 	// The actual payload of the message. In case of an update or notification, this will be a JSON string.
 	Payload string `json:"payload"`
 }
+
+// StreamTypeDirect is the type of stream that a client opens to receive updates for direct message conversations.
+const StreamTypeDirect = "direct"
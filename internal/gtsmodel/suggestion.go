@@ -0,0 +1,63 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// Suggestion is an account that has been suggested for a local account to follow.
+// Suggestions are computed periodically in the background from the local social graph.
+type Suggestion struct {
+	// id of this suggestion in the database
+	ID string `bun:"type:CHAR(26),pk,notnull,unique"`
+	// When was this suggestion created?
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// id of the local account that this suggestion is for
+	AccountID string `bun:"type:CHAR(26),unique:suggestionsrctarget,notnull"`
+	// id of the account that's being suggested
+	TargetAccountID string   `bun:"type:CHAR(26),unique:suggestionsrctarget,notnull"`
+	TargetAccount   *Account `bun:"rel:belongs-to"`
+	// Why was this account suggested?
+	Source SuggestionSource `bun:",notnull"`
+	// Position of this suggestion among all suggestions for the account; lower ranks are shown first
+	Rank int `bun:",notnull"`
+}
+
+// SuggestionSource describes why an account was suggested.
+type SuggestionSource string
+
+const (
+	// SuggestionSourceFeatured means the account was picked by an admin to be suggested to everyone.
+	SuggestionSourceFeatured SuggestionSource = "featured"
+	// SuggestionSourceFriendsOfFriends means the account is followed by accounts that the suggestion's account follows.
+	SuggestionSourceFriendsOfFriends SuggestionSource = "friends_of_friends"
+	// SuggestionSourcePopular means the account is followed by lots of local accounts.
+	SuggestionSourcePopular SuggestionSource = "popular"
+)
+
+// SuggestionDismissal records that a local account doesn't want another account to be suggested to it again.
+type SuggestionDismissal struct {
+	// id of this dismissal in the database
+	ID string `bun:"type:CHAR(26),pk,notnull,unique"`
+	// When was this dismissal created?
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// id of the local account that dismissed the suggestion
+	AccountID string `bun:"type:CHAR(26),unique:suggestiondismissalsrctarget,notnull"`
+	// id of the account that shouldn't be suggested anymore
+	TargetAccountID string `bun:"type:CHAR(26),unique:suggestiondismissalsrctarget,notnull"`
+}
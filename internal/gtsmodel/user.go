@@ -74,6 +74,28 @@ type User struct {
 	// When did we last contact this user
 	LastEmailedAt time.Time `bun:",nullzero"`
 
+	/*
+		USER PREFERENCES
+	*/
+
+	// How should media attachments be shown to this user by default? One of default, show_all, or hide_all.
+	ExpandMedia string `bun:",default:'default'"`
+	// Should content warnings be expanded for this user by default?
+	ExpandSpoilers bool `bun:",default:false"`
+
+	/*
+		NOTIFICATION EMAIL PREFERENCES
+	*/
+
+	// Should new followers be included in this user's notification digest emails?
+	EmailNotifyFollow bool `bun:",default:false"`
+	// Should new follow requests be included in this user's notification digest emails?
+	EmailNotifyFollowRequest bool `bun:",default:false"`
+	// Should mentions in non-direct statuses be included in this user's notification digest emails?
+	EmailNotifyMention bool `bun:",default:false"`
+	// Should direct messages be included in this user's notification digest emails?
+	EmailNotifyDirect bool `bun:",default:false"`
+
 	/*
 		USER CONFIRMATION
 	*/
@@ -0,0 +1,39 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// UserDomainBlock refers to one account blocking a whole domain, so that statuses and notifications from accounts
+// on that domain are hidden from the blocking account, and accounts on that domain can no longer follow it.
+//
+// Unlike a DomainBlock, this only affects the account that created it, and nothing is federated.
+type UserDomainBlock struct {
+	// id of this block in the database
+	ID string `bun:"type:CHAR(26),pk,notnull,unique"`
+	// When was this block created?
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// When was this block last updated?
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// id of the account that created ('did') the block
+	AccountID string   `bun:"type:CHAR(26),unique:accountdomain,notnull"`
+	Account   *Account `bun:"rel:belongs-to"`
+	// The domain that has been blocked, in lowercase
+	Domain string `bun:",unique:accountdomain,notnull"`
+}
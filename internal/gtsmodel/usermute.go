@@ -0,0 +1,47 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// UserMute refers to one account muting another account, so that the muted account's statuses
+// no longer appear in the home timeline of the muting account, and (optionally) its notifications are hidden.
+type UserMute struct {
+	// id of this mute in the database
+	ID string `bun:"type:CHAR(26),pk,notnull,unique"`
+	// When was this mute created?
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// When was this mute last updated?
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// When should this mute no longer be applied? Zero time means the mute doesn't expire.
+	ExpiresAt time.Time `bun:",nullzero"`
+	// id of the account that created ('did') the mute
+	AccountID string   `bun:"type:CHAR(26),unique:srctarget,notnull"`
+	Account   *Account `bun:"rel:belongs-to"`
+	// id of the account that has been muted
+	TargetAccountID string   `bun:"type:CHAR(26),unique:srctarget,notnull"`
+	TargetAccount   *Account `bun:"rel:belongs-to"`
+	// Should notifications from the target account be hidden as well?
+	Notifications bool
+}
+
+// Expired returns true if this mute has an expiry time, and that time has passed.
+func (m *UserMute) Expired() bool {
+	return !m.ExpiresAt.IsZero() && m.ExpiresAt.Before(time.Now())
+}
@@ -0,0 +1,43 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// WebauthnCredential is a security key or passkey that a local user has registered for signing in.
+type WebauthnCredential struct {
+	// id of this credential in the database
+	ID string `bun:"type:CHAR(26),pk,notnull,unique"`
+	// When was this credential registered?
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// When was this credential last updated?
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// id of the user who registered this credential
+	UserID string `bun:"type:CHAR(26),notnull"`
+	// Name given to this credential by the user, so they can tell their credentials apart
+	Name string `bun:",notnull"`
+	// id of the credential as chosen by the authenticator, base64url encoded
+	CredentialID string `bun:",notnull,unique"`
+	// COSE encoded public key of the credential, base64url encoded
+	PublicKey string `bun:",notnull"`
+	// Signature counter last reported by the authenticator, used to detect cloned credentials
+	SignCount int64 `bun:",notnull,default:0"`
+	// When was this credential last used to sign in?
+	LastUsedAt time.Time `bun:",nullzero"`
+}
@@ -0,0 +1,128 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package oauth
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/oauth2/v4"
+	"github.com/superseriousbusiness/oauth2/v4/errors"
+)
+
+const (
+	// TokenTypeHintAccessToken can be given as the token_type_hint of a revocation request for an access token.
+	TokenTypeHintAccessToken = "access_token"
+	// TokenTypeHintRefreshToken can be given as the token_type_hint of a revocation request for a refresh token.
+	TokenTypeHintRefreshToken = "refresh_token"
+)
+
+// ValidateClient checks the id and secret of a client that's authenticating itself directly, rather
+// than through the token flow, and returns errors.ErrInvalidClient if they're not right.
+func (s *s) ValidateClient(ctx context.Context, clientID string, clientSecret string) error {
+	if clientID == "" || clientSecret == "" {
+		return errors.ErrInvalidClient
+	}
+
+	client, err := s.server.Manager.GetClient(ctx, clientID)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return errors.ErrInvalidClient
+		}
+		return err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(client.GetSecret()), []byte(clientSecret)) != 1 {
+		return errors.ErrInvalidClient
+	}
+
+	return nil
+}
+
+// RevokeToken revokes an access or refresh token on behalf of the client it was issued to, as described in
+// https://datatracker.ietf.org/doc/html/rfc7009. Access and refresh tokens from the same grant are stored
+// together, so revoking either one revokes both.
+//
+// The token type hint is only used to decide where to look first. If the token doesn't exist, nothing is
+// revoked and no error is returned, since the client's goal of the token not being usable is met anyway.
+// If the token was issued to a different client, errors.ErrUnauthorizedClient is returned.
+func (s *s) RevokeToken(ctx context.Context, clientID string, token string, tokenTypeHint string) (oauth2.TokenInfo, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	type lookup struct {
+		get    func(context.Context, string) (oauth2.TokenInfo, error)
+		remove func(context.Context, string) error
+	}
+	lookups := []lookup{
+		{s.tokenStore.GetByAccess, s.tokenStore.RemoveByAccess},
+		{s.tokenStore.GetByRefresh, s.tokenStore.RemoveByRefresh},
+	}
+	if tokenTypeHint == TokenTypeHintRefreshToken {
+		lookups[0], lookups[1] = lookups[1], lookups[0]
+	}
+
+	for _, l := range lookups {
+		ti, err := l.get(ctx, token)
+		if err != nil {
+			if err == db.ErrNoEntries {
+				continue
+			}
+			return nil, err
+		}
+
+		if ti.GetClientID() != clientID {
+			return nil, errors.ErrUnauthorizedClient
+		}
+
+		if err := l.remove(ctx, token); err != nil {
+			return nil, err
+		}
+		return ti, nil
+	}
+
+	return nil, nil
+}
+
+// RevokeUserTokens revokes every token that the given user has authorized the given client to use,
+// and returns the tokens that were revoked.
+func (s *s) RevokeUserTokens(ctx context.Context, userID string, clientID string) ([]oauth2.TokenInfo, error) {
+	where := []db.Where{{Key: "user_id", Value: userID}, {Key: "client_id", Value: clientID}}
+
+	tokens := []*Token{}
+	if err := s.tokenStore.db.GetWhere(ctx, where, &tokens); err != nil && err != db.ErrNoEntries {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	if err := s.tokenStore.db.DeleteWhere(ctx, where, &[]*Token{}); err != nil {
+		return nil, err
+	}
+
+	revoked := make([]oauth2.TokenInfo, 0, len(tokens))
+	for _, t := range tokens {
+		revoked = append(revoked, DBTokenToToken(t))
+	}
+	return revoked, nil
+}
@@ -0,0 +1,133 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package oauth_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+	oautherrors "github.com/superseriousbusiness/oauth2/v4/errors"
+)
+
+type RevokeTestSuite struct {
+	suite.Suite
+	db          db.DB
+	oauthServer oauth.Server
+
+	testTokens  map[string]*oauth.Token
+	testClients map[string]*oauth.Client
+}
+
+func (suite *RevokeTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+}
+
+func (suite *RevokeTestSuite) SetupTest() {
+	suite.db = testrig.NewTestDB()
+	suite.oauthServer = testrig.NewTestOauthServer(suite.db)
+	testrig.StandardDBSetup(suite.db, nil)
+}
+
+func (suite *RevokeTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+}
+
+func (suite *RevokeTestSuite) TestValidateClient() {
+	ctx := context.Background()
+	client := suite.testClients["local_account_1"]
+
+	suite.NoError(suite.oauthServer.ValidateClient(ctx, client.ID, client.Secret))
+	suite.Equal(oautherrors.ErrInvalidClient, suite.oauthServer.ValidateClient(ctx, client.ID, "wrong"))
+	suite.Equal(oautherrors.ErrInvalidClient, suite.oauthServer.ValidateClient(ctx, client.ID, ""))
+	suite.Equal(oautherrors.ErrInvalidClient, suite.oauthServer.ValidateClient(ctx, "01FCVW2DFJG5GZJQ58V8QW1J6Y", client.Secret))
+}
+
+func (suite *RevokeTestSuite) TestRevokeToken() {
+	ctx := context.Background()
+	token := suite.testTokens["local_account_1"]
+
+	revoked, err := suite.oauthServer.RevokeToken(ctx, token.ClientID, token.Access, oauth.TokenTypeHintAccessToken)
+	suite.NoError(err)
+	suite.Equal(token.Access, revoked.GetAccess())
+	suite.Equal(token.UserID, revoked.GetUserID())
+
+	_, err = suite.oauthServer.LoadAccessToken(ctx, token.Access)
+	suite.Error(err)
+
+	// revoking it again is fine, but there's nothing to revoke
+	revoked, err = suite.oauthServer.RevokeToken(ctx, token.ClientID, token.Access, "")
+	suite.NoError(err)
+	suite.Nil(revoked)
+}
+
+func (suite *RevokeTestSuite) TestRevokeTokenWrongHint() {
+	ctx := context.Background()
+	token := suite.testTokens["local_account_1"]
+
+	// the hint is only a hint, so an access token is still found when it's said to be a refresh token
+	revoked, err := suite.oauthServer.RevokeToken(ctx, token.ClientID, token.Access, oauth.TokenTypeHintRefreshToken)
+	suite.NoError(err)
+	suite.NotNil(revoked)
+
+	_, err = suite.oauthServer.LoadAccessToken(ctx, token.Access)
+	suite.Error(err)
+}
+
+func (suite *RevokeTestSuite) TestRevokeTokenWrongClient() {
+	ctx := context.Background()
+	token := suite.testTokens["local_account_1"]
+
+	revoked, err := suite.oauthServer.RevokeToken(ctx, suite.testClients["local_account_2"].ID, token.Access, "")
+	suite.Equal(oautherrors.ErrUnauthorizedClient, err)
+	suite.Nil(revoked)
+
+	// the token should still work
+	_, err = suite.oauthServer.LoadAccessToken(ctx, token.Access)
+	suite.NoError(err)
+}
+
+func (suite *RevokeTestSuite) TestRevokeUserTokens() {
+	ctx := context.Background()
+	token := suite.testTokens["local_account_1"]
+
+	// tokens for other users shouldn't be touched
+	revoked, err := suite.oauthServer.RevokeUserTokens(ctx, suite.testTokens["local_account_2"].UserID, token.ClientID)
+	suite.NoError(err)
+	suite.Empty(revoked)
+
+	revoked, err = suite.oauthServer.RevokeUserTokens(ctx, token.UserID, token.ClientID)
+	suite.NoError(err)
+	suite.Len(revoked, 1)
+	suite.Equal(token.Access, revoked[0].GetAccess())
+
+	_, err = suite.oauthServer.LoadAccessToken(ctx, token.Access)
+	suite.Error(err)
+
+	_, err = suite.oauthServer.LoadAccessToken(ctx, suite.testTokens["local_account_2"].Access)
+	suite.NoError(err)
+}
+
+func TestRevokeTestSuite(t *testing.T) {
+	suite.Run(t, new(RevokeTestSuite))
+}
@@ -57,17 +57,21 @@ type Server interface {
 	ValidationBearerToken(r *http.Request) (oauth2.TokenInfo, error)
 	GenerateUserAccessToken(ti oauth2.TokenInfo, clientSecret string, userID string) (accessToken oauth2.TokenInfo, err error)
 	LoadAccessToken(ctx context.Context, access string) (accessToken oauth2.TokenInfo, err error)
+	ValidateClient(ctx context.Context, clientID string, clientSecret string) error
+	RevokeToken(ctx context.Context, clientID string, token string, tokenTypeHint string) (revoked oauth2.TokenInfo, err error)
+	RevokeUserTokens(ctx context.Context, userID string, clientID string) (revoked []oauth2.TokenInfo, err error)
 }
 
 // s fulfils the Server interface using the underlying oauth2 server
 type s struct {
-	server *server.Server
-	log    *logrus.Logger
+	server     *server.Server
+	tokenStore *tokenStore
+	log        *logrus.Logger
 }
 
 // New returns a new oauth server that implements the Server interface
 func New(database db.Basic, log *logrus.Logger) Server {
-	ts := newTokenStore(context.Background(), database, log)
+	ts := newTokenStore(context.Background(), database, log).(*tokenStore)
 	cs := NewClientStore(database)
 
 	manager := manage.NewDefaultManager()
@@ -112,8 +116,9 @@ func New(database db.Basic, log *logrus.Logger) Server {
 	})
 	srv.SetClientInfoHandler(server.ClientFormHandler)
 	return &s{
-		server: srv,
-		log:    log,
+		server:     srv,
+		tokenStore: ts,
+		log:        log,
 	}
 }
 
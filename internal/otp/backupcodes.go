@@ -0,0 +1,81 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package otp
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	// BackupCodeCount is the number of backup codes generated for a user at a time.
+	BackupCodeCount = 10
+	// backupCodeAlphabet avoids characters that are easily confused with one another.
+	backupCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+	backupCodeLength   = 10
+)
+
+// GenerateBackupCodes returns a fresh set of backup codes, along with the hashes of those codes
+// which should be stored on the user in their place. The codes themselves should be shown to the
+// user once and then forgotten.
+func GenerateBackupCodes() (codes []string, hashes []string, err error) {
+	codes = make([]string, 0, BackupCodeCount)
+	hashes = make([]string, 0, BackupCodeCount)
+
+	for i := 0; i < BackupCodeCount; i++ {
+		code := make([]byte, backupCodeLength)
+		for j := range code {
+			n, err := rand.Int(rand.Reader, big.NewInt(int64(len(backupCodeAlphabet))))
+			if err != nil {
+				return nil, nil, fmt.Errorf("GenerateBackupCodes: error generating random number: %s", err)
+			}
+			code[j] = backupCodeAlphabet[n.Int64()]
+		}
+
+		codes = append(codes, string(code))
+		hashes = append(hashes, hashBackupCode(string(code)))
+	}
+
+	return codes, hashes, nil
+}
+
+// MatchBackupCode checks the given code against the given backup code hashes. If it matches one of
+// them, the index of the matching hash is returned, so that the caller can remove it.
+func MatchBackupCode(hashes []string, code string) (int, bool) {
+	hashed := hashBackupCode(code)
+	for i, h := range hashes {
+		if subtle.ConstantTimeCompare([]byte(h), []byte(hashed)) == 1 {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// hashBackupCode hashes a backup code for storage. Backup codes are long and random, so unlike
+// passwords they don't need a slow hash to be safe at rest.
+func hashBackupCode(code string) string {
+	code = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
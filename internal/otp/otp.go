@@ -0,0 +1,134 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package otp implements time-based one-time passwords (RFC 6238) and one-time backup codes,
+// for use as a second factor when users sign in.
+package otp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// Period is the number of seconds that each code is valid for.
+	Period = 30
+	// Digits is the number of digits in each code.
+	Digits = 6
+	// skew is the number of periods either side of the current one that we'll still accept codes
+	// for, to allow for clock drift between the server and the user's device.
+	skew = 1
+	// secretLength is the length in bytes of newly generated secrets, as recommended by RFC 4226.
+	secretLength = 20
+)
+
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret returns a new random secret, encoded as base32 so that it can be typed into an authenticator app.
+func GenerateSecret() (string, error) {
+	b := make([]byte, secretLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("GenerateSecret: error reading random bytes: %s", err)
+	}
+	return secretEncoding.EncodeToString(b), nil
+}
+
+// ProvisioningURI returns an otpauth:// URI for the given secret, which authenticator apps can
+// read (usually from a QR code) to set themselves up.
+//
+// See https://github.com/google/google-authenticator/wiki/Key-Uri-Format
+func ProvisioningURI(issuer string, accountName string, secret string) string {
+	v := url.Values{}
+	v.Set("secret", secret)
+	v.Set("issuer", issuer)
+	v.Set("algorithm", "SHA1")
+	v.Set("digits", fmt.Sprint(Digits))
+	v.Set("period", fmt.Sprint(Period))
+
+	label := url.PathEscape(issuer + ":" + accountName)
+	return "otpauth://totp/" + label + "?" + v.Encode()
+}
+
+// Step returns the time step that the given time falls into.
+func Step(t time.Time) int64 {
+	return t.Unix() / Period
+}
+
+// Validate checks whether the given code is valid for the given secret at time t. Codes for time steps at
+// or before lastStep are rejected, so that a code can't be used twice. If the code is valid, the time step
+// it belongs to is returned, which callers should store and pass as lastStep next time.
+func Validate(secret string, code string, t time.Time, lastStep int64) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != Digits {
+		return 0, false
+	}
+
+	key, err := secretEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return 0, false
+	}
+
+	current := Step(t)
+	for step := current - skew; step <= current+skew; step++ {
+		if step <= lastStep {
+			continue
+		}
+		if hmac.Equal([]byte(generateCode(key, step)), []byte(code)) {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// GenerateCode returns the code for the given secret at time t, as an authenticator app would show it.
+func GenerateCode(secret string, t time.Time) (string, error) {
+	key, err := secretEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", fmt.Errorf("GenerateCode: error decoding secret: %s", err)
+	}
+	return generateCode(key, Step(t)), nil
+}
+
+// generateCode returns the HOTP code (RFC 4226) for the given key and counter.
+func generateCode(key []byte, counter int64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(counter))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	// dynamic truncation as described in https://datatracker.ietf.org/doc/html/rfc4226#section-5.3
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < Digits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", Digits, value%mod)
+}
@@ -0,0 +1,139 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package otp_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/otp"
+)
+
+// rfcSecret is the base32 encoding of the SHA1 test secret from https://datatracker.ietf.org/doc/html/rfc6238#appendix-B
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+type OTPTestSuite struct {
+	suite.Suite
+}
+
+func (suite *OTPTestSuite) TestGenerateCodeRFCVectors() {
+	// the RFC gives 8 digit codes, we use the last 6 of them
+	for unix, expected := range map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	} {
+		code, err := otp.GenerateCode(rfcSecret, time.Unix(unix, 0))
+		suite.NoError(err)
+		suite.Equal(expected, code, "code at %d", unix)
+	}
+}
+
+func (suite *OTPTestSuite) TestValidate() {
+	now := time.Unix(1234567890, 0)
+
+	step, ok := otp.Validate(rfcSecret, "005924", now, 0)
+	suite.True(ok)
+	suite.Equal(otp.Step(now), step)
+
+	// a code can't be used twice
+	_, ok = otp.Validate(rfcSecret, "005924", now, step)
+	suite.False(ok)
+
+	// codes from just before or after are fine, to allow for clock drift
+	previous, err := otp.GenerateCode(rfcSecret, now.Add(-otp.Period*time.Second))
+	suite.NoError(err)
+	_, ok = otp.Validate(rfcSecret, previous, now, 0)
+	suite.True(ok)
+
+	// but not older than that
+	old, err := otp.GenerateCode(rfcSecret, now.Add(-2*otp.Period*time.Second))
+	suite.NoError(err)
+	_, ok = otp.Validate(rfcSecret, old, now, 0)
+	suite.False(ok)
+
+	for _, bad := range []string{"", "123456", "00592", "0059245", "abcdef"} {
+		_, ok = otp.Validate(rfcSecret, bad, now, 0)
+		suite.False(ok, bad)
+	}
+}
+
+func (suite *OTPTestSuite) TestGenerateSecret() {
+	secret, err := otp.GenerateSecret()
+	suite.NoError(err)
+	suite.Len(secret, 32)
+
+	code, err := otp.GenerateCode(secret, time.Now())
+	suite.NoError(err)
+	_, ok := otp.Validate(secret, code, time.Now(), 0)
+	suite.True(ok)
+}
+
+func (suite *OTPTestSuite) TestProvisioningURI() {
+	uri, err := url.Parse(otp.ProvisioningURI("example.org", "some_user", rfcSecret))
+	suite.NoError(err)
+	suite.Equal("otpauth", uri.Scheme)
+	suite.Equal("totp", uri.Host)
+	suite.Equal("/example.org:some_user", uri.Path)
+	suite.Equal(rfcSecret, uri.Query().Get("secret"))
+	suite.Equal("example.org", uri.Query().Get("issuer"))
+}
+
+func (suite *OTPTestSuite) TestEncryptSecret() {
+	key := []byte("an instance key that is 32 bytes")
+
+	encrypted, iv, salt, err := otp.EncryptSecret(key, rfcSecret)
+	suite.NoError(err)
+	suite.NotContains(encrypted, rfcSecret)
+
+	decrypted, err := otp.DecryptSecret(key, encrypted, iv, salt)
+	suite.NoError(err)
+	suite.Equal(rfcSecret, decrypted)
+
+	_, err = otp.DecryptSecret([]byte("some other key that's 32 bytes!!"), encrypted, iv, salt)
+	suite.Error(err)
+}
+
+func (suite *OTPTestSuite) TestBackupCodes() {
+	codes, hashes, err := otp.GenerateBackupCodes()
+	suite.NoError(err)
+	suite.Len(codes, otp.BackupCodeCount)
+	suite.Len(hashes, otp.BackupCodeCount)
+
+	i, ok := otp.MatchBackupCode(hashes, codes[3])
+	suite.True(ok)
+	suite.Equal(3, i)
+
+	// users might type them in with different case or surrounding whitespace
+	_, ok = otp.MatchBackupCode(hashes, "  "+strings.ToUpper(codes[5])+"\n")
+	suite.True(ok)
+
+	_, ok = otp.MatchBackupCode(hashes, "notacode12")
+	suite.False(ok)
+}
+
+func TestOTPTestSuite(t *testing.T) {
+	suite.Run(t, new(OTPTestSuite))
+}
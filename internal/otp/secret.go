@@ -0,0 +1,105 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package otp
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// EncryptSecret encrypts the given secret with AES-GCM, using a key derived from the given
+// instance key and a fresh random salt. The ciphertext, iv, and salt are returned base64 encoded,
+// ready to be stored on the user.
+func EncryptSecret(instanceKey []byte, secret string) (encrypted string, iv string, salt string, err error) {
+	saltBytes := make([]byte, 16)
+	if _, err = rand.Read(saltBytes); err != nil {
+		return "", "", "", fmt.Errorf("EncryptSecret: error generating salt: %s", err)
+	}
+
+	gcm, err := newGCM(instanceKey, saltBytes)
+	if err != nil {
+		return "", "", "", fmt.Errorf("EncryptSecret: %s", err)
+	}
+
+	ivBytes := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(ivBytes); err != nil {
+		return "", "", "", fmt.Errorf("EncryptSecret: error generating iv: %s", err)
+	}
+
+	sealed := gcm.Seal(nil, ivBytes, []byte(secret), nil)
+
+	return base64.StdEncoding.EncodeToString(sealed),
+		base64.StdEncoding.EncodeToString(ivBytes),
+		base64.StdEncoding.EncodeToString(saltBytes),
+		nil
+}
+
+// DecryptSecret reverses EncryptSecret.
+func DecryptSecret(instanceKey []byte, encrypted string, iv string, salt string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		return "", fmt.Errorf("DecryptSecret: error decoding secret: %s", err)
+	}
+	ivBytes, err := base64.StdEncoding.DecodeString(iv)
+	if err != nil {
+		return "", fmt.Errorf("DecryptSecret: error decoding iv: %s", err)
+	}
+	saltBytes, err := base64.StdEncoding.DecodeString(salt)
+	if err != nil {
+		return "", fmt.Errorf("DecryptSecret: error decoding salt: %s", err)
+	}
+
+	gcm, err := newGCM(instanceKey, saltBytes)
+	if err != nil {
+		return "", fmt.Errorf("DecryptSecret: %s", err)
+	}
+
+	if len(ivBytes) != gcm.NonceSize() {
+		return "", fmt.Errorf("DecryptSecret: iv was %d bytes, expected %d", len(ivBytes), gcm.NonceSize())
+	}
+
+	secret, err := gcm.Open(nil, ivBytes, sealed, nil)
+	if err != nil {
+		return "", fmt.Errorf("DecryptSecret: error decrypting secret: %s", err)
+	}
+
+	return string(secret), nil
+}
+
+// newGCM derives an AES-256 key from the instance key and salt, and wraps it in GCM.
+func newGCM(instanceKey []byte, salt []byte) (cipher.AEAD, error) {
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, instanceKey, salt, []byte("gotosocial otp secret")), key); err != nil {
+		return nil, fmt.Errorf("error deriving key: %s", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cipher: %s", err)
+	}
+
+	return cipher.NewGCM(block)
+}
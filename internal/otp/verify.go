@@ -0,0 +1,81 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package otp
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// InstanceKey returns the key that OTP secrets on this instance are encrypted with. It's derived from the
+// private key of the instance account, so that admins don't need to configure anything.
+func InstanceKey(ctx context.Context, database db.DB) ([]byte, error) {
+	instanceAccount, err := database.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("InstanceKey: error getting instance account: %s", err)
+	}
+	if instanceAccount.PrivateKey == nil {
+		return nil, errors.New("InstanceKey: instance account had no private key")
+	}
+	return x509.MarshalPKCS1PrivateKey(instanceAccount.PrivateKey), nil
+}
+
+// Verify checks a code given by the user, which may be either a code from their authenticator app or
+// one of their unused backup codes. If the code is valid, it is marked as used in the database so that
+// it can't be used again, and true is returned.
+func Verify(ctx context.Context, database db.DB, user *gtsmodel.User, code string) (bool, error) {
+	if user.EncryptedOTPSecret == "" {
+		return false, nil
+	}
+
+	key, err := InstanceKey(ctx, database)
+	if err != nil {
+		return false, fmt.Errorf("Verify: %s", err)
+	}
+
+	secret, err := DecryptSecret(key, user.EncryptedOTPSecret, user.EncryptedOTPSecretIv, user.EncryptedOTPSecretSalt)
+	if err != nil {
+		return false, fmt.Errorf("Verify: %s", err)
+	}
+
+	if step, ok := Validate(secret, code, time.Now(), int64(user.ConsumedTimestamp)); ok {
+		user.ConsumedTimestamp = int(step)
+		if err := database.UpdateOneByID(ctx, user.ID, "consumed_timestamp", user.ConsumedTimestamp, &gtsmodel.User{}); err != nil {
+			return false, fmt.Errorf("Verify: error marking code as used: %s", err)
+		}
+		return true, nil
+	}
+
+	if i, ok := MatchBackupCode(user.OTPBackupCodes, code); ok {
+		user.OTPBackupCodes = append(user.OTPBackupCodes[:i], user.OTPBackupCodes[i+1:]...)
+		user.UpdatedAt = time.Now()
+		if err := database.UpdateByID(ctx, user.ID, user); err != nil {
+			return false, fmt.Errorf("Verify: error marking backup code as used: %s", err)
+		}
+		return true, nil
+	}
+
+	return false, nil
+}
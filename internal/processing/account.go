@@ -20,12 +20,19 @@ package processing
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
+// accountDeletionInterval is how often we check for local accounts that are waiting to be deleted.
+const accountDeletionInterval = 1 * time.Minute
+
 func (p *processor) AccountCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountCreateRequest) (*apimodel.Token, error) {
 	return p.accountProcessor.Create(ctx, authed.Token, authed.Application, form)
 }
@@ -34,8 +41,12 @@ func (p *processor) AccountGet(ctx context.Context, authed *oauth.Auth, targetAc
 	return p.accountProcessor.Get(ctx, authed.Account, targetAccountID)
 }
 
+func (p *processor) AccountDeleteLocal(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountDeleteRequest) gtserror.WithCode {
+	return p.accountProcessor.DeleteLocal(ctx, authed.Account, authed.User, form)
+}
+
 func (p *processor) AccountUpdate(ctx context.Context, authed *oauth.Auth, form *apimodel.UpdateCredentialsRequest) (*apimodel.Account, error) {
-	return p.accountProcessor.Update(ctx, authed.Account, form)
+	return p.accountProcessor.Update(ctx, authed.Account, authed.User, form)
 }
 
 func (p *processor) AccountStatusesGet(ctx context.Context, authed *oauth.Auth, targetAccountID string, limit int, excludeReplies bool, maxID string, pinnedOnly bool, mediaOnly bool) ([]apimodel.Status, gtserror.WithCode) {
@@ -69,3 +80,106 @@ func (p *processor) AccountBlockCreate(ctx context.Context, authed *oauth.Auth,
 func (p *processor) AccountBlockRemove(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode) {
 	return p.accountProcessor.BlockRemove(ctx, authed.Account, targetAccountID)
 }
+
+func (p *processor) AccountMuteCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountMuteRequest) (*apimodel.Relationship, gtserror.WithCode) {
+	relationship, errWithCode := p.accountProcessor.MuteCreate(ctx, authed.Account, form)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// remove any of the muted account's statuses from the muting account's timelines
+	if err := p.timelineManager.WipeStatusesFromAccountID(ctx, authed.Account.ID, form.ID); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return relationship, nil
+}
+
+func (p *processor) AccountMuteRemove(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode) {
+	relationship, errWithCode := p.accountProcessor.MuteRemove(ctx, authed.Account, targetAccountID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// the unmuted account's statuses should show up again, so make sure the timelines get indexed again
+	p.timelineManager.WipeTimelinesForAccountID(ctx, authed.Account.ID)
+
+	return relationship, nil
+}
+
+func (p *processor) AccountEndorsementCreate(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode) {
+	return p.accountProcessor.EndorsementCreate(ctx, authed.Account, targetAccountID)
+}
+
+func (p *processor) AccountEndorsementRemove(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode) {
+	return p.accountProcessor.EndorsementRemove(ctx, authed.Account, targetAccountID)
+}
+
+func (p *processor) AccountMove(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountMoveRequest) (*apimodel.Account, gtserror.WithCode) {
+	return p.accountProcessor.Move(ctx, authed.Account, authed.User, form)
+}
+
+// moveLocalFollowers follows target on behalf of every local follower of origin, since origin has moved to target.
+func (p *processor) moveLocalFollowers(ctx context.Context, origin *gtsmodel.Account, target *gtsmodel.Account) error {
+	follows, err := p.db.GetAccountFollowedBy(ctx, origin.ID, true)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil
+		}
+		return fmt.Errorf("moveLocalFollowers: error getting followers of %s: %s", origin.ID, err)
+	}
+
+	for _, follow := range follows {
+		follower, err := p.db.GetAccountByID(ctx, follow.AccountID)
+		if err != nil {
+			p.log.Errorf("moveLocalFollowers: error getting follower %s: %s", follow.AccountID, err)
+			continue
+		}
+		if err := p.accountProcessor.FollowMoved(ctx, follower, origin, target); err != nil {
+			p.log.Errorf("moveLocalFollowers: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// deleteAccount deletes the given account, unless a deletion of that account is already underway.
+func (p *processor) deleteAccount(ctx context.Context, account *gtsmodel.Account, origin string) error {
+	p.accountDeletionsLock.Lock()
+	if p.accountDeletions[account.ID] {
+		p.accountDeletionsLock.Unlock()
+		return nil
+	}
+	p.accountDeletions[account.ID] = true
+	p.accountDeletionsLock.Unlock()
+
+	defer func() {
+		p.accountDeletionsLock.Lock()
+		delete(p.accountDeletions, account.ID)
+		p.accountDeletionsLock.Unlock()
+	}()
+
+	return p.accountProcessor.Delete(ctx, account, origin)
+}
+
+// deletePendingAccounts deletes any local accounts whose deletion was requested but hasn't been carried out yet,
+// either because it was requested outside of the running server, or because the server stopped partway through.
+func (p *processor) deletePendingAccounts(ctx context.Context) error {
+	accounts, err := p.db.GetAccountsPendingDeletion(ctx)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil
+		}
+		return fmt.Errorf("deletePendingAccounts: error getting accounts pending deletion: %s", err)
+	}
+
+	for _, a := range accounts {
+		go func(account *gtsmodel.Account) {
+			if err := p.deleteAccount(ctx, account, account.SuspensionOrigin); err != nil {
+				p.log.Errorf("deletePendingAccounts: error deleting account %s: %s", account.ID, err)
+			}
+		}(a)
+	}
+
+	return nil
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/blob"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
@@ -43,10 +44,13 @@ type Processor interface {
 	// Delete deletes an account, and all of that account's statuses, media, follows, notifications, etc etc etc.
 	// The origin passed here should be either the ID of the account doing the delete (can be itself), or the ID of a domain block.
 	Delete(ctx context.Context, account *gtsmodel.Account, origin string) error
+	// DeleteLocal checks the password given in the form, and then queues the given local account for deletion.
+	// The deletion itself happens in the background.
+	DeleteLocal(ctx context.Context, account *gtsmodel.Account, user *gtsmodel.User, form *apimodel.AccountDeleteRequest) gtserror.WithCode
 	// Get processes the given request for account information.
 	Get(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.Account, error)
 	// Update processes the update of an account with the given form
-	Update(ctx context.Context, account *gtsmodel.Account, form *apimodel.UpdateCredentialsRequest) (*apimodel.Account, error)
+	Update(ctx context.Context, account *gtsmodel.Account, user *gtsmodel.User, form *apimodel.UpdateCredentialsRequest) (*apimodel.Account, error)
 	// StatusesGet fetches a number of statuses (in time descending order) from the given account, filtered by visibility for
 	// the account given in authed.
 	StatusesGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string, limit int, excludeReplies bool, maxID string, pinned bool, mediaOnly bool) ([]apimodel.Status, gtserror.WithCode)
@@ -64,6 +68,20 @@ type Processor interface {
 	BlockCreate(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode)
 	// BlockRemove handles the removal of a block from requestingAccount to targetAccountID, either remote or local.
 	BlockRemove(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode)
+	// MuteCreate handles the creation or update of a mute from requestingAccount to the account targeted in the form.
+	MuteCreate(ctx context.Context, requestingAccount *gtsmodel.Account, form *apimodel.AccountMuteRequest) (*apimodel.Relationship, gtserror.WithCode)
+	// MuteRemove handles the removal of a mute from requestingAccount to targetAccountID.
+	MuteRemove(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode)
+	// EndorsementCreate handles the endorsement of targetAccountID by requestingAccount, so that it's featured on the profile of requestingAccount.
+	EndorsementCreate(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode)
+	// EndorsementRemove handles the removal of an endorsement of targetAccountID by requestingAccount.
+	EndorsementRemove(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode)
+	// Move checks the password given in the form, and then moves the given local account to the target account in the form.
+	// The target account must already list the given account as an alias. Followers are told about the move in the background.
+	Move(ctx context.Context, account *gtsmodel.Account, user *gtsmodel.User, form *apimodel.AccountMoveRequest) (*apimodel.Account, gtserror.WithCode)
+	// FollowMoved follows target on behalf of the given local follower, if follower is following origin,
+	// using the same settings as the follow of origin. To be used when origin has moved to target.
+	FollowMoved(ctx context.Context, follower *gtsmodel.Account, origin *gtsmodel.Account, target *gtsmodel.Account) error
 
 	// UpdateHeader does the dirty work of checking the header part of an account update form,
 	// parsing and checking the image, and doing the necessary updates in the database for this to become
@@ -79,6 +97,7 @@ type processor struct {
 	tc            typeutils.TypeConverter
 	config        *config.Config
 	mediaHandler  media.Handler
+	storage       blob.Storage
 	fromClientAPI chan gtsmodel.FromClientAPI
 	oauthServer   oauth.Server
 	filter        visibility.Filter
@@ -88,11 +107,12 @@ type processor struct {
 }
 
 // New returns a new account processor.
-func New(db db.DB, tc typeutils.TypeConverter, mediaHandler media.Handler, oauthServer oauth.Server, fromClientAPI chan gtsmodel.FromClientAPI, federator federation.Federator, config *config.Config, log *logrus.Logger) Processor {
+func New(db db.DB, tc typeutils.TypeConverter, mediaHandler media.Handler, storage blob.Storage, oauthServer oauth.Server, fromClientAPI chan gtsmodel.FromClientAPI, federator federation.Federator, config *config.Config, log *logrus.Logger) Processor {
 	return &processor{
 		tc:            tc,
 		config:        config,
 		mediaHandler:  mediaHandler,
+		storage:       storage,
 		fromClientAPI: fromClientAPI,
 		oauthServer:   oauthServer,
 		filter:        visibility.NewFilter(db, log),
@@ -63,6 +63,15 @@ func (p *processor) BlockCreate(ctx context.Context, requestingAccount *gtsmodel
 	}
 
 	// clear any follows or follow requests from the blocked account to the target account -- this is a simple delete
+	targetFollow := &gtsmodel.Follow{}
+	if err := p.db.GetWhere(ctx, []db.Where{
+		{Key: "account_id", Value: targetAccountID},
+		{Key: "target_account_id", Value: requestingAccount.ID},
+	}, targetFollow); err == nil {
+		if err := p.db.DeleteListEntriesForFollowID(ctx, targetFollow.ID); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("BlockCreate: error removing list entries from db: %s", err))
+		}
+	}
 	if err := p.db.DeleteWhere(ctx, []db.Where{
 		{Key: "account_id", Value: targetAccountID},
 		{Key: "target_account_id", Value: requestingAccount.ID},
@@ -106,6 +115,9 @@ func (p *processor) BlockCreate(ctx context.Context, requestingAccount *gtsmodel
 		if err := p.db.DeleteByID(ctx, f.ID, f); err != nil {
 			return nil, gtserror.NewErrorInternalError(fmt.Errorf("BlockCreate: error removing follow from db: %s", err))
 		}
+		if err := p.db.DeleteListEntriesForFollowID(ctx, f.ID); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("BlockCreate: error removing list entries from db: %s", err))
+		}
 		fChanged = true
 	}
 
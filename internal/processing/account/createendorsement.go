@@ -0,0 +1,77 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"context"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+func (p *processor) EndorsementCreate(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode) {
+	if requestingAccount.ID == targetAccountID {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("EndorsementCreate: account %s cannot endorse itself", requestingAccount.ID), "you cannot endorse yourself")
+	}
+
+	// make sure the target account actually exists in our db
+	targetAccount, err := p.db.GetAccountByID(ctx, targetAccountID)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("EndorsementCreate: error getting account %s from the db: %s", targetAccountID, err))
+	}
+
+	// if the endorsement already exists we don't need to do anything
+	endorsed, err := p.db.IsEndorsed(ctx, requestingAccount.ID, targetAccount.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("EndorsementCreate: error checking existence of endorsement: %s", err))
+	}
+	if endorsed {
+		return p.RelationshipGet(ctx, requestingAccount, targetAccount.ID)
+	}
+
+	// you can only feature accounts that you follow on your profile
+	following, err := p.db.IsFollowing(ctx, requestingAccount, targetAccount)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("EndorsementCreate: error checking existence of follow: %s", err))
+	}
+	if !following {
+		err := fmt.Errorf("EndorsementCreate: account %s does not follow account %s", requestingAccount.ID, targetAccount.ID)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, "you must follow this account in order to endorse it")
+	}
+
+	newEndorsementID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	endorsement := &gtsmodel.Endorsement{
+		ID:              newEndorsementID,
+		AccountID:       requestingAccount.ID,
+		TargetAccountID: targetAccount.ID,
+	}
+
+	if err := p.db.Put(ctx, endorsement); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("EndorsementCreate: error creating endorsement in db: %s", err))
+	}
+
+	return p.RelationshipGet(ctx, requestingAccount, targetAccount.ID)
+}
@@ -0,0 +1,90 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+func (p *processor) MuteCreate(ctx context.Context, requestingAccount *gtsmodel.Account, form *apimodel.AccountMuteRequest) (*apimodel.Relationship, gtserror.WithCode) {
+	if requestingAccount.ID == form.ID {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("MuteCreate: account %s cannot mute itself", requestingAccount.ID), "you cannot mute yourself")
+	}
+
+	// make sure the target account actually exists in our db
+	targetAccount, err := p.db.GetAccountByID(ctx, form.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("MuteCreate: error getting account %s from the db: %s", form.ID, err))
+	}
+
+	// mastodon mutes notifications by default
+	notifications := true
+	if form.Notifications != nil {
+		notifications = *form.Notifications
+	}
+
+	var expiresAt time.Time
+	if form.Duration != nil && *form.Duration > 0 {
+		expiresAt = time.Now().Add(time.Duration(*form.Duration) * time.Second)
+	}
+
+	// if a mute already exists (even an expired one), we just update it with the new settings
+	mute := &gtsmodel.UserMute{}
+	if err := p.db.GetWhere(ctx, []db.Where{
+		{Key: "account_id", Value: requestingAccount.ID},
+		{Key: "target_account_id", Value: targetAccount.ID},
+	}, mute); err == nil {
+		mute.UpdatedAt = time.Now()
+		mute.ExpiresAt = expiresAt
+		mute.Notifications = notifications
+		if err := p.db.UpdateByID(ctx, mute.ID, mute); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("MuteCreate: error updating mute in db: %s", err))
+		}
+		return p.RelationshipGet(ctx, requestingAccount, targetAccount.ID)
+	} else if err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("MuteCreate: error checking existence of mute: %s", err))
+	}
+
+	newMuteID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	mute = &gtsmodel.UserMute{
+		ID:              newMuteID,
+		ExpiresAt:       expiresAt,
+		AccountID:       requestingAccount.ID,
+		TargetAccountID: targetAccount.ID,
+		Notifications:   notifications,
+	}
+
+	if err := p.db.Put(ctx, mute); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("MuteCreate: error creating mute in db: %s", err))
+	}
+
+	return p.RelationshipGet(ctx, requestingAccount, targetAccount.ID)
+}
@@ -20,18 +20,26 @@ package account
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
 	"time"
 
+	"github.com/go-fed/activity/streams"
 	"github.com/sirupsen/logrus"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"golang.org/x/crypto/bcrypt"
 )
 
 // Delete handles the complete deletion of an account.
 //
 // TODO in this function:
-// 1. Delete account's application(s), clients, and oauth tokens
+// 1. Delete account's application(s), clients, oauth tokens, and webauthn credentials
 // 2. Delete account's blocks
 // 3. Delete account's emoji
 // 4. Delete account's follow requests
@@ -57,7 +65,7 @@ func (p *processor) Delete(ctx context.Context, account *gtsmodel.Account, origi
 
 	l.Debugf("beginning account delete process for username %s", account.Username)
 
-	// 1. Delete account's application(s), clients, and oauth tokens
+	// 1. Delete account's application(s), clients, oauth tokens, and webauthn credentials
 	// we only need to do this step for local account since remote ones won't have any tokens or applications on our server
 	if account.Domain == "" {
 		// see if we can get a user for this account
@@ -82,6 +90,11 @@ func (p *processor) Delete(ctx context.Context, account *gtsmodel.Account, origi
 					}
 				}
 			}
+
+			// the user's security keys and passkeys are no use to anyone anymore
+			if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "user_id", Value: u.ID}}, &[]*gtsmodel.WebauthnCredential{}); err != nil {
+				l.Errorf("error deleting webauthn credentials: %s", err)
+			}
 		}
 	}
 
@@ -116,7 +129,25 @@ func (p *processor) Delete(ctx context.Context, account *gtsmodel.Account, origi
 	// 5. Delete account's follows
 	// TODO: federate these if necessary
 	l.Debug("deleting account follows")
-	// first delete any follows that this account created
+	// first delete any lists that this account created, along with their entries
+	if lists, err := p.db.GetListsForAccountID(ctx, account.ID); err == nil {
+		for _, list := range lists {
+			if err := p.db.DeleteListByID(ctx, list.ID); err != nil {
+				l.Errorf("error deleting list created by account: %s", err)
+			}
+		}
+	}
+
+	// now delete any list entries that refer to follows of this account
+	if follows, err := p.db.GetAccountFollowedBy(ctx, account.ID, false); err == nil {
+		for _, f := range follows {
+			if err := p.db.DeleteListEntriesForFollowID(ctx, f.ID); err != nil {
+				l.Errorf("error deleting list entries targeting account: %s", err)
+			}
+		}
+	}
+
+	// then delete any follows that this account created
 	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.Follow{}); err != nil {
 		l.Errorf("error deleting follows created by account: %s", err)
 	}
@@ -211,18 +242,49 @@ selectStatusesLoop:
 	}
 	l.Debug("done deleting statuses")
 
+	// 7. Delete account's media attachments
+	// this catches the avatar and header of the account as well as any attachments of its statuses
+	l.Debug("deleting account media attachments")
+	attachments := []*gtsmodel.MediaAttachment{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &attachments); err == nil {
+		for _, a := range attachments {
+			if a.Thumbnail.Path != "" {
+				if err := p.storage.RemoveFileAt(a.Thumbnail.Path); err != nil {
+					l.Errorf("error removing thumbnail at path %s: %s", a.Thumbnail.Path, err)
+				}
+			}
+			if a.File.Path != "" {
+				if err := p.storage.RemoveFileAt(a.File.Path); err != nil {
+					l.Errorf("error removing file at path %s: %s", a.File.Path, err)
+				}
+			}
+			if err := p.db.DeleteByID(ctx, a.ID, a); err != nil && err != db.ErrNoEntries {
+				l.Errorf("error deleting media attachment %s: %s", a.ID, err)
+			}
+		}
+	}
+
 	// 10. Delete account's notifications
 	l.Debug("deleting account notifications")
 	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "origin_account_id", Value: account.ID}}, &[]*gtsmodel.Notification{}); err != nil {
 		l.Errorf("error deleting notifications created by account: %s", err)
 	}
 
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "target_account_id", Value: account.ID}}, &[]*gtsmodel.Notification{}); err != nil {
+		l.Errorf("error deleting notifications targeting account: %s", err)
+	}
+
 	// 11. Delete account's bookmarks
 	l.Debug("deleting account bookmarks")
 	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.StatusBookmark{}); err != nil {
 		l.Errorf("error deleting bookmarks created by account: %s", err)
 	}
 
+	// the account's statuses are going away, so bookmarks of them by other accounts need to go too
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "target_account_id", Value: account.ID}}, &[]*gtsmodel.StatusBookmark{}); err != nil {
+		l.Errorf("error deleting bookmarks targeting account: %s", err)
+	}
+
 	// 12. Delete account's faves
 	// TODO: federate these if necessary
 	l.Debug("deleting account faves")
@@ -230,17 +292,105 @@ selectStatusesLoop:
 		l.Errorf("error deleting faves created by account: %s", err)
 	}
 
+	// faves of the account's statuses by other accounts go too
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "target_account_id", Value: account.ID}}, &[]*gtsmodel.StatusFave{}); err != nil {
+		l.Errorf("error deleting faves targeting account: %s", err)
+	}
+
+	// votes by this account in other accounts' polls go too; the polls themselves were deleted along with the statuses
+	l.Debug("deleting account poll votes")
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.PollVote{}); err != nil {
+		l.Errorf("error deleting poll votes created by account: %s", err)
+	}
+
+	// statuses scheduled by this account won't be posted now, so get rid of them
+	l.Debug("deleting account scheduled statuses")
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.ScheduledStatus{}); err != nil {
+		l.Errorf("error deleting scheduled statuses created by account: %s", err)
+	}
+
+	// same goes for any imports the account uploaded
+	l.Debug("deleting account imports")
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.AccountImport{}); err != nil {
+		l.Errorf("error deleting account imports created by account: %s", err)
+	}
+
+	// and any archives, along with their files
+	l.Debug("deleting account archives")
+	for _, archiveType := range []gtsmodel.AccountArchiveType{gtsmodel.AccountArchiveTypeExport, gtsmodel.AccountArchiveTypeImport} {
+		archives, err := p.db.GetAccountArchives(ctx, account.ID, archiveType)
+		if err != nil {
+			continue
+		}
+		for _, a := range archives {
+			if a.Path != "" {
+				if err := p.storage.RemoveFileAt(a.Path); err != nil {
+					l.Debugf("error removing file of archive %s: %s", a.ID, err)
+				}
+			}
+			if err := p.db.DeleteAccountArchiveByID(ctx, a.ID); err != nil {
+				l.Errorf("error deleting archive %s: %s", a.ID, err)
+			}
+		}
+	}
+
 	// 13. Delete account's mutes
 	l.Debug("deleting account mutes")
 	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.StatusMute{}); err != nil {
 		l.Errorf("error deleting status mutes created by account: %s", err)
 	}
 
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.UserMute{}); err != nil {
+		l.Errorf("error deleting user mutes created by account: %s", err)
+	}
+
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "target_account_id", Value: account.ID}}, &[]*gtsmodel.UserMute{}); err != nil {
+		l.Errorf("error deleting user mutes targeting account: %s", err)
+	}
+
+	l.Debug("deleting account domain blocks")
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.UserDomainBlock{}); err != nil {
+		l.Errorf("error deleting domain blocks created by account: %s", err)
+	}
+
+	l.Debug("deleting account endorsements")
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.Endorsement{}); err != nil {
+		l.Errorf("error deleting endorsements created by account: %s", err)
+	}
+
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "target_account_id", Value: account.ID}}, &[]*gtsmodel.Endorsement{}); err != nil {
+		l.Errorf("error deleting endorsements targeting account: %s", err)
+	}
+
+	l.Debug("deleting account suggestions")
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.Suggestion{}); err != nil {
+		l.Errorf("error deleting suggestions for account: %s", err)
+	}
+
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "target_account_id", Value: account.ID}}, &[]*gtsmodel.Suggestion{}); err != nil {
+		l.Errorf("error deleting suggestions of account: %s", err)
+	}
+
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.SuggestionDismissal{}); err != nil {
+		l.Errorf("error deleting suggestion dismissals created by account: %s", err)
+	}
+
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "target_account_id", Value: account.ID}}, &[]*gtsmodel.SuggestionDismissal{}); err != nil {
+		l.Errorf("error deleting suggestion dismissals targeting account: %s", err)
+	}
+
+	if err := p.db.DeleteFeaturedAccount(ctx, account.ID); err != nil {
+		l.Errorf("error unfeaturing account: %s", err)
+	}
+
 	// 14. Delete account's streams
 	// TODO
 
 	// 15. Delete account's tags
-	// TODO
+	l.Debug("deleting account featured tags")
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.FeaturedTag{}); err != nil {
+		l.Errorf("error deleting featured tags of account: %s", err)
+	}
 
 	// 16. Delete account's user
 	l.Debug("deleting account user")
@@ -251,6 +401,14 @@ selectStatusesLoop:
 	// 17. Delete account's timeline
 	// TODO
 
+	// let everyone we know about know that this account is gone; we do this before turning the account into a stub,
+	// so that remote servers can still fetch the account's public key to check the signature of the delete
+	if account.Domain == "" {
+		if err := p.federateAccountDelete(ctx, account); err != nil {
+			l.Errorf("error federating account delete: %s", err)
+		}
+	}
+
 	// 18. Delete account itself
 	// to prevent the account being created again, set all these fields and update it in the db
 	// the account won't actually be *removed* from the database but it will be set to just a stub
@@ -279,3 +437,83 @@ selectStatusesLoop:
 	l.Infof("deleted account with username %s from domain %s", account.Username, account.Domain)
 	return nil
 }
+
+func (p *processor) DeleteLocal(ctx context.Context, account *gtsmodel.Account, user *gtsmodel.User, form *apimodel.AccountDeleteRequest) gtserror.WithCode {
+	if form.Password == "" {
+		return gtserror.NewErrorBadRequest(errors.New("no password provided"), "no password provided")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(form.Password)); err != nil {
+		return gtserror.NewErrorForbidden(errors.New("password was incorrect"), "password was incorrect")
+	}
+
+	// nobody should be able to sign in as this user while the account is being deleted
+	user.Disabled = true
+	if err := p.db.UpdateByID(ctx, user.ID, user); err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("DeleteLocal: error disabling user %s: %s", user.ID, err))
+	}
+
+	// mark the account as waiting for deletion, so that the deletion can be picked up again if we're interrupted
+	account.DeletionRequestedAt = time.Now()
+	account.SuspensionOrigin = account.ID
+	if _, err := p.db.UpdateAccount(ctx, account); err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("DeleteLocal: error marking account %s for deletion: %s", account.ID, err))
+	}
+
+	// the actual deletion can take a while, so process it asynchronously
+	p.fromClientAPI <- gtsmodel.FromClientAPI{
+		APObjectType:   gtsmodel.ActivityStreamsPerson,
+		APActivityType: gtsmodel.ActivityStreamsDelete,
+		GTSModel:       account,
+		OriginAccount:  account,
+		TargetAccount:  account,
+	}
+
+	return nil
+}
+
+// federateAccountDelete sends a Delete of the given local account to the inbox of every remote account we know about,
+// not just the followers of the account, so that as many servers as possible will remove their copies of it.
+func (p *processor) federateAccountDelete(ctx context.Context, account *gtsmodel.Account) error {
+	inboxURIs, err := p.db.GetRemoteInboxURIs(ctx)
+	if err != nil {
+		return fmt.Errorf("federateAccountDelete: error getting inbox uris: %s", err)
+	}
+
+	if len(inboxURIs) == 0 {
+		// nobody to tell
+		return nil
+	}
+
+	recipients := []*url.URL{}
+	for _, i := range inboxURIs {
+		inboxIRI, err := url.Parse(i)
+		if err != nil {
+			p.log.Debugf("federateAccountDelete: error parsing inbox uri %s: %s", i, err)
+			continue
+		}
+		recipients = append(recipients, inboxIRI)
+	}
+
+	delete, err := p.tc.AccountToASDelete(ctx, account)
+	if err != nil {
+		return fmt.Errorf("federateAccountDelete: error converting account delete to AS: %s", err)
+	}
+
+	m, err := streams.Serialize(delete)
+	if err != nil {
+		return fmt.Errorf("federateAccountDelete: error serializing delete: %s", err)
+	}
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("federateAccountDelete: error marshalling delete: %s", err)
+	}
+
+	t, err := p.federator.TransportController().NewTransportForUsername(ctx, account.Username)
+	if err != nil {
+		return fmt.Errorf("federateAccountDelete: error creating transport: %s", err)
+	}
+
+	return t.BatchDeliver(ctx, b, recipients)
+}
@@ -0,0 +1,150 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func (p *processor) Move(ctx context.Context, account *gtsmodel.Account, user *gtsmodel.User, form *apimodel.AccountMoveRequest) (*apimodel.Account, gtserror.WithCode) {
+	if form.Password == "" {
+		return nil, gtserror.NewErrorBadRequest(errors.New("no password provided"), "no password provided")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(form.Password)); err != nil {
+		return nil, gtserror.NewErrorForbidden(errors.New("password was incorrect"), "password was incorrect")
+	}
+
+	if account.MovedToAccountID != "" {
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New("account has already moved"), "account has already moved")
+	}
+
+	targetURI, err := url.Parse(form.Target)
+	if err != nil || !targetURI.IsAbs() {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("Move: target %s was not a valid uri", form.Target), "target was not a valid account uri")
+	}
+
+	if targetURI.String() == account.URI {
+		return nil, gtserror.NewErrorBadRequest(errors.New("Move: account tried to move to itself"), "an account can't move to itself")
+	}
+
+	target, errWithCode := p.getMoveTarget(ctx, account, targetURI)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if target.MovedToAccountID != "" {
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New("target account has already moved"), "target account has already moved")
+	}
+
+	if !IsAlias(target, account) {
+		return nil, gtserror.NewErrorUnprocessableEntity(
+			fmt.Errorf("Move: target account %s doesn't list %s as an alias", target.URI, account.URI),
+			"target account must list this account in its aliases (also_known_as) before this account can move to it",
+		)
+	}
+
+	account.MovedToAccountID = target.ID
+	updatedAccount, err := p.db.UpdateAccount(ctx, account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("Move: error updating account %s: %s", account.ID, err))
+	}
+
+	// telling followers about the move and following the target on their behalf can take a while, so do it asynchronously
+	p.fromClientAPI <- gtsmodel.FromClientAPI{
+		APObjectType:   gtsmodel.ActivityStreamsPerson,
+		APActivityType: gtsmodel.ActivityStreamsMove,
+		GTSModel:       updatedAccount,
+		OriginAccount:  updatedAccount,
+		TargetAccount:  target,
+	}
+
+	acctSensitive, err := p.tc.AccountToMastoSensitive(ctx, updatedAccount)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("Move: could not convert account into mastosensitive account: %s", err))
+	}
+	return acctSensitive, nil
+}
+
+func (p *processor) FollowMoved(ctx context.Context, follower *gtsmodel.Account, origin *gtsmodel.Account, target *gtsmodel.Account) error {
+	if follower.ID == target.ID {
+		// the target can't follow itself
+		return nil
+	}
+
+	follow := &gtsmodel.Follow{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: follower.ID}, {Key: "target_account_id", Value: origin.ID}}, follow); err != nil {
+		if err == db.ErrNoEntries {
+			// follower doesn't actually follow origin, so there's nothing to move
+			return nil
+		}
+		return fmt.Errorf("FollowMoved: error getting follow of %s by %s: %s", origin.ID, follower.ID, err)
+	}
+
+	// follow the target with the same settings as the follow of the origin
+	if _, errWithCode := p.FollowCreate(ctx, follower, &apimodel.AccountFollowRequest{
+		ID:      target.ID,
+		Reblogs: &follow.ShowReblogs,
+		Notify:  &follow.Notify,
+	}); errWithCode != nil {
+		return fmt.Errorf("FollowMoved: error following %s on behalf of %s: %s", target.ID, follower.ID, errWithCode)
+	}
+
+	return nil
+}
+
+// getMoveTarget gets the account at the given uri, making sure that we have an up to date copy of
+// its aliases if it's a remote account.
+func (p *processor) getMoveTarget(ctx context.Context, account *gtsmodel.Account, targetURI *url.URL) (*gtsmodel.Account, gtserror.WithCode) {
+	if targetURI.Host == p.config.Host {
+		target, err := p.db.GetAccountByURI(ctx, targetURI.String())
+		if err != nil {
+			if err == db.ErrNoEntries {
+				return nil, gtserror.NewErrorNotFound(fmt.Errorf("Move: target %s not found", targetURI), "target account not found")
+			}
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("Move: error getting target %s: %s", targetURI, err))
+		}
+		return target, nil
+	}
+
+	target, _, err := p.federator.GetRemoteAccount(ctx, account.Username, targetURI, true)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("Move: error dereferencing target %s: %s", targetURI, err), "target account not found")
+	}
+	return target, nil
+}
+
+// IsAlias returns true if target lists origin in its aliases, which it has to before origin can move to it.
+func IsAlias(target *gtsmodel.Account, origin *gtsmodel.Account) bool {
+	for _, alias := range target.AlsoKnownAs {
+		if alias == origin.URI {
+			return true
+		}
+	}
+	return false
+}
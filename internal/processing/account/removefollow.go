@@ -73,6 +73,9 @@ func (p *processor) FollowRemove(ctx context.Context, requestingAccount *gtsmode
 		if err := p.db.DeleteByID(ctx, f.ID, f); err != nil {
 			return nil, gtserror.NewErrorInternalError(fmt.Errorf("AccountFollowRemove: error removing follow from db: %s", err))
 		}
+		if err := p.db.DeleteListEntriesForFollowID(ctx, f.ID); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("AccountFollowRemove: error removing list entries from db: %s", err))
+		}
 		fChanged = true
 	}
 
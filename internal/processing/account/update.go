@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"io"
 	"mime/multipart"
+	"net/url"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -33,9 +34,24 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
-func (p *processor) Update(ctx context.Context, account *gtsmodel.Account, form *apimodel.UpdateCredentialsRequest) (*apimodel.Account, error) {
+// maxAliases is the maximum number of other accounts that an account can say it's also known as.
+const maxAliases = 5
+
+func (p *processor) Update(ctx context.Context, account *gtsmodel.Account, user *gtsmodel.User, form *apimodel.UpdateCredentialsRequest) (*apimodel.Account, error) {
 	l := p.log.WithField("func", "AccountUpdate")
 
+	// aliases go first, since the whole account model is updated for them
+	if form.AlsoKnownAs != nil {
+		aliases, err := p.parseAliases(account, *form.AlsoKnownAs)
+		if err != nil {
+			return nil, err
+		}
+		account.AlsoKnownAs = aliases
+		if _, err := p.db.UpdateAccount(ctx, account); err != nil {
+			return nil, fmt.Errorf("error updating also known as: %s", err)
+		}
+	}
+
 	if form.Discoverable != nil {
 		if err := p.db.UpdateOneByID(ctx, account.ID, "discoverable", *form.Discoverable, &gtsmodel.Account{}); err != nil {
 			return nil, fmt.Errorf("error updating discoverable: %s", err)
@@ -101,7 +117,7 @@ func (p *processor) Update(ctx context.Context, account *gtsmodel.Account, form
 		}
 
 		if form.Source.Sensitive != nil {
-			if err := p.db.UpdateOneByID(ctx, account.ID, "locked", *form.Locked, &gtsmodel.Account{}); err != nil {
+			if err := p.db.UpdateOneByID(ctx, account.ID, "sensitive", *form.Source.Sensitive, &gtsmodel.Account{}); err != nil {
 				return nil, err
 			}
 		}
@@ -110,7 +126,48 @@ func (p *processor) Update(ctx context.Context, account *gtsmodel.Account, form
 			if err := util.ValidatePrivacy(*form.Source.Privacy); err != nil {
 				return nil, err
 			}
-			if err := p.db.UpdateOneByID(ctx, account.ID, "privacy", *form.Source.Privacy, &gtsmodel.Account{}); err != nil {
+			privacy := p.tc.MastoVisToVis(apimodel.Visibility(*form.Source.Privacy))
+			if err := p.db.UpdateOneByID(ctx, account.ID, "privacy", privacy, &gtsmodel.Account{}); err != nil {
+				return nil, err
+			}
+		}
+
+		// reading preferences are only relevant to the local user, so they're stored there rather than on the account
+		if form.Source.ExpandMedia != nil {
+			if err := util.ValidateExpandMedia(*form.Source.ExpandMedia); err != nil {
+				return nil, err
+			}
+			if err := p.db.UpdateOneByID(ctx, user.ID, "expand_media", *form.Source.ExpandMedia, &gtsmodel.User{}); err != nil {
+				return nil, err
+			}
+		}
+
+		if form.Source.ExpandSpoilers != nil {
+			if err := p.db.UpdateOneByID(ctx, user.ID, "expand_spoilers", *form.Source.ExpandSpoilers, &gtsmodel.User{}); err != nil {
+				return nil, err
+			}
+		}
+
+		if form.Source.EmailNotifyFollow != nil {
+			if err := p.db.UpdateOneByID(ctx, user.ID, "email_notify_follow", *form.Source.EmailNotifyFollow, &gtsmodel.User{}); err != nil {
+				return nil, err
+			}
+		}
+
+		if form.Source.EmailNotifyFollowRequest != nil {
+			if err := p.db.UpdateOneByID(ctx, user.ID, "email_notify_follow_request", *form.Source.EmailNotifyFollowRequest, &gtsmodel.User{}); err != nil {
+				return nil, err
+			}
+		}
+
+		if form.Source.EmailNotifyMention != nil {
+			if err := p.db.UpdateOneByID(ctx, user.ID, "email_notify_mention", *form.Source.EmailNotifyMention, &gtsmodel.User{}); err != nil {
+				return nil, err
+			}
+		}
+
+		if form.Source.EmailNotifyDirect != nil {
+			if err := p.db.UpdateOneByID(ctx, user.ID, "email_notify_direct", *form.Source.EmailNotifyDirect, &gtsmodel.User{}); err != nil {
 				return nil, err
 			}
 		}
@@ -201,3 +258,28 @@ func (p *processor) UpdateHeader(ctx context.Context, header *multipart.FileHead
 
 	return headerInfo, f.Close()
 }
+
+// parseAliases checks the given alias URIs for validity, and returns them without duplicates.
+func (p *processor) parseAliases(account *gtsmodel.Account, aliases []string) ([]string, error) {
+	if len(aliases) > maxAliases {
+		return nil, fmt.Errorf("no more than %d aliases are allowed", maxAliases)
+	}
+
+	parsed := []string{}
+	seen := make(map[string]bool)
+	for _, alias := range aliases {
+		aliasURI, err := url.Parse(alias)
+		if err != nil || !aliasURI.IsAbs() || (aliasURI.Scheme != "https" && aliasURI.Scheme != "http") {
+			return nil, fmt.Errorf("alias %s is not a valid account uri", alias)
+		}
+		if aliasURI.String() == account.URI {
+			return nil, errors.New("an account can't be an alias of itself")
+		}
+		if seen[aliasURI.String()] {
+			continue
+		}
+		seen[aliasURI.String()] = true
+		parsed = append(parsed, aliasURI.String())
+	}
+	return parsed, nil
+}
@@ -0,0 +1,306 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// maxAccountArchiveImportSize is the largest zip file, in bytes, that can be uploaded to be imported.
+const maxAccountArchiveImportSize = 256 << 20
+
+func (p *processor) AccountArchiveExportCreate(ctx context.Context, authed *oauth.Auth) (*apimodel.AccountArchive, gtserror.WithCode) {
+	// only let one export run at a time per account, since they can be expensive
+	archives, err := p.db.GetAccountArchives(ctx, authed.Account.ID, gtsmodel.AccountArchiveTypeExport)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("AccountArchiveExportCreate: error getting archives: %s", err))
+	}
+	for _, a := range archives {
+		if !a.Finished() {
+			return nil, gtserror.NewErrorUnprocessableEntity(fmt.Errorf("archive %s is still being exported", a.ID), "an archive of this account is already being exported")
+		}
+	}
+
+	archiveID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	archive := &gtsmodel.AccountArchive{
+		ID:        archiveID,
+		AccountID: authed.Account.ID,
+		Type:      gtsmodel.AccountArchiveTypeExport,
+	}
+
+	if err := p.db.PutAccountArchive(ctx, archive); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("AccountArchiveExportCreate: error putting archive in the db: %s", err))
+	}
+
+	p.startAccountArchive(archive.ID)
+
+	return p.accountArchiveToMasto(ctx, archive)
+}
+
+func (p *processor) AccountArchiveImportCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountArchiveImportRequest) (*apimodel.AccountArchive, gtserror.WithCode) {
+	if form.Data == nil {
+		return nil, gtserror.NewErrorBadRequest(errors.New("no data provided"), "no archive provided to import")
+	}
+
+	if form.Data.Size > maxAccountArchiveImportSize {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("archive size %d exceeds maximum %d", form.Data.Size, maxAccountArchiveImportSize), fmt.Sprintf("archive is too big; the maximum is %d bytes", maxAccountArchiveImportSize))
+	}
+
+	f, err := form.Data.Open()
+	if err != nil {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("AccountArchiveImportCreate: error opening attachment: %s", err))
+	}
+	defer f.Close()
+
+	buf := new(bytes.Buffer)
+	if _, err := io.Copy(buf, f); err != nil {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("AccountArchiveImportCreate: error reading attachment: %s", err))
+	}
+
+	// make sure this is something we'll be able to import before we store it
+	z, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("AccountArchiveImportCreate: error reading zip: %s", err), "archive is not a valid zip file")
+	}
+	if !zipContains(z, archiveOutboxFile) {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("AccountArchiveImportCreate: zip has no %s", archiveOutboxFile), fmt.Sprintf("archive contains no %s", archiveOutboxFile))
+	}
+
+	archiveID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	archive := &gtsmodel.AccountArchive{
+		ID:        archiveID,
+		AccountID: authed.Account.ID,
+		Type:      gtsmodel.AccountArchiveTypeImport,
+		Path:      accountArchivePath(authed.Account.ID, archiveID),
+		Size:      buf.Len(),
+	}
+
+	// the import happens in the background, so keep the uploaded file around until then
+	if err := p.storage.StoreFileAt(archive.Path, buf.Bytes()); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("AccountArchiveImportCreate: error storing archive: %s", err))
+	}
+
+	if err := p.db.PutAccountArchive(ctx, archive); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("AccountArchiveImportCreate: error putting archive in the db: %s", err))
+	}
+
+	p.startAccountArchive(archive.ID)
+
+	return p.accountArchiveToMasto(ctx, archive)
+}
+
+func (p *processor) AccountArchiveGet(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AccountArchive, gtserror.WithCode) {
+	archive, errWithCode := p.getOwnAccountArchive(ctx, authed.Account, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.accountArchiveToMasto(ctx, archive)
+}
+
+func (p *processor) AccountArchiveDownload(ctx context.Context, authed *oauth.Auth, id string) ([]byte, gtserror.WithCode) {
+	archive, errWithCode := p.getOwnAccountArchive(ctx, authed.Account, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if archive.Type != gtsmodel.AccountArchiveTypeExport {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("archive %s is not an export", id))
+	}
+
+	if !archive.Finished() || archive.Error != "" {
+		return nil, gtserror.NewErrorUnprocessableEntity(fmt.Errorf("archive %s is not ready", id), "this archive isn't ready to be downloaded")
+	}
+
+	b, err := p.storage.RetrieveFileFrom(archive.Path)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("AccountArchiveDownload: error retrieving archive: %s", err))
+	}
+
+	return b, nil
+}
+
+// getOwnAccountArchive gets the archive with the given id, making sure that it belongs to the given account.
+func (p *processor) getOwnAccountArchive(ctx context.Context, account *gtsmodel.Account, id string) (*gtsmodel.AccountArchive, gtserror.WithCode) {
+	archive, err := p.db.GetAccountArchiveByID(ctx, id)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(fmt.Errorf("archive %s not found", id))
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if archive.AccountID != account.ID {
+		// don't let on that someone else's archive exists
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("archive %s does not belong to account %s", id, account.ID))
+	}
+
+	return archive, nil
+}
+
+func (p *processor) accountArchiveToMasto(ctx context.Context, archive *gtsmodel.AccountArchive) (*apimodel.AccountArchive, gtserror.WithCode) {
+	apiArchive, err := p.tc.AccountArchiveToMasto(ctx, archive)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting archive: %s", err))
+	}
+	return apiArchive, nil
+}
+
+// accountArchivePath returns the storage path for the zip file of an account archive.
+func accountArchivePath(accountID string, archiveID string) string {
+	return fmt.Sprintf("%s/archive/%s.zip", accountID, archiveID)
+}
+
+// startAccountArchive works on the given account archive in the background, so that big archives don't hold up the request that created them.
+func (p *processor) startAccountArchive(archiveID string) {
+	go func() {
+		if err := p.runAccountArchive(context.Background(), archiveID); err != nil {
+			p.log.Errorf("startAccountArchive: error running account archive %s: %s", archiveID, err)
+		}
+	}()
+}
+
+// resumeAccountArchives restarts any account archives that were interrupted while we were down.
+func (p *processor) resumeAccountArchives(ctx context.Context) error {
+	archives, err := p.db.GetUnfinishedAccountArchives(ctx)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil
+		}
+		return fmt.Errorf("resumeAccountArchives: error getting unfinished account archives: %s", err)
+	}
+
+	for _, a := range archives {
+		p.startAccountArchive(a.ID)
+	}
+
+	return nil
+}
+
+// runAccountArchive exports or imports the given account archive, unless that archive is already being worked on.
+//
+// If the archive can't be processed, the reason is stored on the archive for the account to see, and no error is returned.
+func (p *processor) runAccountArchive(ctx context.Context, archiveID string) error {
+	p.accountArchivesLock.Lock()
+	if p.accountArchives[archiveID] {
+		p.accountArchivesLock.Unlock()
+		return nil
+	}
+	p.accountArchives[archiveID] = true
+	p.accountArchivesLock.Unlock()
+
+	defer func() {
+		p.accountArchivesLock.Lock()
+		delete(p.accountArchives, archiveID)
+		p.accountArchivesLock.Unlock()
+	}()
+
+	archive, err := p.db.GetAccountArchiveByID(ctx, archiveID)
+	if err != nil {
+		return fmt.Errorf("runAccountArchive: error getting account archive: %s", err)
+	}
+
+	account, err := p.db.GetAccountByID(ctx, archive.AccountID)
+	if err != nil {
+		return fmt.Errorf("runAccountArchive: error getting account %s: %s", archive.AccountID, err)
+	}
+
+	switch archive.Type {
+	case gtsmodel.AccountArchiveTypeExport:
+		err = p.exportAccountArchive(ctx, account, archive)
+	case gtsmodel.AccountArchiveTypeImport:
+		err = p.importAccountArchive(ctx, account, archive)
+	default:
+		err = fmt.Errorf("unknown archive type %s", archive.Type)
+	}
+	if err != nil {
+		p.log.Errorf("runAccountArchive: error processing account archive %s: %s", archive.ID, err)
+		archive.Error = err.Error()
+	}
+
+	archive.FinishedAt = time.Now()
+	if err := p.db.UpdateAccountArchive(ctx, archive); err != nil {
+		return fmt.Errorf("runAccountArchive: error saving finished archive: %s", err)
+	}
+
+	if archive.Type == gtsmodel.AccountArchiveTypeExport && archive.Error == "" {
+		// the new export replaces any older ones, so clean those up
+		p.deleteOldAccountArchives(ctx, archive)
+	}
+
+	return nil
+}
+
+// deleteOldAccountArchives removes any finished exports of the account that made the given archive, apart from the given archive itself.
+func (p *processor) deleteOldAccountArchives(ctx context.Context, archive *gtsmodel.AccountArchive) {
+	archives, err := p.db.GetAccountArchives(ctx, archive.AccountID, gtsmodel.AccountArchiveTypeExport)
+	if err != nil {
+		if err != db.ErrNoEntries {
+			p.log.Errorf("deleteOldAccountArchives: error getting archives: %s", err)
+		}
+		return
+	}
+
+	for _, a := range archives {
+		if a.ID == archive.ID || !a.Finished() {
+			continue
+		}
+
+		if a.Path != "" {
+			if err := p.storage.RemoveFileAt(a.Path); err != nil {
+				p.log.Errorf("deleteOldAccountArchives: error removing file of archive %s: %s", a.ID, err)
+			}
+		}
+
+		if err := p.db.DeleteAccountArchiveByID(ctx, a.ID); err != nil {
+			p.log.Errorf("deleteOldAccountArchives: error deleting archive %s: %s", a.ID, err)
+		}
+	}
+}
+
+// zipContains returns true if the given zip has a file with the given name.
+func zipContains(z *zip.Reader, name string) bool {
+	for _, f := range z.File {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
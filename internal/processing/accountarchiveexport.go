@@ -0,0 +1,286 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// names of the files in an account archive, which follow the layout of mastodon archives
+const (
+	archiveActorFile     = "actor.json"
+	archiveOutboxFile    = "outbox.json"
+	archiveLikesFile     = "likes.json"
+	archiveBookmarksFile = "bookmarks.json"
+	archiveMediaDir      = "media_attachments"
+)
+
+// exportAccountArchive generates a zip of the given account's actor, statuses, media, likes, and bookmarks,
+// and stores it at the path of the given archive.
+func (p *processor) exportAccountArchive(ctx context.Context, account *gtsmodel.Account, archive *gtsmodel.AccountArchive) error {
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+
+	if err := p.exportArchiveActor(ctx, w, account); err != nil {
+		return err
+	}
+
+	statuses, err := p.exportArchiveOutbox(ctx, w, account)
+	if err != nil {
+		return err
+	}
+	archive.Statuses = statuses
+
+	faved, _, _, err := p.db.GetFavedTimeline(ctx, account.ID, "", "", 0)
+	if err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("error getting faved statuses: %s", err)
+	}
+	if err := writeArchiveJSON(w, archiveLikesFile, archiveCollection(archiveLikesFile, statusURIs(faved))); err != nil {
+		return err
+	}
+
+	bookmarked, _, _, err := p.db.GetBookmarkedTimeline(ctx, account.ID, "", "", 0)
+	if err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("error getting bookmarked statuses: %s", err)
+	}
+	if err := writeArchiveJSON(w, archiveBookmarksFile, archiveCollection(archiveBookmarksFile, statusURIs(bookmarked))); err != nil {
+		return err
+	}
+
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("error closing zip: %s", err)
+	}
+
+	archive.Path = accountArchivePath(account.ID, archive.ID)
+	archive.Size = buf.Len()
+	if err := p.storage.StoreFileAt(archive.Path, buf.Bytes()); err != nil {
+		return fmt.Errorf("error storing archive: %s", err)
+	}
+
+	return nil
+}
+
+// exportArchiveActor writes the actor of the given account, along with its avatar and header, into the given zip.
+func (p *processor) exportArchiveActor(ctx context.Context, w *zip.Writer, account *gtsmodel.Account) error {
+	person, err := p.tc.AccountToAS(ctx, account)
+	if err != nil {
+		return fmt.Errorf("error converting account to as: %s", err)
+	}
+
+	actor, err := streams.Serialize(person)
+	if err != nil {
+		return fmt.Errorf("error serializing actor: %s", err)
+	}
+
+	urls := map[string]string{}
+	for name, attachmentID := range map[string]string{"avatar": account.AvatarMediaAttachmentID, "header": account.HeaderMediaAttachmentID} {
+		if attachmentID == "" {
+			continue
+		}
+		if err := p.exportArchiveMedia(ctx, w, attachmentID, name, urls); err != nil {
+			p.log.Errorf("exportArchiveActor: error exporting %s: %s", name, err)
+		}
+	}
+	rewriteArchiveURLs(actor, urls)
+
+	return writeArchiveJSON(w, archiveActorFile, actor)
+}
+
+// exportArchiveOutbox writes a Create for each of the given account's statuses, oldest first, into the outbox of the given zip,
+// along with the media attached to those statuses. It returns the number of statuses written.
+func (p *processor) exportArchiveOutbox(ctx context.Context, w *zip.Writer, account *gtsmodel.Account) (int, error) {
+	statuses, err := p.db.GetAccountStatuses(ctx, account.ID, 0, false, "", false, false)
+	if err != nil && err != db.ErrNoEntries {
+		return 0, fmt.Errorf("error getting statuses: %s", err)
+	}
+
+	items := []interface{}{}
+	for i := len(statuses) - 1; i >= 0; i-- {
+		s := statuses[i]
+		if s.BoostOfID != "" {
+			// boosts aren't the account's own statuses, so they don't belong in the archive
+			continue
+		}
+
+		// get the status again with its attachments, mentions, and so on populated
+		s, err := p.db.GetStatusByID(ctx, s.ID)
+		if err != nil {
+			p.log.Errorf("exportArchiveOutbox: skipping status %s: %s", statuses[i].ID, err)
+			continue
+		}
+
+		create, err := p.statusToArchiveCreate(ctx, w, account, s)
+		if err != nil {
+			p.log.Errorf("exportArchiveOutbox: skipping status %s: %s", s.ID, err)
+			continue
+		}
+		items = append(items, create)
+	}
+
+	if err := writeArchiveJSON(w, archiveOutboxFile, archiveCollection(archiveOutboxFile, items)); err != nil {
+		return 0, err
+	}
+
+	return len(items), nil
+}
+
+// statusToArchiveCreate serializes the given status as a Create, writing any media attached to it into the given zip,
+// and pointing the attachments of the serialized status at the media in the zip.
+func (p *processor) statusToArchiveCreate(ctx context.Context, w *zip.Writer, account *gtsmodel.Account, s *gtsmodel.Status) (map[string]interface{}, error) {
+	// statuses with a poll attached are represented as a Question rather than a Note
+	var create vocab.ActivityStreamsCreate
+	if s.PollID != "" {
+		asQuestion, err := p.tc.StatusToASQuestion(ctx, s)
+		if err != nil {
+			return nil, fmt.Errorf("error converting status to as question: %s", err)
+		}
+		create, err = p.tc.WrapQuestionInCreate(asQuestion, account)
+		if err != nil {
+			return nil, fmt.Errorf("error wrapping question in create: %s", err)
+		}
+	} else {
+		asStatus, err := p.tc.StatusToAS(ctx, s)
+		if err != nil {
+			return nil, fmt.Errorf("error converting status to as: %s", err)
+		}
+		create, err = p.tc.WrapNoteInCreate(asStatus, account)
+		if err != nil {
+			return nil, fmt.Errorf("error wrapping note in create: %s", err)
+		}
+	}
+
+	m, err := streams.Serialize(create)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing create: %s", err)
+	}
+
+	urls := map[string]string{}
+	for _, attachmentID := range s.AttachmentIDs {
+		if err := p.exportArchiveMedia(ctx, w, attachmentID, path.Join(archiveMediaDir, attachmentID), urls); err != nil {
+			p.log.Errorf("statusToArchiveCreate: error exporting attachment %s: %s", attachmentID, err)
+		}
+	}
+	rewriteArchiveURLs(m, urls)
+
+	return m, nil
+}
+
+// exportArchiveMedia writes the original file of the given attachment into the given zip under name, plus the file's extension,
+// and records the attachment's URL against the name it was written under in urls.
+func (p *processor) exportArchiveMedia(ctx context.Context, w *zip.Writer, attachmentID string, name string, urls map[string]string) error {
+	attachment, err := p.db.GetAttachmentByID(ctx, attachmentID)
+	if err != nil {
+		return fmt.Errorf("error getting attachment: %s", err)
+	}
+
+	b, err := p.storage.RetrieveFileFrom(attachment.File.Path)
+	if err != nil {
+		return fmt.Errorf("error retrieving file %s: %s", attachment.File.Path, err)
+	}
+
+	name = name + path.Ext(attachment.File.Path)
+	f, err := w.Create(name)
+	if err != nil {
+		return fmt.Errorf("error creating %s in zip: %s", name, err)
+	}
+	if _, err := f.Write(b); err != nil {
+		return fmt.Errorf("error writing %s to zip: %s", name, err)
+	}
+
+	urls[attachment.URL] = name
+	return nil
+}
+
+// rewriteArchiveURLs replaces any string anywhere in the given serialized activitystreams value that's a key of urls with its value.
+func rewriteArchiveURLs(v interface{}, urls map[string]string) {
+	if len(urls) == 0 {
+		return
+	}
+
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, e := range t {
+			if s, ok := e.(string); ok {
+				if replacement, ok := urls[s]; ok {
+					t[k] = replacement
+				}
+				continue
+			}
+			rewriteArchiveURLs(e, urls)
+		}
+	case []interface{}:
+		for i, e := range t {
+			if s, ok := e.(string); ok {
+				if replacement, ok := urls[s]; ok {
+					t[i] = replacement
+				}
+				continue
+			}
+			rewriteArchiveURLs(e, urls)
+		}
+	}
+}
+
+// archiveCollection returns an activitystreams ordered collection with the given id and items, ready to be written into an archive.
+func archiveCollection(id string, items []interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"@context":     "https://www.w3.org/ns/activitystreams",
+		"id":           id,
+		"type":         "OrderedCollection",
+		"totalItems":   len(items),
+		"orderedItems": items,
+	}
+}
+
+// statusURIs returns the URIs of the given statuses.
+func statusURIs(statuses []*gtsmodel.Status) []interface{} {
+	uris := []interface{}{}
+	for _, s := range statuses {
+		uris = append(uris, s.URI)
+	}
+	return uris
+}
+
+// writeArchiveJSON writes v into the given zip as a json file with the given name.
+func writeArchiveJSON(w *zip.Writer, name string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("error marshalling %s: %s", name, err)
+	}
+
+	f, err := w.Create(name)
+	if err != nil {
+		return fmt.Errorf("error creating %s in zip: %s", name, err)
+	}
+	if _, err := f.Write(b); err != nil {
+		return fmt.Errorf("error writing %s to zip: %s", name, err)
+	}
+
+	return nil
+}
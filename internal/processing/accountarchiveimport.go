@@ -0,0 +1,411 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// accountArchiveSaveEvery is how many statuses get imported between saving the progress of an archive import.
+const accountArchiveSaveEvery = 25
+
+// maxArchiveJSONSize is the largest uncompressed size, in bytes, of a json file in an archive that we'll read when importing.
+const maxArchiveJSONSize = 128 << 20
+
+// archiveCollectionFile models the parts of an ordered collection file in an archive that we care about when importing.
+type archiveCollectionFile struct {
+	OrderedItems []json.RawMessage `json:"orderedItems"`
+}
+
+// archiveActivity models the parts of an activity in the outbox of an archive that we care about when importing.
+type archiveActivity struct {
+	Type   string          `json:"type"`
+	Object json.RawMessage `json:"object"`
+}
+
+// archiveObject models the parts of a note or question in the outbox of an archive that we care about when importing.
+type archiveObject struct {
+	ID         string             `json:"id"`
+	Type       string             `json:"type"`
+	Published  string             `json:"published"`
+	Summary    string             `json:"summary"`
+	Content    string             `json:"content"`
+	Sensitive  bool               `json:"sensitive"`
+	To         archiveStrings     `json:"to"`
+	Cc         archiveStrings     `json:"cc"`
+	Attachment archiveAttachments `json:"attachment"`
+}
+
+// archiveAttachment models the parts of a media attachment of a status in an archive that we care about when importing.
+type archiveAttachment struct {
+	URL  string `json:"url"`
+	Name string `json:"name"`
+}
+
+// archiveAttachments is a json value that can be either a single attachment or an array of them.
+type archiveAttachments []archiveAttachment
+
+func (a *archiveAttachments) UnmarshalJSON(b []byte) error {
+	single := archiveAttachment{}
+	if err := json.Unmarshal(b, &single); err == nil {
+		*a = []archiveAttachment{single}
+		return nil
+	}
+
+	multiple := []archiveAttachment{}
+	if err := json.Unmarshal(b, &multiple); err != nil {
+		return err
+	}
+	*a = multiple
+	return nil
+}
+
+// archiveStrings is a json value that can be either a single string or an array of strings.
+type archiveStrings []string
+
+func (a *archiveStrings) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*a = []string{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(b, &multiple); err != nil {
+		return err
+	}
+	*a = multiple
+	return nil
+}
+
+// importAccountArchive recreates the statuses in the outbox of the given archive as local history of the given account,
+// and restores bookmarks of statuses that are known to this instance. Nothing is federated, and nobody is notified.
+func (p *processor) importAccountArchive(ctx context.Context, account *gtsmodel.Account, archive *gtsmodel.AccountArchive) error {
+	b, err := p.storage.RetrieveFileFrom(archive.Path)
+	if err != nil {
+		return fmt.Errorf("error retrieving archive: %s", err)
+	}
+
+	z, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return fmt.Errorf("error reading zip: %s", err)
+	}
+
+	files := make(map[string]*zip.File, len(z.File))
+	for _, f := range z.File {
+		files[f.Name] = f
+	}
+
+	// the followers collection of the original actor tells us which statuses were followers-only
+	var followersURI string
+	if f, ok := files[archiveActorFile]; ok {
+		actor := struct {
+			Followers string `json:"followers"`
+		}{}
+		if err := readArchiveJSON(f, &actor); err != nil {
+			p.log.Debugf("importAccountArchive: error reading %s: %s", archiveActorFile, err)
+		}
+		followersURI = actor.Followers
+	}
+
+	outbox := &archiveCollectionFile{}
+	f, ok := files[archiveOutboxFile]
+	if !ok {
+		return fmt.Errorf("archive contains no %s", archiveOutboxFile)
+	}
+	if err := readArchiveJSON(f, outbox); err != nil {
+		return fmt.Errorf("error reading %s: %s", archiveOutboxFile, err)
+	}
+
+	archive.Statuses = 0
+	unsaved := 0
+	for _, item := range outbox.OrderedItems {
+		activity := &archiveActivity{}
+		if err := json.Unmarshal(item, activity); err != nil || activity.Type != "Create" {
+			continue
+		}
+
+		object := &archiveObject{}
+		if err := json.Unmarshal(activity.Object, object); err != nil {
+			// probably just the IRI of an object, which we can't do anything with
+			continue
+		}
+		if object.Type != "Note" && object.Type != "Question" {
+			continue
+		}
+
+		imported, err := p.importArchiveStatus(ctx, account, files, followersURI, object)
+		if err != nil {
+			p.log.Errorf("importAccountArchive: error importing %s: %s", object.ID, err)
+			continue
+		}
+		if !imported {
+			continue
+		}
+
+		archive.Statuses = archive.Statuses + 1
+		unsaved++
+		if unsaved >= accountArchiveSaveEvery {
+			if err := p.db.UpdateAccountArchive(ctx, archive); err != nil {
+				return fmt.Errorf("error saving progress: %s", err)
+			}
+			unsaved = 0
+		}
+	}
+
+	if f, ok := files[archiveBookmarksFile]; ok {
+		if err := p.importArchiveBookmarks(ctx, account, f); err != nil {
+			p.log.Errorf("importAccountArchive: error importing bookmarks: %s", err)
+		}
+	}
+
+	// we're done with the uploaded file now
+	if err := p.storage.RemoveFileAt(archive.Path); err != nil {
+		p.log.Errorf("importAccountArchive: error removing archive %s: %s", archive.Path, err)
+	} else {
+		archive.Path = ""
+	}
+
+	return nil
+}
+
+// importArchiveStatus recreates the given object from an archive as a status of the given account, along with its media.
+// It returns false if the status was skipped because it already exists.
+func (p *processor) importArchiveStatus(ctx context.Context, account *gtsmodel.Account, files map[string]*zip.File, followersURI string, object *archiveObject) (bool, error) {
+	published, err := time.Parse(time.RFC3339, object.Published)
+	if err != nil {
+		return false, fmt.Errorf("error parsing published time %s: %s", object.Published, err)
+	}
+
+	// don't import the same status twice, either because it came from this instance in the first place,
+	// or because this archive (or one like it) has been imported already
+	if object.ID != "" {
+		if _, err := p.db.GetStatusByURI(ctx, object.ID); err == nil {
+			return false, nil
+		} else if err != db.ErrNoEntries {
+			return false, fmt.Errorf("error checking for status %s: %s", object.ID, err)
+		}
+	}
+	existing := &gtsmodel.Status{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}, {Key: "created_at", Value: published}}, existing); err == nil {
+		return false, nil
+	} else if err != db.ErrNoEntries {
+		return false, fmt.Errorf("error checking for status created at %s: %s", published, err)
+	}
+
+	statusID, err := id.NewULIDFromTime(published)
+	if err != nil {
+		return false, err
+	}
+	uris := util.GenerateURIsForAccount(account.Username, p.config.Protocol, p.config.Host)
+
+	visibility := archiveVisibility(object, followersURI)
+	status := &gtsmodel.Status{
+		ID:                  statusID,
+		URI:                 fmt.Sprintf("%s/%s", uris.StatusesURI, statusID),
+		URL:                 fmt.Sprintf("%s/%s", uris.StatusesURL, statusID),
+		Content:             text.SanitizeHTML(object.Content),
+		CreatedAt:           published,
+		UpdatedAt:           published,
+		Local:               true,
+		AccountID:           account.ID,
+		AccountURI:          account.URI,
+		ContentWarning:      text.RemoveHTML(object.Summary),
+		Visibility:          visibility,
+		Sensitive:           object.Sensitive,
+		Language:            account.Language,
+		ActivityStreamsType: gtsmodel.ActivityStreamsNote,
+		VisibilityAdvanced: &gtsmodel.VisibilityAdvanced{
+			Federated: true,
+			Boostable: visibility == gtsmodel.VisibilityPublic || visibility == gtsmodel.VisibilityUnlocked,
+			Replyable: true,
+			Likeable:  true,
+		},
+	}
+
+	for _, a := range object.Attachment {
+		f, ok := files[strings.TrimPrefix(a.URL, "/")]
+		if !ok {
+			p.log.Debugf("importArchiveStatus: attachment %s of %s not found in archive", a.URL, object.ID)
+			continue
+		}
+
+		attachment, err := p.importArchiveMedia(ctx, account, f)
+		if err != nil {
+			p.log.Errorf("importArchiveStatus: error importing attachment %s of %s: %s", a.URL, object.ID, err)
+			continue
+		}
+		attachment.StatusID = status.ID
+		attachment.Description = text.RemoveHTML(a.Name)
+
+		if err := p.db.Put(ctx, attachment); err != nil {
+			return false, fmt.Errorf("error putting attachment in the db: %s", err)
+		}
+		status.AttachmentIDs = append(status.AttachmentIDs, attachment.ID)
+		status.Attachments = append(status.Attachments, attachment)
+	}
+
+	if err := p.db.PutStatus(ctx, status); err != nil {
+		return false, fmt.Errorf("error putting status in the db: %s", err)
+	}
+
+	return true, nil
+}
+
+// importArchiveMedia processes the given file from an archive as a new media attachment of the given account.
+func (p *processor) importArchiveMedia(ctx context.Context, account *gtsmodel.Account, f *zip.File) (*gtsmodel.MediaAttachment, error) {
+	maxSize := p.config.MediaConfig.MaxImageSize
+	if p.config.MediaConfig.MaxVideoSize > maxSize {
+		maxSize = p.config.MediaConfig.MaxVideoSize
+	}
+
+	b, err := readArchiveFile(f, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, errors.New("file is empty")
+	}
+
+	return p.mediaHandler.ProcessAttachment(ctx, b, account.ID, "")
+}
+
+// importArchiveBookmarks bookmarks any statuses in the given bookmarks file that are known to this instance and visible to the given account.
+func (p *processor) importArchiveBookmarks(ctx context.Context, account *gtsmodel.Account, f *zip.File) error {
+	bookmarks := &archiveCollectionFile{}
+	if err := readArchiveJSON(f, bookmarks); err != nil {
+		return fmt.Errorf("error reading %s: %s", f.Name, err)
+	}
+
+	for _, item := range bookmarks.OrderedItems {
+		var uri string
+		if err := json.Unmarshal(item, &uri); err != nil {
+			continue
+		}
+
+		status, err := p.db.GetStatusByURI(ctx, uri)
+		if err != nil {
+			continue
+		}
+
+		if visible, err := p.filter.StatusVisible(ctx, status, account); err != nil || !visible {
+			continue
+		}
+
+		if bookmarked, err := p.db.IsStatusBookmarkedBy(ctx, status, account.ID); err != nil || bookmarked {
+			continue
+		}
+
+		bookmarkID, err := id.NewULID()
+		if err != nil {
+			return err
+		}
+
+		if err := p.db.Put(ctx, &gtsmodel.StatusBookmark{
+			ID:              bookmarkID,
+			AccountID:       account.ID,
+			TargetAccountID: status.AccountID,
+			StatusID:        status.ID,
+		}); err != nil {
+			return fmt.Errorf("error putting bookmark in the db: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// archiveVisibility derives the visibility of a status in an archive from who it was addressed to,
+// starting from the most restrictive and working towards the least restrictive.
+func archiveVisibility(object *archiveObject, followersURI string) gtsmodel.Visibility {
+	visibility := gtsmodel.VisibilityDirect
+
+	for _, to := range object.To {
+		if to == followersURI || strings.HasSuffix(to, "/followers") {
+			visibility = gtsmodel.VisibilityFollowersOnly
+		}
+	}
+
+	for _, cc := range object.Cc {
+		if isArchivePublic(cc) {
+			visibility = gtsmodel.VisibilityUnlocked
+		}
+	}
+
+	for _, to := range object.To {
+		if isArchivePublic(to) {
+			visibility = gtsmodel.VisibilityPublic
+		}
+	}
+
+	return visibility
+}
+
+// isArchivePublic returns true if the given address is one of the ways of writing the activitystreams public collection.
+func isArchivePublic(address string) bool {
+	return address == "https://www.w3.org/ns/activitystreams#Public" || address == "as:Public" || address == "Public"
+}
+
+// readArchiveJSON decodes the json in the given file from an archive into v.
+func readArchiveJSON(f *zip.File, v interface{}) error {
+	b, err := readArchiveFile(f, maxArchiveJSONSize)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
+
+// readArchiveFile decompresses the given file from an archive, as long as it's no bigger than maxSize bytes.
+func readArchiveFile(f *zip.File, maxSize int) ([]byte, error) {
+	if f.UncompressedSize64 > uint64(maxSize) {
+		return nil, fmt.Errorf("%s is %d bytes uncompressed, the maximum is %d", f.Name, f.UncompressedSize64, maxSize)
+	}
+
+	r, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	// the uncompressed size in the zip header is whatever the uploader said it was, so don't take it on trust
+	b, err := ioutil.ReadAll(io.LimitReader(r, int64(maxSize)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > maxSize {
+		return nil, fmt.Errorf("%s is more than %d bytes uncompressed", f.Name, maxSize)
+	}
+
+	return b, nil
+}
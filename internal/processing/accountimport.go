@@ -0,0 +1,494 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+const (
+	// maxAccountImportEntries is the most accounts that can be imported from one file.
+	maxAccountImportEntries = 2000
+	// maxAccountImportSize is the largest file, in bytes, that can be uploaded to be imported; it's plenty for maxAccountImportEntries lines.
+	maxAccountImportSize = 1 << 20
+	// accountImportDomainInterval is how long to wait between processing import entries that target the same remote domain,
+	// so that importing a big list doesn't hammer any one instance with webfinger and dereferencing requests.
+	accountImportDomainInterval = 1 * time.Second
+	// accountImportSaveEvery is how many entries get processed between saving the progress of an import.
+	accountImportSaveEvery = 10
+)
+
+const (
+	accountExportAddressHeader = "Account address"
+	accountExportFollowingType = "following.csv"
+	accountExportBlocksType    = "blocks.csv"
+	accountExportMutesType     = "mutes.csv"
+	accountExportDomainsType   = "domain_blocks.csv"
+)
+
+func (p *processor) AccountImportCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountImportRequest) (*apimodel.AccountImport, gtserror.WithCode) {
+	importType := gtsmodel.AccountImportType(form.Type)
+	switch importType {
+	case gtsmodel.AccountImportTypeFollowing, gtsmodel.AccountImportTypeBlocks, gtsmodel.AccountImportTypeMutes, gtsmodel.AccountImportTypeDomainBlocks:
+	default:
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("unknown import type %s", form.Type), "type must be one of following, blocks, mutes, or domain_blocks")
+	}
+
+	if form.Data == nil {
+		return nil, gtserror.NewErrorBadRequest(errors.New("no data provided"), "no file provided to import")
+	}
+
+	tooBig := fmt.Sprintf("file is too big; the maximum size is %d bytes", maxAccountImportSize)
+	if form.Data.Size > maxAccountImportSize {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("AccountImportCreate: file size %d is too big", form.Data.Size), tooBig)
+	}
+
+	f, err := form.Data.Open()
+	if err != nil {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("AccountImportCreate: error opening attachment: %s", err))
+	}
+	defer f.Close()
+
+	// don't trust the declared size, and never read more than one byte past the limit
+	buf := new(bytes.Buffer)
+	if _, err := io.Copy(buf, io.LimitReader(f, maxAccountImportSize+1)); err != nil {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("AccountImportCreate: error reading attachment: %s", err))
+	}
+	if buf.Len() > maxAccountImportSize {
+		return nil, gtserror.NewErrorBadRequest(errors.New("AccountImportCreate: file read is too big"), tooBig)
+	}
+
+	entries, err := parseAccountImport(buf, importType)
+	if err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	importID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	accountImport := &gtsmodel.AccountImport{
+		ID:        importID,
+		AccountID: authed.Account.ID,
+		Type:      importType,
+		Entries:   entries,
+	}
+
+	if err := p.db.PutAccountImport(ctx, accountImport); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("AccountImportCreate: error putting account import in the db: %s", err))
+	}
+
+	// the import will almost certainly take longer than a request, so do the actual work in the background
+	go func() {
+		if err := p.runAccountImport(context.Background(), accountImport.ID); err != nil {
+			p.log.Errorf("AccountImportCreate: error running account import %s: %s", accountImport.ID, err)
+		}
+	}()
+
+	apiImport, err := p.tc.AccountImportToMasto(ctx, accountImport)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("AccountImportCreate: error converting account import: %s", err))
+	}
+
+	return apiImport, nil
+}
+
+func (p *processor) AccountImportGet(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AccountImport, gtserror.WithCode) {
+	accountImport, err := p.db.GetAccountImportByID(ctx, id)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(fmt.Errorf("account import %s not found", id))
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if accountImport.AccountID != authed.Account.ID {
+		// don't let on that someone else's import exists
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("account import %s does not belong to account %s", id, authed.Account.ID))
+	}
+
+	apiImport, err := p.tc.AccountImportToMasto(ctx, accountImport)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("AccountImportGet: error converting account import: %s", err))
+	}
+
+	return apiImport, nil
+}
+
+func (p *processor) AccountExport(ctx context.Context, authed *oauth.Auth, exportType string) ([]byte, gtserror.WithCode) {
+	var records [][]string
+	var err error
+
+	switch exportType {
+	case accountExportFollowingType:
+		records, err = p.exportFollowing(ctx, authed.Account)
+	case accountExportBlocksType:
+		records, err = p.exportBlocks(ctx, authed.Account)
+	case accountExportMutesType:
+		records, err = p.exportMutes(ctx, authed.Account)
+	case accountExportDomainsType:
+		records, err = p.exportDomainBlocks(ctx, authed.Account)
+	default:
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("unknown export type %s", exportType))
+	}
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	buf := new(bytes.Buffer)
+	w := csv.NewWriter(buf)
+	if err := w.WriteAll(records); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("AccountExport: error writing csv: %s", err))
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (p *processor) exportFollowing(ctx context.Context, account *gtsmodel.Account) ([][]string, error) {
+	records := [][]string{{accountExportAddressHeader, "Show boosts", "Notify on new posts", "Languages"}}
+
+	follows, err := p.db.GetAccountFollows(ctx, account.ID)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, fmt.Errorf("exportFollowing: error getting follows: %s", err)
+	}
+
+	for _, follow := range follows {
+		target, err := p.db.GetAccountByID(ctx, follow.TargetAccountID)
+		if err != nil {
+			p.log.Debugf("exportFollowing: skipping follow %s: error getting target account: %s", follow.ID, err)
+			continue
+		}
+		records = append(records, []string{p.accountAddress(target), strconv.FormatBool(follow.ShowReblogs), strconv.FormatBool(follow.Notify), ""})
+	}
+
+	return records, nil
+}
+
+func (p *processor) exportBlocks(ctx context.Context, account *gtsmodel.Account) ([][]string, error) {
+	// mastodon exports blocks as a bare list of addresses, without a header
+	records := [][]string{}
+
+	blocked, _, _, err := p.db.GetAccountBlocks(ctx, account.ID, "", "", 0)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, fmt.Errorf("exportBlocks: error getting blocks: %s", err)
+	}
+
+	for _, target := range blocked {
+		records = append(records, []string{p.accountAddress(target)})
+	}
+
+	return records, nil
+}
+
+func (p *processor) exportMutes(ctx context.Context, account *gtsmodel.Account) ([][]string, error) {
+	records := [][]string{{accountExportAddressHeader, "Hide notifications"}}
+
+	mutes, err := p.db.GetAccountMutes(ctx, account.ID, "", "", 0)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, fmt.Errorf("exportMutes: error getting mutes: %s", err)
+	}
+
+	for _, mute := range mutes {
+		if mute.TargetAccount == nil {
+			continue
+		}
+		records = append(records, []string{p.accountAddress(mute.TargetAccount), strconv.FormatBool(mute.Notifications)})
+	}
+
+	return records, nil
+}
+
+func (p *processor) exportDomainBlocks(ctx context.Context, account *gtsmodel.Account) ([][]string, error) {
+	// mastodon exports domain blocks as a bare list of domains, without a header
+	records := [][]string{}
+
+	blocks, err := p.db.GetAccountDomainBlocks(ctx, account.ID, "", "", 0)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, fmt.Errorf("exportDomainBlocks: error getting domain blocks: %s", err)
+	}
+
+	for _, block := range blocks {
+		records = append(records, []string{block.Domain})
+	}
+
+	return records, nil
+}
+
+// accountAddress returns the address of the given account in the form username@domain, as used in import and export files.
+func (p *processor) accountAddress(account *gtsmodel.Account) string {
+	if account.Domain == "" {
+		if p.config.AccountDomain == "" {
+			return account.Username + "@" + p.config.Host
+		}
+		return account.Username + "@" + p.config.AccountDomain
+	}
+	return account.Username + "@" + account.Domain
+}
+
+// parseAccountImport parses the entries of an import file of the given type.
+//
+// Each non-empty line of the file should start with the address of an account, or with a domain for domain blocks; files with or
+// without a header line are both accepted, as are the extra columns that mastodon includes in follow and mute exports.
+func parseAccountImport(r io.Reader, importType gtsmodel.AccountImportType) ([]gtsmodel.AccountImportEntry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	entries := []gtsmodel.AccountImportEntry{}
+	line := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error parsing csv: %s", err)
+		}
+
+		line++
+		address := strings.TrimPrefix(strings.TrimSpace(record[0]), "@")
+		if address == "" || strings.EqualFold(address, accountExportAddressHeader) {
+			continue
+		}
+
+		entry := gtsmodel.AccountImportEntry{
+			Line:    line,
+			Account: address,
+		}
+
+		switch importType {
+		case gtsmodel.AccountImportTypeFollowing:
+			entry.ShowReblogs = parseAccountImportBool(record, 1, true)
+			entry.Notify = parseAccountImportBool(record, 2, false)
+		case gtsmodel.AccountImportTypeMutes:
+			entry.HideNotifications = parseAccountImportBool(record, 1, true)
+		}
+
+		entries = append(entries, entry)
+		if len(entries) > maxAccountImportEntries {
+			return nil, fmt.Errorf("too many entries in file; the maximum is %d", maxAccountImportEntries)
+		}
+	}
+
+	if len(entries) == 0 {
+		return nil, errors.New("no entries found in file")
+	}
+
+	return entries, nil
+}
+
+// parseAccountImportBool parses the boolean in the given column of record, or returns def if it's missing or can't be parsed.
+func parseAccountImportBool(record []string, column int, def bool) bool {
+	if len(record) <= column {
+		return def
+	}
+	b, err := strconv.ParseBool(strings.TrimSpace(record[column]))
+	if err != nil {
+		return def
+	}
+	return b
+}
+
+// resumeAccountImports restarts any account imports that were interrupted while we were down.
+func (p *processor) resumeAccountImports(ctx context.Context) error {
+	imports, err := p.db.GetUnfinishedAccountImports(ctx)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil
+		}
+		return fmt.Errorf("resumeAccountImports: error getting unfinished account imports: %s", err)
+	}
+
+	for _, i := range imports {
+		go func(importID string) {
+			if err := p.runAccountImport(ctx, importID); err != nil {
+				p.log.Errorf("resumeAccountImports: error running account import %s: %s", importID, err)
+			}
+		}(i.ID)
+	}
+
+	return nil
+}
+
+// runAccountImport processes every entry of the given account import that hasn't been processed yet,
+// unless that import is already being worked on.
+func (p *processor) runAccountImport(ctx context.Context, importID string) error {
+	p.accountImportsLock.Lock()
+	if p.accountImports[importID] {
+		p.accountImportsLock.Unlock()
+		return nil
+	}
+	p.accountImports[importID] = true
+	p.accountImportsLock.Unlock()
+
+	defer func() {
+		p.accountImportsLock.Lock()
+		delete(p.accountImports, importID)
+		p.accountImportsLock.Unlock()
+	}()
+
+	accountImport, err := p.db.GetAccountImportByID(ctx, importID)
+	if err != nil {
+		return fmt.Errorf("runAccountImport: error getting account import: %s", err)
+	}
+
+	account, err := p.db.GetAccountByID(ctx, accountImport.AccountID)
+	if err != nil {
+		return fmt.Errorf("runAccountImport: error getting account %s: %s", accountImport.AccountID, err)
+	}
+	authed := &oauth.Auth{Account: account}
+
+	unsaved := 0
+	for i := range accountImport.Entries {
+		entry := &accountImport.Entries[i]
+		if entry.Result != "" {
+			// already done before we were interrupted
+			continue
+		}
+
+		if err := p.importAccountEntry(ctx, authed, accountImport.Type, entry); err != nil {
+			entry.Result = gtsmodel.AccountImportResultFailed
+			entry.Error = err.Error()
+		} else {
+			entry.Result = gtsmodel.AccountImportResultSucceeded
+		}
+
+		unsaved++
+		if unsaved >= accountImportSaveEvery {
+			if err := p.db.UpdateAccountImport(ctx, accountImport); err != nil {
+				return fmt.Errorf("runAccountImport: error saving progress: %s", err)
+			}
+			unsaved = 0
+		}
+	}
+
+	accountImport.FinishedAt = time.Now()
+	if err := p.db.UpdateAccountImport(ctx, accountImport); err != nil {
+		return fmt.Errorf("runAccountImport: error saving finished import: %s", err)
+	}
+
+	return nil
+}
+
+// importAccountEntry resolves the account in the given entry, and then follows, blocks, or mutes it on behalf of the authed account.
+// For domain block imports, the domain in the given entry is blocked instead, and nothing needs to be resolved.
+// The returned error is shown to the account that uploaded the import, so it shouldn't leak any internal details.
+func (p *processor) importAccountEntry(ctx context.Context, authed *oauth.Auth, importType gtsmodel.AccountImportType, entry *gtsmodel.AccountImportEntry) error {
+	if importType == gtsmodel.AccountImportTypeDomainBlocks {
+		if errWithCode := p.UserDomainBlockCreate(ctx, authed, &apimodel.UserDomainBlockRequest{Domain: entry.Account}); errWithCode != nil {
+			p.log.Debugf("importAccountEntry: error importing %s: %s", entry.Account, errWithCode)
+			return errors.New(errWithCode.Safe())
+		}
+		return nil
+	}
+
+	parts := strings.Split(entry.Account, "@")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return errors.New("not a valid account address")
+	}
+	username, domain := parts[0], strings.ToLower(parts[1])
+
+	var target *gtsmodel.Account
+	if domain == strings.ToLower(p.config.Host) || domain == strings.ToLower(p.config.AccountDomain) {
+		a, err := p.db.GetLocalAccountByUsername(ctx, username)
+		if err != nil {
+			p.log.Debugf("importAccountEntry: error getting local account %s: %s", username, err)
+			return errors.New("account could not be found")
+		}
+		target = a
+	} else {
+		// don't hit any one remote instance too quickly
+		p.waitForAccountImportDomain(domain)
+
+		a, err := p.searchAccountByMention(ctx, authed, "@"+username+"@"+domain, true)
+		if err != nil || a == nil {
+			p.log.Debugf("importAccountEntry: error resolving remote account %s@%s: %s", username, domain, err)
+			return errors.New("account could not be found")
+		}
+		target = a
+	}
+
+	if target.ID == authed.Account.ID {
+		return errors.New("can't import your own account")
+	}
+
+	var errWithCode gtserror.WithCode
+	switch importType {
+	case gtsmodel.AccountImportTypeFollowing:
+		_, errWithCode = p.AccountFollowCreate(ctx, authed, &apimodel.AccountFollowRequest{
+			ID:      target.ID,
+			Reblogs: &entry.ShowReblogs,
+			Notify:  &entry.Notify,
+		})
+	case gtsmodel.AccountImportTypeBlocks:
+		_, errWithCode = p.AccountBlockCreate(ctx, authed, target.ID)
+	case gtsmodel.AccountImportTypeMutes:
+		_, errWithCode = p.AccountMuteCreate(ctx, authed, &apimodel.AccountMuteRequest{
+			ID:            target.ID,
+			Notifications: &entry.HideNotifications,
+		})
+	default:
+		return fmt.Errorf("unknown import type %s", importType)
+	}
+	if errWithCode != nil {
+		p.log.Debugf("importAccountEntry: error importing %s: %s", entry.Account, errWithCode)
+		return errors.New(errWithCode.Safe())
+	}
+
+	return nil
+}
+
+// waitForAccountImportDomain blocks until an account import entry targeting the given remote domain may be processed,
+// and then reserves the next slot for that domain. Slots are shared between all running imports.
+func (p *processor) waitForAccountImportDomain(domain string) {
+	p.accountImportDomainSlotLock.Lock()
+	now := time.Now()
+
+	// clear out domains that nobody is waiting on anymore, so the map doesn't grow forever
+	for d, slot := range p.accountImportDomainSlots {
+		if slot.Before(now) {
+			delete(p.accountImportDomainSlots, d)
+		}
+	}
+
+	slot, ok := p.accountImportDomainSlots[domain]
+	if !ok {
+		slot = now
+	}
+	p.accountImportDomainSlots[domain] = slot.Add(accountImportDomainInterval)
+	p.accountImportDomainSlotLock.Unlock()
+
+	time.Sleep(time.Until(slot))
+}
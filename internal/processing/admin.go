@@ -49,3 +49,43 @@ func (p *processor) AdminDomainBlockGet(ctx context.Context, authed *oauth.Auth,
 func (p *processor) AdminDomainBlockDelete(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.DomainBlock, gtserror.WithCode) {
 	return p.adminProcessor.DomainBlockDelete(ctx, authed.Account, id)
 }
+
+func (p *processor) AdminReportsGet(ctx context.Context, authed *oauth.Auth, resolved bool, accountID string, targetAccountID string, maxID string, sinceID string, minID string, limit int) (*apimodel.AdminReportsResponse, gtserror.WithCode) {
+	return p.adminProcessor.ReportsGet(ctx, authed.Account, resolved, accountID, targetAccountID, maxID, sinceID, minID, limit)
+}
+
+func (p *processor) AdminReportGet(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminReport, gtserror.WithCode) {
+	return p.adminProcessor.ReportGet(ctx, authed.Account, id)
+}
+
+func (p *processor) AdminReportAssignToSelf(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminReport, gtserror.WithCode) {
+	return p.adminProcessor.ReportAssignToSelf(ctx, authed.Account, id)
+}
+
+func (p *processor) AdminReportUnassign(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminReport, gtserror.WithCode) {
+	return p.adminProcessor.ReportUnassign(ctx, authed.Account, id)
+}
+
+func (p *processor) AdminReportResolve(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminReport, gtserror.WithCode) {
+	return p.adminProcessor.ReportResolve(ctx, authed.Account, id)
+}
+
+func (p *processor) AdminReportReopen(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminReport, gtserror.WithCode) {
+	return p.adminProcessor.ReportReopen(ctx, authed.Account, id)
+}
+
+func (p *processor) AdminReportAction(ctx context.Context, authed *oauth.Auth, id string, form *apimodel.AdminReportActionRequest) (*apimodel.AdminReport, gtserror.WithCode) {
+	return p.adminProcessor.ReportAction(ctx, authed.Account, id, form)
+}
+
+func (p *processor) AdminFeaturedAccountsGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.Account, gtserror.WithCode) {
+	return p.adminProcessor.FeaturedAccountsGet(ctx, authed.Account)
+}
+
+func (p *processor) AdminFeaturedAccountCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AdminFeaturedAccountCreateRequest) (*apimodel.Account, gtserror.WithCode) {
+	return p.adminProcessor.FeaturedAccountCreate(ctx, authed.Account, form)
+}
+
+func (p *processor) AdminFeaturedAccountDelete(ctx context.Context, authed *oauth.Auth, accountID string) gtserror.WithCode {
+	return p.adminProcessor.FeaturedAccountDelete(ctx, authed.Account, accountID)
+}
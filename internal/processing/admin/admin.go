@@ -40,6 +40,16 @@ type Processor interface {
 	DomainBlockGet(ctx context.Context, account *gtsmodel.Account, id string, export bool) (*apimodel.DomainBlock, gtserror.WithCode)
 	DomainBlockDelete(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.DomainBlock, gtserror.WithCode)
 	EmojiCreate(ctx context.Context, account *gtsmodel.Account, user *gtsmodel.User, form *apimodel.EmojiCreateRequest) (*apimodel.Emoji, error)
+	ReportsGet(ctx context.Context, account *gtsmodel.Account, resolved bool, accountID string, targetAccountID string, maxID string, sinceID string, minID string, limit int) (*apimodel.AdminReportsResponse, gtserror.WithCode)
+	ReportGet(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminReport, gtserror.WithCode)
+	ReportAssignToSelf(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminReport, gtserror.WithCode)
+	ReportUnassign(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminReport, gtserror.WithCode)
+	ReportResolve(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminReport, gtserror.WithCode)
+	ReportReopen(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminReport, gtserror.WithCode)
+	ReportAction(ctx context.Context, account *gtsmodel.Account, id string, form *apimodel.AdminReportActionRequest) (*apimodel.AdminReport, gtserror.WithCode)
+	FeaturedAccountsGet(ctx context.Context, account *gtsmodel.Account) ([]*apimodel.Account, gtserror.WithCode)
+	FeaturedAccountCreate(ctx context.Context, account *gtsmodel.Account, form *apimodel.AdminFeaturedAccountCreateRequest) (*apimodel.Account, gtserror.WithCode)
+	FeaturedAccountDelete(ctx context.Context, account *gtsmodel.Account, accountID string) gtserror.WithCode
 }
 
 type processor struct {
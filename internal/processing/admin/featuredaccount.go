@@ -0,0 +1,106 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+func (p *processor) FeaturedAccountsGet(ctx context.Context, account *gtsmodel.Account) ([]*apimodel.Account, gtserror.WithCode) {
+	featuredAccounts, err := p.db.GetFeaturedAccounts(ctx)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	mastoAccounts := []*apimodel.Account{}
+	for _, fa := range featuredAccounts {
+		if fa.Account == nil {
+			continue
+		}
+		mastoAccount, err := p.tc.AccountToMastoPublic(ctx, fa.Account)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		mastoAccounts = append(mastoAccounts, mastoAccount)
+	}
+
+	return mastoAccounts, nil
+}
+
+func (p *processor) FeaturedAccountCreate(ctx context.Context, account *gtsmodel.Account, form *apimodel.AdminFeaturedAccountCreateRequest) (*apimodel.Account, gtserror.WithCode) {
+	targetAccount, err := p.db.GetAccountByID(ctx, form.AccountID)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(fmt.Errorf("FeaturedAccountCreate: account %s not found", form.AccountID))
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !targetAccount.SuspendedAt.IsZero() {
+		err := errors.New("suspended accounts can't be featured")
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	featuredAccountID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.db.PutFeaturedAccount(ctx, &gtsmodel.FeaturedAccount{
+		ID:                 featuredAccountID,
+		AccountID:          targetAccount.ID,
+		CreatedByAccountID: account.ID,
+	}); err != nil {
+		if err == db.ErrAlreadyExists {
+			err := errors.New("account is already featured")
+			return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	mastoAccount, err := p.tc.AccountToMastoPublic(ctx, targetAccount)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return mastoAccount, nil
+}
+
+func (p *processor) FeaturedAccountDelete(ctx context.Context, account *gtsmodel.Account, accountID string) gtserror.WithCode {
+	if _, err := p.db.GetFeaturedAccount(ctx, accountID); err != nil {
+		if err == db.ErrNoEntries {
+			return gtserror.NewErrorNotFound(fmt.Errorf("FeaturedAccountDelete: account %s is not featured", accountID))
+		}
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.db.DeleteFeaturedAccount(ctx, accountID); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,249 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+func (p *processor) ReportsGet(ctx context.Context, account *gtsmodel.Account, resolved bool, accountID string, targetAccountID string, maxID string, sinceID string, minID string, limit int) (*apimodel.AdminReportsResponse, gtserror.WithCode) {
+	reports, err := p.db.GetReports(ctx, resolved, accountID, targetAccountID, maxID, sinceID, minID, limit)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			// there are just no entries
+			return &apimodel.AdminReportsResponse{
+				Reports: []*apimodel.AdminReport{},
+			}, nil
+		}
+		// there's an actual error
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	mastoReports := []*apimodel.AdminReport{}
+	for _, r := range reports {
+		mastoReport, err := p.tc.ReportToAdminMasto(ctx, r, account)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		mastoReports = append(mastoReports, mastoReport)
+	}
+
+	resp := &apimodel.AdminReportsResponse{
+		Reports: mastoReports,
+	}
+
+	// keep the filters in the next and previous links so the client can page through the same queue
+	query := fmt.Sprintf("limit=%d&resolved=%t", limit, resolved)
+	if accountID != "" {
+		query = fmt.Sprintf("%s&account_id=%s", query, accountID)
+	}
+	if targetAccountID != "" {
+		query = fmt.Sprintf("%s&target_account_id=%s", query, targetAccountID)
+	}
+
+	nextLink := &url.URL{
+		Scheme:   p.config.Protocol,
+		Host:     p.config.Host,
+		Path:     "/api/v1/admin/reports",
+		RawQuery: fmt.Sprintf("%s&max_id=%s", query, reports[len(reports)-1].ID),
+	}
+	next := fmt.Sprintf("<%s>; rel=\"next\"", nextLink.String())
+
+	prevLink := &url.URL{
+		Scheme:   p.config.Protocol,
+		Host:     p.config.Host,
+		Path:     "/api/v1/admin/reports",
+		RawQuery: fmt.Sprintf("%s&min_id=%s", query, reports[0].ID),
+	}
+	prev := fmt.Sprintf("<%s>; rel=\"prev\"", prevLink.String())
+	resp.LinkHeader = fmt.Sprintf("%s, %s", next, prev)
+
+	return resp, nil
+}
+
+func (p *processor) ReportGet(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminReport, gtserror.WithCode) {
+	report, errWithCode := p.getReport(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.reportToAdminMasto(ctx, report, account)
+}
+
+func (p *processor) ReportAssignToSelf(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminReport, gtserror.WithCode) {
+	report, errWithCode := p.getReport(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	report.AssignedAccountID = account.ID
+	report.AssignedAccount = account
+
+	return p.updateReport(ctx, report, account)
+}
+
+func (p *processor) ReportUnassign(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminReport, gtserror.WithCode) {
+	report, errWithCode := p.getReport(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	report.AssignedAccountID = ""
+	report.AssignedAccount = nil
+
+	return p.updateReport(ctx, report, account)
+}
+
+func (p *processor) ReportResolve(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminReport, gtserror.WithCode) {
+	report, errWithCode := p.getReport(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	resolveReport(report, account, gtsmodel.ReportActionNone, "")
+
+	return p.updateReport(ctx, report, account)
+}
+
+func (p *processor) ReportReopen(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminReport, gtserror.WithCode) {
+	report, errWithCode := p.getReport(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	report.ActionTakenAt = time.Time{}
+	report.ActionTakenByAccountID = ""
+	report.ActionTakenByAccount = nil
+	report.ActionTaken = ""
+	report.ActionTakenComment = ""
+
+	return p.updateReport(ctx, report, account)
+}
+
+func (p *processor) ReportAction(ctx context.Context, account *gtsmodel.Account, id string, form *apimodel.AdminReportActionRequest) (*apimodel.AdminReport, gtserror.WithCode) {
+	if err := util.ValidateReportAction(form.Type); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	action := gtsmodel.ReportAction(form.Type)
+
+	report, errWithCode := p.getReport(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if action != gtsmodel.ReportActionNone {
+		targetAccount, err := p.db.GetAccountByID(ctx, report.TargetAccountID)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("ReportAction: error getting target account %s: %s", report.TargetAccountID, err))
+		}
+
+		if errWithCode := p.actOnAccount(ctx, account, targetAccount, action, report); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	resolveReport(report, account, action, form.Text)
+
+	return p.updateReport(ctx, report, account)
+}
+
+// actOnAccount applies the given moderation action to the target account.
+func (p *processor) actOnAccount(ctx context.Context, account *gtsmodel.Account, targetAccount *gtsmodel.Account, action gtsmodel.ReportAction, report *gtsmodel.Report) gtserror.WithCode {
+	if targetAccount.ID == account.ID {
+		return gtserror.NewErrorBadRequest(errors.New("admin cannot act on their own account"), "you cannot act on your own account")
+	}
+
+	// local admins can't be moderated through reports
+	if targetAccount.Domain == "" {
+		targetUser := &gtsmodel.User{}
+		if err := p.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: targetAccount.ID}}, targetUser); err == nil && targetUser.Admin {
+			return gtserror.NewErrorForbidden(errors.New("target account is an admin"), "you cannot act on the account of another admin")
+		}
+	}
+
+	switch action {
+	case gtsmodel.ReportActionSensitive:
+		targetAccount.SensitizedAt = time.Now()
+	case gtsmodel.ReportActionSilence:
+		targetAccount.SilencedAt = time.Now()
+	case gtsmodel.ReportActionSuspend:
+		// pass the account delete through the client api channel for processing
+		p.fromClientAPI <- gtsmodel.FromClientAPI{
+			APObjectType:   gtsmodel.ActivityStreamsPerson,
+			APActivityType: gtsmodel.ActivityStreamsDelete,
+			GTSModel:       report,
+			OriginAccount:  account,
+			TargetAccount:  targetAccount,
+		}
+		return nil
+	}
+
+	if _, err := p.db.UpdateAccount(ctx, targetAccount); err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("actOnAccount: error updating account %s: %s", targetAccount.ID, err))
+	}
+
+	return nil
+}
+
+// resolveReport marks the given report as resolved by account, with the given action and comment.
+func resolveReport(report *gtsmodel.Report, account *gtsmodel.Account, action gtsmodel.ReportAction, comment string) {
+	report.ActionTakenAt = time.Now()
+	report.ActionTakenByAccountID = account.ID
+	report.ActionTakenByAccount = account
+	report.ActionTaken = action
+	report.ActionTakenComment = comment
+}
+
+func (p *processor) getReport(ctx context.Context, id string) (*gtsmodel.Report, gtserror.WithCode) {
+	report, err := p.db.GetReportByID(ctx, id)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(fmt.Errorf("report %s not found", id))
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	return report, nil
+}
+
+func (p *processor) updateReport(ctx context.Context, report *gtsmodel.Report, account *gtsmodel.Account) (*apimodel.AdminReport, gtserror.WithCode) {
+	report.UpdatedAt = time.Now()
+	if err := p.db.UpdateReport(ctx, report); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("updateReport: error updating report %s: %s", report.ID, err))
+	}
+
+	return p.reportToAdminMasto(ctx, report, account)
+}
+
+func (p *processor) reportToAdminMasto(ctx context.Context, report *gtsmodel.Report, account *gtsmodel.Account) (*apimodel.AdminReport, gtserror.WithCode) {
+	mastoReport, err := p.tc.ReportToAdminMasto(ctx, report, account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting report %s to frontend/masto representation: %s", report.ID, err))
+	}
+	return mastoReport, nil
+}
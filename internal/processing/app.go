@@ -20,12 +20,20 @@ package processing
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	oautherrors "github.com/superseriousbusiness/oauth2/v4/errors"
 )
 
 func (p *processor) AppCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.ApplicationCreateRequest) (*apimodel.Application, error) {
@@ -87,3 +95,133 @@ func (p *processor) AppCreate(ctx context.Context, authed *oauth.Auth, form *api
 
 	return mastoApp, nil
 }
+
+func (p *processor) AppVerifyCredentials(ctx context.Context, authed *oauth.Auth) (*apimodel.Application, gtserror.WithCode) {
+	if authed.Application == nil {
+		err := errors.New("no application found for token")
+		return nil, gtserror.NewErrorNotAuthorized(err, err.Error())
+	}
+
+	mastoApp, err := p.tc.AppToMastoPublic(ctx, authed.Application)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	mastoApp.VapidKey = authed.Application.VapidKey
+
+	return mastoApp, nil
+}
+
+func (p *processor) AppsAuthorizedGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.AuthorizedApplication, gtserror.WithCode) {
+	tokens := []*oauth.Token{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "user_id", Value: authed.User.ID}}, &tokens); err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("AppsAuthorizedGet: error getting tokens: %s", err))
+	}
+
+	// there can be lots of tokens for the same app, eg., if the user signed in more than once,
+	// so gather up the scopes and earliest authorization time for each app's client
+	type authorization struct {
+		scopes    map[string]bool
+		createdAt time.Time
+	}
+	authorizations := map[string]*authorization{}
+	for _, t := range tokens {
+		// authorization codes that haven't been exchanged for an access token yet don't count
+		if t.Access == "" {
+			continue
+		}
+
+		a, ok := authorizations[t.ClientID]
+		if !ok {
+			a = &authorization{
+				scopes:    map[string]bool{},
+				createdAt: t.AccessCreateAt,
+			}
+			authorizations[t.ClientID] = a
+		}
+
+		for _, scope := range strings.Fields(t.Scope) {
+			a.scopes[scope] = true
+		}
+		if t.AccessCreateAt.Before(a.createdAt) {
+			a.createdAt = t.AccessCreateAt
+		}
+	}
+
+	apps := make([]*apimodel.AuthorizedApplication, 0, len(authorizations))
+	for clientID, a := range authorizations {
+		app := &gtsmodel.Application{}
+		if err := p.db.GetWhere(ctx, []db.Where{{Key: "client_id", Value: clientID}}, app); err != nil {
+			if err == db.ErrNoEntries {
+				// the app has gone, so its tokens are no use to anyone
+				p.log.Debugf("AppsAuthorizedGet: no application found for client %s", clientID)
+				continue
+			}
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("AppsAuthorizedGet: error getting application: %s", err))
+		}
+
+		scopes := make([]string, 0, len(a.scopes))
+		for scope := range a.scopes {
+			scopes = append(scopes, scope)
+		}
+		sort.Strings(scopes)
+
+		apps = append(apps, &apimodel.AuthorizedApplication{
+			ID:        app.ID,
+			Name:      app.Name,
+			Website:   app.Website,
+			Scopes:    scopes,
+			CreatedAt: a.createdAt.Format(time.RFC3339),
+		})
+	}
+
+	sort.Slice(apps, func(i, j int) bool {
+		if apps[i].CreatedAt != apps[j].CreatedAt {
+			return apps[i].CreatedAt < apps[j].CreatedAt
+		}
+		return apps[i].ID < apps[j].ID
+	})
+
+	return apps, nil
+}
+
+func (p *processor) AppAuthorizedRevoke(ctx context.Context, authed *oauth.Auth, appID string) gtserror.WithCode {
+	app := &gtsmodel.Application{}
+	if err := p.db.GetByID(ctx, appID, app); err != nil {
+		if err == db.ErrNoEntries {
+			return gtserror.NewErrorNotFound(err, "application not found")
+		}
+		return gtserror.NewErrorInternalError(fmt.Errorf("AppAuthorizedRevoke: error getting application: %s", err))
+	}
+
+	revoked, err := p.oauthServer.RevokeUserTokens(ctx, authed.User.ID, app.ClientID)
+	if err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("AppAuthorizedRevoke: error revoking tokens: %s", err))
+	}
+
+	// don't let on whether an app that the user never authorized exists
+	if len(revoked) == 0 {
+		return gtserror.NewErrorNotFound(errors.New("user has no tokens for application"), "application not found")
+	}
+
+	for _, ti := range revoked {
+		p.streamingProcessor.CloseStreamsForToken(ti.GetAccess())
+	}
+
+	return nil
+}
+
+func (p *processor) OAuthRevokeToken(ctx context.Context, clientID string, token string, tokenTypeHint string) gtserror.WithCode {
+	revoked, err := p.oauthServer.RevokeToken(ctx, clientID, token, tokenTypeHint)
+	if err != nil {
+		if err == oautherrors.ErrUnauthorizedClient {
+			return gtserror.NewErrorForbidden(err, "token was not issued to this client")
+		}
+		return gtserror.NewErrorInternalError(fmt.Errorf("OAuthRevokeToken: error revoking token: %s", err))
+	}
+
+	if revoked != nil {
+		p.streamingProcessor.CloseStreamsForToken(revoked.GetAccess())
+	}
+
+	return nil
+}
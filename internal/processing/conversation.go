@@ -0,0 +1,131 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+func (p *processor) ConversationsGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, minID string, limit int) (*apimodel.ConversationsResponse, gtserror.WithCode) {
+	conversations, err := p.db.GetConversationsForAccountID(ctx, authed.Account.ID, maxID, sinceID, minID, limit)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			// there are just no entries
+			return &apimodel.ConversationsResponse{
+				Conversations: []*apimodel.Conversation{},
+			}, nil
+		}
+		// there's an actual error
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	mastoConversations := []*apimodel.Conversation{}
+	for _, c := range conversations {
+		mastoConversation, err := p.tc.ConversationToMasto(ctx, c, authed.Account)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		mastoConversations = append(mastoConversations, mastoConversation)
+	}
+
+	resp := &apimodel.ConversationsResponse{
+		Conversations: mastoConversations,
+	}
+
+	// prepare the next and previous links; conversations are paged by their last status
+	nextLink := &url.URL{
+		Scheme:   p.config.Protocol,
+		Host:     p.config.Host,
+		Path:     "/api/v1/conversations",
+		RawQuery: fmt.Sprintf("limit=%d&max_id=%s", limit, conversations[len(conversations)-1].LastStatusID),
+	}
+	next := fmt.Sprintf("<%s>; rel=\"next\"", nextLink.String())
+
+	prevLink := &url.URL{
+		Scheme:   p.config.Protocol,
+		Host:     p.config.Host,
+		Path:     "/api/v1/conversations",
+		RawQuery: fmt.Sprintf("limit=%d&min_id=%s", limit, conversations[0].LastStatusID),
+	}
+	prev := fmt.Sprintf("<%s>; rel=\"prev\"", prevLink.String())
+	resp.LinkHeader = fmt.Sprintf("%s, %s", next, prev)
+
+	return resp, nil
+}
+
+func (p *processor) ConversationDelete(ctx context.Context, authed *oauth.Auth, conversationID string) gtserror.WithCode {
+	conversation, errWithCode := p.getOwnConversation(ctx, authed.Account, conversationID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.db.DeleteConversationByID(ctx, conversation.ID); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+func (p *processor) ConversationRead(ctx context.Context, authed *oauth.Auth, conversationID string) (*apimodel.Conversation, gtserror.WithCode) {
+	conversation, errWithCode := p.getOwnConversation(ctx, authed.Account, conversationID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if !conversation.Read {
+		conversation.Read = true
+		conversation.UpdatedAt = time.Now()
+		if err := p.db.UpdateConversation(ctx, conversation); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	mastoConversation, err := p.tc.ConversationToMasto(ctx, conversation, authed.Account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return mastoConversation, nil
+}
+
+func (p *processor) getOwnConversation(ctx context.Context, account *gtsmodel.Account, conversationID string) (*gtsmodel.Conversation, gtserror.WithCode) {
+	conversation, err := p.db.GetConversationByID(ctx, conversationID)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(fmt.Errorf("conversation %s not found", conversationID))
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if conversation.AccountID != account.ID {
+		// don't reveal that the conversation exists to accounts that don't own it
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("conversation %s not owned by account %s", conversationID, account.ID))
+	}
+
+	return conversation, nil
+}
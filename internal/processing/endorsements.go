@@ -0,0 +1,80 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+func (p *processor) EndorsementsGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, limit int) (*apimodel.EndorsementsResponse, gtserror.WithCode) {
+	endorsements, err := p.db.GetAccountEndorsements(ctx, authed.Account.ID, maxID, sinceID, limit)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			// there are just no entries
+			return &apimodel.EndorsementsResponse{
+				Accounts: []*apimodel.Account{},
+			}, nil
+		}
+		// there's an actual error
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiAccounts := []*apimodel.Account{}
+	for _, e := range endorsements {
+		if e.TargetAccount == nil {
+			continue
+		}
+		apiAccount, err := p.tc.AccountToMastoPublic(ctx, e.TargetAccount)
+		if err != nil {
+			continue
+		}
+		apiAccounts = append(apiAccounts, apiAccount)
+	}
+
+	resp := &apimodel.EndorsementsResponse{
+		Accounts: apiAccounts,
+	}
+
+	// prepare the next and previous links
+	nextLink := &url.URL{
+		Scheme:   p.config.Protocol,
+		Host:     p.config.Host,
+		Path:     "/api/v1/endorsements",
+		RawQuery: fmt.Sprintf("limit=%d&max_id=%s", limit, endorsements[len(endorsements)-1].ID),
+	}
+	next := fmt.Sprintf("<%s>; rel=\"next\"", nextLink.String())
+
+	prevLink := &url.URL{
+		Scheme:   p.config.Protocol,
+		Host:     p.config.Host,
+		Path:     "/api/v1/endorsements",
+		RawQuery: fmt.Sprintf("limit=%d&min_id=%s", limit, endorsements[0].ID),
+	}
+	prev := fmt.Sprintf("<%s>; rel=\"prev\"", prevLink.String())
+	resp.LinkHeader = fmt.Sprintf("%s, %s", next, prev)
+
+	return resp, nil
+}
@@ -0,0 +1,204 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// maxFeaturedTags is the maximum number of hashtags an account can feature on its profile,
+// and also the number of suggestions given for tags to feature.
+const maxFeaturedTags = 10
+
+func (p *processor) FeaturedTagsGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.FeaturedTag, gtserror.WithCode) {
+	return p.featuredTagsForAccountID(ctx, authed.Account.ID)
+}
+
+func (p *processor) AccountFeaturedTagsGet(ctx context.Context, authed *oauth.Auth, targetAccountID string) ([]*apimodel.FeaturedTag, gtserror.WithCode) {
+	if _, err := p.db.GetAccountByID(ctx, targetAccountID); err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(fmt.Errorf("account %s not found", targetAccountID))
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// don't show anything if there's a block in place between the accounts
+	blocked, err := p.db.IsBlocked(ctx, authed.Account.ID, targetAccountID, true)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error checking account block: %s", err))
+	}
+	if blocked {
+		return []*apimodel.FeaturedTag{}, nil
+	}
+
+	return p.featuredTagsForAccountID(ctx, targetAccountID)
+}
+
+func (p *processor) FeaturedTagCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.FeaturedTagCreateRequest) (*apimodel.FeaturedTag, gtserror.WithCode) {
+	tagName := strings.ToLower(strings.TrimPrefix(form.Name, "#"))
+	if err := util.ValidateHashtag(tagName); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	featuredTags, err := p.db.GetFeaturedTagsForAccountID(ctx, authed.Account.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	if len(featuredTags) >= maxFeaturedTags {
+		err := fmt.Errorf("you cannot feature more than %d hashtags", maxFeaturedTags)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	// we can feature a tag nobody has used yet, so create it if necessary
+	tags, err := p.db.TagStringsToTags(ctx, []string{tagName}, authed.Account.ID, "")
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	if len(tags) == 0 {
+		err := fmt.Errorf("tag %s cannot be used", tagName)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+	tag := tags[0]
+
+	if err := p.db.Put(ctx, tag); err != nil && err != db.ErrAlreadyExists {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error putting tag in db: %s", err))
+	}
+
+	if _, err := p.db.GetFeaturedTag(ctx, authed.Account.ID, tag.ID); err == nil {
+		err := fmt.Errorf("tag %s is already featured", tagName)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	} else if err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error checking existing featured tag: %s", err))
+	}
+
+	featuredTagID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	featuredTag := &gtsmodel.FeaturedTag{
+		ID:        featuredTagID,
+		AccountID: authed.Account.ID,
+		TagID:     tag.ID,
+		Tag:       tag,
+	}
+
+	if err := p.db.PutFeaturedTag(ctx, featuredTag); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error putting featured tag in db: %s", err))
+	}
+
+	mastoFeaturedTag, err := p.tc.FeaturedTagToMasto(ctx, featuredTag)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return mastoFeaturedTag, nil
+}
+
+func (p *processor) FeaturedTagDelete(ctx context.Context, authed *oauth.Auth, id string) gtserror.WithCode {
+	featuredTag, err := p.db.GetFeaturedTagByID(ctx, id)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return gtserror.NewErrorNotFound(fmt.Errorf("featured tag %s not found", id))
+		}
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// pretend the featured tag doesn't exist if it isn't ours
+	if featuredTag.AccountID != authed.Account.ID {
+		return gtserror.NewErrorNotFound(fmt.Errorf("featured tag %s does not belong to account %s", id, authed.Account.ID))
+	}
+
+	if err := p.db.DeleteFeaturedTagByID(ctx, featuredTag.ID); err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("error deleting featured tag: %s", err))
+	}
+
+	return nil
+}
+
+func (p *processor) FeaturedTagSuggestionsGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.Tag, gtserror.WithCode) {
+	featuredTags, err := p.db.GetFeaturedTagsForAccountID(ctx, authed.Account.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	featured := make(map[string]bool, len(featuredTags))
+	for _, ft := range featuredTags {
+		featured[ft.TagID] = true
+	}
+
+	// get enough tags that we'll still have a full page of suggestions when featured tags are skipped
+	tags, err := p.db.GetAccountMostUsedTags(ctx, authed.Account.ID, maxFeaturedTags+len(featuredTags))
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	mastoTags := []*apimodel.Tag{}
+	for _, t := range tags {
+		if featured[t.ID] {
+			continue
+		}
+
+		if len(mastoTags) >= maxFeaturedTags {
+			break
+		}
+
+		mastoTag, errWithCode := p.tagToMastoForAccount(ctx, t, authed.Account)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		mastoTags = append(mastoTags, mastoTag)
+	}
+
+	return mastoTags, nil
+}
+
+// featuredTagsForAccountID returns the api representation of the hashtags featured by the given account ID.
+func (p *processor) featuredTagsForAccountID(ctx context.Context, accountID string) ([]*apimodel.FeaturedTag, gtserror.WithCode) {
+	featuredTags, err := p.db.GetFeaturedTagsForAccountID(ctx, accountID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	mastoFeaturedTags := []*apimodel.FeaturedTag{}
+	for _, ft := range featuredTags {
+		if ft.Tag == nil {
+			return nil, gtserror.NewErrorInternalError(errors.New("featured tag had no tag populated"))
+		}
+
+		mastoFeaturedTag, err := p.tc.FeaturedTagToMasto(ctx, ft)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		mastoFeaturedTags = append(mastoFeaturedTags, mastoFeaturedTag)
+	}
+
+	return mastoFeaturedTags, nil
+}
@@ -49,6 +49,11 @@ func (p *processor) GetFediUser(ctx context.Context, requestedUsername string, r
 			return nil, gtserror.NewErrorInternalError(err)
 		}
 	} else if util.IsUserPath(requestURL) {
+		// if the account has been deleted or suspended there's nothing left to serve here except a tombstone
+		if !requestedAccount.SuspendedAt.IsZero() {
+			return nil, gtserror.NewErrorGone(fmt.Errorf("account with username %s is gone", requestedUsername))
+		}
+
 		// if it's a user path, we want to fully authenticate the request before we serve any data, and then we can serve a more complete profile
 		requestingAccountURI, authenticated, err := p.federator.AuthenticateFederatedRequest(ctx, requestedUsername)
 		if err != nil || !authenticated {
@@ -178,6 +183,66 @@ func (p *processor) GetFediFollowing(ctx context.Context, requestedUsername stri
 	return data, nil
 }
 
+func (p *processor) GetFediFeaturedCollection(ctx context.Context, requestedUsername string, requestURL *url.URL) (interface{}, gtserror.WithCode) {
+	// get the account the request is referring to
+	requestedAccount, err := p.db.GetLocalAccountByUsername(ctx, requestedUsername)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("database error getting account with username %s: %s", requestedUsername, err))
+	}
+
+	// authenticate the request
+	requestingAccountURI, authenticated, err := p.federator.AuthenticateFederatedRequest(ctx, requestedUsername)
+	if err != nil || !authenticated {
+		return nil, gtserror.NewErrorNotAuthorized(errors.New("not authorized"), "not authorized")
+	}
+
+	requestingAccount, _, err := p.federator.GetRemoteAccount(ctx, requestedUsername, requestingAccountURI, false)
+	if err != nil {
+		return nil, gtserror.NewErrorNotAuthorized(err)
+	}
+
+	blocked, err := p.db.IsBlocked(ctx, requestedAccount.ID, requestingAccount.ID, true)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if blocked {
+		return nil, gtserror.NewErrorNotAuthorized(fmt.Errorf("block exists between accounts %s and %s", requestedAccount.ID, requestingAccount.ID))
+	}
+
+	pinned, err := p.db.GetAccountStatuses(ctx, requestedAccount.ID, 0, false, "", true, false)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error getting pinned statuses for account %s: %s", requestedAccount.ID, err))
+	}
+
+	// only show pinned statuses that are public or unlocked, and that the requester can see
+	featured := []*gtsmodel.Status{}
+	for _, s := range pinned {
+		if s.Visibility != gtsmodel.VisibilityPublic && s.Visibility != gtsmodel.VisibilityUnlocked {
+			continue
+		}
+
+		visible, err := p.filter.StatusVisible(ctx, s, requestingAccount)
+		if err != nil || !visible {
+			continue
+		}
+
+		featured = append(featured, s)
+	}
+
+	collection, err := p.tc.StatusesToASFeaturedCollection(ctx, requestedAccount.FeaturedCollectionURI, featured)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	data, err := streams.Serialize(collection)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return data, nil
+}
+
 func (p *processor) GetFediStatus(ctx context.Context, requestedUsername string, requestedStatusID string, requestURL *url.URL) (interface{}, gtserror.WithCode) {
 	// get the account the request is referring to
 	requestedAccount, err := p.db.GetLocalAccountByUsername(ctx, requestedUsername)
@@ -224,8 +289,14 @@ func (p *processor) GetFediStatus(ctx context.Context, requestedUsername string,
 		return nil, gtserror.NewErrorNotFound(fmt.Errorf("status with id %s not visible to user with id %s", s.ID, requestingAccount.ID))
 	}
 
-	// requester is authorized to view the status, so convert it to AP representation and serialize it
-	asStatus, err := p.tc.StatusToAS(ctx, s)
+	// requester is authorized to view the status, so convert it to AP representation and serialize it;
+	// statuses with a poll attached are represented as a Question rather than a Note
+	var asStatus vocab.Type
+	if s.PollID != "" {
+		asStatus, err = p.tc.StatusToASQuestion(ctx, s)
+	} else {
+		asStatus, err = p.tc.StatusToAS(ctx, s)
+	}
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
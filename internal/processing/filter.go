@@ -0,0 +1,193 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+func (p *processor) FiltersGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.Filter, gtserror.WithCode) {
+	filters, err := p.db.GetFiltersForAccountID(ctx, authed.Account.ID)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiFilters := []*apimodel.Filter{}
+	for _, f := range filters {
+		apiFilter, err := p.tc.FilterToMasto(ctx, f)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiFilters = append(apiFilters, apiFilter)
+	}
+
+	return apiFilters, nil
+}
+
+func (p *processor) FilterGet(ctx context.Context, authed *oauth.Auth, filterID string) (*apimodel.Filter, gtserror.WithCode) {
+	filter, errWithCode := p.getOwnedFilter(ctx, authed.Account, filterID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiFilter, err := p.tc.FilterToMasto(ctx, filter)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiFilter, nil
+}
+
+func (p *processor) FilterCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.FilterCreateUpdateRequest) (*apimodel.Filter, gtserror.WithCode) {
+	filterID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	filter := &gtsmodel.Filter{
+		ID:        filterID,
+		AccountID: authed.Account.ID,
+	}
+
+	if errWithCode := applyFilterForm(filter, form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.db.PutFilter(ctx, filter); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("FilterCreate: error putting filter in db: %s", err))
+	}
+
+	// statuses that were already prepared for this account may now be filtered
+	p.timelineManager.WipeTimelinesForAccountID(ctx, authed.Account.ID)
+
+	apiFilter, err := p.tc.FilterToMasto(ctx, filter)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiFilter, nil
+}
+
+func (p *processor) FilterUpdate(ctx context.Context, authed *oauth.Auth, filterID string, form *apimodel.FilterCreateUpdateRequest) (*apimodel.Filter, gtserror.WithCode) {
+	filter, errWithCode := p.getOwnedFilter(ctx, authed.Account, filterID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if errWithCode := applyFilterForm(filter, form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.db.UpdateFilter(ctx, filter); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("FilterUpdate: error updating filter in db: %s", err))
+	}
+
+	p.timelineManager.WipeTimelinesForAccountID(ctx, authed.Account.ID)
+
+	apiFilter, err := p.tc.FilterToMasto(ctx, filter)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiFilter, nil
+}
+
+func (p *processor) FilterDelete(ctx context.Context, authed *oauth.Auth, filterID string) gtserror.WithCode {
+	filter, errWithCode := p.getOwnedFilter(ctx, authed.Account, filterID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.db.DeleteFilterByID(ctx, filter.ID); err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("FilterDelete: error deleting filter from db: %s", err))
+	}
+
+	// statuses that were dropped because of this filter should be shown again
+	p.timelineManager.WipeTimelinesForAccountID(ctx, authed.Account.ID)
+	return nil
+}
+
+// getOwnedFilter fetches the filter with the given ID from the db, and checks that it's owned by the given account.
+func (p *processor) getOwnedFilter(ctx context.Context, account *gtsmodel.Account, filterID string) (*gtsmodel.Filter, gtserror.WithCode) {
+	filter, err := p.db.GetFilterByID(ctx, filterID)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(fmt.Errorf("filter %s not found", filterID))
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if filter.AccountID != account.ID {
+		// don't reveal that the filter exists to accounts that don't own it
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("filter %s not owned by account %s", filterID, account.ID))
+	}
+
+	return filter, nil
+}
+
+// applyFilterForm validates the given form, and sets the fields of the given filter from it.
+//
+// Like the mastodon API, all fields of the filter are replaced, so unset optional fields go back to their defaults.
+func applyFilterForm(filter *gtsmodel.Filter, form *apimodel.FilterCreateUpdateRequest) gtserror.WithCode {
+	if err := util.ValidateFilterPhrase(form.Phrase); err != nil {
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if err := util.ValidateFilterContexts(form.Context); err != nil {
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	filter.Phrase = form.Phrase
+	filter.ContextHome = false
+	filter.ContextNotifications = false
+	filter.ContextPublic = false
+	filter.ContextThread = false
+	for _, c := range form.Context {
+		switch gtsmodel.FilterContext(c) {
+		case gtsmodel.FilterContextHome:
+			filter.ContextHome = true
+		case gtsmodel.FilterContextNotifications:
+			filter.ContextNotifications = true
+		case gtsmodel.FilterContextPublic:
+			filter.ContextPublic = true
+		case gtsmodel.FilterContextThread:
+			filter.ContextThread = true
+		}
+	}
+
+	filter.Irreversible = form.Irreversible != nil && *form.Irreversible
+	filter.WholeWord = form.WholeWord != nil && *form.WholeWord
+
+	filter.ExpiresAt = time.Time{}
+	if form.ExpiresIn != nil && *form.ExpiresIn > 0 {
+		filter.ExpiresAt = time.Now().Add(time.Duration(*form.ExpiresIn) * time.Second)
+	}
+
+	return nil
+}
@@ -20,6 +20,7 @@ package processing
 
 import (
 	"context"
+	"fmt"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -98,6 +99,56 @@ func (p *processor) FollowRequestAccept(ctx context.Context, auth *oauth.Auth, a
 	return r, nil
 }
 
-func (p *processor) FollowRequestDeny(ctx context.Context, auth *oauth.Auth) gtserror.WithCode {
-	return nil
+func (p *processor) FollowRequestDeny(ctx context.Context, auth *oauth.Auth, accountID string) (*apimodel.Relationship, gtserror.WithCode) {
+	fr := &gtsmodel.FollowRequest{}
+	if err := p.db.GetWhere(ctx, []db.Where{
+		{Key: "account_id", Value: accountID},
+		{Key: "target_account_id", Value: auth.Account.ID},
+	}, fr); err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(fmt.Errorf("no follow request from %s to %s", accountID, auth.Account.ID))
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if fr.Account == nil {
+		frAccount, err := p.db.GetAccountByID(ctx, fr.AccountID)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		fr.Account = frAccount
+	}
+
+	if err := p.db.DeleteByID(ctx, fr.ID, &gtsmodel.FollowRequest{}); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error deleting follow request %s: %s", fr.ID, err))
+	}
+
+	// the follow request notification is no use to anyone now
+	if err := p.db.DeleteWhere(ctx, []db.Where{
+		{Key: "notification_type", Value: gtsmodel.NotificationFollowRequest},
+		{Key: "origin_account_id", Value: fr.AccountID},
+		{Key: "target_account_id", Value: auth.Account.ID},
+	}, &gtsmodel.Notification{}); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error deleting follow request notification: %s", err))
+	}
+
+	p.fromClientAPI <- gtsmodel.FromClientAPI{
+		APObjectType:   gtsmodel.ActivityStreamsFollow,
+		APActivityType: gtsmodel.ActivityStreamsReject,
+		GTSModel:       fr,
+		OriginAccount:  fr.Account,
+		TargetAccount:  auth.Account,
+	}
+
+	gtsR, err := p.db.GetRelationship(ctx, auth.Account.ID, accountID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	r, err := p.tc.RelationshipToMasto(ctx, gtsR)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return r, nil
 }
@@ -34,6 +34,14 @@ func (p *processor) processFromClientAPI(ctx context.Context, clientMsg gtsmodel
 	case gtsmodel.ActivityStreamsCreate:
 		// CREATE
 		switch clientMsg.APObjectType {
+		case gtsmodel.ActivityStreamsProfile, gtsmodel.ActivityStreamsPerson:
+			// CREATE ACCOUNT/PROFILE
+			user, ok := clientMsg.GTSModel.(*gtsmodel.User)
+			if !ok {
+				return errors.New("user was not parseable as *gtsmodel.User")
+			}
+
+			return p.sendConfirmEmail(ctx, user)
 		case gtsmodel.ActivityStreamsNote:
 			// CREATE NOTE
 			status, ok := clientMsg.GTSModel.(*gtsmodel.Status)
@@ -49,9 +57,21 @@ func (p *processor) processFromClientAPI(ctx context.Context, clientMsg gtsmodel
 				return err
 			}
 
+			if err := p.conversationStatus(ctx, status); err != nil {
+				return err
+			}
+
 			if status.VisibilityAdvanced != nil && status.VisibilityAdvanced.Federated {
 				return p.federateStatus(ctx, status)
 			}
+		case gtsmodel.ActivityStreamsQuestion:
+			// CREATE POLL VOTE
+			vote, ok := clientMsg.GTSModel.(*gtsmodel.PollVote)
+			if !ok {
+				return errors.New("question was not parseable as *gtsmodel.PollVote")
+			}
+
+			return p.federatePollVote(ctx, vote)
 		case gtsmodel.ActivityStreamsFollow:
 			// CREATE FOLLOW REQUEST
 			followRequest, ok := clientMsg.GTSModel.(*gtsmodel.FollowRequest)
@@ -115,6 +135,25 @@ func (p *processor) processFromClientAPI(ctx context.Context, clientMsg gtsmodel
 	case gtsmodel.ActivityStreamsUpdate:
 		// UPDATE
 		switch clientMsg.APObjectType {
+		case gtsmodel.ActivityStreamsNote:
+			// UPDATE NOTE
+			status, ok := clientMsg.GTSModel.(*gtsmodel.Status)
+			if !ok {
+				return errors.New("note was not parseable as *gtsmodel.Status")
+			}
+
+			if err := p.refreshStatusInTimelines(ctx, status); err != nil {
+				return err
+			}
+
+			// notify anyone who was newly mentioned by the edit
+			if err := p.notifyStatus(ctx, status); err != nil {
+				return err
+			}
+
+			if status.VisibilityAdvanced != nil && status.VisibilityAdvanced.Federated {
+				return p.federateStatusUpdate(ctx, status)
+			}
 		case gtsmodel.ActivityStreamsProfile, gtsmodel.ActivityStreamsPerson:
 			// UPDATE ACCOUNT/PROFILE
 			account, ok := clientMsg.GTSModel.(*gtsmodel.Account)
@@ -124,6 +163,30 @@ func (p *processor) processFromClientAPI(ctx context.Context, clientMsg gtsmodel
 
 			return p.federateAccountUpdate(ctx, account, clientMsg.OriginAccount)
 		}
+	case gtsmodel.ActivityStreamsFlag:
+		// FLAG
+		switch clientMsg.APObjectType {
+		case gtsmodel.ActivityStreamsProfile:
+			// FLAG ACCOUNT
+			report, ok := clientMsg.GTSModel.(*gtsmodel.Report)
+			if !ok {
+				return errors.New("flag was not parseable as *gtsmodel.Report")
+			}
+
+			return p.federateReport(ctx, report)
+		}
+	case gtsmodel.ActivityStreamsMove:
+		// MOVE
+		switch clientMsg.APObjectType {
+		case gtsmodel.ActivityStreamsProfile, gtsmodel.ActivityStreamsPerson:
+			// MOVE ACCOUNT/PROFILE
+			if err := p.federateMove(ctx, clientMsg.OriginAccount, clientMsg.TargetAccount); err != nil {
+				return err
+			}
+
+			// remote followers will follow the target themselves when they get the move, but local followers need to be done here
+			return p.moveLocalFollowers(ctx, clientMsg.OriginAccount, clientMsg.TargetAccount)
+		}
 	case gtsmodel.ActivityStreamsAccept:
 		// ACCEPT
 		switch clientMsg.APObjectType {
@@ -140,6 +203,18 @@ func (p *processor) processFromClientAPI(ctx context.Context, clientMsg gtsmodel
 
 			return p.federateAcceptFollowRequest(ctx, follow, clientMsg.OriginAccount, clientMsg.TargetAccount)
 		}
+	case gtsmodel.ActivityStreamsReject:
+		// REJECT
+		switch clientMsg.APObjectType {
+		case gtsmodel.ActivityStreamsFollow:
+			// REJECT FOLLOW (REQUEST)
+			followRequest, ok := clientMsg.GTSModel.(*gtsmodel.FollowRequest)
+			if !ok {
+				return errors.New("reject was not parseable as *gtsmodel.FollowRequest")
+			}
+
+			return p.federateRejectFollowRequest(ctx, followRequest, clientMsg.OriginAccount, clientMsg.TargetAccount)
+		}
 	case gtsmodel.ActivityStreamsUndo:
 		// UNDO
 		switch clientMsg.APObjectType {
@@ -210,6 +285,26 @@ func (p *processor) processFromClientAPI(ctx context.Context, clientMsg gtsmodel
 				return err
 			}
 
+			// delete all bookmarks of this status
+			if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "status_id", Value: statusToDelete.ID}}, &[]*gtsmodel.StatusBookmark{}); err != nil {
+				return err
+			}
+
+			// delete the poll attached to this status, and any votes cast in it
+			if err := p.deleteStatusPoll(ctx, statusToDelete); err != nil {
+				return err
+			}
+
+			// delete all previous revisions of this status
+			if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "status_id", Value: statusToDelete.ID}}, &[]*gtsmodel.StatusEdit{}); err != nil {
+				return err
+			}
+
+			// remove this status from any direct message conversations
+			if err := p.deleteStatusFromConversations(ctx, statusToDelete); err != nil {
+				return err
+			}
+
 			// delete this status from any and all timelines
 			if err := p.deleteStatusFromTimelines(ctx, statusToDelete); err != nil {
 				return err
@@ -228,7 +323,7 @@ func (p *processor) processFromClientAPI(ctx context.Context, clientMsg gtsmodel
 				// origin is whichever account caused this message
 				origin = clientMsg.OriginAccount.ID
 			}
-			return p.accountProcessor.Delete(ctx, clientMsg.TargetAccount, origin)
+			return p.deleteAccount(ctx, clientMsg.TargetAccount, origin)
 		}
 	}
 	return nil
@@ -250,17 +345,155 @@ func (p *processor) federateStatus(ctx context.Context, status *gtsmodel.Status)
 		return nil
 	}
 
+	outboxIRI, err := url.Parse(status.Account.OutboxURI)
+	if err != nil {
+		return fmt.Errorf("federateStatus: error parsing outboxURI %s: %s", status.Account.OutboxURI, err)
+	}
+
+	if status.PollID != "" {
+		// statuses with polls are federated as questions, which we need to wrap in a create ourselves
+		asQuestion, err := p.tc.StatusToASQuestion(ctx, status)
+		if err != nil {
+			return fmt.Errorf("federateStatus: error converting status to as question: %s", err)
+		}
+
+		create, err := p.tc.WrapQuestionInCreate(asQuestion, status.Account)
+		if err != nil {
+			return fmt.Errorf("federateStatus: error wrapping question in create: %s", err)
+		}
+
+		_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, create)
+		return err
+	}
+
 	asStatus, err := p.tc.StatusToAS(ctx, status)
 	if err != nil {
 		return fmt.Errorf("federateStatus: error converting status to as format: %s", err)
 	}
 
+	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, asStatus)
+	return err
+}
+
+// federatePollVote sends the given vote to the owner of the poll it was cast in if the poll is remote,
+// or federates the poll's new vote counts if the poll is local.
+func (p *processor) federatePollVote(ctx context.Context, vote *gtsmodel.PollVote) error {
+	if vote.Poll == nil {
+		poll, err := p.db.GetPollByID(ctx, vote.PollID)
+		if err != nil {
+			return fmt.Errorf("federatePollVote: error fetching poll: %s", err)
+		}
+		vote.Poll = poll
+	}
+
+	pollStatus, err := p.db.GetStatusByID(ctx, vote.Poll.StatusID)
+	if err != nil {
+		return fmt.Errorf("federatePollVote: error fetching poll status: %s", err)
+	}
+
+	if pollStatus.Local {
+		// the vote counts of our poll have changed, so let everyone know
+		return p.federatePollUpdate(ctx, pollStatus)
+	}
+
+	if vote.Account == nil {
+		voter, err := p.db.GetAccountByID(ctx, vote.AccountID)
+		if err != nil {
+			return fmt.Errorf("federatePollVote: error fetching voting account: %s", err)
+		}
+		vote.Account = voter
+	}
+
+	outboxIRI, err := url.Parse(vote.Account.OutboxURI)
+	if err != nil {
+		return fmt.Errorf("federatePollVote: error parsing outboxURI %s: %s", vote.Account.OutboxURI, err)
+	}
+
+	creates, err := p.tc.PollVoteToASCreates(ctx, vote)
+	if err != nil {
+		return fmt.Errorf("federatePollVote: error converting vote to as creates: %s", err)
+	}
+
+	for _, create := range creates {
+		if _, err := p.federator.FederatingActor().Send(ctx, outboxIRI, create); err != nil {
+			return fmt.Errorf("federatePollVote: error sending vote: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// federatePollUpdate sends an Update with the current state of the poll attached to the given status,
+// so that remote instances can update their vote counts, or see that the poll has closed.
+//
+// This is a no-op for remote or unfederated statuses.
+func (p *processor) federatePollUpdate(ctx context.Context, status *gtsmodel.Status) error {
+	if !status.Local || status.VisibilityAdvanced == nil || !status.VisibilityAdvanced.Federated {
+		return nil
+	}
+
+	if status.Account == nil {
+		statusAccount, err := p.db.GetAccountByID(ctx, status.AccountID)
+		if err != nil {
+			return fmt.Errorf("federatePollUpdate: error fetching status author account: %s", err)
+		}
+		status.Account = statusAccount
+	}
+
+	asQuestion, err := p.tc.StatusToASQuestion(ctx, status)
+	if err != nil {
+		return fmt.Errorf("federatePollUpdate: error converting status to as question: %s", err)
+	}
+
+	update, err := p.tc.WrapQuestionInUpdate(asQuestion, status.Account)
+	if err != nil {
+		return fmt.Errorf("federatePollUpdate: error wrapping question in update: %s", err)
+	}
+
 	outboxIRI, err := url.Parse(status.Account.OutboxURI)
 	if err != nil {
-		return fmt.Errorf("federateStatus: error parsing outboxURI %s: %s", status.Account.OutboxURI, err)
+		return fmt.Errorf("federatePollUpdate: error parsing outboxURI %s: %s", status.Account.OutboxURI, err)
 	}
 
-	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, asStatus)
+	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, update)
+	return err
+}
+
+func (p *processor) federateStatusUpdate(ctx context.Context, status *gtsmodel.Status) error {
+	if status.Account == nil {
+		statusAccount, err := p.db.GetAccountByID(ctx, status.AccountID)
+		if err != nil {
+			return fmt.Errorf("federateStatusUpdate: error fetching status author account: %s", err)
+		}
+		status.Account = statusAccount
+	}
+
+	// do nothing if this isn't our status
+	if status.Account.Domain != "" {
+		return nil
+	}
+
+	if status.PollID != "" {
+		// statuses with polls are federated as questions
+		return p.federatePollUpdate(ctx, status)
+	}
+
+	asStatus, err := p.tc.StatusToAS(ctx, status)
+	if err != nil {
+		return fmt.Errorf("federateStatusUpdate: error converting status to as format: %s", err)
+	}
+
+	update, err := p.tc.WrapNoteInUpdate(asStatus, status.Account)
+	if err != nil {
+		return fmt.Errorf("federateStatusUpdate: error wrapping note in update: %s", err)
+	}
+
+	outboxIRI, err := url.Parse(status.Account.OutboxURI)
+	if err != nil {
+		return fmt.Errorf("federateStatusUpdate: error parsing outboxURI %s: %s", status.Account.OutboxURI, err)
+	}
+
+	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, update)
 	return err
 }
 
@@ -501,6 +734,57 @@ func (p *processor) federateAcceptFollowRequest(ctx context.Context, follow *gts
 	return err
 }
 
+func (p *processor) federateRejectFollowRequest(ctx context.Context, followRequest *gtsmodel.FollowRequest, originAccount *gtsmodel.Account, targetAccount *gtsmodel.Account) error {
+	// only remote requesters need to be told about the rejection
+	if originAccount.Domain == "" {
+		return nil
+	}
+
+	// recreate the AS follow
+	follow := p.tc.FollowRequestToFollow(ctx, followRequest)
+	asFollow, err := p.tc.FollowToAS(ctx, follow, originAccount, targetAccount)
+	if err != nil {
+		return fmt.Errorf("federateRejectFollowRequest: error converting follow to as format: %s", err)
+	}
+
+	rejectingAccountURI, err := url.Parse(targetAccount.URI)
+	if err != nil {
+		return fmt.Errorf("error parsing uri %s: %s", targetAccount.URI, err)
+	}
+
+	requestingAccountURI, err := url.Parse(originAccount.URI)
+	if err != nil {
+		return fmt.Errorf("error parsing uri %s: %s", originAccount.URI, err)
+	}
+
+	// create a Reject
+	reject := streams.NewActivityStreamsReject()
+
+	// set the rejecting actor on it
+	rejectActorProp := streams.NewActivityStreamsActorProperty()
+	rejectActorProp.AppendIRI(rejectingAccountURI)
+	reject.SetActivityStreamsActor(rejectActorProp)
+
+	// Set the recreated follow as the 'object' property.
+	rejectObject := streams.NewActivityStreamsObjectProperty()
+	rejectObject.AppendActivityStreamsFollow(asFollow)
+	reject.SetActivityStreamsObject(rejectObject)
+
+	// Set the To of the reject as the originator of the follow
+	rejectTo := streams.NewActivityStreamsToProperty()
+	rejectTo.AppendIRI(requestingAccountURI)
+	reject.SetActivityStreamsTo(rejectTo)
+
+	outboxIRI, err := url.Parse(targetAccount.OutboxURI)
+	if err != nil {
+		return fmt.Errorf("federateRejectFollowRequest: error parsing outboxURI %s: %s", targetAccount.OutboxURI, err)
+	}
+
+	// send off the reject using the rejecter's outbox
+	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, reject)
+	return err
+}
+
 func (p *processor) federateFave(ctx context.Context, fave *gtsmodel.StatusFave, originAccount *gtsmodel.Account, targetAccount *gtsmodel.Account) error {
 	// if both accounts are local there's nothing to do here
 	if originAccount.Domain == "" && targetAccount.Domain == "" {
@@ -592,6 +876,47 @@ func (p *processor) federateBlock(ctx context.Context, block *gtsmodel.Block) er
 	return err
 }
 
+func (p *processor) federateReport(ctx context.Context, report *gtsmodel.Report) error {
+	// only reports that the reporter asked us to forward to a remote instance are federated
+	if !report.Forwarded {
+		return nil
+	}
+
+	flag, err := p.tc.ReportToASFlag(ctx, report)
+	if err != nil {
+		return fmt.Errorf("federateReport: error converting report to AS format: %s", err)
+	}
+
+	// flags are sent from the instance account, not the account that made the report
+	instanceAccount, err := p.db.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return fmt.Errorf("federateReport: error getting instance account from database: %s", err)
+	}
+
+	outboxIRI, err := url.Parse(instanceAccount.OutboxURI)
+	if err != nil {
+		return fmt.Errorf("federateReport: error parsing outboxURI %s: %s", instanceAccount.OutboxURI, err)
+	}
+
+	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, flag)
+	return err
+}
+
+func (p *processor) federateMove(ctx context.Context, origin *gtsmodel.Account, target *gtsmodel.Account) error {
+	move, err := p.tc.AccountToASMove(ctx, origin, target)
+	if err != nil {
+		return fmt.Errorf("federateMove: error converting move to AS format: %s", err)
+	}
+
+	outboxIRI, err := url.Parse(origin.OutboxURI)
+	if err != nil {
+		return fmt.Errorf("federateMove: error parsing outboxURI %s: %s", origin.OutboxURI, err)
+	}
+
+	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, move)
+	return err
+}
+
 func (p *processor) federateUnblock(ctx context.Context, block *gtsmodel.Block) error {
 	if block.Account == nil {
 		blockAccount, err := p.db.GetAccountByID(ctx, block.AccountID)
@@ -21,8 +21,10 @@ package processing
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -60,6 +62,20 @@ func (p *processor) notifyStatus(ctx context.Context, status *gtsmodel.Status) e
 			continue
 		}
 
+		// make sure the mentioned account hasn't muted notifications from the status author
+		if muted, err := p.notificationsMuted(ctx, m.TargetAccountID, status.AccountID); err != nil {
+			return fmt.Errorf("notifyStatus: error checking mute for mention with id %s: %s", m.ID, err)
+		} else if muted {
+			continue
+		}
+
+		// make sure the mentioned account hasn't muted the thread this status belongs to
+		if muted, err := p.db.IsStatusMutedBy(ctx, status, m.TargetAccountID); err != nil {
+			return fmt.Errorf("notifyStatus: error checking thread mute for mention with id %s: %s", m.ID, err)
+		} else if muted {
+			continue
+		}
+
 		// make sure a notif doesn't already exist for this mention
 		err := p.db.GetWhere(ctx, []db.Where{
 			{Key: "notification_type", Value: gtsmodel.NotificationMention},
@@ -97,13 +113,18 @@ func (p *processor) notifyStatus(ctx context.Context, status *gtsmodel.Status) e
 			return fmt.Errorf("notifyStatus: error putting notification in database: %s", err)
 		}
 
+		// queue an email about the notification, if the user wants one
+		if err := p.userProcessor.QueueNotificationEmail(ctx, notif); err != nil {
+			p.log.Errorf("notifyStatus: error queueing notification email: %s", err)
+		}
+
 		// now stream the notification to the user
 		mastoNotif, err := p.tc.NotificationToMasto(ctx, notif)
 		if err != nil {
 			return fmt.Errorf("notifyStatus: error converting notification to masto representation: %s", err)
 		}
 
-		if err := p.streamingProcessor.StreamNotificationToAccount(mastoNotif, m.TargetAccount); err != nil {
+		if err := p.streamingProcessor.StreamNotificationToAccount(ctx, mastoNotif, m.TargetAccount); err != nil {
 			return fmt.Errorf("notifyStatus: error streaming notification to account: %s", err)
 		}
 	}
@@ -111,12 +132,39 @@ func (p *processor) notifyStatus(ctx context.Context, status *gtsmodel.Status) e
 	return nil
 }
 
+// notificationsMuted returns true if targetAccountID has an active mute against originAccountID which also covers notifications,
+// or has blocked the domain of originAccountID, meaning that no notifications should be created for targetAccountID as a result
+// of things originAccountID does.
+func (p *processor) notificationsMuted(ctx context.Context, targetAccountID string, originAccountID string) (bool, error) {
+	mute, err := p.db.GetMute(ctx, targetAccountID, originAccountID)
+	if err == nil {
+		if mute.Notifications {
+			return true, nil
+		}
+	} else if err != db.ErrNoEntries {
+		return false, err
+	}
+
+	originAccount, err := p.db.GetAccountByID(ctx, originAccountID)
+	if err != nil {
+		return false, err
+	}
+
+	return p.db.IsDomainBlockedByAccount(ctx, targetAccountID, originAccount.Domain)
+}
+
 func (p *processor) notifyFollowRequest(ctx context.Context, followRequest *gtsmodel.FollowRequest, receivingAccount *gtsmodel.Account) error {
 	// return if this isn't a local account
 	if receivingAccount.Domain != "" {
 		return nil
 	}
 
+	if muted, err := p.notificationsMuted(ctx, followRequest.TargetAccountID, followRequest.AccountID); err != nil {
+		return fmt.Errorf("notifyFollowRequest: error checking mute: %s", err)
+	} else if muted {
+		return nil
+	}
+
 	notifID, err := id.NewULID()
 	if err != nil {
 		return err
@@ -133,13 +181,18 @@ func (p *processor) notifyFollowRequest(ctx context.Context, followRequest *gtsm
 		return fmt.Errorf("notifyFollowRequest: error putting notification in database: %s", err)
 	}
 
+	// queue an email about the notification, if the user wants one
+	if err := p.userProcessor.QueueNotificationEmail(ctx, notif); err != nil {
+		p.log.Errorf("notifyFollowRequest: error queueing notification email: %s", err)
+	}
+
 	// now stream the notification to the user
 	mastoNotif, err := p.tc.NotificationToMasto(ctx, notif)
 	if err != nil {
 		return fmt.Errorf("notifyStatus: error converting notification to masto representation: %s", err)
 	}
 
-	if err := p.streamingProcessor.StreamNotificationToAccount(mastoNotif, receivingAccount); err != nil {
+	if err := p.streamingProcessor.StreamNotificationToAccount(ctx, mastoNotif, receivingAccount); err != nil {
 		return fmt.Errorf("notifyStatus: error streaming notification to account: %s", err)
 	}
 
@@ -161,6 +214,12 @@ func (p *processor) notifyFollow(ctx context.Context, follow *gtsmodel.Follow, t
 		return fmt.Errorf("notifyFollow: error removing old follow request notification from database: %s", err)
 	}
 
+	if muted, err := p.notificationsMuted(ctx, follow.TargetAccountID, follow.AccountID); err != nil {
+		return fmt.Errorf("notifyFollow: error checking mute: %s", err)
+	} else if muted {
+		return nil
+	}
+
 	// now create the new follow notification
 	notifID, err := id.NewULID()
 	if err != nil {
@@ -179,13 +238,18 @@ func (p *processor) notifyFollow(ctx context.Context, follow *gtsmodel.Follow, t
 		return fmt.Errorf("notifyFollow: error putting notification in database: %s", err)
 	}
 
+	// queue an email about the notification, if the user wants one
+	if err := p.userProcessor.QueueNotificationEmail(ctx, notif); err != nil {
+		p.log.Errorf("notifyFollow: error queueing notification email: %s", err)
+	}
+
 	// now stream the notification to the user
 	mastoNotif, err := p.tc.NotificationToMasto(ctx, notif)
 	if err != nil {
 		return fmt.Errorf("notifyStatus: error converting notification to masto representation: %s", err)
 	}
 
-	if err := p.streamingProcessor.StreamNotificationToAccount(mastoNotif, targetAccount); err != nil {
+	if err := p.streamingProcessor.StreamNotificationToAccount(ctx, mastoNotif, targetAccount); err != nil {
 		return fmt.Errorf("notifyStatus: error streaming notification to account: %s", err)
 	}
 
@@ -198,6 +262,26 @@ func (p *processor) notifyFave(ctx context.Context, fave *gtsmodel.StatusFave, t
 		return nil
 	}
 
+	if muted, err := p.notificationsMuted(ctx, fave.TargetAccountID, fave.AccountID); err != nil {
+		return fmt.Errorf("notifyFave: error checking mute: %s", err)
+	} else if muted {
+		return nil
+	}
+
+	if fave.Status == nil {
+		favedStatus, err := p.db.GetStatusByID(ctx, fave.StatusID)
+		if err != nil {
+			return fmt.Errorf("notifyFave: error getting status with id %s: %s", fave.StatusID, err)
+		}
+		fave.Status = favedStatus
+	}
+
+	if muted, err := p.db.IsStatusMutedBy(ctx, fave.Status, fave.TargetAccountID); err != nil {
+		return fmt.Errorf("notifyFave: error checking thread mute: %s", err)
+	} else if muted {
+		return nil
+	}
+
 	notifID, err := id.NewULID()
 	if err != nil {
 		return err
@@ -224,7 +308,7 @@ func (p *processor) notifyFave(ctx context.Context, fave *gtsmodel.StatusFave, t
 		return fmt.Errorf("notifyStatus: error converting notification to masto representation: %s", err)
 	}
 
-	if err := p.streamingProcessor.StreamNotificationToAccount(mastoNotif, targetAccount); err != nil {
+	if err := p.streamingProcessor.StreamNotificationToAccount(ctx, mastoNotif, targetAccount); err != nil {
 		return fmt.Errorf("notifyStatus: error streaming notification to account: %s", err)
 	}
 
@@ -254,7 +338,7 @@ func (p *processor) notifyAnnounce(ctx context.Context, status *gtsmodel.Status)
 		status.BoostOfAccount = boostedAcct
 	}
 
-	if status.BoostOfAccount.Domain == "" {
+	if status.BoostOfAccount.Domain != "" {
 		// remote account, nothing to do
 		return nil
 	}
@@ -264,6 +348,18 @@ func (p *processor) notifyAnnounce(ctx context.Context, status *gtsmodel.Status)
 		return nil
 	}
 
+	if muted, err := p.notificationsMuted(ctx, status.BoostOfAccountID, status.AccountID); err != nil {
+		return fmt.Errorf("notifyAnnounce: error checking mute: %s", err)
+	} else if muted {
+		return nil
+	}
+
+	if muted, err := p.db.IsStatusMutedBy(ctx, status.BoostOf, status.BoostOfAccountID); err != nil {
+		return fmt.Errorf("notifyAnnounce: error checking thread mute: %s", err)
+	} else if muted {
+		return nil
+	}
+
 	// make sure a notif doesn't already exist for this announce
 	err := p.db.GetWhere(ctx, []db.Where{
 		{Key: "notification_type", Value: gtsmodel.NotificationReblog},
@@ -303,13 +399,87 @@ func (p *processor) notifyAnnounce(ctx context.Context, status *gtsmodel.Status)
 		return fmt.Errorf("notifyStatus: error converting notification to masto representation: %s", err)
 	}
 
-	if err := p.streamingProcessor.StreamNotificationToAccount(mastoNotif, status.BoostOfAccount); err != nil {
+	if err := p.streamingProcessor.StreamNotificationToAccount(ctx, mastoNotif, status.BoostOfAccount); err != nil {
 		return fmt.Errorf("notifyStatus: error streaming notification to account: %s", err)
 	}
 
 	return nil
 }
 
+// notifyPollEnded notifies the owner of the given poll (if local), and any local accounts that voted in it, that the poll has ended.
+func (p *processor) notifyPollEnded(ctx context.Context, poll *gtsmodel.Poll, pollStatus *gtsmodel.Status) error {
+	if pollStatus.Account == nil {
+		a, err := p.db.GetAccountByID(ctx, pollStatus.AccountID)
+		if err != nil {
+			return fmt.Errorf("notifyPollEnded: error getting account with id %s from the db: %s", pollStatus.AccountID, err)
+		}
+		pollStatus.Account = a
+	}
+
+	// work out who we need to notify
+	targetAccounts := []*gtsmodel.Account{}
+	if pollStatus.Account.Domain == "" {
+		targetAccounts = append(targetAccounts, pollStatus.Account)
+	}
+
+	votes, err := p.db.GetPollVotes(ctx, poll.ID)
+	if err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("notifyPollEnded: error getting votes for poll %s: %s", poll.ID, err)
+	}
+	for _, v := range votes {
+		voter, err := p.db.GetAccountByID(ctx, v.AccountID)
+		if err != nil {
+			return fmt.Errorf("notifyPollEnded: error getting account with id %s from the db: %s", v.AccountID, err)
+		}
+		if voter.Domain != "" {
+			// not a local account so skip it
+			continue
+		}
+		targetAccounts = append(targetAccounts, voter)
+	}
+
+	for _, targetAccount := range targetAccounts {
+		// make sure the voter hasn't muted notifications from the poll owner
+		if muted, err := p.notificationsMuted(ctx, targetAccount.ID, pollStatus.AccountID); err != nil {
+			return fmt.Errorf("notifyPollEnded: error checking mute: %s", err)
+		} else if muted {
+			continue
+		}
+
+		notifID, err := id.NewULID()
+		if err != nil {
+			return err
+		}
+
+		notif := &gtsmodel.Notification{
+			ID:               notifID,
+			NotificationType: gtsmodel.NotificationPoll,
+			TargetAccountID:  targetAccount.ID,
+			TargetAccount:    targetAccount,
+			OriginAccountID:  pollStatus.AccountID,
+			OriginAccount:    pollStatus.Account,
+			StatusID:         pollStatus.ID,
+			Status:           pollStatus,
+		}
+
+		if err := p.db.Put(ctx, notif); err != nil {
+			return fmt.Errorf("notifyPollEnded: error putting notification in database: %s", err)
+		}
+
+		// now stream the notification to the user
+		mastoNotif, err := p.tc.NotificationToMasto(ctx, notif)
+		if err != nil {
+			return fmt.Errorf("notifyPollEnded: error converting notification to masto representation: %s", err)
+		}
+
+		if err := p.streamingProcessor.StreamNotificationToAccount(ctx, mastoNotif, targetAccount); err != nil {
+			return fmt.Errorf("notifyPollEnded: error streaming notification to account: %s", err)
+		}
+	}
+
+	return nil
+}
+
 func (p *processor) timelineStatus(ctx context.Context, status *gtsmodel.Status) error {
 	// make sure the author account is pinned onto the status
 	if status.Account == nil {
@@ -334,12 +504,34 @@ func (p *processor) timelineStatus(ctx context.Context, status *gtsmodel.Status)
 		})
 	}
 
+	// add fake entries for local accounts that follow one of the status' tags, if they aren't already covered by a follow
+	tagFollows, err := p.statusTagFollows(ctx, status)
+	if err != nil {
+		return fmt.Errorf("timelineStatus: error getting tag follows for status %s: %s", status.ID, err)
+	}
+
+	covered := make(map[string]bool, len(follows))
+	for _, f := range follows {
+		covered[f.AccountID] = true
+	}
+
+	for _, tf := range tagFollows {
+		if covered[tf.AccountID] {
+			continue
+		}
+		covered[tf.AccountID] = true
+		follows = append(follows, &gtsmodel.Follow{
+			AccountID: tf.AccountID,
+		})
+	}
+
 	wg := sync.WaitGroup{}
-	wg.Add(len(follows))
-	errors := make(chan error, len(follows))
+	wg.Add(len(follows) * 2)
+	errors := make(chan error, len(follows)*2)
 
 	for _, f := range follows {
 		go p.timelineStatusForAccount(ctx, status, f.AccountID, errors, &wg)
+		go p.timelineStatusForLists(ctx, status, f, errors, &wg)
 	}
 
 	// read any errors that come in from the async functions
@@ -366,6 +558,16 @@ func (p *processor) timelineStatus(ctx context.Context, status *gtsmodel.Status)
 	return nil
 }
 
+// statusTagFollows returns the follows of any of the tags used in the given status.
+// Only public statuses that aren't boosts are brought into timelines by tag follows.
+func (p *processor) statusTagFollows(ctx context.Context, status *gtsmodel.Status) ([]*gtsmodel.TagFollow, error) {
+	if status.Visibility != gtsmodel.VisibilityPublic || status.BoostOfID != "" || len(status.TagIDs) == 0 {
+		return nil, nil
+	}
+
+	return p.db.GetTagFollowsForTagIDs(ctx, status.TagIDs)
+}
+
 func (p *processor) timelineStatusForAccount(ctx context.Context, status *gtsmodel.Status, accountID string, errors chan error, wg *sync.WaitGroup) {
 	defer wg.Done()
 
@@ -376,8 +578,14 @@ func (p *processor) timelineStatusForAccount(ctx context.Context, status *gtsmod
 		return
 	}
 
+	filtered, err := p.filter.StatusFilterer(ctx, timelineAccount, gtsmodel.FilterContextHome)
+	if err != nil {
+		errors <- fmt.Errorf("timelineStatusForAccount: error getting filters for timeline with id %s: %s", accountID, err)
+		return
+	}
+
 	// make sure the status is timelineable
-	timelineable, err := p.filter.StatusHometimelineable(ctx, status, timelineAccount)
+	timelineable, err := p.filter.StatusHometimelineable(ctx, status, timelineAccount, filtered)
 	if err != nil {
 		errors <- fmt.Errorf("timelineStatusForAccount: error getting timelineability for status for timeline with id %s: %s", accountID, err)
 		return
@@ -416,6 +624,68 @@ func (p *processor) timelineStatusForAccount(ctx context.Context, status *gtsmod
 	}
 }
 
+func (p *processor) timelineStatusForLists(ctx context.Context, status *gtsmodel.Status, follow *gtsmodel.Follow, errors chan error, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	// if there's no follow ID, this is either the poster's own status or a status brought in by a tag follow,
+	// neither of which can be in any lists
+	if follow.ID == "" {
+		return
+	}
+
+	// get the lists that the follower has put the status author into
+	entries, err := p.db.GetListEntriesForFollowID(ctx, follow.ID)
+	if err != nil {
+		if err != db.ErrNoEntries {
+			errors <- fmt.Errorf("timelineStatusForLists: error getting list entries for follow with id %s: %s", follow.ID, err)
+		}
+		return
+	}
+
+	if len(entries) == 0 {
+		return
+	}
+
+	// get the list owner account
+	timelineAccount, err := p.db.GetAccountByID(ctx, follow.AccountID)
+	if err != nil {
+		errors <- fmt.Errorf("timelineStatusForLists: error getting account for list timeline with id %s: %s", follow.AccountID, err)
+		return
+	}
+
+	// list timelines use the home filters of the list owner, so they only need preparing once for all the lists
+	filtered, err := p.filter.StatusFilterer(ctx, timelineAccount, gtsmodel.FilterContextHome)
+	if err != nil {
+		errors <- fmt.Errorf("timelineStatusForLists: error getting filters for list timelines of account with id %s: %s", follow.AccountID, err)
+		return
+	}
+
+	for _, e := range entries {
+		list, err := p.db.GetListByID(ctx, e.ListID)
+		if err != nil {
+			errors <- fmt.Errorf("timelineStatusForLists: error getting list with id %s: %s", e.ListID, err)
+			return
+		}
+
+		// make sure the status is timelineable in this list
+		timelineable, err := p.filter.StatusListTimelineable(ctx, status, list, timelineAccount, filtered)
+		if err != nil {
+			errors <- fmt.Errorf("timelineStatusForLists: error getting timelineability for status for list with id %s: %s", list.ID, err)
+			return
+		}
+
+		if !timelineable {
+			continue
+		}
+
+		// stick the status in the list timeline and then immediately prepare it so it can be seen right away
+		if _, err := p.timelineManager.IngestAndPrepareIntoList(ctx, status, list.ID); err != nil {
+			errors <- fmt.Errorf("timelineStatusForLists: error ingesting status %s into list %s: %s", status.ID, list.ID, err)
+			return
+		}
+	}
+}
+
 func (p *processor) deleteStatusFromTimelines(ctx context.Context, status *gtsmodel.Status) error {
 	if err := p.timelineManager.WipeStatusFromAllTimelines(ctx, status.ID); err != nil {
 		return err
@@ -423,3 +693,267 @@ func (p *processor) deleteStatusFromTimelines(ctx context.Context, status *gtsmo
 
 	return p.streamingProcessor.StreamDelete(status.ID)
 }
+
+// refreshStatusInTimelines re-prepares the given edited status anywhere it appears in timelines,
+// and streams the updated version of it to the author and their local followers, if they can see it.
+func (p *processor) refreshStatusInTimelines(ctx context.Context, status *gtsmodel.Status) error {
+	if err := p.timelineManager.RefreshStatusInAllTimelines(ctx, status.ID); err != nil {
+		return err
+	}
+
+	// make sure the author account is pinned onto the status
+	if status.Account == nil {
+		a, err := p.db.GetAccountByID(ctx, status.AccountID)
+		if err != nil {
+			return fmt.Errorf("refreshStatusInTimelines: error getting author account with id %s: %s", status.AccountID, err)
+		}
+		status.Account = a
+	}
+
+	// get local followers of the account that posted the status
+	follows, err := p.db.GetAccountFollowedBy(ctx, status.AccountID, true)
+	if err != nil {
+		return fmt.Errorf("refreshStatusInTimelines: error getting followers for account id %s: %s", status.AccountID, err)
+	}
+
+	accountIDs := []string{}
+	for _, f := range follows {
+		accountIDs = append(accountIDs, f.AccountID)
+	}
+
+	// if the poster is local, they should see the update of their own status too
+	if status.Account.Domain == "" {
+		accountIDs = append(accountIDs, status.AccountID)
+	}
+
+	for _, accountID := range accountIDs {
+		streamAccount, err := p.db.GetAccountByID(ctx, accountID)
+		if err != nil {
+			return fmt.Errorf("refreshStatusInTimelines: error getting account with id %s: %s", accountID, err)
+		}
+
+		visible, err := p.filter.StatusVisible(ctx, status, streamAccount)
+		if err != nil {
+			return fmt.Errorf("refreshStatusInTimelines: error checking visibility of status %s: %s", status.ID, err)
+		}
+		if !visible {
+			continue
+		}
+
+		mastoStatus, err := p.tc.StatusToMasto(ctx, status, streamAccount)
+		if err != nil {
+			return fmt.Errorf("refreshStatusInTimelines: error converting status %s to frontend representation: %s", status.ID, err)
+		}
+
+		if err := p.streamingProcessor.StreamStatusUpdateToAccount(mastoStatus, streamAccount); err != nil {
+			return fmt.Errorf("refreshStatusInTimelines: error streaming status update %s: %s", status.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// conversationStatus adds the given direct status to the conversations of any local accounts taking part in it,
+// starting new conversations where necessary, and streams the updated conversations to those accounts.
+func (p *processor) conversationStatus(ctx context.Context, status *gtsmodel.Status) error {
+	if status.Visibility != gtsmodel.VisibilityDirect || status.BoostOfID != "" {
+		return nil
+	}
+
+	// make sure the author account is pinned onto the status
+	if status.Account == nil {
+		a, err := p.db.GetAccountByID(ctx, status.AccountID)
+		if err != nil {
+			return fmt.Errorf("conversationStatus: error getting author account with id %s: %s", status.AccountID, err)
+		}
+		status.Account = a
+	}
+
+	// the participants in a direct conversation are the author and everyone they mentioned
+	participants := map[string]*gtsmodel.Account{
+		status.AccountID: status.Account,
+	}
+
+	if status.Mentions == nil && len(status.MentionIDs) != 0 {
+		menchies, err := p.db.GetMentions(ctx, status.MentionIDs)
+		if err != nil {
+			return fmt.Errorf("conversationStatus: error getting mentions for status %s from the db: %s", status.ID, err)
+		}
+		status.Mentions = menchies
+	}
+
+	for _, m := range status.Mentions {
+		if m.TargetAccount == nil {
+			a, err := p.db.GetAccountByID(ctx, m.TargetAccountID)
+			if err != nil {
+				return fmt.Errorf("conversationStatus: error getting account with id %s from the db: %s", m.TargetAccountID, err)
+			}
+			m.TargetAccount = a
+		}
+		participants[m.TargetAccountID] = m.TargetAccount
+	}
+
+	participantIDs := make([]string, 0, len(participants))
+	for participantID := range participants {
+		participantIDs = append(participantIDs, participantID)
+	}
+	sort.Strings(participantIDs)
+
+	for _, ownerID := range participantIDs {
+		owner := participants[ownerID]
+		if owner.Domain != "" {
+			// only local accounts have conversations
+			continue
+		}
+
+		visible, err := p.filter.StatusVisible(ctx, status, owner)
+		if err != nil {
+			return fmt.Errorf("conversationStatus: error checking visibility of status %s for account %s: %s", status.ID, ownerID, err)
+		}
+		if !visible {
+			continue
+		}
+
+		otherAccountIDs := []string{}
+		for _, participantID := range participantIDs {
+			if participantID != ownerID {
+				otherAccountIDs = append(otherAccountIDs, participantID)
+			}
+		}
+
+		if err := p.conversationStatusForAccount(ctx, status, owner, otherAccountIDs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *processor) conversationStatusForAccount(ctx context.Context, status *gtsmodel.Status, owner *gtsmodel.Account, otherAccountIDs []string) error {
+	threadID, err := p.conversationThreadID(ctx, status, owner.ID)
+	if err != nil {
+		return err
+	}
+
+	otherAccountsKey := strings.Join(otherAccountIDs, ",")
+
+	conversation, err := p.db.GetConversationByThreadAndAccounts(ctx, owner.ID, threadID, otherAccountsKey)
+	if err != nil {
+		if err != db.ErrNoEntries {
+			return fmt.Errorf("conversationStatusForAccount: error getting conversation for account %s: %s", owner.ID, err)
+		}
+
+		// no conversation yet between these participants in this thread, so start one
+		conversationID, err := id.NewULID()
+		if err != nil {
+			return err
+		}
+
+		conversation = &gtsmodel.Conversation{
+			ID:               conversationID,
+			AccountID:        owner.ID,
+			ThreadID:         threadID,
+			OtherAccountIDs:  otherAccountIDs,
+			OtherAccountsKey: otherAccountsKey,
+			LastStatusID:     status.ID,
+			LastStatus:       status,
+			Read:             owner.ID == status.AccountID,
+		}
+
+		if err := p.db.PutConversation(ctx, conversation); err != nil {
+			return fmt.Errorf("conversationStatusForAccount: error putting conversation for account %s: %s", owner.ID, err)
+		}
+	} else {
+		// statuses can arrive out of order when federating, so only move the conversation forward
+		if status.ID > conversation.LastStatusID {
+			conversation.LastStatusID = status.ID
+			conversation.LastStatus = status
+		}
+		conversation.Read = owner.ID == status.AccountID
+		conversation.UpdatedAt = time.Now()
+
+		if err := p.db.UpdateConversation(ctx, conversation); err != nil {
+			return fmt.Errorf("conversationStatusForAccount: error updating conversation %s: %s", conversation.ID, err)
+		}
+	}
+
+	if err := p.db.PutConversationToStatus(ctx, conversation.ID, status.ID); err != nil && err != db.ErrAlreadyExists {
+		return fmt.Errorf("conversationStatusForAccount: error adding status %s to conversation %s: %s", status.ID, conversation.ID, err)
+	}
+
+	mastoConversation, err := p.tc.ConversationToMasto(ctx, conversation, owner)
+	if err != nil {
+		return fmt.Errorf("conversationStatusForAccount: error converting conversation %s to frontend representation: %s", conversation.ID, err)
+	}
+
+	if err := p.streamingProcessor.StreamConversationToAccount(mastoConversation, owner); err != nil {
+		return fmt.Errorf("conversationStatusForAccount: error streaming conversation %s: %s", conversation.ID, err)
+	}
+
+	return nil
+}
+
+// conversationThreadID works out which thread the given status belongs to, from the point of view of the given account.
+//
+// A reply to a status that's already in one of the account's conversations joins that conversation's thread;
+// any other reply starts a thread rooted at the status it replies to, and a status that isn't a reply starts its own.
+func (p *processor) conversationThreadID(ctx context.Context, status *gtsmodel.Status, accountID string) (string, error) {
+	if status.InReplyToID == "" {
+		return status.ID, nil
+	}
+
+	conversation, err := p.db.GetConversationForStatusID(ctx, accountID, status.InReplyToID)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return status.InReplyToID, nil
+		}
+		return "", fmt.Errorf("conversationThreadID: error getting conversation for status %s: %s", status.InReplyToID, err)
+	}
+
+	return conversation.ThreadID, nil
+}
+
+// deleteStatusFromConversations removes the given status from any conversations it belongs to.
+// Conversations that had it as their last status fall back to the newest status left in them,
+// and conversations with no statuses left are removed altogether.
+func (p *processor) deleteStatusFromConversations(ctx context.Context, status *gtsmodel.Status) error {
+	if status.Visibility != gtsmodel.VisibilityDirect {
+		return nil
+	}
+
+	conversations, err := p.db.GetConversationsForStatusID(ctx, status.ID)
+	if err != nil {
+		return fmt.Errorf("deleteStatusFromConversations: error getting conversations for status %s: %s", status.ID, err)
+	}
+
+	if err := p.db.DeleteStatusFromConversations(ctx, status.ID); err != nil {
+		return fmt.Errorf("deleteStatusFromConversations: error removing status %s from conversations: %s", status.ID, err)
+	}
+
+	for _, c := range conversations {
+		if c.LastStatusID != status.ID {
+			continue
+		}
+
+		lastStatusID, err := p.db.GetConversationLastStatusID(ctx, c.ID)
+		if err != nil {
+			if err != db.ErrNoEntries {
+				return fmt.Errorf("deleteStatusFromConversations: error getting last status of conversation %s: %s", c.ID, err)
+			}
+
+			// nothing left in this conversation
+			if err := p.db.DeleteConversationByID(ctx, c.ID); err != nil {
+				return fmt.Errorf("deleteStatusFromConversations: error deleting conversation %s: %s", c.ID, err)
+			}
+			continue
+		}
+
+		c.LastStatusID = lastStatusID
+		c.UpdatedAt = time.Now()
+		if err := p.db.UpdateConversation(ctx, c); err != nil {
+			return fmt.Errorf("deleteStatusFromConversations: error updating conversation %s: %s", c.ID, err)
+		}
+	}
+
+	return nil
+}
@@ -23,11 +23,14 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
 )
 
 func (p *processor) processFromFederator(ctx context.Context, federatorMsg gtsmodel.FromFederator) error {
@@ -61,6 +64,34 @@ func (p *processor) processFromFederator(ctx context.Context, federatorMsg gtsmo
 			if err := p.notifyStatus(ctx, status); err != nil {
 				return err
 			}
+
+			if err := p.conversationStatus(ctx, status); err != nil {
+				return err
+			}
+		case gtsmodel.ActivityStreamsQuestion:
+			// CREATE A VOTE IN A LOCAL POLL
+			incomingVote, ok := federatorMsg.GTSModel.(*gtsmodel.PollVote)
+			if !ok {
+				return errors.New("vote was not parseable as *gtsmodel.PollVote")
+			}
+
+			poll, err := p.db.GetPollByID(ctx, incomingVote.PollID)
+			if err != nil {
+				return err
+			}
+
+			if err := p.recountPoll(ctx, poll); err != nil {
+				return err
+			}
+
+			pollStatus, err := p.db.GetStatusByID(ctx, poll.StatusID)
+			if err != nil {
+				return err
+			}
+
+			if err := p.federatePollUpdate(ctx, pollStatus); err != nil {
+				return err
+			}
 		case gtsmodel.ActivityStreamsProfile:
 			// CREATE AN ACCOUNT
 			// nothing to do here
@@ -81,6 +112,21 @@ func (p *processor) processFromFederator(ctx context.Context, federatorMsg gtsmo
 				return errors.New("incomingFollowRequest was not parseable as *gtsmodel.FollowRequest")
 			}
 
+			requestingAccount, err := p.db.GetAccountByID(ctx, incomingFollowRequest.AccountID)
+			if err != nil {
+				return fmt.Errorf("error getting requesting account %s: %s", incomingFollowRequest.AccountID, err)
+			}
+
+			// accounts on a domain that the receiving account has blocked aren't allowed to follow it
+			if blocked, err := p.db.IsDomainBlockedByAccount(ctx, federatorMsg.ReceivingAccount.ID, requestingAccount.Domain); err != nil {
+				return fmt.Errorf("error checking domain block: %s", err)
+			} else if blocked {
+				if err := p.db.DeleteByID(ctx, incomingFollowRequest.ID, &gtsmodel.FollowRequest{}); err != nil {
+					return fmt.Errorf("error deleting follow request %s: %s", incomingFollowRequest.ID, err)
+				}
+				return p.federateRejectFollowRequest(ctx, incomingFollowRequest, requestingAccount, federatorMsg.ReceivingAccount)
+			}
+
 			if err := p.notifyFollowRequest(ctx, incomingFollowRequest, federatorMsg.ReceivingAccount); err != nil {
 				return err
 			}
@@ -149,6 +195,14 @@ func (p *processor) processFromFederator(ctx context.Context, federatorMsg gtsmo
 			if _, _, err := p.federator.GetRemoteAccount(ctx, federatorMsg.ReceivingAccount.Username, incomingAccountURI, true); err != nil {
 				return fmt.Errorf("error dereferencing account from federator: %s", err)
 			}
+		case gtsmodel.ActivityStreamsNote:
+			// UPDATE A STATUS
+			existingStatus, ok := federatorMsg.GTSModel.(*gtsmodel.Status)
+			if !ok {
+				return errors.New("note was not parseable as *gtsmodel.Status")
+			}
+
+			return p.processRemoteStatusEdit(ctx, existingStatus, federatorMsg.ReceivingAccount)
 		}
 	case gtsmodel.ActivityStreamsDelete:
 		// DELETE
@@ -183,6 +237,26 @@ func (p *processor) processFromFederator(ctx context.Context, federatorMsg gtsmo
 				return err
 			}
 
+			// delete all bookmarks of this status
+			if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "status_id", Value: statusToDelete.ID}}, &[]*gtsmodel.StatusBookmark{}); err != nil {
+				return err
+			}
+
+			// delete the poll attached to this status, and any votes cast in it
+			if err := p.deleteStatusPoll(ctx, statusToDelete); err != nil {
+				return err
+			}
+
+			// delete all previous revisions of this status
+			if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "status_id", Value: statusToDelete.ID}}, &[]*gtsmodel.StatusEdit{}); err != nil {
+				return err
+			}
+
+			// remove this status from any direct message conversations
+			if err := p.deleteStatusFromConversations(ctx, statusToDelete); err != nil {
+				return err
+			}
+
 			// remove this status from any and all timelines
 			return p.deleteStatusFromTimelines(ctx, statusToDelete)
 		case gtsmodel.ActivityStreamsProfile:
@@ -203,7 +277,157 @@ func (p *processor) processFromFederator(ctx context.Context, federatorMsg gtsmo
 				return err
 			}
 		}
+	case gtsmodel.ActivityStreamsReject:
+		// REJECT
+		switch federatorMsg.APObjectType {
+		case gtsmodel.ActivityStreamsFollow:
+			// REJECT A FOLLOW
+			follow, ok := federatorMsg.GTSModel.(*gtsmodel.Follow)
+			if !ok {
+				return errors.New("follow was not parseable as *gtsmodel.Follow")
+			}
+
+			// the follow is gone, so remove the rejecting account's statuses from the follower's timelines
+			if err := p.timelineManager.WipeStatusesFromAccountID(ctx, follow.AccountID, follow.TargetAccountID); err != nil {
+				return err
+			}
+		}
+	case gtsmodel.ActivityStreamsFlag:
+		// FLAG
+		switch federatorMsg.APObjectType {
+		case gtsmodel.ActivityStreamsProfile:
+			// FLAG AN ACCOUNT
+			incomingReport, ok := federatorMsg.GTSModel.(*gtsmodel.Report)
+			if !ok {
+				return errors.New("flag was not parseable as *gtsmodel.Report")
+			}
+
+			reportID, err := id.NewULID()
+			if err != nil {
+				return err
+			}
+			incomingReport.ID = reportID
+
+			if err := p.db.PutReport(ctx, incomingReport); err != nil && err != db.ErrAlreadyExists {
+				return fmt.Errorf("error adding report to the db: %s", err)
+			}
+		}
+	case gtsmodel.ActivityStreamsMove:
+		// MOVE
+		switch federatorMsg.APObjectType {
+		case gtsmodel.ActivityStreamsProfile, gtsmodel.ActivityStreamsPerson:
+			// MOVE AN ACCOUNT
+			origin, ok := federatorMsg.GTSModel.(*gtsmodel.Account)
+			if !ok {
+				return errors.New("move origin was not parseable as *gtsmodel.Account")
+			}
+
+			if federatorMsg.APIri == nil {
+				return errors.New("move target iri was not set")
+			}
+
+			return p.processRemoteMove(ctx, origin, federatorMsg.APIri, federatorMsg.ReceivingAccount)
+		}
 	}
 
 	return nil
 }
+
+// processRemoteMove checks that the target of a Move of a remote account really is an alias of the origin account,
+// and then follows the target on behalf of the receiving account, if the receiving account was following the origin.
+//
+// The Move is delivered to the inbox of each of our accounts that follows origin, so each delivery only needs to
+// take care of the receiving account.
+func (p *processor) processRemoteMove(ctx context.Context, origin *gtsmodel.Account, targetIRI *url.URL, receivingAccount *gtsmodel.Account) error {
+	// make sure we've got the latest version of the target, so that we see any newly added aliases
+	var target *gtsmodel.Account
+	var err error
+	if targetIRI.Host == p.config.Host {
+		target, err = p.db.GetAccountByURI(ctx, targetIRI.String())
+	} else {
+		target, _, err = p.federator.GetRemoteAccount(ctx, receivingAccount.Username, targetIRI, true)
+	}
+	if err != nil {
+		return fmt.Errorf("processRemoteMove: error getting move target %s: %s", targetIRI, err)
+	}
+
+	if target.MovedToAccountID != "" {
+		return fmt.Errorf("processRemoteMove: move target %s has itself moved", target.URI)
+	}
+
+	if !account.IsAlias(target, origin) {
+		return fmt.Errorf("processRemoteMove: move target %s doesn't list %s as an alias", target.URI, origin.URI)
+	}
+
+	if origin.MovedToAccountID != target.ID {
+		origin.MovedToAccountID = target.ID
+		if _, err := p.db.UpdateAccount(ctx, origin); err != nil {
+			return fmt.Errorf("processRemoteMove: error updating account %s: %s", origin.ID, err)
+		}
+	}
+
+	return p.accountProcessor.FollowMoved(ctx, receivingAccount, origin, target)
+}
+
+// processRemoteStatusEdit dereferences the latest version of a remote status that we've been told has been updated,
+// storing the previous version as a revision if the content has changed, and refreshing any timelines it appears in.
+func (p *processor) processRemoteStatusEdit(ctx context.Context, existingStatus *gtsmodel.Status, receivingAccount *gtsmodel.Account) error {
+	statusURI, err := url.Parse(existingStatus.URI)
+	if err != nil {
+		return err
+	}
+
+	// take a snapshot of the status as it is now, before it gets overwritten
+	editID, err := id.NewULID()
+	if err != nil {
+		return err
+	}
+	revisionCreatedAt := existingStatus.CreatedAt
+	if !existingStatus.EditedAt.IsZero() {
+		revisionCreatedAt = existingStatus.EditedAt
+	}
+	edit := &gtsmodel.StatusEdit{
+		ID:             editID,
+		CreatedAt:      revisionCreatedAt,
+		StatusID:       existingStatus.ID,
+		AccountID:      existingStatus.AccountID,
+		Content:        existingStatus.Content,
+		ContentWarning: existingStatus.ContentWarning,
+		Text:           existingStatus.Text,
+		Sensitive:      existingStatus.Sensitive,
+		Language:       existingStatus.Language,
+		AttachmentIDs:  existingStatus.AttachmentIDs,
+		EmojiIDs:       existingStatus.EmojiIDs,
+	}
+
+	status, _, _, err := p.federator.GetRemoteStatus(ctx, receivingAccount.Username, statusURI, true, false, true)
+	if err != nil {
+		return fmt.Errorf("error dereferencing status from federator: %s", err)
+	}
+
+	edited := status.Content != existingStatus.Content ||
+		status.ContentWarning != existingStatus.ContentWarning ||
+		status.Sensitive != existingStatus.Sensitive ||
+		strings.Join(status.AttachmentIDs, ",") != strings.Join(existingStatus.AttachmentIDs, ",")
+
+	if edited {
+		// not every implementation sets 'updated' on edited statuses, so make sure the status is marked as edited
+		if status.EditedAt.IsZero() || !status.EditedAt.After(revisionCreatedAt) {
+			status.EditedAt = time.Now()
+			if err := p.db.UpdateStatus(ctx, status); err != nil {
+				return fmt.Errorf("error marking status as edited: %s", err)
+			}
+		}
+
+		if err := p.db.PutStatusEdit(ctx, edit); err != nil {
+			return fmt.Errorf("error putting status edit in the database: %s", err)
+		}
+	}
+
+	if err := p.refreshStatusInTimelines(ctx, status); err != nil {
+		return err
+	}
+
+	// notify anyone who was newly mentioned by the edit
+	return p.notifyStatus(ctx, status)
+}
@@ -0,0 +1,323 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+func (p *processor) ListsGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.List, gtserror.WithCode) {
+	lists, err := p.db.GetListsForAccountID(ctx, authed.Account.ID)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiLists := []*apimodel.List{}
+	for _, l := range lists {
+		apiList, err := p.tc.ListToMasto(ctx, l)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiLists = append(apiLists, apiList)
+	}
+
+	return apiLists, nil
+}
+
+func (p *processor) ListGet(ctx context.Context, authed *oauth.Auth, listID string) (*apimodel.List, gtserror.WithCode) {
+	list, errWithCode := p.getOwnedList(ctx, authed.Account, listID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiList, err := p.tc.ListToMasto(ctx, list)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiList, nil
+}
+
+func (p *processor) ListCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.ListCreateRequest) (*apimodel.List, gtserror.WithCode) {
+	if err := util.ValidateListTitle(form.Title); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	repliesPolicy := gtsmodel.RepliesPolicyFollowed
+	if form.RepliesPolicy != "" {
+		if err := util.ValidateListRepliesPolicy(form.RepliesPolicy); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		repliesPolicy = gtsmodel.RepliesPolicy(form.RepliesPolicy)
+	}
+
+	listID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	list := &gtsmodel.List{
+		ID:            listID,
+		Title:         form.Title,
+		AccountID:     authed.Account.ID,
+		RepliesPolicy: repliesPolicy,
+	}
+
+	if err := p.db.PutList(ctx, list); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("ListCreate: error putting list in db: %s", err))
+	}
+
+	apiList, err := p.tc.ListToMasto(ctx, list)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiList, nil
+}
+
+func (p *processor) ListUpdate(ctx context.Context, authed *oauth.Auth, listID string, form *apimodel.ListUpdateRequest) (*apimodel.List, gtserror.WithCode) {
+	list, errWithCode := p.getOwnedList(ctx, authed.Account, listID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if form.Title != nil {
+		if err := util.ValidateListTitle(*form.Title); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		list.Title = *form.Title
+	}
+
+	if form.RepliesPolicy != nil {
+		if err := util.ValidateListRepliesPolicy(*form.RepliesPolicy); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		list.RepliesPolicy = gtsmodel.RepliesPolicy(*form.RepliesPolicy)
+	}
+
+	if err := p.db.UpdateList(ctx, list); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("ListUpdate: error updating list in db: %s", err))
+	}
+
+	// the replies policy may have changed, so make sure the list timeline gets indexed again
+	p.timelineManager.WipeListTimeline(ctx, list.ID)
+
+	apiList, err := p.tc.ListToMasto(ctx, list)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiList, nil
+}
+
+func (p *processor) ListDelete(ctx context.Context, authed *oauth.Auth, listID string) gtserror.WithCode {
+	list, errWithCode := p.getOwnedList(ctx, authed.Account, listID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.db.DeleteListByID(ctx, list.ID); err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("ListDelete: error deleting list from db: %s", err))
+	}
+
+	p.timelineManager.WipeListTimeline(ctx, list.ID)
+	return nil
+}
+
+func (p *processor) ListAccountsGet(ctx context.Context, authed *oauth.Auth, listID string, maxID string, sinceID string, minID string, limit int) (*apimodel.ListAccountsResponse, gtserror.WithCode) {
+	list, errWithCode := p.getOwnedList(ctx, authed.Account, listID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	entries, err := p.db.GetListEntries(ctx, list.ID, maxID, sinceID, minID, limit)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	resp := &apimodel.ListAccountsResponse{
+		Accounts: []*apimodel.Account{},
+	}
+
+	for _, e := range entries {
+		if e.Follow == nil {
+			continue
+		}
+
+		targetAccount, err := p.db.GetAccountByID(ctx, e.Follow.TargetAccountID)
+		if err != nil {
+			continue
+		}
+
+		apiAccount, err := p.tc.AccountToMastoPublic(ctx, targetAccount)
+		if err != nil {
+			continue
+		}
+		resp.Accounts = append(resp.Accounts, apiAccount)
+	}
+
+	// a limit of 0 means all entries were returned, so there's no need for paging
+	if limit == 0 || len(entries) == 0 {
+		return resp, nil
+	}
+
+	path := fmt.Sprintf("/api/v1/lists/%s/accounts", list.ID)
+	nextLink := &url.URL{
+		Scheme:   p.config.Protocol,
+		Host:     p.config.Host,
+		Path:     path,
+		RawQuery: fmt.Sprintf("limit=%d&max_id=%s", limit, entries[len(entries)-1].ID),
+	}
+	next := fmt.Sprintf("<%s>; rel=\"next\"", nextLink.String())
+
+	prevLink := &url.URL{
+		Scheme:   p.config.Protocol,
+		Host:     p.config.Host,
+		Path:     path,
+		RawQuery: fmt.Sprintf("limit=%d&min_id=%s", limit, entries[0].ID),
+	}
+	prev := fmt.Sprintf("<%s>; rel=\"prev\"", prevLink.String())
+	resp.LinkHeader = fmt.Sprintf("%s, %s", next, prev)
+
+	return resp, nil
+}
+
+func (p *processor) ListAccountsAdd(ctx context.Context, authed *oauth.Auth, listID string, accountIDs []string) gtserror.WithCode {
+	list, errWithCode := p.getOwnedList(ctx, authed.Account, listID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if len(accountIDs) == 0 {
+		err := errors.New("ListAccountsAdd: no account ids provided")
+		return gtserror.NewErrorBadRequest(err, "no account ids provided")
+	}
+
+	existing, err := p.db.GetListEntries(ctx, list.ID, "", "", "", 0)
+	if err != nil && err != db.ErrNoEntries {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	entries := []*gtsmodel.ListEntry{}
+	for _, accountID := range accountIDs {
+		// only followed accounts can be added to a list
+		follow := &gtsmodel.Follow{}
+		if err := p.db.GetWhere(ctx, []db.Where{
+			{Key: "account_id", Value: authed.Account.ID},
+			{Key: "target_account_id", Value: accountID},
+		}, follow); err != nil {
+			if err == db.ErrNoEntries {
+				err = fmt.Errorf("ListAccountsAdd: account %s is not followed by the requesting account", accountID)
+				return gtserror.NewErrorNotFound(err, err.Error())
+			}
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		alreadyListed := false
+		for _, e := range existing {
+			if e.FollowID == follow.ID {
+				alreadyListed = true
+				break
+			}
+		}
+		if alreadyListed {
+			err := fmt.Errorf("ListAccountsAdd: account %s is already in list %s", accountID, list.ID)
+			return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+
+		entryID, err := id.NewULID()
+		if err != nil {
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		entries = append(entries, &gtsmodel.ListEntry{
+			ID:       entryID,
+			ListID:   list.ID,
+			FollowID: follow.ID,
+		})
+	}
+
+	if err := p.db.PutListEntries(ctx, entries); err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("ListAccountsAdd: error putting list entries in db: %s", err))
+	}
+
+	p.timelineManager.WipeListTimeline(ctx, list.ID)
+	return nil
+}
+
+func (p *processor) ListAccountsRemove(ctx context.Context, authed *oauth.Auth, listID string, accountIDs []string) gtserror.WithCode {
+	list, errWithCode := p.getOwnedList(ctx, authed.Account, listID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if len(accountIDs) == 0 {
+		err := errors.New("ListAccountsRemove: no account ids provided")
+		return gtserror.NewErrorBadRequest(err, "no account ids provided")
+	}
+
+	entries, err := p.db.GetListEntries(ctx, list.ID, "", "", "", 0)
+	if err != nil && err != db.ErrNoEntries {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	for _, accountID := range accountIDs {
+		for _, e := range entries {
+			if e.Follow == nil || e.Follow.TargetAccountID != accountID {
+				continue
+			}
+
+			if err := p.db.DeleteListEntry(ctx, e.ID); err != nil {
+				return gtserror.NewErrorInternalError(fmt.Errorf("ListAccountsRemove: error deleting list entry from db: %s", err))
+			}
+		}
+	}
+
+	p.timelineManager.WipeListTimeline(ctx, list.ID)
+	return nil
+}
+
+// getOwnedList fetches the list with the given id from the database, making sure that it's owned by the given account.
+func (p *processor) getOwnedList(ctx context.Context, account *gtsmodel.Account, listID string) (*gtsmodel.List, gtserror.WithCode) {
+	list, err := p.db.GetListByID(ctx, listID)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(fmt.Errorf("list %s not found", listID))
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if list.AccountID != account.ID {
+		// don't reveal that the list exists to accounts that don't own it
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("list %s not owned by account %s", listID, account.ID))
+	}
+
+	return list, nil
+}
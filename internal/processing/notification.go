@@ -23,6 +23,7 @@ import (
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
@@ -34,8 +35,39 @@ func (p *processor) NotificationsGet(ctx context.Context, authed *oauth.Auth, li
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	filtered, err := p.filter.StatusFilterer(ctx, authed.Account, gtsmodel.FilterContextNotifications)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
 	mastoNotifs := []*apimodel.Notification{}
 	for _, n := range notifs {
+		// the origin account may have been muted since the notification was created
+		muted, err := p.notificationsMuted(ctx, authed.Account.ID, n.OriginAccountID)
+		if err != nil {
+			l.Debugf("got an error checking mutes for a notification, will skip it: %s", err)
+			continue
+		}
+		if muted {
+			continue
+		}
+
+		if n.StatusID != "" {
+			status, err := p.db.GetStatusByID(ctx, n.StatusID)
+			if err != nil {
+				l.Debugf("got an error fetching the status of a notification, will skip it: %s", err)
+				continue
+			}
+			isFiltered, err := filtered(ctx, status)
+			if err != nil {
+				l.Debugf("got an error checking filters for a notification, will skip it: %s", err)
+				continue
+			}
+			if isFiltered {
+				continue
+			}
+		}
+
 		mastoNotif, err := p.tc.NotificationToMasto(ctx, n)
 		if err != nil {
 			l.Debugf("got an error converting a notification to masto, will skip it: %s", err)
@@ -0,0 +1,258 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// pollExpiryInterval is how often we check for polls that have expired and need to be closed.
+const pollExpiryInterval = 1 * time.Minute
+
+func (p *processor) PollGet(ctx context.Context, authed *oauth.Auth, pollID string) (*apimodel.Poll, gtserror.WithCode) {
+	poll, _, errWithCode := p.getVisiblePoll(ctx, authed.Account, pollID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiPoll, err := p.tc.PollToMasto(ctx, poll, authed.Account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiPoll, nil
+}
+
+func (p *processor) PollVote(ctx context.Context, authed *oauth.Auth, pollID string, choices []int) (*apimodel.Poll, gtserror.WithCode) {
+	poll, pollStatus, errWithCode := p.getVisiblePoll(ctx, authed.Account, pollID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if poll.Expired() {
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New("poll has already ended"), "poll has already ended")
+	}
+
+	if poll.AccountID == authed.Account.ID {
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New("account cannot vote in own poll"), "you cannot vote in your own poll")
+	}
+
+	if err := validatePollChoices(poll, choices); err != nil {
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	if _, err := p.db.GetPollVoteByAccountID(ctx, poll.ID, authed.Account.ID); err == nil {
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New("account has already voted in poll"), "you have already voted in this poll")
+	} else if err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	voteID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	vote := &gtsmodel.PollVote{
+		ID:        voteID,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+		URI:       util.GenerateURIForVote(authed.Account.Username, p.config.Protocol, p.config.Host, voteID),
+		PollID:    poll.ID,
+		Poll:      poll,
+		AccountID: authed.Account.ID,
+		Account:   authed.Account,
+		Choices:   choices,
+	}
+
+	if err := p.db.PutPollVote(ctx, vote); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error putting poll vote in database: %s", err))
+	}
+
+	if pollStatus.Local {
+		// we have all the votes for local polls, so we can just count them up again
+		if err := p.recountPoll(ctx, poll); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	} else {
+		// we only know about the votes that remote polls have told us about, plus this one,
+		// so add this vote to the counts we have -- they'll be corrected when the poll owner sends us an update
+		for _, c := range choices {
+			poll.Votes[c] = poll.Votes[c] + 1
+		}
+		poll.VotersCount = poll.VotersCount + 1
+		if err := p.db.UpdatePoll(ctx, poll); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error updating poll: %s", err))
+		}
+	}
+
+	// federate the vote (or the updated poll) asynchronously
+	p.fromClientAPI <- gtsmodel.FromClientAPI{
+		APObjectType:   gtsmodel.ActivityStreamsQuestion,
+		APActivityType: gtsmodel.ActivityStreamsCreate,
+		GTSModel:       vote,
+		OriginAccount:  authed.Account,
+	}
+
+	apiPoll, err := p.tc.PollToMasto(ctx, poll, authed.Account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiPoll, nil
+}
+
+// getVisiblePoll fetches the poll with the given ID along with the status it's attached to,
+// returning a not found error if the poll doesn't exist or its status isn't visible to requestingAccount.
+func (p *processor) getVisiblePoll(ctx context.Context, requestingAccount *gtsmodel.Account, pollID string) (*gtsmodel.Poll, *gtsmodel.Status, gtserror.WithCode) {
+	poll, err := p.db.GetPollByID(ctx, pollID)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, nil, gtserror.NewErrorNotFound(fmt.Errorf("poll %s not found", pollID))
+		}
+		return nil, nil, gtserror.NewErrorInternalError(err)
+	}
+
+	pollStatus, err := p.db.GetStatusByID(ctx, poll.StatusID)
+	if err != nil {
+		return nil, nil, gtserror.NewErrorNotFound(fmt.Errorf("error fetching status %s of poll %s: %s", poll.StatusID, pollID, err))
+	}
+
+	visible, err := p.filter.StatusVisible(ctx, pollStatus, requestingAccount)
+	if err != nil {
+		return nil, nil, gtserror.NewErrorNotFound(fmt.Errorf("error seeing if status %s is visible: %s", pollStatus.ID, err))
+	}
+	if !visible {
+		return nil, nil, gtserror.NewErrorNotFound(errors.New("poll is not visible"))
+	}
+
+	return poll, pollStatus, nil
+}
+
+// validatePollChoices makes sure the given choices are a valid vote in the given poll.
+func validatePollChoices(poll *gtsmodel.Poll, choices []int) error {
+	if len(choices) == 0 {
+		return errors.New("no choices provided")
+	}
+
+	if !poll.Multiple && len(choices) > 1 {
+		return errors.New("poll only allows one choice")
+	}
+
+	seen := make(map[int]bool, len(choices))
+	for _, c := range choices {
+		if c < 0 || c >= len(poll.Options) {
+			return fmt.Errorf("choice %d is not a valid option", c)
+		}
+		if seen[c] {
+			return fmt.Errorf("choice %d was provided more than once", c)
+		}
+		seen[c] = true
+	}
+
+	return nil
+}
+
+// recountPoll counts up all the votes that have been cast in the given poll, and stores the new totals.
+// This is only useful for local polls, since we don't have every vote for remote polls.
+func (p *processor) recountPoll(ctx context.Context, poll *gtsmodel.Poll) error {
+	votes, err := p.db.GetPollVotes(ctx, poll.ID)
+	if err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("recountPoll: error getting votes for poll %s: %s", poll.ID, err)
+	}
+
+	counts := make([]int, len(poll.Options))
+	for _, v := range votes {
+		for _, c := range v.Choices {
+			if c >= 0 && c < len(counts) {
+				counts[c] = counts[c] + 1
+			}
+		}
+	}
+
+	poll.Votes = counts
+	poll.VotersCount = len(votes)
+	if err := p.db.UpdatePoll(ctx, poll); err != nil {
+		return fmt.Errorf("recountPoll: error updating poll %s: %s", poll.ID, err)
+	}
+
+	return nil
+}
+
+// closeExpiredPolls closes any polls whose expiry time has passed, notifying the
+// owner and voters that the poll has ended, and federating the final results of local polls.
+func (p *processor) closeExpiredPolls(ctx context.Context) error {
+	polls, err := p.db.GetExpiredPolls(ctx)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil
+		}
+		return fmt.Errorf("closeExpiredPolls: error getting expired polls: %s", err)
+	}
+
+	for _, poll := range polls {
+		poll.ClosedAt = time.Now()
+		if err := p.db.UpdatePoll(ctx, poll); err != nil {
+			return fmt.Errorf("closeExpiredPolls: error closing poll %s: %s", poll.ID, err)
+		}
+
+		pollStatus, err := p.db.GetStatusByID(ctx, poll.StatusID)
+		if err != nil {
+			p.log.Errorf("closeExpiredPolls: error getting status of poll %s: %s", poll.ID, err)
+			continue
+		}
+
+		if err := p.notifyPollEnded(ctx, poll, pollStatus); err != nil {
+			p.log.Errorf("closeExpiredPolls: error notifying end of poll %s: %s", poll.ID, err)
+		}
+
+		if err := p.federatePollUpdate(ctx, pollStatus); err != nil {
+			p.log.Errorf("closeExpiredPolls: error federating end of poll %s: %s", poll.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// deleteStatusPoll removes the poll attached to the given status, if there is one, along with any votes cast in it.
+func (p *processor) deleteStatusPoll(ctx context.Context, status *gtsmodel.Status) error {
+	if status.PollID == "" {
+		return nil
+	}
+
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "poll_id", Value: status.PollID}}, &[]*gtsmodel.PollVote{}); err != nil {
+		return fmt.Errorf("deleteStatusPoll: error deleting votes of poll %s: %s", status.PollID, err)
+	}
+
+	if err := p.db.DeleteByID(ctx, status.PollID, &gtsmodel.Poll{}); err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("deleteStatusPoll: error deleting poll %s: %s", status.PollID, err)
+	}
+
+	return nil
+}
@@ -22,12 +22,15 @@ import (
 	"context"
 	"net/http"
 	"net/url"
+	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/blob"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -38,9 +41,11 @@ import (
 	mediaProcessor "github.com/superseriousbusiness/gotosocial/internal/processing/media"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/status"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/streaming"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/user"
 	"github.com/superseriousbusiness/gotosocial/internal/timeline"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/webauthn"
 )
 
 // Processor should be passed to api modules (see internal/apimodule/...). It is used for
@@ -67,6 +72,8 @@ type Processor interface {
 	AccountCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountCreateRequest) (*apimodel.Token, error)
 	// AccountGet processes the given request for account information.
 	AccountGet(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.Account, error)
+	// AccountDeleteLocal checks the password in the given form, and then queues the requesting account for deletion.
+	AccountDeleteLocal(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountDeleteRequest) gtserror.WithCode
 	// AccountUpdate processes the update of an account with the given form
 	AccountUpdate(ctx context.Context, authed *oauth.Auth, form *apimodel.UpdateCredentialsRequest) (*apimodel.Account, error)
 	// AccountStatusesGet fetches a number of statuses (in time descending order) from the given account, filtered by visibility for
@@ -86,6 +93,35 @@ type Processor interface {
 	AccountBlockCreate(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode)
 	// AccountBlockRemove handles the removal of a block from authed account to target account, either remote or local.
 	AccountBlockRemove(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode)
+	// AccountMuteCreate handles the creation or update of a mute from authed account to the account targeted in the form.
+	AccountMuteCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountMuteRequest) (*apimodel.Relationship, gtserror.WithCode)
+	// AccountMuteRemove handles the removal of a mute from authed account to target account.
+	AccountMuteRemove(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode)
+	// AccountEndorsementCreate handles the endorsement of target account by authed account, so that it's featured on the authed account's profile.
+	AccountEndorsementCreate(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode)
+	// AccountEndorsementRemove handles the removal of an endorsement of target account by authed account.
+	AccountEndorsementRemove(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode)
+	// AccountFeaturedTagsGet returns the hashtags featured on the profile of the given target account.
+	AccountFeaturedTagsGet(ctx context.Context, authed *oauth.Auth, targetAccountID string) ([]*apimodel.FeaturedTag, gtserror.WithCode)
+	// AccountMove checks the password in the given form, and then moves the authed account to the target account in the form,
+	// telling followers of the authed account about the move.
+	AccountMove(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountMoveRequest) (*apimodel.Account, gtserror.WithCode)
+	// AccountImportCreate parses the CSV file in the given form, and then starts importing the follows, blocks, or mutes in it
+	// into the authed account in the background.
+	AccountImportCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountImportRequest) (*apimodel.AccountImport, gtserror.WithCode)
+	// AccountImportGet returns the progress of one account import belonging to the authed account, specified by ID.
+	AccountImportGet(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AccountImport, gtserror.WithCode)
+	// AccountExport returns a CSV file of the follows, blocks, or mutes of the authed account, in a format suitable for AccountImportCreate.
+	AccountExport(ctx context.Context, authed *oauth.Auth, exportType string) ([]byte, gtserror.WithCode)
+	// AccountArchiveExportCreate starts generating a zip archive of the authed account's data in the background.
+	AccountArchiveExportCreate(ctx context.Context, authed *oauth.Auth) (*apimodel.AccountArchive, gtserror.WithCode)
+	// AccountArchiveImportCreate stores the zip archive in the given form, and then starts recreating the statuses in it
+	// as local history of the authed account in the background.
+	AccountArchiveImportCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountArchiveImportRequest) (*apimodel.AccountArchive, gtserror.WithCode)
+	// AccountArchiveGet returns the progress of one account archive belonging to the authed account, specified by ID.
+	AccountArchiveGet(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AccountArchive, gtserror.WithCode)
+	// AccountArchiveDownload returns the zip file of one finished account archive export belonging to the authed account, specified by ID.
+	AccountArchiveDownload(ctx context.Context, authed *oauth.Auth, id string) ([]byte, gtserror.WithCode)
 
 	// AdminEmojiCreate handles the creation of a new instance emoji by an admin, using the given form.
 	AdminEmojiCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.EmojiCreateRequest) (*apimodel.Emoji, error)
@@ -100,19 +136,80 @@ type Processor interface {
 	// AdminDomainBlockDelete deletes one domain block, specified by ID, returning the deleted domain block.
 	AdminDomainBlockDelete(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.DomainBlock, gtserror.WithCode)
 
+	// AdminReportsGet returns reports in the moderation queue, filtered and paged using the given parameters.
+	AdminReportsGet(ctx context.Context, authed *oauth.Auth, resolved bool, accountID string, targetAccountID string, maxID string, sinceID string, minID string, limit int) (*apimodel.AdminReportsResponse, gtserror.WithCode)
+	// AdminReportGet returns one report, specified by ID.
+	AdminReportGet(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminReport, gtserror.WithCode)
+	// AdminReportAssignToSelf assigns the report with the given ID to the requesting admin.
+	AdminReportAssignToSelf(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminReport, gtserror.WithCode)
+	// AdminReportUnassign removes any assigned moderator from the report with the given ID.
+	AdminReportUnassign(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminReport, gtserror.WithCode)
+	// AdminReportResolve marks the report with the given ID as resolved, without doing anything to the target account.
+	AdminReportResolve(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminReport, gtserror.WithCode)
+	// AdminReportReopen marks the report with the given ID as unresolved again.
+	AdminReportReopen(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminReport, gtserror.WithCode)
+	// AdminReportAction takes the action given in the form against the target account of the report with the given ID, and resolves the report.
+	AdminReportAction(ctx context.Context, authed *oauth.Auth, id string, form *apimodel.AdminReportActionRequest) (*apimodel.AdminReport, gtserror.WithCode)
+	// AdminFeaturedAccountsGet returns the accounts that admins have featured as follow suggestions for everyone.
+	AdminFeaturedAccountsGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.Account, gtserror.WithCode)
+	// AdminFeaturedAccountCreate features the account given in the form as a follow suggestion for everyone.
+	AdminFeaturedAccountCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AdminFeaturedAccountCreateRequest) (*apimodel.Account, gtserror.WithCode)
+	// AdminFeaturedAccountDelete stops featuring the account with the given ID as a follow suggestion.
+	AdminFeaturedAccountDelete(ctx context.Context, authed *oauth.Auth, accountID string) gtserror.WithCode
+
 	// AppCreate processes the creation of a new API application
 	AppCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.ApplicationCreateRequest) (*apimodel.Application, error)
+	// AppVerifyCredentials returns the application that the requesting token belongs to.
+	AppVerifyCredentials(ctx context.Context, authed *oauth.Auth) (*apimodel.Application, gtserror.WithCode)
+	// AppsAuthorizedGet returns the applications that the requesting user has given access to their account, oldest first.
+	AppsAuthorizedGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.AuthorizedApplication, gtserror.WithCode)
+	// AppAuthorizedRevoke revokes every token that the requesting user has given to the given application,
+	// and closes any streams that were opened with them.
+	AppAuthorizedRevoke(ctx context.Context, authed *oauth.Auth, appID string) gtserror.WithCode
+	// OAuthRevokeToken revokes the given token on behalf of the client it was issued to, and closes any streams that
+	// were opened with it. The client should already have been authenticated.
+	OAuthRevokeToken(ctx context.Context, clientID string, token string, tokenTypeHint string) gtserror.WithCode
 
 	// BlocksGet returns a list of accounts blocked by the requesting account.
 	BlocksGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, limit int) (*apimodel.BlocksResponse, gtserror.WithCode)
+	// UserDomainBlocksGet returns a list of domains blocked by the requesting account.
+	UserDomainBlocksGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, limit int) (*apimodel.UserDomainBlocksResponse, gtserror.WithCode)
+	// UserDomainBlockCreate blocks the domain in the given form on behalf of the requesting account, removing any followers on that domain.
+	UserDomainBlockCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.UserDomainBlockRequest) gtserror.WithCode
+	// UserDomainBlockDelete removes the block of the domain in the given form by the requesting account, if there is one.
+	UserDomainBlockDelete(ctx context.Context, authed *oauth.Auth, form *apimodel.UserDomainBlockRequest) gtserror.WithCode
+
+	// ConversationsGet returns the direct message conversations of the requesting account, with the given paging parameters.
+	ConversationsGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, minID string, limit int) (*apimodel.ConversationsResponse, gtserror.WithCode)
+	// ConversationDelete removes the conversation with the given ID from the requesting account's conversations.
+	// The statuses in the conversation are left alone.
+	ConversationDelete(ctx context.Context, authed *oauth.Auth, conversationID string) gtserror.WithCode
+	// ConversationRead marks the conversation with the given ID as read by the requesting account.
+	ConversationRead(ctx context.Context, authed *oauth.Auth, conversationID string) (*apimodel.Conversation, gtserror.WithCode)
+
+	// EndorsementsGet returns a list of accounts endorsed by the requesting account.
+	EndorsementsGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, limit int) (*apimodel.EndorsementsResponse, gtserror.WithCode)
 
 	// FileGet handles the fetching of a media attachment file via the fileserver.
 	FileGet(ctx context.Context, authed *oauth.Auth, form *apimodel.GetContentRequestForm) (*apimodel.Content, error)
 
+	// FiltersGet returns all filters owned by the requesting account.
+	FiltersGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.Filter, gtserror.WithCode)
+	// FilterGet returns one filter with the given ID, if it's owned by the requesting account.
+	FilterGet(ctx context.Context, authed *oauth.Auth, filterID string) (*apimodel.Filter, gtserror.WithCode)
+	// FilterCreate creates a new filter for the requesting account, using the given form.
+	FilterCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.FilterCreateUpdateRequest) (*apimodel.Filter, gtserror.WithCode)
+	// FilterUpdate replaces the filter with the given ID, if it's owned by the requesting account, using the given form.
+	FilterUpdate(ctx context.Context, authed *oauth.Auth, filterID string, form *apimodel.FilterCreateUpdateRequest) (*apimodel.Filter, gtserror.WithCode)
+	// FilterDelete deletes the filter with the given ID, if it's owned by the requesting account.
+	FilterDelete(ctx context.Context, authed *oauth.Auth, filterID string) gtserror.WithCode
+
 	// FollowRequestsGet handles the getting of the authed account's incoming follow requests
 	FollowRequestsGet(ctx context.Context, auth *oauth.Auth) ([]apimodel.Account, gtserror.WithCode)
 	// FollowRequestAccept handles the acceptance of a follow request from the given account ID
 	FollowRequestAccept(ctx context.Context, auth *oauth.Auth, accountID string) (*apimodel.Relationship, gtserror.WithCode)
+	// FollowRequestDeny handles the rejection of a follow request from the given account ID
+	FollowRequestDeny(ctx context.Context, auth *oauth.Auth, accountID string) (*apimodel.Relationship, gtserror.WithCode)
 
 	// InstanceGet retrieves instance information for serving at api/v1/instance
 	InstanceGet(ctx context.Context, domain string) (*apimodel.Instance, gtserror.WithCode)
@@ -121,6 +218,24 @@ type Processor interface {
 	// It should already be ascertained that the requesting account is authenticated and an admin.
 	InstancePatch(ctx context.Context, form *apimodel.InstanceSettingsUpdateRequest) (*apimodel.Instance, gtserror.WithCode)
 
+	// ListsGet returns all lists owned by the requesting account.
+	ListsGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.List, gtserror.WithCode)
+	// ListGet returns one list with the given ID, if it's owned by the requesting account.
+	ListGet(ctx context.Context, authed *oauth.Auth, listID string) (*apimodel.List, gtserror.WithCode)
+	// ListCreate handles the creation of a new list for the requesting account, using the given form.
+	ListCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.ListCreateRequest) (*apimodel.List, gtserror.WithCode)
+	// ListUpdate handles the update of a list with the given ID, using the given form.
+	ListUpdate(ctx context.Context, authed *oauth.Auth, listID string, form *apimodel.ListUpdateRequest) (*apimodel.List, gtserror.WithCode)
+	// ListDelete handles the deletion of a list with the given ID, along with all of its entries.
+	ListDelete(ctx context.Context, authed *oauth.Auth, listID string) gtserror.WithCode
+	// ListAccountsGet returns the accounts that are members of the list with the given ID.
+	// If limit is 0, then all members of the list will be returned.
+	ListAccountsGet(ctx context.Context, authed *oauth.Auth, listID string, maxID string, sinceID string, minID string, limit int) (*apimodel.ListAccountsResponse, gtserror.WithCode)
+	// ListAccountsAdd adds the given account IDs to the list with the given ID. The requesting account must follow all of the given accounts.
+	ListAccountsAdd(ctx context.Context, authed *oauth.Auth, listID string, accountIDs []string) gtserror.WithCode
+	// ListAccountsRemove removes the given account IDs from the list with the given ID.
+	ListAccountsRemove(ctx context.Context, authed *oauth.Auth, listID string, accountIDs []string) gtserror.WithCode
+
 	// MediaCreate handles the creation of a media attachment, using the given form.
 	MediaCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AttachmentRequest) (*apimodel.Attachment, error)
 	// MediaGet handles the GET of a media attachment with the given ID
@@ -128,16 +243,49 @@ type Processor interface {
 	// MediaUpdate handles the PUT of a media attachment with the given ID and form
 	MediaUpdate(ctx context.Context, authed *oauth.Auth, attachmentID string, form *apimodel.AttachmentUpdateRequest) (*apimodel.Attachment, gtserror.WithCode)
 
+	// MutesGet returns a list of accounts muted by the requesting account.
+	MutesGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, limit int) (*apimodel.MutesResponse, gtserror.WithCode)
+
+
 	// NotificationsGet
 	NotificationsGet(ctx context.Context, authed *oauth.Auth, limit int, maxID string, sinceID string) ([]*apimodel.Notification, gtserror.WithCode)
 
+	// PollGet returns the poll with the given ID, if the status it's attached to is visible to the requesting account.
+	PollGet(ctx context.Context, authed *oauth.Auth, pollID string) (*apimodel.Poll, gtserror.WithCode)
+	// PollVote casts a vote with the given choices in the poll with the given ID, returning the updated poll.
+	PollVote(ctx context.Context, authed *oauth.Auth, pollID string, choices []int) (*apimodel.Poll, gtserror.WithCode)
+
+	// PreferencesGet returns the posting and reading preferences of the requesting account.
+	PreferencesGet(ctx context.Context, authed *oauth.Auth) (*apimodel.Preferences, gtserror.WithCode)
+
+	// ReportCreate creates a report of the account given in the form, made by the requesting account.
+	// If the reported account is remote and the form asks for it, the report will also be forwarded to the remote instance.
+	ReportCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.ReportCreateRequest) (*apimodel.Report, gtserror.WithCode)
+
 	// SearchGet performs a search with the given params, resolving/dereferencing remotely as desired
 	SearchGet(ctx context.Context, authed *oauth.Auth, searchQuery *apimodel.SearchQuery) (*apimodel.SearchResult, gtserror.WithCode)
 
+	// ScheduledStatusCreate processes the given form to schedule a new status to be posted at the time given in the form.
+	ScheduledStatusCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AdvancedStatusCreateForm) (*apimodel.ScheduledStatus, gtserror.WithCode)
+	// ScheduledStatusesGet returns the statuses scheduled by the requesting account, with the given paging parameters.
+	ScheduledStatusesGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, minID string, limit int) (*apimodel.ScheduledStatusesResponse, gtserror.WithCode)
+	// ScheduledStatusGet returns one scheduled status with the given ID, if it's owned by the requesting account.
+	ScheduledStatusGet(ctx context.Context, authed *oauth.Auth, scheduledStatusID string) (*apimodel.ScheduledStatus, gtserror.WithCode)
+	// ScheduledStatusUpdate moves the scheduled status with the given ID to the time given in the form, if it's owned by the requesting account.
+	ScheduledStatusUpdate(ctx context.Context, authed *oauth.Auth, scheduledStatusID string, form *apimodel.ScheduledStatusUpdateRequest) (*apimodel.ScheduledStatus, gtserror.WithCode)
+	// ScheduledStatusDelete cancels the scheduled status with the given ID, if it's owned by the requesting account.
+	ScheduledStatusDelete(ctx context.Context, authed *oauth.Auth, scheduledStatusID string) gtserror.WithCode
+
 	// StatusCreate processes the given form to create a new status, returning the api model representation of that status if it's OK.
 	StatusCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AdvancedStatusCreateForm) (*apimodel.Status, error)
 	// StatusDelete processes the delete of a given status, returning the deleted status if the delete goes through.
 	StatusDelete(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, error)
+	// StatusEdit processes the edit of a given status, returning the updated status if the edit goes through.
+	StatusEdit(ctx context.Context, authed *oauth.Auth, targetStatusID string, form *apimodel.StatusEditRequest) (*apimodel.Status, gtserror.WithCode)
+	// StatusHistory returns the revisions of a given status, oldest first, ending with its current version.
+	StatusHistory(ctx context.Context, authed *oauth.Auth, targetStatusID string) ([]*apimodel.StatusEdit, gtserror.WithCode)
+	// StatusSource returns the plain-text source of a given status, for editing it.
+	StatusSource(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.StatusSource, gtserror.WithCode)
 	// StatusFave processes the faving of a given status, returning the updated status if the fave goes through.
 	StatusFave(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, error)
 	// StatusBoost processes the boost/reblog of a given status, returning the newly-created boost if all is well.
@@ -152,20 +300,86 @@ type Processor interface {
 	StatusGet(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, error)
 	// StatusUnfave processes the unfaving of a given status, returning the updated status if the fave goes through.
 	StatusUnfave(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, error)
+	// StatusBookmark processes the bookmarking of a given status, returning the updated status if the bookmark goes through.
+	StatusBookmark(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
+	// StatusUnbookmark processes the unbookmarking of a given status, returning the updated status if the unbookmark goes through.
+	StatusUnbookmark(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
+	// StatusMute processes the muting of the thread that a given status belongs to, returning the updated status.
+	StatusMute(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
+	// StatusUnmute processes the unmuting of the thread that a given status belongs to, returning the updated status.
+	StatusUnmute(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
+	// StatusPin processes the pinning of a given status to the requesting account's profile, returning the updated status.
+	StatusPin(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
+	// StatusUnpin processes the unpinning of a given status from the requesting account's profile, returning the updated status.
+	StatusUnpin(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
 	// StatusGetContext returns the context (previous and following posts) from the given status ID
 	StatusGetContext(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Context, gtserror.WithCode)
 
+	// SuggestionsGet returns up to limit accounts that the requesting account might want to follow, from the suggestions computed for it in the background.
+	SuggestionsGet(ctx context.Context, authed *oauth.Auth, limit int) ([]*apimodel.Account, gtserror.WithCode)
+	// SuggestionDelete removes the given account from the requesting account's suggestions, and makes sure it won't be suggested again.
+	SuggestionDelete(ctx context.Context, authed *oauth.Auth, targetAccountID string) gtserror.WithCode
+
+	// TagGet returns the hashtag with the given name, noting whether the requesting account follows it.
+	TagGet(ctx context.Context, authed *oauth.Auth, tagName string) (*apimodel.Tag, gtserror.WithCode)
+	// TagFollow makes the requesting account follow the hashtag with the given name, so that public statuses using it turn up in their home timeline.
+	TagFollow(ctx context.Context, authed *oauth.Auth, tagName string) (*apimodel.Tag, gtserror.WithCode)
+	// TagUnfollow makes the requesting account stop following the hashtag with the given name.
+	TagUnfollow(ctx context.Context, authed *oauth.Auth, tagName string) (*apimodel.Tag, gtserror.WithCode)
+	// FollowedTagsGet returns the hashtags followed by the requesting account, with the given paging parameters.
+	FollowedTagsGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, minID string, limit int) (*apimodel.FollowedTagsResponse, gtserror.WithCode)
+	// FeaturedTagsGet returns the hashtags featured on the requesting account's profile.
+	FeaturedTagsGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.FeaturedTag, gtserror.WithCode)
+	// FeaturedTagCreate features the hashtag with the given name on the requesting account's profile.
+	FeaturedTagCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.FeaturedTagCreateRequest) (*apimodel.FeaturedTag, gtserror.WithCode)
+	// FeaturedTagDelete removes the featured tag with the given ID from the requesting account's profile.
+	FeaturedTagDelete(ctx context.Context, authed *oauth.Auth, id string) gtserror.WithCode
+	// FeaturedTagSuggestionsGet returns the hashtags most used by the requesting account, which are not featured yet.
+	FeaturedTagSuggestionsGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.Tag, gtserror.WithCode)
+
 	// HomeTimelineGet returns statuses from the home timeline, with the given filters/parameters.
 	HomeTimelineGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, minID string, limit int, local bool) (*apimodel.StatusTimelineResponse, gtserror.WithCode)
 	// PublicTimelineGet returns statuses from the public/local timeline, with the given filters/parameters.
 	PublicTimelineGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, minID string, limit int, local bool) (*apimodel.StatusTimelineResponse, gtserror.WithCode)
+	// TagTimelineGet returns public statuses that use the given hashtag, with the given filters/parameters.
+	TagTimelineGet(ctx context.Context, authed *oauth.Auth, tagName string, maxID string, sinceID string, minID string, limit int, local bool) (*apimodel.StatusTimelineResponse, gtserror.WithCode)
 	// FavedTimelineGet returns faved statuses, with the given filters/parameters.
 	FavedTimelineGet(ctx context.Context, authed *oauth.Auth, maxID string, minID string, limit int) (*apimodel.StatusTimelineResponse, gtserror.WithCode)
 
+	// BookmarksTimelineGet returns bookmarked statuses, with the given filters/parameters.
+	BookmarksTimelineGet(ctx context.Context, authed *oauth.Auth, maxID string, minID string, limit int) (*apimodel.StatusTimelineResponse, gtserror.WithCode)
+
+	// ListTimelineGet returns statuses from the timeline of the given list, with the given filters/parameters.
+	ListTimelineGet(ctx context.Context, authed *oauth.Auth, listID string, maxID string, sinceID string, minID string, limit int) (*apimodel.StatusTimelineResponse, gtserror.WithCode)
+
 	// AuthorizeStreamingRequest returns a gotosocial account in exchange for an access token, or an error if the given token is not valid.
 	AuthorizeStreamingRequest(ctx context.Context, accessToken string) (*gtsmodel.Account, error)
 	// OpenStreamForAccount opens a new stream for the given account, with the given stream type.
-	OpenStreamForAccount(ctx context.Context, account *gtsmodel.Account, streamType string) (*gtsmodel.Stream, gtserror.WithCode)
+	// The access token is kept with the stream so that the stream can be closed if the token is revoked.
+	OpenStreamForAccount(ctx context.Context, account *gtsmodel.Account, streamType string, accessToken string) (*gtsmodel.Stream, gtserror.WithCode)
+
+	// UserConfirmEmail confirms the email address of the user with the given confirmation token.
+	UserConfirmEmail(ctx context.Context, token string) (*gtsmodel.User, gtserror.WithCode)
+	// UserResetPasswordRequest sends a reset password email to the user with the given email address, if there is one.
+	UserResetPasswordRequest(ctx context.Context, email string) gtserror.WithCode
+	// UserResetPassword sets a new password for the user with the given reset password token.
+	UserResetPassword(ctx context.Context, token string, password string) gtserror.WithCode
+	// UserTwoFactorSetup generates a new OTP secret for the authed user, to be added to their authenticator app.
+	UserTwoFactorSetup(ctx context.Context, authed *oauth.Auth) (*apimodel.TwoFactorSetup, gtserror.WithCode)
+	// UserTwoFactorEnable turns on two-factor authentication for the authed user, if the given code is valid.
+	UserTwoFactorEnable(ctx context.Context, authed *oauth.Auth, code string) (*apimodel.TwoFactorBackupCodes, gtserror.WithCode)
+	// UserTwoFactorDisable turns off two-factor authentication for the authed user, if the given code is valid.
+	UserTwoFactorDisable(ctx context.Context, authed *oauth.Auth, code string) gtserror.WithCode
+	// UserTwoFactorBackupCodes replaces the backup codes of the authed user, if the given code is valid.
+	UserTwoFactorBackupCodes(ctx context.Context, authed *oauth.Auth, code string) (*apimodel.TwoFactorBackupCodes, gtserror.WithCode)
+	// UserWebauthnRegisterBegin returns the options for registering a new security key or passkey for the authed user.
+	UserWebauthnRegisterBegin(ctx context.Context, authed *oauth.Auth) (*webauthn.CredentialCreationOptions, gtserror.WithCode)
+	// UserWebauthnRegisterFinish checks and stores a new security key or passkey for the authed user.
+	UserWebauthnRegisterFinish(ctx context.Context, authed *oauth.Auth, form *apimodel.WebauthnRegistrationRequest) (*apimodel.WebauthnCredential, gtserror.WithCode)
+	// UserWebauthnCredentialsGet returns the security keys and passkeys registered by the authed user.
+	UserWebauthnCredentialsGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.WebauthnCredential, gtserror.WithCode)
+	// UserWebauthnCredentialDelete removes one of the security keys or passkeys of the authed user.
+	UserWebauthnCredentialDelete(ctx context.Context, authed *oauth.Auth, credentialID string) gtserror.WithCode
 
 	/*
 		FEDERATION API-FACING PROCESSING FUNCTIONS
@@ -187,6 +401,10 @@ type Processor interface {
 	// authentication before returning a JSON serializable interface to the caller.
 	GetFediFollowing(ctx context.Context, requestedUsername string, requestURL *url.URL) (interface{}, gtserror.WithCode)
 
+	// GetFediFeaturedCollection handles the getting of a fedi/activitypub representation of a user/account's featured collection,
+	// ie., the statuses that they have pinned to their profile, performing appropriate authentication before returning a JSON serializable interface.
+	GetFediFeaturedCollection(ctx context.Context, requestedUsername string, requestURL *url.URL) (interface{}, gtserror.WithCode)
+
 	// GetFediStatus handles the getting of a fedi/activitypub representation of a particular status, performing appropriate
 	// authentication before returning a JSON serializable interface to the caller.
 	GetFediStatus(ctx context.Context, requestedUsername string, requestedStatusID string, requestURL *url.URL) (interface{}, gtserror.WithCode)
@@ -233,6 +451,20 @@ type processor struct {
 	db              db.DB
 	filter          visibility.Filter
 
+	scheduledStatusTimers     map[string]*time.Timer // timers for posting scheduled statuses, keyed by scheduled status ID
+	scheduledStatusTimersLock *sync.Mutex            // mutex to lock/unlock when checking or updating the timers map
+
+	accountDeletions     map[string]bool // IDs of accounts that are currently being deleted
+	accountDeletionsLock *sync.Mutex     // mutex to lock/unlock when checking or updating the account deletions map
+
+	accountImports              map[string]bool      // IDs of account imports that are currently being worked on
+	accountImportsLock          *sync.Mutex          // mutex to lock/unlock when checking or updating the account imports map
+	accountImportDomainSlots    map[string]time.Time // earliest time the next account import entry for each remote domain may be processed
+	accountImportDomainSlotLock *sync.Mutex          // mutex to lock/unlock when checking or updating the domain slots map
+
+	accountArchives     map[string]bool // IDs of account archives that are currently being worked on
+	accountArchivesLock *sync.Mutex     // mutex to lock/unlock when checking or updating the account archives map
+
 	/*
 		SUB-PROCESSORS
 	*/
@@ -242,19 +474,21 @@ type processor struct {
 	statusProcessor    status.Processor
 	streamingProcessor streaming.Processor
 	mediaProcessor     mediaProcessor.Processor
+	userProcessor      user.Processor
 }
 
 // NewProcessor returns a new Processor that uses the given federator and logger
-func NewProcessor(config *config.Config, tc typeutils.TypeConverter, federator federation.Federator, oauthServer oauth.Server, mediaHandler media.Handler, storage blob.Storage, timelineManager timeline.Manager, db db.DB, log *logrus.Logger) Processor {
+func NewProcessor(config *config.Config, tc typeutils.TypeConverter, federator federation.Federator, oauthServer oauth.Server, mediaHandler media.Handler, storage blob.Storage, timelineManager timeline.Manager, db db.DB, emailSender email.Sender, log *logrus.Logger) Processor {
 
 	fromClientAPI := make(chan gtsmodel.FromClientAPI, 1000)
 	fromFederator := make(chan gtsmodel.FromFederator, 1000)
 
 	statusProcessor := status.New(db, tc, config, fromClientAPI, log)
 	streamingProcessor := streaming.New(db, tc, oauthServer, config, log)
-	accountProcessor := account.New(db, tc, mediaHandler, oauthServer, fromClientAPI, federator, config, log)
+	accountProcessor := account.New(db, tc, mediaHandler, storage, oauthServer, fromClientAPI, federator, config, log)
 	adminProcessor := admin.New(db, tc, mediaHandler, fromClientAPI, config, log)
 	mediaProcessor := mediaProcessor.New(db, tc, mediaHandler, storage, config, log)
+	userProcessor := user.New(db, emailSender, config, log)
 
 	return &processor{
 		fromClientAPI:   fromClientAPI,
@@ -271,17 +505,72 @@ func NewProcessor(config *config.Config, tc typeutils.TypeConverter, federator f
 		db:              db,
 		filter:          visibility.NewFilter(db, log),
 
+		scheduledStatusTimers:     make(map[string]*time.Timer),
+		scheduledStatusTimersLock: &sync.Mutex{},
+
+		accountDeletions:     make(map[string]bool),
+		accountDeletionsLock: &sync.Mutex{},
+
+		accountImports:              make(map[string]bool),
+		accountImportsLock:          &sync.Mutex{},
+		accountImportDomainSlots:    make(map[string]time.Time),
+		accountImportDomainSlotLock: &sync.Mutex{},
+
+		accountArchives:     make(map[string]bool),
+		accountArchivesLock: &sync.Mutex{},
+
 		accountProcessor:   accountProcessor,
 		adminProcessor:     adminProcessor,
 		statusProcessor:    statusProcessor,
 		streamingProcessor: streamingProcessor,
 		mediaProcessor:     mediaProcessor,
+		userProcessor:      userProcessor,
 	}
 }
 
 // Start starts the Processor, reading from its channels and passing messages back and forth.
 func (p *processor) Start(ctx context.Context) error {
+	// pick up any statuses that were scheduled before we last shut down
+	if err := p.loadScheduledStatuses(ctx); err != nil {
+		return err
+	}
+
+	// pick up any account deletions that were requested or interrupted while we were down
+	if err := p.deletePendingAccounts(ctx); err != nil {
+		return err
+	}
+
+	// pick up any account imports that were interrupted while we were down
+	if err := p.resumeAccountImports(ctx); err != nil {
+		return err
+	}
+
+	// same goes for account archives
+	if err := p.resumeAccountArchives(ctx); err != nil {
+		return err
+	}
+
+	// suggestions may take a while to compute, so don't hold up starting while we do it
 	go func() {
+		if err := p.computeSuggestions(ctx); err != nil {
+			p.log.Error(err)
+		}
+	}()
+
+	go func() {
+		pollTicker := time.NewTicker(pollExpiryInterval)
+		defer pollTicker.Stop()
+		accountDeletionTicker := time.NewTicker(accountDeletionInterval)
+		defer accountDeletionTicker.Stop()
+		suggestionsTicker := time.NewTicker(suggestionsInterval)
+		defer suggestionsTicker.Stop()
+		// notification digests can be switched off by setting the interval to 0, in which case this never fires
+		var notificationDigestTick <-chan time.Time
+		if p.config.SMTPConfig.NotificationDigestInterval > 0 {
+			notificationDigestTicker := time.NewTicker(p.config.SMTPConfig.NotificationDigestInterval)
+			defer notificationDigestTicker.Stop()
+			notificationDigestTick = notificationDigestTicker.C
+		}
 	DistLoop:
 		for {
 			select {
@@ -299,6 +588,30 @@ func (p *processor) Start(ctx context.Context) error {
 						p.log.Error(err)
 					}
 				}()
+			case <-pollTicker.C:
+				go func() {
+					if err := p.closeExpiredPolls(ctx); err != nil {
+						p.log.Error(err)
+					}
+				}()
+			case <-accountDeletionTicker.C:
+				go func() {
+					if err := p.deletePendingAccounts(ctx); err != nil {
+						p.log.Error(err)
+					}
+				}()
+			case <-suggestionsTicker.C:
+				go func() {
+					if err := p.computeSuggestions(ctx); err != nil {
+						p.log.Error(err)
+					}
+				}()
+			case <-notificationDigestTick:
+				go func() {
+					if err := p.userProcessor.SendNotificationDigests(ctx); err != nil {
+						p.log.Error(err)
+					}
+				}()
 			case <-p.stop:
 				break DistLoop
 			}
@@ -310,6 +623,7 @@ func (p *processor) Start(ctx context.Context) error {
 // Stop stops the processor cleanly, finishing handling any remaining messages before closing down.
 // TODO: empty message buffer properly before stopping otherwise we'll lose federating messages.
 func (p *processor) Stop() error {
+	p.unscheduleAllStatuses()
 	close(p.stop)
 	return nil
 }
@@ -0,0 +1,116 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+func (p *processor) ReportCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.ReportCreateRequest) (*apimodel.Report, gtserror.WithCode) {
+	if form.AccountID == "" {
+		return nil, gtserror.NewErrorBadRequest(errors.New("account_id must be set"), "account_id must be set")
+	}
+
+	if form.AccountID == authed.Account.ID {
+		return nil, gtserror.NewErrorBadRequest(errors.New("account cannot report itself"), "you cannot report yourself")
+	}
+
+	if err := util.ValidateReportComment(form.Comment); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	targetAccount, err := p.db.GetAccountByID(ctx, form.AccountID)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(fmt.Errorf("account %s not found", form.AccountID))
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// only statuses actually belonging to the target account can be attached to the report
+	statusIDs := []string{}
+	for _, statusID := range form.StatusIDs {
+		status, err := p.db.GetStatusByID(ctx, statusID)
+		if err != nil {
+			if err == db.ErrNoEntries {
+				return nil, gtserror.NewErrorBadRequest(fmt.Errorf("status %s not found", statusID), fmt.Sprintf("status %s not found", statusID))
+			}
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		if status.AccountID != targetAccount.ID {
+			err := fmt.Errorf("status %s does not belong to account %s", statusID, targetAccount.ID)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		statusIDs = append(statusIDs, status.ID)
+	}
+
+	reportID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// reports are owned by the instance actor as far as federation is concerned, so the report's URI
+	// lives under the instance account rather than under the account that made the report
+	instanceAccount, err := p.db.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("ReportCreate: error getting instance account: %s", err))
+	}
+
+	report := &gtsmodel.Report{
+		ID:              reportID,
+		URI:             util.GenerateURIForReport(instanceAccount.Username, p.config.Protocol, p.config.Host, reportID),
+		AccountID:       authed.Account.ID,
+		Account:         authed.Account,
+		TargetAccountID: targetAccount.ID,
+		TargetAccount:   targetAccount,
+		StatusIDs:       statusIDs,
+		Comment:         form.Comment,
+		Forwarded:       form.Forward && targetAccount.Domain != "",
+	}
+
+	if err := p.db.PutReport(ctx, report); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("ReportCreate: error putting report in db: %s", err))
+	}
+
+	// send it to the processor for async processing -- it'll be forwarded from there if necessary
+	p.fromClientAPI <- gtsmodel.FromClientAPI{
+		APObjectType:   gtsmodel.ActivityStreamsProfile,
+		APActivityType: gtsmodel.ActivityStreamsFlag,
+		GTSModel:       report,
+		OriginAccount:  authed.Account,
+		TargetAccount:  targetAccount,
+	}
+
+	apiReport, err := p.tc.ReportToMasto(ctx, report)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiReport, nil
+}
@@ -0,0 +1,493 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+const (
+	// scheduledStatusMinimumOffset is how far in the future a status must be scheduled.
+	scheduledStatusMinimumOffset = 5 * time.Minute
+	// scheduledStatusDailyLimit is the maximum number of statuses one account can schedule to be posted on the same day.
+	scheduledStatusDailyLimit = 25
+	// scheduledStatusTotalLimit is the maximum number of statuses one account can have scheduled at once.
+	scheduledStatusTotalLimit = 300
+)
+
+func (p *processor) ScheduledStatusCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AdvancedStatusCreateForm) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	if authed.Account.MovedToAccountID != "" {
+		return nil, gtserror.NewErrorForbidden(fmt.Errorf("account %s has moved", authed.Account.ID), "this account has moved, so it can't post new statuses")
+	}
+
+	scheduledAt, errWithCode := p.parseScheduledAt(ctx, authed.Account.ID, form.ScheduledAt, nil)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// check the attachments exist and aren't already spoken for
+	attachments := []*gtsmodel.MediaAttachment{}
+	for _, mediaID := range form.MediaIDs {
+		a, err := p.db.GetAttachmentByID(ctx, mediaID)
+		if err != nil {
+			return nil, gtserror.NewErrorBadRequest(fmt.Errorf("invalid media type or media not found for media id %s", mediaID))
+		}
+		if a.AccountID != authed.Account.ID {
+			return nil, gtserror.NewErrorBadRequest(fmt.Errorf("media with id %s does not belong to account %s", mediaID, authed.Account.ID))
+		}
+		if a.StatusID != "" || a.ScheduledStatusID != "" {
+			return nil, gtserror.NewErrorBadRequest(fmt.Errorf("media with id %s is already attached to a status", mediaID))
+		}
+		attachments = append(attachments, a)
+	}
+
+	scheduledStatusID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	scheduledStatus := &gtsmodel.ScheduledStatus{
+		ID:          scheduledStatusID,
+		ScheduledAt: scheduledAt,
+		AccountID:   authed.Account.ID,
+		Text:        form.Status,
+		SpoilerText: form.SpoilerText,
+		Sensitive:   authed.Account.Sensitive,
+		Federated:   form.Federated,
+		Boostable:   form.Boostable,
+		Replyable:   form.Replyable,
+		Likeable:    form.Likeable,
+		InReplyToID: form.InReplyToID,
+		Language:    form.Language,
+		Format:      string(form.Format),
+		MediaIDs:    form.MediaIDs,
+	}
+
+	if authed.Application != nil {
+		scheduledStatus.ApplicationID = authed.Application.ID
+	}
+
+	if form.Sensitive != nil {
+		scheduledStatus.Sensitive = *form.Sensitive
+	}
+
+	if form.Visibility != "" {
+		scheduledStatus.Visibility = p.tc.MastoVisToVis(form.Visibility)
+	}
+
+	if form.Poll != nil {
+		scheduledStatus.PollOptions = form.Poll.Options
+		scheduledStatus.PollExpiresIn = form.Poll.ExpiresIn
+		scheduledStatus.PollMultiple = form.Poll.Multiple
+		scheduledStatus.PollHideTotals = form.Poll.HideTotals
+	}
+
+	if err := p.db.PutScheduledStatus(ctx, scheduledStatus); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// reserve the attachments for this scheduled status so they can't be used elsewhere in the meantime
+	for _, a := range attachments {
+		a.ScheduledStatusID = scheduledStatus.ID
+		if err := p.db.UpdateByID(ctx, a.ID, a); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error updating attachment %s: %s", a.ID, err))
+		}
+	}
+
+	p.scheduleStatus(scheduledStatus)
+
+	mastoScheduledStatus, err := p.tc.ScheduledStatusToMasto(ctx, scheduledStatus)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return mastoScheduledStatus, nil
+}
+
+func (p *processor) ScheduledStatusesGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, minID string, limit int) (*apimodel.ScheduledStatusesResponse, gtserror.WithCode) {
+	scheduledStatuses, err := p.db.GetScheduledStatusesForAccountID(ctx, authed.Account.ID, maxID, sinceID, minID, limit)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			// there are just no entries
+			return &apimodel.ScheduledStatusesResponse{
+				ScheduledStatuses: []*apimodel.ScheduledStatus{},
+			}, nil
+		}
+		// there's an actual error
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	mastoScheduledStatuses := []*apimodel.ScheduledStatus{}
+	for _, s := range scheduledStatuses {
+		mastoScheduledStatus, err := p.tc.ScheduledStatusToMasto(ctx, s)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		mastoScheduledStatuses = append(mastoScheduledStatuses, mastoScheduledStatus)
+	}
+
+	resp := &apimodel.ScheduledStatusesResponse{
+		ScheduledStatuses: mastoScheduledStatuses,
+	}
+
+	// prepare the next and previous links
+	nextLink := &url.URL{
+		Scheme:   p.config.Protocol,
+		Host:     p.config.Host,
+		Path:     "/api/v1/scheduled_statuses",
+		RawQuery: fmt.Sprintf("limit=%d&max_id=%s", limit, scheduledStatuses[len(scheduledStatuses)-1].ID),
+	}
+	next := fmt.Sprintf("<%s>; rel=\"next\"", nextLink.String())
+
+	prevLink := &url.URL{
+		Scheme:   p.config.Protocol,
+		Host:     p.config.Host,
+		Path:     "/api/v1/scheduled_statuses",
+		RawQuery: fmt.Sprintf("limit=%d&min_id=%s", limit, scheduledStatuses[0].ID),
+	}
+	prev := fmt.Sprintf("<%s>; rel=\"prev\"", prevLink.String())
+	resp.LinkHeader = fmt.Sprintf("%s, %s", next, prev)
+
+	return resp, nil
+}
+
+func (p *processor) ScheduledStatusGet(ctx context.Context, authed *oauth.Auth, scheduledStatusID string) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	scheduledStatus, errWithCode := p.getOwnScheduledStatus(ctx, authed.Account.ID, scheduledStatusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	mastoScheduledStatus, err := p.tc.ScheduledStatusToMasto(ctx, scheduledStatus)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return mastoScheduledStatus, nil
+}
+
+func (p *processor) ScheduledStatusUpdate(ctx context.Context, authed *oauth.Auth, scheduledStatusID string, form *apimodel.ScheduledStatusUpdateRequest) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	scheduledStatus, errWithCode := p.getOwnScheduledStatus(ctx, authed.Account.ID, scheduledStatusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	scheduledAt, errWithCode := p.parseScheduledAt(ctx, authed.Account.ID, form.ScheduledAt, scheduledStatus)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	scheduledStatus.ScheduledAt = scheduledAt
+	if err := p.db.UpdateScheduledStatus(ctx, scheduledStatus); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.scheduleStatus(scheduledStatus)
+
+	mastoScheduledStatus, err := p.tc.ScheduledStatusToMasto(ctx, scheduledStatus)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return mastoScheduledStatus, nil
+}
+
+func (p *processor) ScheduledStatusDelete(ctx context.Context, authed *oauth.Auth, scheduledStatusID string) gtserror.WithCode {
+	scheduledStatus, errWithCode := p.getOwnScheduledStatus(ctx, authed.Account.ID, scheduledStatusID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	p.unscheduleStatus(scheduledStatus.ID)
+
+	if err := p.deleteScheduledStatus(ctx, scheduledStatus); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// getOwnScheduledStatus returns the scheduled status with the given ID, or a not found error if it doesn't exist or isn't owned by the given account.
+func (p *processor) getOwnScheduledStatus(ctx context.Context, accountID string, scheduledStatusID string) (*gtsmodel.ScheduledStatus, gtserror.WithCode) {
+	scheduledStatus, err := p.db.GetScheduledStatusByID(ctx, scheduledStatusID)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(fmt.Errorf("scheduled status %s not found", scheduledStatusID))
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if scheduledStatus.AccountID != accountID {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("scheduled status %s not owned by account %s", scheduledStatusID, accountID))
+	}
+
+	return scheduledStatus, nil
+}
+
+// parseScheduledAt parses the given ISO 8601 datetime and checks that the account is allowed to schedule a status at that time.
+// If an existing scheduled status is being moved, it should be passed as existing so that it isn't counted against the limits.
+func (p *processor) parseScheduledAt(ctx context.Context, accountID string, scheduledAtString string, existing *gtsmodel.ScheduledStatus) (time.Time, gtserror.WithCode) {
+	scheduledAt, err := time.Parse(time.RFC3339, scheduledAtString)
+	if err != nil {
+		return time.Time{}, gtserror.NewErrorUnprocessableEntity(err, "scheduled_at must be an ISO 8601 datetime")
+	}
+
+	if scheduledAt.Before(time.Now().Add(scheduledStatusMinimumOffset)) {
+		return time.Time{}, gtserror.NewErrorUnprocessableEntity(errors.New("scheduled_at too soon"), "scheduled_at must be at least 5 minutes in the future")
+	}
+
+	total, err := p.db.CountScheduledStatusesForAccountID(ctx, accountID, time.Time{}, time.Time{})
+	if err != nil {
+		return time.Time{}, gtserror.NewErrorInternalError(err)
+	}
+	if existing != nil {
+		total = total - 1
+	}
+	if total >= scheduledStatusTotalLimit {
+		return time.Time{}, gtserror.NewErrorUnprocessableEntity(errors.New("too many scheduled statuses"), fmt.Sprintf("you can't have more than %d scheduled statuses", scheduledStatusTotalLimit))
+	}
+
+	dayStart := scheduledAt.UTC().Truncate(24 * time.Hour)
+	dayEnd := dayStart.Add(24 * time.Hour)
+	daily, err := p.db.CountScheduledStatusesForAccountID(ctx, accountID, dayStart, dayEnd)
+	if err != nil {
+		return time.Time{}, gtserror.NewErrorInternalError(err)
+	}
+	if existing != nil && !existing.ScheduledAt.Before(dayStart) && existing.ScheduledAt.Before(dayEnd) {
+		daily = daily - 1
+	}
+	if daily >= scheduledStatusDailyLimit {
+		return time.Time{}, gtserror.NewErrorUnprocessableEntity(errors.New("too many scheduled statuses on one day"), fmt.Sprintf("you can't schedule more than %d statuses on the same day", scheduledStatusDailyLimit))
+	}
+
+	return scheduledAt, nil
+}
+
+// deleteScheduledStatus removes the given scheduled status from the database, releasing any attachments it reserved.
+func (p *processor) deleteScheduledStatus(ctx context.Context, scheduledStatus *gtsmodel.ScheduledStatus) error {
+	if err := p.releaseScheduledAttachments(ctx, scheduledStatus); err != nil {
+		return fmt.Errorf("deleteScheduledStatus: %s", err)
+	}
+
+	if err := p.db.DeleteScheduledStatusByID(ctx, scheduledStatus.ID); err != nil {
+		return fmt.Errorf("deleteScheduledStatus: error deleting scheduled status %s: %s", scheduledStatus.ID, err)
+	}
+
+	return nil
+}
+
+// releaseScheduledAttachments frees up any attachments reserved by the given scheduled status, so that they can be used by another status.
+func (p *processor) releaseScheduledAttachments(ctx context.Context, scheduledStatus *gtsmodel.ScheduledStatus) error {
+	for _, mediaID := range scheduledStatus.MediaIDs {
+		a, err := p.db.GetAttachmentByID(ctx, mediaID)
+		if err != nil {
+			// the attachment has gone away in the meantime, nothing to release
+			continue
+		}
+		if a.ScheduledStatusID != scheduledStatus.ID {
+			continue
+		}
+		a.ScheduledStatusID = ""
+		if err := p.db.UpdateByID(ctx, a.ID, a); err != nil {
+			return fmt.Errorf("releaseScheduledAttachments: error updating attachment %s: %s", a.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// reserveScheduledAttachments marks any attachments of the given scheduled status that aren't used elsewhere as
+// reserved by it again, undoing releaseScheduledAttachments.
+func (p *processor) reserveScheduledAttachments(ctx context.Context, scheduledStatus *gtsmodel.ScheduledStatus) error {
+	for _, mediaID := range scheduledStatus.MediaIDs {
+		a, err := p.db.GetAttachmentByID(ctx, mediaID)
+		if err != nil {
+			// the attachment has gone away in the meantime, nothing to reserve
+			continue
+		}
+		if a.StatusID != "" || a.ScheduledStatusID != "" {
+			continue
+		}
+		a.ScheduledStatusID = scheduledStatus.ID
+		if err := p.db.UpdateByID(ctx, a.ID, a); err != nil {
+			return fmt.Errorf("reserveScheduledAttachments: error updating attachment %s: %s", a.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// loadScheduledStatuses sets a timer for every scheduled status in the database, so that statuses
+// scheduled before a restart are still posted. Any that should have been posted while we were down
+// will be posted straight away.
+func (p *processor) loadScheduledStatuses(ctx context.Context) error {
+	scheduledStatuses, err := p.db.GetAllScheduledStatuses(ctx)
+	if err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("loadScheduledStatuses: error getting scheduled statuses: %s", err)
+	}
+
+	for _, s := range scheduledStatuses {
+		p.scheduleStatus(s)
+	}
+
+	p.log.Infof("loadScheduledStatuses: loaded %d scheduled statuses", len(scheduledStatuses))
+	return nil
+}
+
+// scheduleStatus sets a timer to post the given scheduled status at its scheduled time, replacing any timer already set for it.
+func (p *processor) scheduleStatus(scheduledStatus *gtsmodel.ScheduledStatus) {
+	scheduledStatusID := scheduledStatus.ID
+
+	p.scheduledStatusTimersLock.Lock()
+	defer p.scheduledStatusTimersLock.Unlock()
+
+	if t, ok := p.scheduledStatusTimers[scheduledStatusID]; ok {
+		t.Stop()
+	}
+
+	p.scheduledStatusTimers[scheduledStatusID] = time.AfterFunc(time.Until(scheduledStatus.ScheduledAt), func() {
+		if err := p.publishScheduledStatus(context.Background(), scheduledStatusID); err != nil {
+			p.log.Errorf("error publishing scheduled status %s: %s", scheduledStatusID, err)
+		}
+	})
+}
+
+// unscheduleStatus cancels the timer for the scheduled status with the given ID, if there is one.
+func (p *processor) unscheduleStatus(scheduledStatusID string) {
+	p.scheduledStatusTimersLock.Lock()
+	defer p.scheduledStatusTimersLock.Unlock()
+
+	if t, ok := p.scheduledStatusTimers[scheduledStatusID]; ok {
+		t.Stop()
+		delete(p.scheduledStatusTimers, scheduledStatusID)
+	}
+}
+
+// unscheduleAllStatuses cancels every scheduled status timer; the scheduled statuses themselves stay in the database.
+func (p *processor) unscheduleAllStatuses() {
+	p.scheduledStatusTimersLock.Lock()
+	defer p.scheduledStatusTimersLock.Unlock()
+
+	for scheduledStatusID, t := range p.scheduledStatusTimers {
+		t.Stop()
+		delete(p.scheduledStatusTimers, scheduledStatusID)
+	}
+}
+
+// publishScheduledStatus turns the scheduled status with the given ID into a real status, by passing
+// its parameters through the normal status creation process, and then removes the scheduled status.
+func (p *processor) publishScheduledStatus(ctx context.Context, scheduledStatusID string) error {
+	p.unscheduleStatus(scheduledStatusID)
+
+	scheduledStatus, err := p.db.GetScheduledStatusByID(ctx, scheduledStatusID)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			// it's been deleted in the meantime so there's nothing to do
+			return nil
+		}
+		return fmt.Errorf("publishScheduledStatus: error getting scheduled status: %s", err)
+	}
+
+	if time.Until(scheduledStatus.ScheduledAt) > time.Second {
+		// it's been moved later in the meantime, so set a new timer for it
+		p.scheduleStatus(scheduledStatus)
+		return nil
+	}
+
+	account, err := p.db.GetAccountByID(ctx, scheduledStatus.AccountID)
+	if err != nil {
+		return fmt.Errorf("publishScheduledStatus: error getting account %s: %s", scheduledStatus.AccountID, err)
+	}
+
+	application := &gtsmodel.Application{}
+	if scheduledStatus.ApplicationID != "" {
+		if err := p.db.GetByID(ctx, scheduledStatus.ApplicationID, application); err != nil && err != db.ErrNoEntries {
+			return fmt.Errorf("publishScheduledStatus: error getting application %s: %s", scheduledStatus.ApplicationID, err)
+		}
+	}
+
+	if !account.SuspendedAt.IsZero() {
+		// the account has been suspended since the status was scheduled so don't post it
+		if err := p.deleteScheduledStatus(ctx, scheduledStatus); err != nil {
+			return fmt.Errorf("publishScheduledStatus: %s", err)
+		}
+		return nil
+	}
+
+	// release the attachments before creating the status so that they can be used by the new status
+	if err := p.releaseScheduledAttachments(ctx, scheduledStatus); err != nil {
+		return fmt.Errorf("publishScheduledStatus: %s", err)
+	}
+
+	form := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      scheduledStatus.Text,
+			MediaIDs:    scheduledStatus.MediaIDs,
+			InReplyToID: scheduledStatus.InReplyToID,
+			Sensitive:   &scheduledStatus.Sensitive,
+			SpoilerText: scheduledStatus.SpoilerText,
+			Language:    scheduledStatus.Language,
+			Format:      apimodel.StatusFormat(scheduledStatus.Format),
+		},
+		AdvancedVisibilityFlagsForm: apimodel.AdvancedVisibilityFlagsForm{
+			Federated: scheduledStatus.Federated,
+			Boostable: scheduledStatus.Boostable,
+			Replyable: scheduledStatus.Replyable,
+			Likeable:  scheduledStatus.Likeable,
+		},
+	}
+
+	if scheduledStatus.Visibility != "" {
+		form.Visibility = p.tc.VisToMasto(ctx, scheduledStatus.Visibility)
+	}
+
+	if len(scheduledStatus.PollOptions) != 0 {
+		form.Poll = &apimodel.PollRequest{
+			Options:    scheduledStatus.PollOptions,
+			ExpiresIn:  scheduledStatus.PollExpiresIn,
+			Multiple:   scheduledStatus.PollMultiple,
+			HideTotals: scheduledStatus.PollHideTotals,
+		}
+	}
+
+	if _, errWithCode := p.statusProcessor.Create(ctx, account, application, form); errWithCode != nil {
+		// keep the scheduled status around so that it isn't lost, and give it its attachments back
+		if err := p.reserveScheduledAttachments(ctx, scheduledStatus); err != nil {
+			p.log.Errorf("publishScheduledStatus: %s", err)
+		}
+		return fmt.Errorf("publishScheduledStatus: error creating status: %s", errWithCode)
+	}
+
+	// the status has been posted now, so remove the scheduled status so that we don't post it twice
+	if err := p.db.DeleteScheduledStatusByID(ctx, scheduledStatus.ID); err != nil {
+		return fmt.Errorf("publishScheduledStatus: error deleting scheduled status %s: %s", scheduledStatus.ID, err)
+	}
+
+	return nil
+}
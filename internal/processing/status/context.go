@@ -27,6 +27,7 @@ import (
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/visibility"
 )
 
 func (p *processor) Context(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*apimodel.Context, gtserror.WithCode) {
@@ -46,6 +47,12 @@ func (p *processor) Context(ctx context.Context, requestingAccount *gtsmodel.Acc
 		return nil, gtserror.NewErrorNotFound(errors.New("status is not visible"))
 	}
 
+	// prepare the thread filters of the requester just once for all the statuses in the thread
+	filtered, err := p.filter.StatusFilterer(ctx, requestingAccount, gtsmodel.FilterContextThread)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
 	context := &apimodel.Context{
 		Ancestors:   []apimodel.Status{},
 		Descendants: []apimodel.Status{},
@@ -57,7 +64,7 @@ func (p *processor) Context(ctx context.Context, requestingAccount *gtsmodel.Acc
 	}
 
 	for _, status := range parents {
-		if v, err := p.filter.StatusVisible(ctx, status, requestingAccount); err == nil && v {
+		if p.visibleInThread(ctx, status, requestingAccount, filtered) {
 			mastoStatus, err := p.tc.StatusToMasto(ctx, status, requestingAccount)
 			if err == nil {
 				context.Ancestors = append(context.Ancestors, *mastoStatus)
@@ -75,7 +82,7 @@ func (p *processor) Context(ctx context.Context, requestingAccount *gtsmodel.Acc
 	}
 
 	for _, status := range children {
-		if v, err := p.filter.StatusVisible(ctx, status, requestingAccount); err == nil && v {
+		if p.visibleInThread(ctx, status, requestingAccount, filtered) {
 			mastoStatus, err := p.tc.StatusToMasto(ctx, status, requestingAccount)
 			if err == nil {
 				context.Descendants = append(context.Descendants, *mastoStatus)
@@ -85,3 +92,14 @@ func (p *processor) Context(ctx context.Context, requestingAccount *gtsmodel.Acc
 
 	return context, nil
 }
+
+// visibleInThread returns true if the given status is visible to the requesting account,
+// and isn't hidden from them by one of their thread filters.
+func (p *processor) visibleInThread(ctx context.Context, status *gtsmodel.Status, requestingAccount *gtsmodel.Account, filtered visibility.StatusFilterFunc) bool {
+	if v, err := p.filter.StatusVisible(ctx, status, requestingAccount); err != nil || !v {
+		return false
+	}
+
+	isFiltered, err := filtered(ctx, status)
+	return err == nil && !isFiltered
+}
@@ -32,6 +32,10 @@ import (
 )
 
 func (p *processor) Create(ctx context.Context, account *gtsmodel.Account, application *gtsmodel.Application, form *apimodel.AdvancedStatusCreateForm) (*apimodel.Status, gtserror.WithCode) {
+	if account.MovedToAccountID != "" {
+		return nil, gtserror.NewErrorForbidden(fmt.Errorf("account %s has moved", account.ID), "this account has moved, so it can't post new statuses")
+	}
+
 	uris := util.GenerateURIsForAccount(account.Username, p.config.Protocol, p.config.Host)
 	thisStatusID, err := id.NewULID()
 	if err != nil {
@@ -51,7 +55,6 @@ func (p *processor) Create(ctx context.Context, account *gtsmodel.Account, appli
 		AccountURI:               account.URI,
 		ContentWarning:           text.RemoveHTML(form.SpoilerText),
 		ActivityStreamsType:      gtsmodel.ActivityStreamsNote,
-		Sensitive:                form.Sensitive,
 		Language:                 form.Language,
 		CreatedWithApplicationID: application.ID,
 		Text:                     form.Status,
@@ -65,6 +68,10 @@ func (p *processor) Create(ctx context.Context, account *gtsmodel.Account, appli
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	if err := p.ProcessPoll(ctx, form, account.ID, newStatus); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
 	if err := p.ProcessVisibility(ctx, form, account.Privacy, newStatus); err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
@@ -73,6 +80,10 @@ func (p *processor) Create(ctx context.Context, account *gtsmodel.Account, appli
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	if err := p.ProcessSensitive(ctx, form, account.Sensitive, newStatus); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
 	if err := p.ProcessMentions(ctx, form, account.ID, newStatus); err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
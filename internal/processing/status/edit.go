@@ -0,0 +1,181 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+func (p *processor) Edit(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string, form *apimodel.StatusEditRequest) (*apimodel.Status, gtserror.WithCode) {
+	targetStatus, err := p.db.GetStatusByID(ctx, targetStatusID)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error fetching status %s: %s", targetStatusID, err))
+	}
+	if targetStatus.Account == nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("no status owner for status %s", targetStatusID))
+	}
+
+	if targetStatus.AccountID != requestingAccount.ID {
+		return nil, gtserror.NewErrorForbidden(errors.New("status doesn't belong to requesting account"))
+	}
+
+	if targetStatus.BoostOfID != "" {
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New("boosts cannot be edited"), "boosts cannot be edited")
+	}
+
+	// store the current version of the status as a revision before we change anything
+	revisionCreatedAt := targetStatus.CreatedAt
+	if !targetStatus.EditedAt.IsZero() {
+		revisionCreatedAt = targetStatus.EditedAt
+	}
+	editID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	edit := &gtsmodel.StatusEdit{
+		ID:             editID,
+		CreatedAt:      revisionCreatedAt,
+		StatusID:       targetStatus.ID,
+		AccountID:      targetStatus.AccountID,
+		Content:        targetStatus.Content,
+		ContentWarning: targetStatus.ContentWarning,
+		Text:           targetStatus.Text,
+		Sensitive:      targetStatus.Sensitive,
+		Language:       targetStatus.Language,
+		AttachmentIDs:  targetStatus.AttachmentIDs,
+		EmojiIDs:       targetStatus.EmojiIDs,
+	}
+
+	// the existing mentions are needed so that they can be reused rather than created again
+	oldMentions, err := p.db.GetMentions(ctx, targetStatus.MentionIDs)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error getting mentions of status %s: %s", targetStatus.ID, err))
+	}
+	targetStatus.Mentions = oldMentions
+
+	// wrap the edit in a create form so that we can reuse the status processing functions
+	createForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      form.Status,
+			MediaIDs:    form.MediaIDs,
+			Sensitive:   &form.Sensitive,
+			SpoilerText: form.SpoilerText,
+			Language:    form.Language,
+		},
+	}
+
+	if err := p.processEditMediaIDs(ctx, createForm, requestingAccount.ID, targetStatus); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if err := p.ProcessLanguage(ctx, createForm, targetStatus.Language, targetStatus); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.ProcessMentions(ctx, createForm, requestingAccount.ID, targetStatus); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.ProcessTags(ctx, createForm, requestingAccount.ID, targetStatus); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.ProcessEmojis(ctx, createForm, requestingAccount.ID, targetStatus); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.ProcessContent(ctx, createForm, requestingAccount.ID, targetStatus); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	now := time.Now()
+	targetStatus.Text = form.Status
+	targetStatus.ContentWarning = text.RemoveHTML(form.SpoilerText)
+	targetStatus.Sensitive = form.Sensitive
+	targetStatus.EditedAt = now
+	targetStatus.UpdatedAt = now
+
+	if err := p.db.PutStatusEdit(ctx, edit); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error putting status edit in the database: %s", err))
+	}
+
+	if err := p.db.UpdateStatus(ctx, targetStatus); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error updating status in the database: %s", err))
+	}
+
+	// remove any mentions which were dropped by the edit
+	for _, m := range oldMentions {
+		if existingMention(targetStatus.Mentions, m.TargetAccountID) != nil {
+			continue
+		}
+		if err := p.db.DeleteByID(ctx, m.ID, &gtsmodel.Mention{}); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error deleting mention %s: %s", m.ID, err))
+		}
+	}
+
+	// send it back to the processor for async processing
+	p.fromClientAPI <- gtsmodel.FromClientAPI{
+		APObjectType:   gtsmodel.ActivityStreamsNote,
+		APActivityType: gtsmodel.ActivityStreamsUpdate,
+		GTSModel:       targetStatus,
+		OriginAccount:  requestingAccount,
+	}
+
+	mastoStatus, err := p.tc.StatusToMasto(ctx, targetStatus, requestingAccount)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting status %s to frontend representation: %s", targetStatus.ID, err))
+	}
+
+	return mastoStatus, nil
+}
+
+// processEditMediaIDs is like ProcessMediaIDs, except that attachments which are already attached to the status being edited are allowed.
+func (p *processor) processEditMediaIDs(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, thisAccountID string, status *gtsmodel.Status) error {
+	gtsMediaAttachments := []*gtsmodel.MediaAttachment{}
+	attachments := []string{}
+	for _, mediaID := range form.MediaIDs {
+		// check these attachments exist
+		a, err := p.db.GetAttachmentByID(ctx, mediaID)
+		if err != nil {
+			return fmt.Errorf("invalid media type or media not found for media id %s", mediaID)
+		}
+		// check they belong to the requesting account id
+		if a.AccountID != thisAccountID {
+			return fmt.Errorf("media with id %s does not belong to account %s", mediaID, thisAccountID)
+		}
+		// check they're not already used in another status
+		if (a.StatusID != "" && a.StatusID != status.ID) || a.ScheduledStatusID != "" {
+			return fmt.Errorf("media with id %s is already attached to a status", mediaID)
+		}
+		gtsMediaAttachments = append(gtsMediaAttachments, a)
+		attachments = append(attachments, a.ID)
+	}
+	status.Attachments = gtsMediaAttachments
+	status.AttachmentIDs = attachments
+	return nil
+}
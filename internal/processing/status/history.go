@@ -0,0 +1,82 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func (p *processor) History(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) ([]*apimodel.StatusEdit, gtserror.WithCode) {
+	targetStatus, err := p.db.GetStatusByID(ctx, targetStatusID)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error fetching status %s: %s", targetStatusID, err))
+	}
+	if targetStatus.Account == nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("no status owner for status %s", targetStatusID))
+	}
+
+	visible, err := p.filter.StatusVisible(ctx, targetStatus, requestingAccount)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error seeing if status %s is visible: %s", targetStatus.ID, err))
+	}
+	if !visible {
+		return nil, gtserror.NewErrorNotFound(errors.New("status is not visible"))
+	}
+
+	edits, err := p.db.GetStatusEdits(ctx, targetStatus.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error getting edits of status %s: %s", targetStatus.ID, err))
+	}
+
+	// the current version of the status is always the last entry in its history
+	currentCreatedAt := targetStatus.CreatedAt
+	if !targetStatus.EditedAt.IsZero() {
+		currentCreatedAt = targetStatus.EditedAt
+	}
+	edits = append(edits, &gtsmodel.StatusEdit{
+		CreatedAt:      currentCreatedAt,
+		StatusID:       targetStatus.ID,
+		AccountID:      targetStatus.AccountID,
+		Account:        targetStatus.Account,
+		Content:        targetStatus.Content,
+		ContentWarning: targetStatus.ContentWarning,
+		Text:           targetStatus.Text,
+		Sensitive:      targetStatus.Sensitive,
+		Language:       targetStatus.Language,
+		AttachmentIDs:  targetStatus.AttachmentIDs,
+		EmojiIDs:       targetStatus.EmojiIDs,
+	})
+
+	mastoEdits := []*apimodel.StatusEdit{}
+	for _, e := range edits {
+		mastoEdit, err := p.tc.StatusEditToMasto(ctx, e)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting status edit to frontend representation: %s", err))
+		}
+		mastoEdits = append(mastoEdits, mastoEdit)
+	}
+
+	return mastoEdits, nil
+}
@@ -0,0 +1,87 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// pinnedStatusLimit is the maximum number of statuses one account can have pinned to their profile at once.
+const pinnedStatusLimit = 5
+
+func (p *processor) Pin(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode) {
+	targetStatus, errWithCode := p.getPinnableStatus(ctx, requestingAccount, targetStatusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if !targetStatus.Pinned {
+		pinned, err := p.db.CountAccountPinned(ctx, requestingAccount.ID)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error counting pinned statuses: %s", err))
+		}
+		if pinned >= pinnedStatusLimit {
+			err := fmt.Errorf("an account can only have %d statuses pinned at once", pinnedStatusLimit)
+			return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+
+		targetStatus.Pinned = true
+		if err := p.db.UpdateStatus(ctx, targetStatus); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error pinning status: %s", err))
+		}
+	}
+
+	mastoStatus, err := p.tc.StatusToMasto(ctx, targetStatus, requestingAccount)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting status %s to frontend representation: %s", targetStatus.ID, err))
+	}
+
+	return mastoStatus, nil
+}
+
+// getPinnableStatus fetches the target status and makes sure that the requesting account is allowed to pin or unpin it.
+func (p *processor) getPinnableStatus(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*gtsmodel.Status, gtserror.WithCode) {
+	targetStatus, err := p.db.GetStatusByID(ctx, targetStatusID)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error fetching status %s: %s", targetStatusID, err))
+	}
+	if targetStatus.Account == nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("no status owner for status %s", targetStatusID))
+	}
+
+	if targetStatus.AccountID != requestingAccount.ID {
+		return nil, gtserror.NewErrorForbidden(errors.New("status doesn't belong to requesting account"))
+	}
+
+	if targetStatus.BoostOfID != "" {
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New("boosts cannot be pinned"), "boosts cannot be pinned")
+	}
+
+	if targetStatus.Visibility == gtsmodel.VisibilityDirect {
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New("direct statuses cannot be pinned"), "direct statuses cannot be pinned")
+	}
+
+	return targetStatus, nil
+}
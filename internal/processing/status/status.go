@@ -36,6 +36,12 @@ import (
 type Processor interface {
 	// Create processes the given form to create a new status, returning the api model representation of that status if it's OK.
 	Create(ctx context.Context, account *gtsmodel.Account, application *gtsmodel.Application, form *apimodel.AdvancedStatusCreateForm) (*apimodel.Status, gtserror.WithCode)
+	// Edit processes the edit of a given status, storing its previous version as a revision and returning the updated status if all is well.
+	Edit(ctx context.Context, account *gtsmodel.Account, targetStatusID string, form *apimodel.StatusEditRequest) (*apimodel.Status, gtserror.WithCode)
+	// History returns the revisions of a given status, oldest first, ending with its current version.
+	History(ctx context.Context, account *gtsmodel.Account, targetStatusID string) ([]*apimodel.StatusEdit, gtserror.WithCode)
+	// Source returns the plain-text source of a given status, for editing it. Only the author of the status can see this.
+	Source(ctx context.Context, account *gtsmodel.Account, targetStatusID string) (*apimodel.StatusSource, gtserror.WithCode)
 	// Delete processes the delete of a given status, returning the deleted status if the delete goes through.
 	Delete(ctx context.Context, account *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
 	// Fave processes the faving of a given status, returning the updated status if the fave goes through.
@@ -52,6 +58,18 @@ type Processor interface {
 	Get(ctx context.Context, account *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
 	// Unfave processes the unfaving of a given status, returning the updated status if the fave goes through.
 	Unfave(ctx context.Context, account *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
+	// Bookmark processes the bookmarking of a given status, returning the updated status if the bookmark goes through.
+	Bookmark(ctx context.Context, account *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
+	// Unbookmark processes the unbookmarking of a given status, returning the updated status if the unbookmark goes through.
+	Unbookmark(ctx context.Context, account *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
+	// Mute processes the muting of the thread that a given status belongs to, returning the updated status.
+	Mute(ctx context.Context, account *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
+	// Unmute processes the unmuting of the thread that a given status belongs to, returning the updated status.
+	Unmute(ctx context.Context, account *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
+	// Pin processes the pinning of a given status to the profile of its author, returning the updated status.
+	Pin(ctx context.Context, account *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
+	// Unpin processes the unpinning of a given status from the profile of its author, returning the updated status.
+	Unpin(ctx context.Context, account *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
 	// Context returns the context (previous and following posts) from the given status ID
 	Context(ctx context.Context, account *gtsmodel.Account, targetStatusID string) (*apimodel.Context, gtserror.WithCode)
 
@@ -63,6 +81,7 @@ type Processor interface {
 	ProcessReplyToID(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, thisAccountID string, status *gtsmodel.Status) error
 	ProcessMediaIDs(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, thisAccountID string, status *gtsmodel.Status) error
 	ProcessLanguage(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, accountDefaultLanguage string, status *gtsmodel.Status) error
+	ProcessSensitive(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, accountDefaultSensitive bool, status *gtsmodel.Status) error
 	ProcessMentions(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, accountID string, status *gtsmodel.Status) error
 	ProcessTags(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, accountID string, status *gtsmodel.Status) error
 	ProcessEmojis(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, accountID string, status *gtsmodel.Status) error
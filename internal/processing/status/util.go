@@ -22,6 +22,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -180,6 +181,37 @@ func (p *processor) ProcessMediaIDs(ctx context.Context, form *apimodel.Advanced
 	return nil
 }
 
+func (p *processor) ProcessPoll(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, thisAccountID string, status *gtsmodel.Status) error {
+	if form.Poll == nil {
+		return nil
+	}
+
+	pollID, err := id.NewULIDFromTime(status.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	options := []string{}
+	for _, o := range form.Poll.Options {
+		options = append(options, text.RemoveHTML(o))
+	}
+
+	status.Poll = &gtsmodel.Poll{
+		ID:         pollID,
+		CreatedAt:  status.CreatedAt,
+		UpdatedAt:  status.CreatedAt,
+		StatusID:   status.ID,
+		AccountID:  thisAccountID,
+		ExpiresAt:  status.CreatedAt.Add(time.Duration(form.Poll.ExpiresIn) * time.Second),
+		Multiple:   form.Poll.Multiple,
+		HideTotals: form.Poll.HideTotals,
+		Options:    options,
+		Votes:      make([]int, len(options)),
+	}
+	status.PollID = pollID
+	return nil
+}
+
 func (p *processor) ProcessLanguage(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, accountDefaultLanguage string, status *gtsmodel.Status) error {
 	if form.Language != "" {
 		status.Language = form.Language
@@ -192,13 +224,29 @@ func (p *processor) ProcessLanguage(ctx context.Context, form *apimodel.Advanced
 	return nil
 }
 
+func (p *processor) ProcessSensitive(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, accountDefaultSensitive bool, status *gtsmodel.Status) error {
+	if form.Sensitive != nil {
+		status.Sensitive = *form.Sensitive
+	} else {
+		status.Sensitive = accountDefaultSensitive
+	}
+	return nil
+}
+
 func (p *processor) ProcessMentions(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, accountID string, status *gtsmodel.Status) error {
 	menchies := []string{}
 	gtsMenchies, err := p.db.MentionStringsToMentions(ctx, util.DeriveMentionsFromStatus(form.Status), accountID, status.ID)
 	if err != nil {
 		return fmt.Errorf("error generating mentions from status: %s", err)
 	}
-	for _, menchie := range gtsMenchies {
+	for i, menchie := range gtsMenchies {
+		// if the status already mentions this account (ie., it's being edited), just keep the existing mention
+		if existing := existingMention(status.Mentions, menchie.TargetAccountID); existing != nil {
+			gtsMenchies[i] = existing
+			menchies = append(menchies, existing.ID)
+			continue
+		}
+
 		menchieID, err := id.NewRandomULID()
 		if err != nil {
 			return err
@@ -217,6 +265,16 @@ func (p *processor) ProcessMentions(ctx context.Context, form *apimodel.Advanced
 	return nil
 }
 
+// existingMention returns the mention of the given target account from the given slice, or nil if there isn't one.
+func existingMention(mentions []*gtsmodel.Mention, targetAccountID string) *gtsmodel.Mention {
+	for _, m := range mentions {
+		if m != nil && m.TargetAccountID == targetAccountID {
+			return m
+		}
+	}
+	return nil
+}
+
 func (p *processor) ProcessTags(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, accountID string, status *gtsmodel.Status) error {
 	tags := []string{}
 	gtsTags, err := p.db.TagStringsToTags(ctx, util.DeriveHashtagsFromStatus(form.Status), accountID, status.ID)
@@ -88,7 +88,6 @@ func (suite *UtilTestSuite) TestProcessMentions1() {
 			MediaIDs:    []string{},
 			Poll:        nil,
 			InReplyToID: "",
-			Sensitive:   false,
 			SpoilerText: "",
 			Visibility:  model.VisibilityPublic,
 			ScheduledAt: "",
@@ -138,7 +137,6 @@ func (suite *UtilTestSuite) TestProcessContentFull1() {
 			MediaIDs:    []string{},
 			Poll:        nil,
 			InReplyToID: "",
-			Sensitive:   false,
 			SpoilerText: "",
 			Visibility:  model.VisibilityPublic,
 			ScheduledAt: "",
@@ -187,7 +185,6 @@ func (suite *UtilTestSuite) TestProcessContentPartial1() {
 			MediaIDs:    []string{},
 			Poll:        nil,
 			InReplyToID: "",
-			Sensitive:   false,
 			SpoilerText: "",
 			Visibility:  model.VisibilityPublic,
 			ScheduledAt: "",
@@ -229,7 +226,6 @@ func (suite *UtilTestSuite) TestProcessMentions2() {
 			MediaIDs:    []string{},
 			Poll:        nil,
 			InReplyToID: "",
-			Sensitive:   false,
 			SpoilerText: "",
 			Visibility:  model.VisibilityPublic,
 			ScheduledAt: "",
@@ -279,7 +275,6 @@ func (suite *UtilTestSuite) TestProcessContentFull2() {
 			MediaIDs:    []string{},
 			Poll:        nil,
 			InReplyToID: "",
-			Sensitive:   false,
 			SpoilerText: "",
 			Visibility:  model.VisibilityPublic,
 			ScheduledAt: "",
@@ -329,7 +324,6 @@ func (suite *UtilTestSuite) TestProcessContentPartial2() {
 			MediaIDs:    []string{},
 			Poll:        nil,
 			InReplyToID: "",
-			Sensitive:   false,
 			SpoilerText: "",
 			Visibility:  model.VisibilityPublic,
 			ScheduledAt: "",
@@ -363,6 +357,23 @@ func (suite *UtilTestSuite) TestProcessContentPartial2() {
 	// assert.Equal(suite.T(), statusText2ExpectedPartial, status.Content)
 }
 
+func (suite *UtilTestSuite) TestProcessSensitive() {
+	form := &model.AdvancedStatusCreateForm{}
+	status := &gtsmodel.Status{}
+
+	// sensitive not set on the form, so the account default should be used
+	err := suite.status.ProcessSensitive(context.Background(), form, true, status)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), status.Sensitive)
+
+	// sensitive explicitly set to false on the form, which should override the account default
+	sensitive := false
+	form.Sensitive = &sensitive
+	err = suite.status.ProcessSensitive(context.Background(), form, true, status)
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), status.Sensitive)
+}
+
 func TestUtilTestSuite(t *testing.T) {
 	suite.Run(t, new(UtilTestSuite))
 }
@@ -29,6 +29,6 @@ func (p *processor) AuthorizeStreamingRequest(ctx context.Context, accessToken s
 	return p.streamingProcessor.AuthorizeStreamingRequest(ctx, accessToken)
 }
 
-func (p *processor) OpenStreamForAccount(ctx context.Context, account *gtsmodel.Account, streamType string) (*gtsmodel.Stream, gtserror.WithCode) {
-	return p.streamingProcessor.OpenStreamForAccount(ctx, account, streamType)
+func (p *processor) OpenStreamForAccount(ctx context.Context, account *gtsmodel.Account, streamType string, accessToken string) (*gtsmodel.Stream, gtserror.WithCode) {
+	return p.streamingProcessor.OpenStreamForAccount(ctx, account, streamType, accessToken)
 }
@@ -0,0 +1,36 @@
+package streaming
+
+import (
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func (p *processor) CloseStreamsForToken(accessToken string) {
+	if accessToken == "" {
+		return
+	}
+
+	p.streamMap.Range(func(k interface{}, v interface{}) bool {
+		streamsForAccount, ok := v.(*gtsmodel.StreamsForAccount)
+		if !ok {
+			return true
+		}
+
+		streamsForAccount.Lock()
+		defer streamsForAccount.Unlock()
+		for _, stream := range streamsForAccount.Streams {
+			if stream.AccessToken != accessToken {
+				continue
+			}
+
+			stream.Lock()
+			select {
+			case <-stream.Closed:
+				// already closed
+			default:
+				close(stream.Closed)
+			}
+			stream.Unlock()
+		}
+		return true
+	})
+}
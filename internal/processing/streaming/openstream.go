@@ -11,7 +11,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 )
 
-func (p *processor) OpenStreamForAccount(ctx context.Context, account *gtsmodel.Account, streamType string) (*gtsmodel.Stream, gtserror.WithCode) {
+func (p *processor) OpenStreamForAccount(ctx context.Context, account *gtsmodel.Account, streamType string, accessToken string) (*gtsmodel.Stream, gtserror.WithCode) {
 	l := p.log.WithFields(logrus.Fields{
 		"func":       "OpenStreamForAccount",
 		"account":    account.ID,
@@ -26,11 +26,13 @@ func (p *processor) OpenStreamForAccount(ctx context.Context, account *gtsmodel.
 	}
 
 	thisStream := &gtsmodel.Stream{
-		ID:        streamID,
-		Type:      streamType,
-		Messages:  make(chan *gtsmodel.Message, 100),
-		Hangup:    make(chan interface{}, 1),
-		Connected: true,
+		ID:          streamID,
+		Type:        streamType,
+		AccessToken: accessToken,
+		Messages:    make(chan *gtsmodel.Message, 100),
+		Hangup:      make(chan interface{}, 1),
+		Closed:      make(chan interface{}),
+		Connected:   true,
 	}
 	go p.waitToCloseStream(account, thisStream)
 
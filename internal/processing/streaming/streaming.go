@@ -20,13 +20,19 @@ type Processor interface {
 	// AuthorizeStreamingRequest returns an oauth2 token info in response to an access token query from the streaming API
 	AuthorizeStreamingRequest(ctx context.Context, accessToken string) (*gtsmodel.Account, error)
 	// OpenStreamForAccount returns a new Stream for the given account, which will contain a channel for passing messages back to the caller.
-	OpenStreamForAccount(ctx context.Context, account *gtsmodel.Account, streamType string) (*gtsmodel.Stream, gtserror.WithCode)
+	OpenStreamForAccount(ctx context.Context, account *gtsmodel.Account, streamType string, accessToken string) (*gtsmodel.Stream, gtserror.WithCode)
 	// StreamStatusToAccount streams the given status to any open, appropriate streams belonging to the given account.
 	StreamStatusToAccount(s *apimodel.Status, account *gtsmodel.Account) error
+	// StreamStatusUpdateToAccount streams the given edited status to any open, appropriate streams belonging to the given account.
+	StreamStatusUpdateToAccount(s *apimodel.Status, account *gtsmodel.Account) error
 	// StreamNotificationToAccount streams the given notification to any open, appropriate streams belonging to the given account.
-	StreamNotificationToAccount(n *apimodel.Notification, account *gtsmodel.Account) error
+	StreamNotificationToAccount(ctx context.Context, n *apimodel.Notification, account *gtsmodel.Account) error
+	// StreamConversationToAccount streams the given conversation to any open direct message streams belonging to the given account.
+	StreamConversationToAccount(c *apimodel.Conversation, account *gtsmodel.Account) error
 	// StreamDelete streams the delete of the given statusID to *ALL* open streams.
 	StreamDelete(statusID string) error
+	// CloseStreamsForToken tells any open streams that were opened with the given access token to hang up.
+	CloseStreamsForToken(accessToken string)
 }
 
 type processor struct {
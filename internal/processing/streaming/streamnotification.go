@@ -1,6 +1,7 @@
 package streaming
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,7 +11,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
-func (p *processor) StreamNotificationToAccount(n *apimodel.Notification, account *gtsmodel.Account) error {
+func (p *processor) StreamNotificationToAccount(ctx context.Context, n *apimodel.Notification, account *gtsmodel.Account) error {
 	l := p.log.WithFields(logrus.Fields{
 		"func":    "StreamNotificationToAccount",
 		"account": account.ID,
@@ -26,6 +27,23 @@ func (p *processor) StreamNotificationToAccount(n *apimodel.Notification, accoun
 		return errors.New("stream map error")
 	}
 
+	if n.Status != nil {
+		status, err := p.db.GetStatusByID(ctx, n.Status.ID)
+		if err != nil {
+			return fmt.Errorf("error fetching notification status %s: %s", n.Status.ID, err)
+		}
+
+		filtered, err := p.filter.StatusFiltered(ctx, status, account, gtsmodel.FilterContextNotifications)
+		if err != nil {
+			return fmt.Errorf("error checking filters for notification status %s: %s", n.Status.ID, err)
+		}
+
+		if filtered {
+			l.Debugf("notification status %s is filtered for account, not streaming", n.Status.ID)
+			return nil
+		}
+	}
+
 	notificationBytes, err := json.Marshal(n)
 	if err != nil {
 		return fmt.Errorf("error marshalling notification to json: %s", err)
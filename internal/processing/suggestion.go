@@ -0,0 +1,254 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// suggestionsInterval is how often we recompute the follow suggestions of local accounts.
+const suggestionsInterval = 1 * time.Hour
+
+// maxSuggestions is the maximum number of follow suggestions that will be stored for each local account.
+const maxSuggestions = 80
+
+func (p *processor) SuggestionsGet(ctx context.Context, authed *oauth.Auth, limit int) ([]*apimodel.Account, gtserror.WithCode) {
+	// get all the stored suggestions, since some of them might be filtered out below
+	suggestions, err := p.db.GetSuggestionsForAccount(ctx, authed.Account.ID, 0)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("SuggestionsGet: error getting suggestions: %s", err))
+	}
+
+	apiAccounts := []*apimodel.Account{}
+	for _, s := range suggestions {
+		if len(apiAccounts) >= limit {
+			break
+		}
+
+		// the relationship might have changed since these suggestions were computed, so check it again
+		suggestable, err := p.suggestable(ctx, authed.Account, s.TargetAccount)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("SuggestionsGet: error checking suggestion %s: %s", s.ID, err))
+		}
+		if !suggestable {
+			continue
+		}
+
+		apiAccount, err := p.tc.AccountToMastoPublic(ctx, s.TargetAccount)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("SuggestionsGet: error converting account %s: %s", s.TargetAccountID, err))
+		}
+		apiAccounts = append(apiAccounts, apiAccount)
+	}
+
+	return apiAccounts, nil
+}
+
+func (p *processor) SuggestionDelete(ctx context.Context, authed *oauth.Auth, targetAccountID string) gtserror.WithCode {
+	if _, err := p.db.GetAccountByID(ctx, targetAccountID); err != nil {
+		if err == db.ErrNoEntries {
+			return gtserror.NewErrorNotFound(fmt.Errorf("SuggestionDelete: account %s not found", targetAccountID))
+		}
+		return gtserror.NewErrorInternalError(fmt.Errorf("SuggestionDelete: error getting account %s: %s", targetAccountID, err))
+	}
+
+	dismissalID, err := id.NewULID()
+	if err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// remember the dismissal so that the account isn't suggested again next time suggestions are computed
+	if err := p.db.PutSuggestionDismissal(ctx, &gtsmodel.SuggestionDismissal{
+		ID:              dismissalID,
+		AccountID:       authed.Account.ID,
+		TargetAccountID: targetAccountID,
+	}); err != nil && err != db.ErrAlreadyExists {
+		return gtserror.NewErrorInternalError(fmt.Errorf("SuggestionDelete: error putting dismissal: %s", err))
+	}
+
+	if err := p.db.DeleteSuggestion(ctx, authed.Account.ID, targetAccountID); err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("SuggestionDelete: error deleting suggestion: %s", err))
+	}
+
+	return nil
+}
+
+// computeSuggestions recomputes and stores the follow suggestions of every active local account.
+func (p *processor) computeSuggestions(ctx context.Context) error {
+	users := []*gtsmodel.User{}
+	if err := p.db.GetAll(ctx, &users); err != nil {
+		if err == db.ErrNoEntries {
+			return nil
+		}
+		return fmt.Errorf("computeSuggestions: error getting users: %s", err)
+	}
+
+	// these are the same for everyone, so only get them once
+	featuredAccounts, err := p.db.GetFeaturedAccounts(ctx)
+	if err != nil {
+		return fmt.Errorf("computeSuggestions: error getting featured accounts: %s", err)
+	}
+
+	popularAccountIDs, err := p.db.GetMostFollowedByLocalAccounts(ctx, maxSuggestions)
+	if err != nil {
+		return fmt.Errorf("computeSuggestions: error getting popular accounts: %s", err)
+	}
+
+	for _, user := range users {
+		if user.AccountID == "" || user.Disabled || !user.Approved {
+			continue
+		}
+
+		account, err := p.db.GetAccountByID(ctx, user.AccountID)
+		if err != nil {
+			p.log.Errorf("computeSuggestions: error getting account %s: %s", user.AccountID, err)
+			continue
+		}
+
+		if !account.SuspendedAt.IsZero() {
+			continue
+		}
+
+		if err := p.computeSuggestionsForAccount(ctx, account, featuredAccounts, popularAccountIDs); err != nil {
+			p.log.Errorf("computeSuggestions: error computing suggestions for account %s: %s", account.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// computeSuggestionsForAccount works out which accounts to suggest to the given account, and stores them in place of its previous suggestions.
+// Accounts featured by admins come first, followed by friends of friends, and finally accounts that are popular with local accounts.
+func (p *processor) computeSuggestionsForAccount(ctx context.Context, account *gtsmodel.Account, featuredAccounts []*gtsmodel.FeaturedAccount, popularAccountIDs []string) error {
+	dismissedIDs, err := p.db.GetDismissedSuggestionIDs(ctx, account.ID)
+	if err != nil {
+		return fmt.Errorf("error getting dismissed suggestions: %s", err)
+	}
+
+	fofAccountIDs, err := p.db.GetFollowsOfFollows(ctx, account.ID, maxSuggestions)
+	if err != nil {
+		return fmt.Errorf("error getting follows of follows: %s", err)
+	}
+
+	// don't suggest the account to itself or anything that's been dismissed
+	seen := map[string]bool{account.ID: true}
+	for _, dismissedID := range dismissedIDs {
+		seen[dismissedID] = true
+	}
+
+	suggestions := []*gtsmodel.Suggestion{}
+	suggest := func(targetAccountID string, source gtsmodel.SuggestionSource) error {
+		if len(suggestions) >= maxSuggestions || seen[targetAccountID] {
+			return nil
+		}
+		seen[targetAccountID] = true
+
+		targetAccount, err := p.db.GetAccountByID(ctx, targetAccountID)
+		if err != nil {
+			if err == db.ErrNoEntries {
+				return nil
+			}
+			return err
+		}
+
+		// only suggest accounts that have opted in to being discovered, unless an admin has featured them
+		if source != gtsmodel.SuggestionSourceFeatured && !targetAccount.Discoverable {
+			return nil
+		}
+
+		suggestable, err := p.suggestable(ctx, account, targetAccount)
+		if err != nil || !suggestable {
+			return err
+		}
+
+		suggestionID, err := id.NewULID()
+		if err != nil {
+			return err
+		}
+
+		suggestions = append(suggestions, &gtsmodel.Suggestion{
+			ID:              suggestionID,
+			AccountID:       account.ID,
+			TargetAccountID: targetAccountID,
+			Source:          source,
+			Rank:            len(suggestions),
+		})
+		return nil
+	}
+
+	for _, featuredAccount := range featuredAccounts {
+		if err := suggest(featuredAccount.AccountID, gtsmodel.SuggestionSourceFeatured); err != nil {
+			return err
+		}
+	}
+
+	for _, fofAccountID := range fofAccountIDs {
+		if err := suggest(fofAccountID, gtsmodel.SuggestionSourceFriendsOfFriends); err != nil {
+			return err
+		}
+	}
+
+	for _, popularAccountID := range popularAccountIDs {
+		if err := suggest(popularAccountID, gtsmodel.SuggestionSourcePopular); err != nil {
+			return err
+		}
+	}
+
+	return p.db.ReplaceSuggestionsForAccount(ctx, account.ID, suggestions)
+}
+
+// suggestable returns true if targetAccount can be suggested to account as an account to follow, ie., it's not suspended,
+// and account doesn't already follow it, hasn't already requested to follow it, hasn't muted it, and there's no block between the two accounts.
+func (p *processor) suggestable(ctx context.Context, account *gtsmodel.Account, targetAccount *gtsmodel.Account) (bool, error) {
+	if targetAccount == nil {
+		return false, errors.New("target account was nil")
+	}
+
+	if !targetAccount.SuspendedAt.IsZero() || targetAccount.MovedToAccountID != "" {
+		return false, nil
+	}
+
+	if blocked, err := p.db.IsBlocked(ctx, account.ID, targetAccount.ID, true); err != nil || blocked {
+		return false, err
+	}
+
+	if muted, err := p.db.IsMuted(ctx, account.ID, targetAccount.ID); err != nil || muted {
+		return false, err
+	}
+
+	if following, err := p.db.IsFollowing(ctx, account, targetAccount); err != nil || following {
+		return false, err
+	}
+
+	if requested, err := p.db.IsFollowRequested(ctx, account, targetAccount); err != nil || requested {
+		return false, err
+	}
+
+	return true, nil
+}
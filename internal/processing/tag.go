@@ -0,0 +1,177 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+func (p *processor) TagGet(ctx context.Context, authed *oauth.Auth, tagName string) (*apimodel.Tag, gtserror.WithCode) {
+	tag, err := p.db.GetTagByName(ctx, tagName)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(fmt.Errorf("tag %s not found", tagName))
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.tagToMastoForAccount(ctx, tag, authed.Account)
+}
+
+func (p *processor) TagFollow(ctx context.Context, authed *oauth.Auth, tagName string) (*apimodel.Tag, gtserror.WithCode) {
+	tagName = strings.ToLower(tagName)
+	if err := util.ValidateHashtag(tagName); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// we can follow a tag nobody has used yet, so create it if necessary
+	tags, err := p.db.TagStringsToTags(ctx, []string{tagName}, authed.Account.ID, "")
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	if len(tags) == 0 {
+		err := fmt.Errorf("tag %s cannot be used", tagName)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+	tag := tags[0]
+
+	if err := p.db.Put(ctx, tag); err != nil && err != db.ErrAlreadyExists {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error putting tag in db: %s", err))
+	}
+
+	// check if we're already following the tag, if so we don't need to do anything
+	if _, err := p.db.GetTagFollow(ctx, authed.Account.ID, tag.ID); err != nil {
+		if err != db.ErrNoEntries {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error checking existing tag follow: %s", err))
+		}
+
+		tagFollowID, err := id.NewULID()
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		tagFollow := &gtsmodel.TagFollow{
+			ID:        tagFollowID,
+			AccountID: authed.Account.ID,
+			TagID:     tag.ID,
+		}
+
+		if err := p.db.PutTagFollow(ctx, tagFollow); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error putting tag follow in db: %s", err))
+		}
+	}
+
+	return p.tagToMastoForAccount(ctx, tag, authed.Account)
+}
+
+func (p *processor) TagUnfollow(ctx context.Context, authed *oauth.Auth, tagName string) (*apimodel.Tag, gtserror.WithCode) {
+	tag, err := p.db.GetTagByName(ctx, tagName)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(fmt.Errorf("tag %s not found", tagName))
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.db.DeleteTagFollow(ctx, authed.Account.ID, tag.ID); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error deleting tag follow: %s", err))
+	}
+
+	return p.tagToMastoForAccount(ctx, tag, authed.Account)
+}
+
+func (p *processor) FollowedTagsGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, minID string, limit int) (*apimodel.FollowedTagsResponse, gtserror.WithCode) {
+	tagFollows, err := p.db.GetTagFollowsForAccountID(ctx, authed.Account.ID, maxID, sinceID, minID, limit)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			// there are just no entries
+			return &apimodel.FollowedTagsResponse{
+				Tags: []*apimodel.Tag{},
+			}, nil
+		}
+		// there's an actual error
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	mastoTags := []*apimodel.Tag{}
+	for _, tf := range tagFollows {
+		if tf.Tag == nil {
+			return nil, gtserror.NewErrorInternalError(errors.New("tag follow had no tag populated"))
+		}
+
+		mastoTag, err := p.tc.TagToMasto(ctx, tf.Tag)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		mastoTag.Following = true
+		mastoTags = append(mastoTags, &mastoTag)
+	}
+
+	resp := &apimodel.FollowedTagsResponse{
+		Tags: mastoTags,
+	}
+
+	// prepare the next and previous links
+	nextLink := &url.URL{
+		Scheme:   p.config.Protocol,
+		Host:     p.config.Host,
+		Path:     "/api/v1/followed_tags",
+		RawQuery: fmt.Sprintf("limit=%d&max_id=%s", limit, tagFollows[len(tagFollows)-1].ID),
+	}
+	next := fmt.Sprintf("<%s>; rel=\"next\"", nextLink.String())
+
+	prevLink := &url.URL{
+		Scheme:   p.config.Protocol,
+		Host:     p.config.Host,
+		Path:     "/api/v1/followed_tags",
+		RawQuery: fmt.Sprintf("limit=%d&min_id=%s", limit, tagFollows[0].ID),
+	}
+	prev := fmt.Sprintf("<%s>; rel=\"prev\"", prevLink.String())
+	resp.LinkHeader = fmt.Sprintf("%s, %s", next, prev)
+
+	return resp, nil
+}
+
+// tagToMastoForAccount converts the given tag to its api representation, noting whether or not the given account follows it.
+func (p *processor) tagToMastoForAccount(ctx context.Context, tag *gtsmodel.Tag, account *gtsmodel.Account) (*apimodel.Tag, gtserror.WithCode) {
+	mastoTag, err := p.tc.TagToMasto(ctx, tag)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if _, err := p.db.GetTagFollow(ctx, account.ID, tag.ID); err == nil {
+		mastoTag.Following = true
+	} else if err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error checking tag follow: %s", err))
+	}
+
+	return &mastoTag, nil
+}
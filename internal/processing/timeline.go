@@ -101,6 +101,44 @@ func (p *processor) PublicTimelineGet(ctx context.Context, authed *oauth.Auth, m
 	return p.packageStatusResponse(s, "api/v1/timelines/public", s[len(s)-1].ID, s[0].ID, limit)
 }
 
+func (p *processor) TagTimelineGet(ctx context.Context, authed *oauth.Auth, tagName string, maxID string, sinceID string, minID string, limit int, local bool) (*apimodel.StatusTimelineResponse, gtserror.WithCode) {
+	tag, err := p.db.GetTagByName(ctx, tagName)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			// we haven't seen this tag used yet
+			return &apimodel.StatusTimelineResponse{
+				Statuses: []*apimodel.Status{},
+			}, nil
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	statuses, err := p.db.GetTagTimeline(ctx, tag.ID, maxID, sinceID, minID, limit, local)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			// there are just no entries left
+			return &apimodel.StatusTimelineResponse{
+				Statuses: []*apimodel.Status{},
+			}, nil
+		}
+		// there's an actual error
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	s, err := p.filterPublicStatuses(ctx, authed, statuses)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if len(s) == 0 {
+		return &apimodel.StatusTimelineResponse{
+			Statuses: []*apimodel.Status{},
+		}, nil
+	}
+
+	return p.packageStatusResponse(s, "api/v1/timelines/tag/"+tag.Name, s[len(s)-1].ID, s[0].ID, limit)
+}
+
 func (p *processor) FavedTimelineGet(ctx context.Context, authed *oauth.Auth, maxID string, minID string, limit int) (*apimodel.StatusTimelineResponse, gtserror.WithCode) {
 	statuses, nextMaxID, prevMinID, err := p.db.GetFavedTimeline(ctx, authed.Account.ID, maxID, minID, limit)
 	if err != nil {
@@ -128,9 +166,62 @@ func (p *processor) FavedTimelineGet(ctx context.Context, authed *oauth.Auth, ma
 	return p.packageStatusResponse(s, "api/v1/favourites", nextMaxID, prevMinID, limit)
 }
 
+func (p *processor) BookmarksTimelineGet(ctx context.Context, authed *oauth.Auth, maxID string, minID string, limit int) (*apimodel.StatusTimelineResponse, gtserror.WithCode) {
+	statuses, nextMaxID, prevMinID, err := p.db.GetBookmarkedTimeline(ctx, authed.Account.ID, maxID, minID, limit)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			// there are just no entries left
+			return &apimodel.StatusTimelineResponse{
+				Statuses: []*apimodel.Status{},
+			}, nil
+		}
+		// there's an actual error
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// bookmarked statuses need the same visibility checks as faved statuses
+	s, err := p.filterFavedStatuses(ctx, authed, statuses)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if len(s) == 0 {
+		return &apimodel.StatusTimelineResponse{
+			Statuses: []*apimodel.Status{},
+		}, nil
+	}
+
+	return p.packageStatusResponse(s, "api/v1/bookmarks", nextMaxID, prevMinID, limit)
+}
+
+func (p *processor) ListTimelineGet(ctx context.Context, authed *oauth.Auth, listID string, maxID string, sinceID string, minID string, limit int) (*apimodel.StatusTimelineResponse, gtserror.WithCode) {
+	list, errWithCode := p.getOwnedList(ctx, authed.Account, listID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	statuses, err := p.timelineManager.ListTimeline(ctx, list.ID, maxID, sinceID, minID, limit)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if len(statuses) == 0 {
+		return &apimodel.StatusTimelineResponse{
+			Statuses: []*apimodel.Status{},
+		}, nil
+	}
+
+	return p.packageStatusResponse(statuses, "api/v1/timelines/list/"+list.ID, statuses[len(statuses)-1].ID, statuses[0].ID, limit)
+}
+
 func (p *processor) filterPublicStatuses(ctx context.Context, authed *oauth.Auth, statuses []*gtsmodel.Status) ([]*apimodel.Status, error) {
 	l := p.log.WithField("func", "filterPublicStatuses")
 
+	filtered, err := p.filter.StatusFilterer(ctx, authed.Account, gtsmodel.FilterContextPublic)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("filterPublicStatuses: error getting filters: %s", err))
+	}
+
 	apiStatuses := []*apimodel.Status{}
 	for _, s := range statuses {
 		targetAccount := &gtsmodel.Account{}
@@ -151,6 +242,15 @@ func (p *processor) filterPublicStatuses(ctx context.Context, authed *oauth.Auth
 			continue
 		}
 
+		isFiltered, err := filtered(ctx, s)
+		if err != nil {
+			l.Debugf("filterPublicStatuses: skipping status %s because of an error checking filters: %s", s.ID, err)
+			continue
+		}
+		if isFiltered {
+			continue
+		}
+
 		apiStatus, err := p.tc.StatusToMasto(ctx, s, authed.Account)
 		if err != nil {
 			l.Debugf("filterPublicStatuses: skipping status %s because it couldn't be converted to its mastodon representation: %s", s.ID, err)
@@ -0,0 +1,84 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/webauthn"
+)
+
+func (p *processor) UserConfirmEmail(ctx context.Context, token string) (*gtsmodel.User, gtserror.WithCode) {
+	return p.userProcessor.ConfirmEmail(ctx, token)
+}
+
+func (p *processor) UserResetPasswordRequest(ctx context.Context, email string) gtserror.WithCode {
+	return p.userProcessor.SendResetPasswordEmail(ctx, email)
+}
+
+func (p *processor) UserResetPassword(ctx context.Context, token string, password string) gtserror.WithCode {
+	return p.userProcessor.ResetPassword(ctx, token, password)
+}
+
+func (p *processor) UserTwoFactorSetup(ctx context.Context, authed *oauth.Auth) (*apimodel.TwoFactorSetup, gtserror.WithCode) {
+	return p.userProcessor.SetupTwoFactor(ctx, authed.User)
+}
+
+func (p *processor) UserTwoFactorEnable(ctx context.Context, authed *oauth.Auth, code string) (*apimodel.TwoFactorBackupCodes, gtserror.WithCode) {
+	return p.userProcessor.EnableTwoFactor(ctx, authed.User, code)
+}
+
+func (p *processor) UserTwoFactorDisable(ctx context.Context, authed *oauth.Auth, code string) gtserror.WithCode {
+	return p.userProcessor.DisableTwoFactor(ctx, authed.User, code)
+}
+
+func (p *processor) UserTwoFactorBackupCodes(ctx context.Context, authed *oauth.Auth, code string) (*apimodel.TwoFactorBackupCodes, gtserror.WithCode) {
+	return p.userProcessor.RegenerateBackupCodes(ctx, authed.User, code)
+}
+
+func (p *processor) UserWebauthnRegisterBegin(ctx context.Context, authed *oauth.Auth) (*webauthn.CredentialCreationOptions, gtserror.WithCode) {
+	return p.userProcessor.BeginWebauthnRegistration(ctx, authed.User)
+}
+
+func (p *processor) UserWebauthnRegisterFinish(ctx context.Context, authed *oauth.Auth, form *apimodel.WebauthnRegistrationRequest) (*apimodel.WebauthnCredential, gtserror.WithCode) {
+	return p.userProcessor.FinishWebauthnRegistration(ctx, authed.User, form)
+}
+
+func (p *processor) UserWebauthnCredentialsGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.WebauthnCredential, gtserror.WithCode) {
+	return p.userProcessor.GetWebauthnCredentials(ctx, authed.User)
+}
+
+func (p *processor) UserWebauthnCredentialDelete(ctx context.Context, authed *oauth.Auth, credentialID string) gtserror.WithCode {
+	return p.userProcessor.DeleteWebauthnCredential(ctx, authed.User, credentialID)
+}
+
+// sendConfirmEmail sends a confirmation email to a newly signed up user.
+func (p *processor) sendConfirmEmail(ctx context.Context, user *gtsmodel.User) error {
+	account, err := p.db.GetAccountByID(ctx, user.AccountID)
+	if err != nil {
+		return fmt.Errorf("sendConfirmEmail: error getting account for user %s: %s", user.ID, err)
+	}
+
+	return p.userProcessor.SendConfirmEmail(ctx, user, account.Username)
+}
@@ -0,0 +1,104 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// confirmEmailPath is the web path at which email confirmation links are handled.
+const confirmEmailPath = "/confirm_email"
+
+// confirmTokenExpiry is how long an email confirmation link stays valid after it's sent.
+const confirmTokenExpiry = 7 * 24 * time.Hour
+
+func (p *processor) SendConfirmEmail(ctx context.Context, user *gtsmodel.User, username string) error {
+	if user.UnconfirmedEmail == "" || user.UnconfirmedEmail == user.Email {
+		// user has already confirmed this email address, so there's nothing to do
+		return nil
+	}
+
+	// generate a new token for this confirmation
+	confirmationToken := uuid.NewString()
+	instanceName, instanceURL := p.instanceNameAndURL(ctx)
+
+	confirmData := email.ConfirmData{
+		Username:     username,
+		InstanceURL:  instanceURL,
+		InstanceName: instanceName,
+		ConfirmLink:  fmt.Sprintf("%s%s?token=%s", instanceURL, confirmEmailPath, confirmationToken),
+	}
+
+	if err := p.emailSender.SendConfirmEmail(user.UnconfirmedEmail, confirmData); err != nil {
+		return fmt.Errorf("SendConfirmEmail: error sending to email address %s belonging to user %s: %s", user.UnconfirmedEmail, username, err)
+	}
+
+	// email sent, now we need to update the user entry with the token we just sent them
+	now := time.Now()
+	user.ConfirmationToken = confirmationToken
+	user.ConfirmationSentAt = now
+	user.LastEmailedAt = now
+	user.UpdatedAt = now
+
+	if err := p.db.UpdateByID(ctx, user.ID, user); err != nil {
+		return fmt.Errorf("SendConfirmEmail: error updating user entry after email sent: %s", err)
+	}
+
+	return nil
+}
+
+func (p *processor) ConfirmEmail(ctx context.Context, token string) (*gtsmodel.User, gtserror.WithCode) {
+	if token == "" {
+		return nil, gtserror.NewErrorNotFound(errors.New("no token provided"), "confirmation link is not valid")
+	}
+
+	user := &gtsmodel.User{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "confirmation_token", Value: token}}, user); err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(err, "confirmation link is not valid")
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if user.ConfirmationSentAt.Before(time.Now().Add(-confirmTokenExpiry)) {
+		return nil, gtserror.NewErrorForbidden(errors.New("ConfirmEmail: confirmation token expired"), "confirmation link has expired, please ask for a new one")
+	}
+
+	// mark the user's email address as confirmed + remove the unconfirmed address and the token
+	user.Email = user.UnconfirmedEmail
+	user.UnconfirmedEmail = ""
+	user.ConfirmedAt = time.Now()
+	user.ConfirmationToken = ""
+	user.UpdatedAt = time.Now()
+
+	if err := p.db.UpdateByID(ctx, user.ID, user); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return user, nil
+}
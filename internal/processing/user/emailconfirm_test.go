@@ -0,0 +1,113 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package user_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type EmailConfirmTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *EmailConfirmTestSuite) TestSendConfirmEmail() {
+	ctx := context.Background()
+	user := suite.testUsers["unconfirmed_account"]
+
+	err := suite.user.SendConfirmEmail(ctx, user, "weed_lord420")
+	suite.NoError(err)
+
+	// the user should have a new token stored
+	dbUser := &gtsmodel.User{}
+	err = suite.db.GetByID(ctx, user.ID, dbUser)
+	suite.NoError(err)
+	suite.NotEmpty(dbUser.ConfirmationToken)
+	suite.NotEqual("a5a280bd-34be-44a3-8330-a57eaf61b8dd", dbUser.ConfirmationToken)
+	suite.WithinDuration(time.Now(), dbUser.ConfirmationSentAt, time.Minute)
+	suite.WithinDuration(time.Now(), dbUser.LastEmailedAt, time.Minute)
+
+	// and the email should contain a link with that token
+	email := suite.decodedEmail("weed_lord420@example.org")
+	suite.Contains(email, "Hello weed_lord420!")
+	suite.Contains(email, "http://localhost:8080/confirm_email?token="+dbUser.ConfirmationToken)
+}
+
+func (suite *EmailConfirmTestSuite) TestSendConfirmEmailAlreadyConfirmed() {
+	user := suite.testUsers["local_account_1"]
+
+	err := suite.user.SendConfirmEmail(context.Background(), user, "the_mighty_zork")
+	suite.NoError(err)
+	suite.Empty(suite.sentEmails)
+}
+
+func (suite *EmailConfirmTestSuite) TestConfirmEmail() {
+	ctx := context.Background()
+	user := suite.testUsers["unconfirmed_account"]
+
+	confirmedUser, errWithCode := suite.user.ConfirmEmail(ctx, user.ConfirmationToken)
+	suite.NoError(errWithCode)
+	suite.Equal("weed_lord420@example.org", confirmedUser.Email)
+	suite.Empty(confirmedUser.UnconfirmedEmail)
+	suite.Empty(confirmedUser.ConfirmationToken)
+	suite.WithinDuration(time.Now(), confirmedUser.ConfirmedAt, time.Minute)
+
+	// the change should be stored
+	dbUser := &gtsmodel.User{}
+	err := suite.db.GetByID(ctx, user.ID, dbUser)
+	suite.NoError(err)
+	suite.Equal("weed_lord420@example.org", dbUser.Email)
+	suite.False(dbUser.ConfirmedAt.IsZero())
+
+	// the token can only be used once
+	_, errWithCode = suite.user.ConfirmEmail(ctx, user.ConfirmationToken)
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *EmailConfirmTestSuite) TestConfirmEmailExpiredToken() {
+	ctx := context.Background()
+	user := suite.testUsers["unconfirmed_account"]
+
+	err := suite.db.UpdateOneByID(ctx, user.ID, "confirmation_sent_at", time.Now().Add(-8*24*time.Hour), &gtsmodel.User{})
+	suite.NoError(err)
+
+	_, errWithCode := suite.user.ConfirmEmail(ctx, user.ConfirmationToken)
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+}
+
+func (suite *EmailConfirmTestSuite) TestConfirmEmailBadToken() {
+	_, errWithCode := suite.user.ConfirmEmail(context.Background(), "not-a-real-token")
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	_, errWithCode = suite.user.ConfirmEmail(context.Background(), "")
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestEmailConfirmTestSuite(t *testing.T) {
+	suite.Run(t, new(EmailConfirmTestSuite))
+}
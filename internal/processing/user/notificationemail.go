@@ -0,0 +1,294 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+const (
+	digestTypeFollow        = "follow"
+	digestTypeFollowRequest = "follow_request"
+	digestTypeMention       = "mention"
+	digestTypeDirect        = "direct"
+)
+
+// digestTextMaxChars is the length that status text is cut down to for inclusion in a digest email.
+const digestTextMaxChars = 500
+
+var htmlBreaks = strings.NewReplacer("<br>", "\n", "<br/>", "\n", "<br />", "\n", "</p>", "\n\n")
+
+func (p *processor) QueueNotificationEmail(ctx context.Context, notif *gtsmodel.Notification) error {
+	user := &gtsmodel.User{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: notif.TargetAccountID}}, user); err != nil {
+		if err == db.ErrNoEntries {
+			// not a local account, so there's nobody to email
+			return nil
+		}
+		return fmt.Errorf("QueueNotificationEmail: error getting user for account %s: %s", notif.TargetAccountID, err)
+	}
+
+	if user.Email == "" {
+		// no confirmed email address to send to
+		return nil
+	}
+
+	digestType, err := p.digestType(ctx, notif)
+	if err != nil {
+		return fmt.Errorf("QueueNotificationEmail: %s", err)
+	}
+
+	if !wantsDigest(user, digestType) {
+		return nil
+	}
+
+	queueID, err := id.NewULID()
+	if err != nil {
+		return err
+	}
+
+	if err := p.db.Put(ctx, &gtsmodel.QueuedNotificationEmail{
+		ID:             queueID,
+		UserID:         user.ID,
+		NotificationID: notif.ID,
+	}); err != nil && err != db.ErrAlreadyExists {
+		return fmt.Errorf("QueueNotificationEmail: error queueing notification %s: %s", notif.ID, err)
+	}
+
+	return nil
+}
+
+func (p *processor) SendNotificationDigests(ctx context.Context) error {
+	l := p.log.WithField("func", "SendNotificationDigests")
+
+	queued := []*gtsmodel.QueuedNotificationEmail{}
+	if err := p.db.GetAll(ctx, &queued); err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("SendNotificationDigests: error getting queued notification emails: %s", err)
+	}
+
+	// ULIDs sort chronologically, so this puts each user's notifications in the order they were created
+	sort.Slice(queued, func(i, j int) bool {
+		return queued[i].ID < queued[j].ID
+	})
+
+	userIDs := []string{}
+	queuedByUser := make(map[string][]*gtsmodel.QueuedNotificationEmail)
+	for _, q := range queued {
+		if _, ok := queuedByUser[q.UserID]; !ok {
+			userIDs = append(userIDs, q.UserID)
+		}
+		queuedByUser[q.UserID] = append(queuedByUser[q.UserID], q)
+	}
+
+	for _, userID := range userIDs {
+		if err := p.sendNotificationDigest(ctx, userID, queuedByUser[userID]); err != nil {
+			// don't let one user's digest hold up everyone else's
+			l.Errorf("error sending notification digest to user %s: %s", userID, err)
+		}
+	}
+
+	return nil
+}
+
+// sendNotificationDigest sends one digest email to the given user, containing the given queued notifications,
+// and removes them from the queue. If the user was emailed too recently, nothing is sent and the notifications
+// are left in the queue for next time.
+func (p *processor) sendNotificationDigest(ctx context.Context, userID string, queued []*gtsmodel.QueuedNotificationEmail) error {
+	user := &gtsmodel.User{}
+	if err := p.db.GetByID(ctx, userID, user); err != nil {
+		if err == db.ErrNoEntries {
+			// the user has been deleted in the meantime
+			return p.dequeueNotificationEmails(ctx, queued)
+		}
+		return err
+	}
+
+	if user.Email == "" || user.Disabled {
+		return p.dequeueNotificationEmails(ctx, queued)
+	}
+
+	if !user.LastEmailedAt.IsZero() && user.LastEmailedAt.After(time.Now().Add(-p.config.SMTPConfig.NotificationDigestInterval)) {
+		// the user got an email recently, so let this wait until next time
+		return nil
+	}
+
+	account, err := p.db.GetAccountByID(ctx, user.AccountID)
+	if err != nil {
+		return fmt.Errorf("error getting account: %s", err)
+	}
+
+	notifications := []email.DigestNotification{}
+	for _, q := range queued {
+		n, err := p.digestNotification(ctx, user, q.NotificationID)
+		if err != nil {
+			return err
+		}
+		if n != nil {
+			notifications = append(notifications, *n)
+		}
+	}
+
+	if len(notifications) != 0 {
+		instanceName, instanceURL := p.instanceNameAndURL(ctx)
+		digestData := email.DigestData{
+			Username:      account.Username,
+			InstanceURL:   instanceURL,
+			InstanceName:  instanceName,
+			Notifications: notifications,
+		}
+
+		if err := p.emailSender.SendNotificationDigestEmail(user.Email, digestData); err != nil {
+			return fmt.Errorf("error sending email to %s: %s", user.Email, err)
+		}
+
+		if err := p.db.UpdateOneByID(ctx, user.ID, "last_emailed_at", time.Now(), &gtsmodel.User{}); err != nil {
+			return fmt.Errorf("error updating user after email sent: %s", err)
+		}
+	}
+
+	return p.dequeueNotificationEmails(ctx, queued)
+}
+
+// digestNotification converts the notification with the given ID into something that can be included in a digest
+// email to the given user. If the notification shouldn't be included anymore, because it's been read or removed,
+// or because the user has since turned off emails for that type of notification, then nil will be returned.
+func (p *processor) digestNotification(ctx context.Context, user *gtsmodel.User, notificationID string) (*email.DigestNotification, error) {
+	notif := &gtsmodel.Notification{}
+	if err := p.db.GetByID(ctx, notificationID, notif); err != nil {
+		if err == db.ErrNoEntries {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error getting notification %s: %s", notificationID, err)
+	}
+
+	if notif.Read {
+		return nil, nil
+	}
+
+	digestType, err := p.digestType(ctx, notif)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if !wantsDigest(user, digestType) {
+		return nil, nil
+	}
+
+	originAccount, err := p.db.GetAccountByID(ctx, notif.OriginAccountID)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error getting origin account %s: %s", notif.OriginAccountID, err)
+	}
+
+	acct := "@" + originAccount.Username
+	if originAccount.Domain != "" {
+		acct = acct + "@" + originAccount.Domain
+	}
+
+	n := &email.DigestNotification{
+		Type:       digestType,
+		Account:    acct,
+		AccountURL: originAccount.URL,
+	}
+
+	if notif.Status != nil {
+		n.StatusURL = notif.Status.URL
+		n.Text = digestText(notif.Status)
+	}
+
+	return n, nil
+}
+
+// digestType returns which kind of digest entry the given notification would be, or an empty string if it's
+// not one that's included in digest emails. For mentions, the notification's status will be populated.
+func (p *processor) digestType(ctx context.Context, notif *gtsmodel.Notification) (string, error) {
+	switch notif.NotificationType {
+	case gtsmodel.NotificationFollow:
+		return digestTypeFollow, nil
+	case gtsmodel.NotificationFollowRequest:
+		return digestTypeFollowRequest, nil
+	case gtsmodel.NotificationMention:
+		if notif.Status == nil {
+			status, err := p.db.GetStatusByID(ctx, notif.StatusID)
+			if err != nil {
+				return "", err
+			}
+			notif.Status = status
+		}
+		if notif.Status.Visibility == gtsmodel.VisibilityDirect {
+			return digestTypeDirect, nil
+		}
+		return digestTypeMention, nil
+	}
+	return "", nil
+}
+
+func (p *processor) dequeueNotificationEmails(ctx context.Context, queued []*gtsmodel.QueuedNotificationEmail) error {
+	for _, q := range queued {
+		if err := p.db.DeleteByID(ctx, q.ID, &gtsmodel.QueuedNotificationEmail{}); err != nil && err != db.ErrNoEntries {
+			return fmt.Errorf("error removing queued notification email %s: %s", q.ID, err)
+		}
+	}
+	return nil
+}
+
+// wantsDigest returns true if the given user has opted into emails for the given digest type.
+func wantsDigest(user *gtsmodel.User, digestType string) bool {
+	switch digestType {
+	case digestTypeFollow:
+		return user.EmailNotifyFollow
+	case digestTypeFollowRequest:
+		return user.EmailNotifyFollowRequest
+	case digestTypeMention:
+		return user.EmailNotifyMention
+	case digestTypeDirect:
+		return user.EmailNotifyDirect
+	}
+	return false
+}
+
+// digestText returns the text of a status as plaintext suitable for an email, hiding it behind its content warning if it has one.
+func digestText(status *gtsmodel.Status) string {
+	if status.ContentWarning != "" {
+		return "CW: " + status.ContentWarning
+	}
+
+	plain := strings.TrimSpace(html.UnescapeString(text.RemoveHTML(htmlBreaks.Replace(status.Content))))
+	if runes := []rune(plain); len(runes) > digestTextMaxChars {
+		plain = string(runes[:digestTextMaxChars]) + "…"
+	}
+
+	return plain
+}
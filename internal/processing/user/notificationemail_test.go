@@ -0,0 +1,149 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package user_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type NotificationEmailTestSuite struct {
+	UserStandardTestSuite
+}
+
+// optIn turns on the given notification email preferences for a user, and makes sure
+// that they haven't been emailed recently.
+func (suite *NotificationEmailTestSuite) optIn(user *gtsmodel.User, columns ...string) {
+	for _, c := range columns {
+		suite.NoError(suite.db.UpdateOneByID(context.Background(), user.ID, c, true, &gtsmodel.User{}))
+	}
+	suite.NoError(suite.db.UpdateOneByID(context.Background(), user.ID, "last_emailed_at", time.Now().Add(-24*time.Hour), &gtsmodel.User{}))
+}
+
+func (suite *NotificationEmailTestSuite) putNotification(id string, notificationType gtsmodel.NotificationType, originAccountID string, statusID string) *gtsmodel.Notification {
+	notif := &gtsmodel.Notification{
+		ID:               id,
+		NotificationType: notificationType,
+		TargetAccountID:  suite.testAccounts["local_account_1"].ID,
+		OriginAccountID:  originAccountID,
+		StatusID:         statusID,
+	}
+	suite.NoError(suite.db.Put(context.Background(), notif))
+	return notif
+}
+
+func (suite *NotificationEmailTestSuite) queued() []*gtsmodel.QueuedNotificationEmail {
+	queued := []*gtsmodel.QueuedNotificationEmail{}
+	if err := suite.db.GetAll(context.Background(), &queued); err != nil && err != db.ErrNoEntries {
+		suite.FailNow(err.Error())
+	}
+	return queued
+}
+
+func (suite *NotificationEmailTestSuite) TestQueueAndSendDigest() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+	suite.optIn(user, "email_notify_follow", "email_notify_mention", "email_notify_direct")
+
+	// a direct message from admin to zork
+	dm := testrig.NewTestStatuses()["admin_account_status_1"]
+	dm.ID = "01FYBM7E4Y3GZYDNWBXYMV0A8Y"
+	dm.URI = "http://localhost:8080/users/admin/statuses/01FYBM7E4Y3GZYDNWBXYMV0A8Y"
+	dm.URL = "http://localhost:8080/@admin/statuses/01FYBM7E4Y3GZYDNWBXYMV0A8Y"
+	dm.Content = "<p>hey zork, how&#39;s it going?</p>"
+	dm.Visibility = gtsmodel.VisibilityDirect
+	suite.NoError(suite.db.Put(ctx, dm))
+
+	notifs := []*gtsmodel.Notification{
+		suite.putNotification("01FYBM7E4Y3GZYDNWBXYMV0A8A", gtsmodel.NotificationFollow, suite.testAccounts["remote_account_1"].ID, ""),
+		suite.putNotification("01FYBM7E4Y3GZYDNWBXYMV0A8B", gtsmodel.NotificationMention, suite.testAccounts["admin_account"].ID, "01F8MH75CBF9JFX4ZAD54N0W0R"),
+		suite.putNotification("01FYBM7E4Y3GZYDNWBXYMV0A8C", gtsmodel.NotificationMention, suite.testAccounts["admin_account"].ID, dm.ID),
+		// zork hasn't opted in to emails about faves, so this one shouldn't be queued
+		suite.putNotification("01FYBM7E4Y3GZYDNWBXYMV0A8D", gtsmodel.NotificationFave, suite.testAccounts["admin_account"].ID, "01F8MH75CBF9JFX4ZAD54N0W0R"),
+	}
+	for _, n := range notifs {
+		suite.NoError(suite.user.QueueNotificationEmail(ctx, n))
+	}
+	suite.Len(suite.queued(), 3)
+
+	suite.NoError(suite.user.SendNotificationDigests(ctx))
+
+	email := suite.decodedEmail(user.Email)
+	suite.Contains(email, "Hello the_mighty_zork!")
+	suite.Contains(email, "@foss_satan@fossbros-anonymous.io followed you.")
+	suite.Contains(email, "@admin mentioned you:\r\nhello world! #welcome ! first post on the instance :rainbow: !\r\nhttp://localhost:8080/@admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R")
+	suite.Contains(email, "@admin sent you a direct message:\r\nhey zork, how's it going?\r\nhttp://localhost:8080/@admin/statuses/01FYBM7E4Y3GZYDNWBXYMV0A8Y")
+	suite.NotContains(email, "favourite")
+
+	// the queue should be emptied and the user marked as emailed
+	suite.Empty(suite.queued())
+	dbUser := &gtsmodel.User{}
+	suite.NoError(suite.db.GetByID(ctx, user.ID, dbUser))
+	suite.WithinDuration(time.Now(), dbUser.LastEmailedAt, time.Minute)
+}
+
+func (suite *NotificationEmailTestSuite) TestNotOptedIn() {
+	ctx := context.Background()
+	notif := suite.putNotification("01FYBM7E4Y3GZYDNWBXYMV0A8A", gtsmodel.NotificationFollow, suite.testAccounts["remote_account_1"].ID, "")
+
+	suite.NoError(suite.user.QueueNotificationEmail(ctx, notif))
+	suite.Empty(suite.queued())
+}
+
+func (suite *NotificationEmailTestSuite) TestDigestWaitsForInterval() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+	suite.optIn(user, "email_notify_follow")
+
+	notif := suite.putNotification("01FYBM7E4Y3GZYDNWBXYMV0A8A", gtsmodel.NotificationFollow, suite.testAccounts["remote_account_1"].ID, "")
+	suite.NoError(suite.user.QueueNotificationEmail(ctx, notif))
+
+	// zork was just sent a different email
+	suite.NoError(suite.db.UpdateOneByID(ctx, user.ID, "last_emailed_at", time.Now().Add(-10*time.Minute), &gtsmodel.User{}))
+
+	suite.NoError(suite.user.SendNotificationDigests(ctx))
+	suite.Empty(suite.sentEmails)
+	suite.Len(suite.queued(), 1)
+}
+
+func (suite *NotificationEmailTestSuite) TestDigestSkipsReadNotifications() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+	suite.optIn(user, "email_notify_follow")
+
+	notif := suite.putNotification("01FYBM7E4Y3GZYDNWBXYMV0A8A", gtsmodel.NotificationFollow, suite.testAccounts["remote_account_1"].ID, "")
+	suite.NoError(suite.user.QueueNotificationEmail(ctx, notif))
+
+	// zork sees the notification in their client before the digest goes out
+	suite.NoError(suite.db.UpdateOneByID(ctx, notif.ID, "read", true, &gtsmodel.Notification{}))
+
+	suite.NoError(suite.user.SendNotificationDigests(ctx))
+	suite.Empty(suite.sentEmails)
+	suite.Empty(suite.queued())
+}
+
+func TestNotificationEmailTestSuite(t *testing.T) {
+	suite.Run(t, new(NotificationEmailTestSuite))
+}
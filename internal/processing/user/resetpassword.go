@@ -0,0 +1,143 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// resetPasswordPath is the web path at which the reset password form is served.
+const resetPasswordPath = "/reset_password/change"
+
+const (
+	// resetTokenExpiry is how long a reset password link stays valid after it's sent.
+	resetTokenExpiry = 6 * time.Hour
+	// resetEmailInterval is the minimum time between two reset password emails to the same user,
+	// so that the reset form can't be used to flood someone's inbox.
+	resetEmailInterval = 5 * time.Minute
+)
+
+func (p *processor) SendResetPasswordEmail(ctx context.Context, emailAddress string) gtserror.WithCode {
+	l := p.log.WithField("func", "SendResetPasswordEmail")
+
+	if err := util.ValidateEmail(emailAddress); err != nil {
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	user := &gtsmodel.User{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "email", Value: emailAddress, CaseInsensitive: true}}, user); err != nil {
+		if err == db.ErrNoEntries {
+			// don't let the caller know that there's no user with this address
+			l.Debugf("no user found with email address %s", emailAddress)
+			return nil
+		}
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if user.Disabled {
+		l.Debugf("user %s is disabled, not sending reset email", user.ID)
+		return nil
+	}
+
+	if !user.ResetPasswordSentAt.IsZero() && user.ResetPasswordSentAt.After(time.Now().Add(-resetEmailInterval)) {
+		l.Debugf("reset email was sent to user %s recently, not sending another one", user.ID)
+		return nil
+	}
+
+	account, err := p.db.GetAccountByID(ctx, user.AccountID)
+	if err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("SendResetPasswordEmail: error getting account for user %s: %s", user.ID, err))
+	}
+
+	resetToken := uuid.NewString()
+	instanceName, instanceURL := p.instanceNameAndURL(ctx)
+
+	resetData := email.ResetData{
+		Username:     account.Username,
+		InstanceURL:  instanceURL,
+		InstanceName: instanceName,
+		ResetLink:    fmt.Sprintf("%s%s?token=%s", instanceURL, resetPasswordPath, resetToken),
+	}
+
+	if err := p.emailSender.SendResetEmail(user.Email, resetData); err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("SendResetPasswordEmail: error sending to email address %s belonging to user %s: %s", user.Email, user.ID, err))
+	}
+
+	now := time.Now()
+	user.ResetPasswordToken = resetToken
+	user.ResetPasswordSentAt = now
+	user.LastEmailedAt = now
+	user.UpdatedAt = now
+
+	if err := p.db.UpdateByID(ctx, user.ID, user); err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("SendResetPasswordEmail: error updating user entry after email sent: %s", err))
+	}
+
+	return nil
+}
+
+func (p *processor) ResetPassword(ctx context.Context, token string, newPassword string) gtserror.WithCode {
+	if token == "" {
+		return gtserror.NewErrorNotFound(errors.New("no token provided"), "reset link is not valid, please ask for a new one")
+	}
+
+	user := &gtsmodel.User{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "reset_password_token", Value: token}}, user); err != nil {
+		if err == db.ErrNoEntries {
+			return gtserror.NewErrorNotFound(err, "reset link is not valid, please ask for a new one")
+		}
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if user.ResetPasswordSentAt.Before(time.Now().Add(-resetTokenExpiry)) {
+		return gtserror.NewErrorForbidden(errors.New("ResetPassword: reset password token expired"), "reset link has expired, please ask for a new one")
+	}
+
+	if err := util.ValidateNewPassword(newPassword); err != nil {
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	encryptedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("ResetPassword: error hashing password: %s", err))
+	}
+
+	// set the new password and make sure the token can't be used again
+	user.EncryptedPassword = string(encryptedPassword)
+	user.ResetPasswordToken = ""
+	user.ResetPasswordSentAt = time.Time{}
+	user.UpdatedAt = time.Now()
+
+	if err := p.db.UpdateByID(ctx, user.ID, user); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
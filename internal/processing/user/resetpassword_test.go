@@ -0,0 +1,101 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package user_test
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type ResetPasswordTestSuite struct {
+	UserStandardTestSuite
+}
+
+var resetLinkRegex = regexp.MustCompile(`http://localhost:8080/reset_password/change\?token=([a-f0-9-]+)`)
+
+func (suite *ResetPasswordTestSuite) TestSendResetPasswordEmail() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+
+	errWithCode := suite.user.SendResetPasswordEmail(ctx, "Zork@example.org")
+	suite.NoError(errWithCode)
+
+	dbUser := &gtsmodel.User{}
+	err := suite.db.GetByID(ctx, user.ID, dbUser)
+	suite.NoError(err)
+	suite.NotEmpty(dbUser.ResetPasswordToken)
+	suite.False(dbUser.ResetPasswordSentAt.IsZero())
+
+	email := suite.decodedEmail(user.Email)
+	suite.Contains(email, "Hello the_mighty_zork!")
+	suite.Contains(email, "http://localhost:8080/reset_password/change?token="+dbUser.ResetPasswordToken)
+
+	// asking again straight away shouldn't send another email
+	delete(suite.sentEmails, user.Email)
+	errWithCode = suite.user.SendResetPasswordEmail(ctx, user.Email)
+	suite.NoError(errWithCode)
+	suite.Empty(suite.sentEmails)
+}
+
+func (suite *ResetPasswordTestSuite) TestSendResetPasswordEmailUnknownAddress() {
+	errWithCode := suite.user.SendResetPasswordEmail(context.Background(), "nobody@example.org")
+	suite.NoError(errWithCode)
+	suite.Empty(suite.sentEmails)
+}
+
+func (suite *ResetPasswordTestSuite) TestResetPassword() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+
+	errWithCode := suite.user.SendResetPasswordEmail(ctx, user.Email)
+	suite.NoError(errWithCode)
+
+	match := resetLinkRegex.FindStringSubmatch(suite.decodedEmail(user.Email))
+	suite.Len(match, 2)
+	token := match[1]
+
+	// a weak password should be rejected without using up the token
+	errWithCode = suite.user.ResetPassword(ctx, token, "password")
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	errWithCode = suite.user.ResetPassword(ctx, token, "verygoodnewpassword6969!")
+	suite.NoError(errWithCode)
+
+	dbUser := &gtsmodel.User{}
+	err := suite.db.GetByID(ctx, user.ID, dbUser)
+	suite.NoError(err)
+	suite.NoError(bcrypt.CompareHashAndPassword([]byte(dbUser.EncryptedPassword), []byte("verygoodnewpassword6969!")))
+	suite.Empty(dbUser.ResetPasswordToken)
+
+	// the token can only be used once
+	errWithCode = suite.user.ResetPassword(ctx, token, "anothergoodnewpassword4242!")
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestResetPasswordTestSuite(t *testing.T) {
+	suite.Run(t, new(ResetPasswordTestSuite))
+}
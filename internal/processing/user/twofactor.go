@@ -0,0 +1,160 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/otp"
+)
+
+func (p *processor) SetupTwoFactor(ctx context.Context, user *gtsmodel.User) (*apimodel.TwoFactorSetup, gtserror.WithCode) {
+	if user.OTPRequiredForLogin {
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New("two-factor authentication is already enabled"), "two-factor authentication is already enabled")
+	}
+
+	account, err := p.db.GetAccountByID(ctx, user.AccountID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("SetupTwoFactor: error getting account: %s", err))
+	}
+
+	key, err := otp.InstanceKey(ctx, p.db)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	secret, err := otp.GenerateSecret()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	encrypted, iv, salt, err := otp.EncryptSecret(key, secret)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// store the secret straight away so that we can check the code the user gives us when they enable
+	// two-factor authentication, but don't require it for signing in until then
+	user.EncryptedOTPSecret = encrypted
+	user.EncryptedOTPSecretIv = iv
+	user.EncryptedOTPSecretSalt = salt
+	user.OTPBackupCodes = nil
+	user.UpdatedAt = time.Now()
+	if err := p.db.UpdateByID(ctx, user.ID, user); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("SetupTwoFactor: error updating user: %s", err))
+	}
+
+	return &apimodel.TwoFactorSetup{
+		Secret:          secret,
+		ProvisioningURI: otp.ProvisioningURI(p.config.Host, account.Username, secret),
+	}, nil
+}
+
+func (p *processor) EnableTwoFactor(ctx context.Context, user *gtsmodel.User, code string) (*apimodel.TwoFactorBackupCodes, gtserror.WithCode) {
+	if user.OTPRequiredForLogin {
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New("two-factor authentication is already enabled"), "two-factor authentication is already enabled")
+	}
+
+	if user.EncryptedOTPSecret == "" {
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New("two-factor authentication hasn't been set up"), "two-factor authentication hasn't been set up yet")
+	}
+
+	ok, err := otp.Verify(ctx, p.db, user, code)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	if !ok {
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New("code was not valid"), "code was not valid")
+	}
+
+	codes, hashes, err := otp.GenerateBackupCodes()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	user.OTPRequiredForLogin = true
+	user.OTPBackupCodes = hashes
+	user.UpdatedAt = time.Now()
+	if err := p.db.UpdateByID(ctx, user.ID, user); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("EnableTwoFactor: error updating user: %s", err))
+	}
+
+	return &apimodel.TwoFactorBackupCodes{BackupCodes: codes}, nil
+}
+
+func (p *processor) DisableTwoFactor(ctx context.Context, user *gtsmodel.User, code string) gtserror.WithCode {
+	if errWithCode := p.checkTwoFactorCode(ctx, user, code); errWithCode != nil {
+		return errWithCode
+	}
+
+	user.EncryptedOTPSecret = ""
+	user.EncryptedOTPSecretIv = ""
+	user.EncryptedOTPSecretSalt = ""
+	user.OTPRequiredForLogin = false
+	user.OTPBackupCodes = nil
+	user.UpdatedAt = time.Now()
+	if err := p.db.UpdateByID(ctx, user.ID, user); err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("DisableTwoFactor: error updating user: %s", err))
+	}
+
+	return nil
+}
+
+func (p *processor) RegenerateBackupCodes(ctx context.Context, user *gtsmodel.User, code string) (*apimodel.TwoFactorBackupCodes, gtserror.WithCode) {
+	if errWithCode := p.checkTwoFactorCode(ctx, user, code); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	codes, hashes, err := otp.GenerateBackupCodes()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	user.OTPBackupCodes = hashes
+	user.UpdatedAt = time.Now()
+	if err := p.db.UpdateByID(ctx, user.ID, user); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("RegenerateBackupCodes: error updating user: %s", err))
+	}
+
+	return &apimodel.TwoFactorBackupCodes{BackupCodes: codes}, nil
+}
+
+// checkTwoFactorCode makes sure that the user has two-factor authentication enabled, and that the given
+// code is either a valid code from their authenticator app or one of their unused backup codes.
+func (p *processor) checkTwoFactorCode(ctx context.Context, user *gtsmodel.User, code string) gtserror.WithCode {
+	if !user.OTPRequiredForLogin {
+		return gtserror.NewErrorUnprocessableEntity(errors.New("two-factor authentication is not enabled"), "two-factor authentication is not enabled")
+	}
+
+	ok, err := otp.Verify(ctx, p.db, user, code)
+	if err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+	if !ok {
+		return gtserror.NewErrorForbidden(errors.New("code was not valid"), "code was not valid")
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package user_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/otp"
+)
+
+type TwoFactorTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *TwoFactorTestSuite) getUser(id string) *gtsmodel.User {
+	user := &gtsmodel.User{}
+	if err := suite.db.GetByID(context.Background(), id, user); err != nil {
+		suite.FailNow(err.Error())
+	}
+	return user
+}
+
+// enable sets up and enables two-factor authentication for the given user, and returns the secret and backup codes.
+func (suite *TwoFactorTestSuite) enable(id string) (string, []string) {
+	ctx := context.Background()
+
+	setup, errWithCode := suite.user.SetupTwoFactor(ctx, suite.getUser(id))
+	suite.NoError(errWithCode)
+
+	code, err := otp.GenerateCode(setup.Secret, time.Now())
+	suite.NoError(err)
+
+	backupCodes, errWithCode := suite.user.EnableTwoFactor(ctx, suite.getUser(id), code)
+	suite.NoError(errWithCode)
+
+	return setup.Secret, backupCodes.BackupCodes
+}
+
+func (suite *TwoFactorTestSuite) TestSetupAndEnable() {
+	ctx := context.Background()
+	userID := suite.testUsers["local_account_1"].ID
+
+	setup, errWithCode := suite.user.SetupTwoFactor(ctx, suite.getUser(userID))
+	suite.NoError(errWithCode)
+	suite.Len(setup.Secret, 32)
+	suite.Equal("otpauth://totp/localhost:8080:the_mighty_zork?algorithm=SHA1&digits=6&issuer=localhost%3A8080&period=30&secret="+setup.Secret, setup.ProvisioningURI)
+
+	// the secret should be stored encrypted, and not required for sign in yet
+	dbUser := suite.getUser(userID)
+	suite.NotEmpty(dbUser.EncryptedOTPSecret)
+	suite.NotContains(dbUser.EncryptedOTPSecret, setup.Secret)
+	suite.False(dbUser.OTPRequiredForLogin)
+
+	// a wrong code shouldn't enable anything
+	_, errWithCode = suite.user.EnableTwoFactor(ctx, dbUser, "000000")
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+	suite.False(suite.getUser(userID).OTPRequiredForLogin)
+
+	code, err := otp.GenerateCode(setup.Secret, time.Now())
+	suite.NoError(err)
+	backupCodes, errWithCode := suite.user.EnableTwoFactor(ctx, suite.getUser(userID), code)
+	suite.NoError(errWithCode)
+	suite.Len(backupCodes.BackupCodes, otp.BackupCodeCount)
+
+	dbUser = suite.getUser(userID)
+	suite.True(dbUser.OTPRequiredForLogin)
+	suite.Len(dbUser.OTPBackupCodes, otp.BackupCodeCount)
+	suite.NotContains(dbUser.OTPBackupCodes, backupCodes.BackupCodes[0])
+
+	// setting up again shouldn't be possible without disabling first
+	_, errWithCode = suite.user.SetupTwoFactor(ctx, dbUser)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func (suite *TwoFactorTestSuite) TestDisable() {
+	ctx := context.Background()
+	userID := suite.testUsers["local_account_1"].ID
+	secret, backupCodes := suite.enable(userID)
+
+	// the code used to enable two-factor authentication has been used up
+	code, err := otp.GenerateCode(secret, time.Now())
+	suite.NoError(err)
+	errWithCode := suite.user.DisableTwoFactor(ctx, suite.getUser(userID), code)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	// but a backup code will work
+	errWithCode = suite.user.DisableTwoFactor(ctx, suite.getUser(userID), backupCodes[0])
+	suite.NoError(errWithCode)
+
+	dbUser := suite.getUser(userID)
+	suite.False(dbUser.OTPRequiredForLogin)
+	suite.Empty(dbUser.EncryptedOTPSecret)
+	suite.Empty(dbUser.OTPBackupCodes)
+
+	errWithCode = suite.user.DisableTwoFactor(ctx, dbUser, backupCodes[1])
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func (suite *TwoFactorTestSuite) TestBackupCodesAreOneTime() {
+	ctx := context.Background()
+	userID := suite.testUsers["local_account_1"].ID
+	_, backupCodes := suite.enable(userID)
+
+	ok, err := otp.Verify(ctx, suite.db, suite.getUser(userID), backupCodes[2])
+	suite.NoError(err)
+	suite.True(ok)
+	suite.Len(suite.getUser(userID).OTPBackupCodes, otp.BackupCodeCount-1)
+
+	ok, err = otp.Verify(ctx, suite.db, suite.getUser(userID), backupCodes[2])
+	suite.NoError(err)
+	suite.False(ok)
+}
+
+func (suite *TwoFactorTestSuite) TestRegenerateBackupCodes() {
+	ctx := context.Background()
+	userID := suite.testUsers["local_account_1"].ID
+	_, oldCodes := suite.enable(userID)
+
+	newCodes, errWithCode := suite.user.RegenerateBackupCodes(ctx, suite.getUser(userID), oldCodes[0])
+	suite.NoError(errWithCode)
+	suite.Len(newCodes.BackupCodes, otp.BackupCodeCount)
+	suite.Len(suite.getUser(userID).OTPBackupCodes, otp.BackupCodeCount)
+
+	// the old codes don't work anymore
+	ok, err := otp.Verify(ctx, suite.db, suite.getUser(userID), oldCodes[1])
+	suite.NoError(err)
+	suite.False(ok)
+
+	ok, err = otp.Verify(ctx, suite.db, suite.getUser(userID), newCodes.BackupCodes[0])
+	suite.NoError(err)
+	suite.True(ok)
+}
+
+func TestTwoFactorTestSuite(t *testing.T) {
+	suite.Run(t, new(TwoFactorTestSuite))
+}
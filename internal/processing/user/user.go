@@ -0,0 +1,109 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package user
+
+import (
+	"context"
+
+	"github.com/ReneKroon/ttlcache"
+	"github.com/sirupsen/logrus"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/webauthn"
+)
+
+// Processor wraps a bunch of functions for processing user-level actions.
+type Processor interface {
+	// SendConfirmEmail sends a 'confirm-your-email-address' type email to a user.
+	SendConfirmEmail(ctx context.Context, user *gtsmodel.User, username string) error
+	// ConfirmEmail confirms an email address using the given token.
+	ConfirmEmail(ctx context.Context, token string) (*gtsmodel.User, gtserror.WithCode)
+	// SendResetPasswordEmail sends a 'reset-your-password' type email to the user with the given
+	// email address, if there is one. No error is returned if no such user exists, so that callers
+	// don't leak which email addresses are registered.
+	SendResetPasswordEmail(ctx context.Context, emailAddress string) gtserror.WithCode
+	// ResetPassword sets a new password for the user with the given reset password token.
+	ResetPassword(ctx context.Context, token string, newPassword string) gtserror.WithCode
+	// QueueNotificationEmail queues the given notification to be included in the next digest email sent to
+	// its target account, if the account belongs to a local user who has opted in to emails about it.
+	QueueNotificationEmail(ctx context.Context, notif *gtsmodel.Notification) error
+	// SendNotificationDigests sends digest emails of queued notifications to all users who have any waiting
+	// and haven't been emailed within the notification digest interval.
+	SendNotificationDigests(ctx context.Context) error
+	// SetupTwoFactor generates a new OTP secret for the user, replacing any earlier one that was never enabled.
+	// Two-factor authentication isn't required at sign in until it's enabled with EnableTwoFactor.
+	SetupTwoFactor(ctx context.Context, user *gtsmodel.User) (*apimodel.TwoFactorSetup, gtserror.WithCode)
+	// EnableTwoFactor checks the given code against the secret from SetupTwoFactor, and if it's valid, turns on
+	// two-factor authentication for the user and returns a set of backup codes.
+	EnableTwoFactor(ctx context.Context, user *gtsmodel.User, code string) (*apimodel.TwoFactorBackupCodes, gtserror.WithCode)
+	// DisableTwoFactor turns off two-factor authentication for the user, if the given code is valid.
+	DisableTwoFactor(ctx context.Context, user *gtsmodel.User, code string) gtserror.WithCode
+	// RegenerateBackupCodes replaces the user's backup codes with a fresh set, if the given code is valid.
+	RegenerateBackupCodes(ctx context.Context, user *gtsmodel.User, code string) (*apimodel.TwoFactorBackupCodes, gtserror.WithCode)
+	// BeginWebauthnRegistration returns the options for registering a new security key or passkey for the user,
+	// which should be passed to navigator.credentials.create() in the browser.
+	BeginWebauthnRegistration(ctx context.Context, user *gtsmodel.User) (*webauthn.CredentialCreationOptions, gtserror.WithCode)
+	// FinishWebauthnRegistration checks the result of navigator.credentials.create() and stores the new credential.
+	FinishWebauthnRegistration(ctx context.Context, user *gtsmodel.User, form *apimodel.WebauthnRegistrationRequest) (*apimodel.WebauthnCredential, gtserror.WithCode)
+	// GetWebauthnCredentials returns all the security keys and passkeys registered by the user.
+	GetWebauthnCredentials(ctx context.Context, user *gtsmodel.User) ([]*apimodel.WebauthnCredential, gtserror.WithCode)
+	// DeleteWebauthnCredential removes one of the user's security keys or passkeys, so it can't be used to sign in anymore.
+	DeleteWebauthnCredential(ctx context.Context, user *gtsmodel.User, credentialID string) gtserror.WithCode
+}
+
+type processor struct {
+	config      *config.Config
+	emailSender email.Sender
+	db          db.DB
+	log         *logrus.Logger
+
+	// webauthnChallenges maps user ids to the challenge of their in-progress security key registration
+	webauthnChallenges *ttlcache.Cache
+}
+
+// New returns a new user processor
+func New(db db.DB, emailSender email.Sender, config *config.Config, log *logrus.Logger) Processor {
+	webauthnChallenges := ttlcache.NewCache()
+	webauthnChallenges.SetTTL(webauthn.Timeout)
+	webauthnChallenges.SkipTtlExtensionOnHit(true)
+
+	return &processor{
+		config:             config,
+		emailSender:        emailSender,
+		db:                 db,
+		log:                log,
+		webauthnChallenges: webauthnChallenges,
+	}
+}
+
+// instanceNameAndURL returns the title and the base URL of this instance, for use in emails.
+func (p *processor) instanceNameAndURL(ctx context.Context) (string, string) {
+	instanceURL := p.config.Protocol + "://" + p.config.Host
+
+	instance := &gtsmodel.Instance{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "domain", Value: p.config.Host}}, instance); err != nil || instance.Title == "" {
+		return p.config.Host, instanceURL
+	}
+
+	return instance.Title, instanceURL
+}
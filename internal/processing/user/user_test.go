@@ -0,0 +1,88 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package user_test
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/user"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type UserStandardTestSuite struct {
+	suite.Suite
+	config     *config.Config
+	db         db.DB
+	log        *logrus.Logger
+	sentEmails map[string]string
+
+	testUsers    map[string]*gtsmodel.User
+	testAccounts map[string]*gtsmodel.Account
+
+	user user.Processor
+}
+
+func (suite *UserStandardTestSuite) SetupSuite() {
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+}
+
+func (suite *UserStandardTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.log = testrig.NewTestLog()
+	suite.sentEmails = make(map[string]string)
+	suite.user = user.New(suite.db, testrig.NewEmailSender(suite.sentEmails), suite.config, suite.log)
+
+	testrig.StandardDBSetup(suite.db, nil)
+}
+
+func (suite *UserStandardTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+}
+
+// decodedEmail returns the plaintext part of the email sent to the given address.
+func (suite *UserStandardTestSuite) decodedEmail(toAddress string) string {
+	message, ok := suite.sentEmails[toAddress]
+	suite.True(ok, "no email sent to %s", toAddress)
+
+	msg, err := mail.ReadMessage(strings.NewReader(message))
+	suite.NoError(err)
+
+	_, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	suite.NoError(err)
+
+	// the plaintext part comes first; quoted-printable encoding is removed by the multipart reader
+	part, err := multipart.NewReader(msg.Body, params["boundary"]).NextPart()
+	suite.NoError(err)
+	suite.Equal("text/plain; charset=utf-8", part.Header.Get("Content-Type"))
+
+	b, err := io.ReadAll(part)
+	suite.NoError(err)
+	return string(b)
+}
@@ -0,0 +1,194 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/webauthn"
+)
+
+// maxWebauthnCredentialNameLength is the longest name a user can give a credential.
+const maxWebauthnCredentialNameLength = 100
+
+func (p *processor) BeginWebauthnRegistration(ctx context.Context, user *gtsmodel.User) (*webauthn.CredentialCreationOptions, gtserror.WithCode) {
+	rp, err := webauthn.NewRelyingParty(p.config)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	account, err := p.db.GetAccountByID(ctx, user.AccountID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("BeginWebauthnRegistration: error getting account: %s", err))
+	}
+
+	if user.WebauthnID == "" {
+		handle, err := webauthn.NewUserHandle()
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		user.WebauthnID = handle
+		user.UpdatedAt = time.Now()
+		if err := p.db.UpdateByID(ctx, user.ID, user); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("BeginWebauthnRegistration: error updating user: %s", err))
+		}
+	}
+
+	existing, err := webauthn.UserCredentials(ctx, p.db, user.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// only the most recent challenge for each user is valid
+	p.webauthnChallenges.Set(user.ID, challenge)
+
+	accountDomain := p.config.AccountDomain
+	if accountDomain == "" {
+		accountDomain = p.config.Host
+	}
+	username := account.Username + "@" + accountDomain
+	displayName := account.DisplayName
+	if displayName == "" {
+		displayName = account.Username
+	}
+
+	return rp.RegistrationOptions(challenge, user.WebauthnID, username, displayName, webauthn.CredentialIDs(existing)), nil
+}
+
+func (p *processor) FinishWebauthnRegistration(ctx context.Context, user *gtsmodel.User, form *apimodel.WebauthnRegistrationRequest) (*apimodel.WebauthnCredential, gtserror.WithCode) {
+	if form.Name == "" || len([]rune(form.Name)) > maxWebauthnCredentialNameLength {
+		err := fmt.Errorf("name must be between 1 and %d characters", maxWebauthnCredentialNameLength)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	c, ok := p.webauthnChallenges.Get(user.ID)
+	if !ok {
+		err := errors.New("no registration in progress, or it took too long")
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+	p.webauthnChallenges.Remove(user.ID)
+
+	resp := &webauthn.AttestationResponse{}
+	if err := json.Unmarshal(form.Credential, resp); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, "credential could not be parsed")
+	}
+
+	rp, err := webauthn.NewRelyingParty(p.config)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	credential, err := rp.VerifyRegistration(c.(string), resp)
+	if err != nil {
+		return nil, gtserror.NewErrorUnprocessableEntity(err, "credential could not be verified")
+	}
+
+	credentialID := webauthn.Encoding.EncodeToString(credential.ID)
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "credential_id", Value: credentialID}}, &gtsmodel.WebauthnCredential{}); err == nil {
+		err := errors.New("credential is already registered")
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	} else if err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	newID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	dbCredential := &gtsmodel.WebauthnCredential{
+		ID:           newID,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+		UserID:       user.ID,
+		Name:         form.Name,
+		CredentialID: credentialID,
+		PublicKey:    webauthn.Encoding.EncodeToString(credential.PublicKey),
+		SignCount:    int64(credential.SignCount),
+	}
+	if err := p.db.Put(ctx, dbCredential); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("FinishWebauthnRegistration: error putting credential: %s", err))
+	}
+
+	return webauthnCredentialToAPI(dbCredential), nil
+}
+
+func (p *processor) GetWebauthnCredentials(ctx context.Context, user *gtsmodel.User) ([]*apimodel.WebauthnCredential, gtserror.WithCode) {
+	credentials, err := webauthn.UserCredentials(ctx, p.db, user.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiCredentials := make([]*apimodel.WebauthnCredential, 0, len(credentials))
+	for _, c := range credentials {
+		apiCredentials = append(apiCredentials, webauthnCredentialToAPI(c))
+	}
+
+	return apiCredentials, nil
+}
+
+func (p *processor) DeleteWebauthnCredential(ctx context.Context, user *gtsmodel.User, credentialID string) gtserror.WithCode {
+	credential := &gtsmodel.WebauthnCredential{}
+	if err := p.db.GetByID(ctx, credentialID, credential); err != nil {
+		if err == db.ErrNoEntries {
+			return gtserror.NewErrorNotFound(err, "credential not found")
+		}
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// don't let on that other users' credentials exist
+	if credential.UserID != user.ID {
+		return gtserror.NewErrorNotFound(errors.New("credential belongs to a different user"), "credential not found")
+	}
+
+	if err := p.db.DeleteByID(ctx, credential.ID, credential); err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("DeleteWebauthnCredential: error deleting credential: %s", err))
+	}
+
+	return nil
+}
+
+func webauthnCredentialToAPI(c *gtsmodel.WebauthnCredential) *apimodel.WebauthnCredential {
+	apiCredential := &apimodel.WebauthnCredential{
+		ID:        c.ID,
+		Name:      c.Name,
+		CreatedAt: c.CreatedAt.Format(time.RFC3339),
+	}
+
+	if !c.LastUsedAt.IsZero() {
+		l := c.LastUsedAt.Format(time.RFC3339)
+		apiCredential.LastUsedAt = &l
+	}
+
+	return apiCredential
+}
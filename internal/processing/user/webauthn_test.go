@@ -0,0 +1,200 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package user_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/webauthn"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type WebauthnTestSuite struct {
+	UserStandardTestSuite
+	authenticator *testrig.WebauthnAuthenticator
+}
+
+func (suite *WebauthnTestSuite) SetupTest() {
+	suite.UserStandardTestSuite.SetupTest()
+	suite.authenticator = testrig.NewWebauthnAuthenticator("http://localhost:8080")
+}
+
+func (suite *WebauthnTestSuite) getUser(id string) *gtsmodel.User {
+	user := &gtsmodel.User{}
+	if err := suite.db.GetByID(context.Background(), id, user); err != nil {
+		suite.FailNow(err.Error())
+	}
+	return user
+}
+
+// register creates a credential with the software authenticator and registers it for the given user.
+func (suite *WebauthnTestSuite) register(userID string, name string) *apimodel.WebauthnCredential {
+	ctx := context.Background()
+
+	options, errWithCode := suite.user.BeginWebauthnRegistration(ctx, suite.getUser(userID))
+	suite.NoError(errWithCode)
+
+	resp, err := suite.authenticator.Create(options)
+	suite.NoError(err)
+	credential, err := json.Marshal(resp)
+	suite.NoError(err)
+
+	apiCredential, errWithCode := suite.user.FinishWebauthnRegistration(ctx, suite.getUser(userID), &apimodel.WebauthnRegistrationRequest{
+		Name:       name,
+		Credential: credential,
+	})
+	suite.NoError(errWithCode)
+	return apiCredential
+}
+
+func (suite *WebauthnTestSuite) TestBeginRegistration() {
+	userID := suite.testUsers["local_account_1"].ID
+
+	options, errWithCode := suite.user.BeginWebauthnRegistration(context.Background(), suite.getUser(userID))
+	suite.NoError(errWithCode)
+
+	// the user should have been given a user handle, which is used in the options
+	dbUser := suite.getUser(userID)
+	suite.NotEmpty(dbUser.WebauthnID)
+	suite.Equal(dbUser.WebauthnID, options.PublicKey.User.ID)
+	suite.Equal("the_mighty_zork@localhost:8080", options.PublicKey.User.Name)
+	suite.Equal("original zork (he/they)", options.PublicKey.User.DisplayName)
+	suite.Equal("localhost", options.PublicKey.RP.ID)
+	suite.NotEmpty(options.PublicKey.Challenge)
+	suite.Empty(options.PublicKey.ExcludeCredentials)
+}
+
+func (suite *WebauthnTestSuite) TestRegisterListDelete() {
+	ctx := context.Background()
+	userID := suite.testUsers["local_account_1"].ID
+
+	first := suite.register(userID, "yubikey")
+	suite.Equal("yubikey", first.Name)
+	suite.NotEmpty(first.CreatedAt)
+	suite.Nil(first.LastUsedAt)
+
+	// the first credential should be excluded when registering another
+	options, errWithCode := suite.user.BeginWebauthnRegistration(ctx, suite.getUser(userID))
+	suite.NoError(errWithCode)
+	suite.Len(options.PublicKey.ExcludeCredentials, 1)
+
+	// the software authenticator refuses to make a second credential, like a real one would
+	_, err := suite.authenticator.Create(options)
+	suite.Error(err)
+
+	// but a different authenticator is fine
+	suite.authenticator = testrig.NewWebauthnAuthenticator("http://localhost:8080")
+	second := suite.register(userID, "phone")
+
+	credentials, errWithCode := suite.user.GetWebauthnCredentials(ctx, suite.getUser(userID))
+	suite.NoError(errWithCode)
+	suite.Len(credentials, 2)
+	suite.Equal(first.ID, credentials[0].ID)
+	suite.Equal(second.ID, credentials[1].ID)
+
+	// other users can't see or delete them
+	otherUser := suite.getUser(suite.testUsers["local_account_2"].ID)
+	otherCredentials, errWithCode := suite.user.GetWebauthnCredentials(ctx, otherUser)
+	suite.NoError(errWithCode)
+	suite.Empty(otherCredentials)
+
+	errWithCode = suite.user.DeleteWebauthnCredential(ctx, otherUser, first.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	suite.NoError(suite.user.DeleteWebauthnCredential(ctx, suite.getUser(userID), first.ID))
+
+	credentials, errWithCode = suite.user.GetWebauthnCredentials(ctx, suite.getUser(userID))
+	suite.NoError(errWithCode)
+	suite.Len(credentials, 1)
+	suite.Equal(second.ID, credentials[0].ID)
+
+	errWithCode = suite.user.DeleteWebauthnCredential(ctx, suite.getUser(userID), first.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *WebauthnTestSuite) TestFinishRegistrationWithoutBegin() {
+	_, errWithCode := suite.user.FinishWebauthnRegistration(context.Background(), suite.getUser(suite.testUsers["local_account_1"].ID), &apimodel.WebauthnRegistrationRequest{
+		Name:       "yubikey",
+		Credential: json.RawMessage(`{}`),
+	})
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func (suite *WebauthnTestSuite) TestFinishRegistrationWrongChallenge() {
+	ctx := context.Background()
+	userID := suite.testUsers["local_account_1"].ID
+
+	options, errWithCode := suite.user.BeginWebauthnRegistration(ctx, suite.getUser(userID))
+	suite.NoError(errWithCode)
+
+	// starting again makes the first challenge invalid
+	_, errWithCode = suite.user.BeginWebauthnRegistration(ctx, suite.getUser(userID))
+	suite.NoError(errWithCode)
+
+	resp, err := suite.authenticator.Create(options)
+	suite.NoError(err)
+	credential, err := json.Marshal(resp)
+	suite.NoError(err)
+
+	_, errWithCode = suite.user.FinishWebauthnRegistration(ctx, suite.getUser(userID), &apimodel.WebauthnRegistrationRequest{
+		Name:       "yubikey",
+		Credential: credential,
+	})
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+	suite.Equal("422 unprocessable entity: credential could not be verified", errWithCode.Safe())
+}
+
+func (suite *WebauthnTestSuite) TestFinishRegistrationBadName() {
+	_, errWithCode := suite.user.FinishWebauthnRegistration(context.Background(), suite.getUser(suite.testUsers["local_account_1"].ID), &apimodel.WebauthnRegistrationRequest{
+		Credential: json.RawMessage(`{}`),
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *WebauthnTestSuite) TestRegisteredCredentialCanSignIn() {
+	userID := suite.testUsers["local_account_1"].ID
+	suite.register(userID, "yubikey")
+
+	rp, err := webauthn.NewRelyingParty(suite.config)
+	suite.NoError(err)
+
+	challenge, err := webauthn.NewChallenge()
+	suite.NoError(err)
+
+	resp, err := suite.authenticator.Get(rp.LoginOptions(challenge, nil, webauthn.UserVerificationRequired))
+	suite.NoError(err)
+
+	user, err := webauthn.Login(context.Background(), suite.db, rp, challenge, resp, "")
+	suite.NoError(err)
+	suite.Equal(userID, user.ID)
+
+	credentials, errWithCode := suite.user.GetWebauthnCredentials(context.Background(), user)
+	suite.NoError(errWithCode)
+	suite.NotNil(credentials[0].LastUsedAt)
+}
+
+func TestWebauthnTestSuite(t *testing.T) {
+	suite.Run(t, new(WebauthnTestSuite))
+}
@@ -0,0 +1,213 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+func (p *processor) UserDomainBlocksGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, limit int) (*apimodel.UserDomainBlocksResponse, gtserror.WithCode) {
+	blocks, err := p.db.GetAccountDomainBlocks(ctx, authed.Account.ID, maxID, sinceID, limit)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			// there are just no entries
+			return &apimodel.UserDomainBlocksResponse{
+				Domains: []string{},
+			}, nil
+		}
+		// there's an actual error
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	domains := []string{}
+	for _, b := range blocks {
+		domains = append(domains, b.Domain)
+	}
+
+	resp := &apimodel.UserDomainBlocksResponse{
+		Domains: domains,
+	}
+
+	// prepare the next and previous links
+	nextLink := &url.URL{
+		Scheme:   p.config.Protocol,
+		Host:     p.config.Host,
+		Path:     "/api/v1/domain_blocks",
+		RawQuery: fmt.Sprintf("limit=%d&max_id=%s", limit, blocks[len(blocks)-1].ID),
+	}
+	next := fmt.Sprintf("<%s>; rel=\"next\"", nextLink.String())
+
+	prevLink := &url.URL{
+		Scheme:   p.config.Protocol,
+		Host:     p.config.Host,
+		Path:     "/api/v1/domain_blocks",
+		RawQuery: fmt.Sprintf("limit=%d&min_id=%s", limit, blocks[0].ID),
+	}
+	prev := fmt.Sprintf("<%s>; rel=\"prev\"", prevLink.String())
+	resp.LinkHeader = fmt.Sprintf("%s, %s", next, prev)
+
+	return resp, nil
+}
+
+func (p *processor) UserDomainBlockCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.UserDomainBlockRequest) gtserror.WithCode {
+	domain, err := p.parseUserDomainBlock(form.Domain)
+	if err != nil {
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	blocked, err := p.db.IsDomainBlockedByAccount(ctx, authed.Account.ID, domain)
+	if err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+	if blocked {
+		// nothing to do
+		return nil
+	}
+
+	blockID, err := id.NewULID()
+	if err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.db.Put(ctx, &gtsmodel.UserDomainBlock{
+		ID:        blockID,
+		AccountID: authed.Account.ID,
+		Domain:    domain,
+	}); err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("UserDomainBlockCreate: error putting domain block in the db: %s", err))
+	}
+
+	if err := p.removeDomainFollowers(ctx, authed.Account, domain); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+func (p *processor) UserDomainBlockDelete(ctx context.Context, authed *oauth.Auth, form *apimodel.UserDomainBlockRequest) gtserror.WithCode {
+	domain, err := p.parseUserDomainBlock(form.Domain)
+	if err != nil {
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if err := p.db.DeleteWhere(ctx, []db.Where{
+		{Key: "account_id", Value: authed.Account.ID},
+		{Key: "domain", Value: domain},
+	}, &gtsmodel.UserDomainBlock{}); err != nil && err != db.ErrNoEntries {
+		return gtserror.NewErrorInternalError(fmt.Errorf("UserDomainBlockDelete: error deleting domain block: %s", err))
+	}
+
+	return nil
+}
+
+// parseUserDomainBlock checks that the given domain is something an account can block, and returns it in lowercase.
+func (p *processor) parseUserDomainBlock(domain string) (string, error) {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	if domain == "" {
+		return "", errors.New("no domain provided")
+	}
+
+	if u, err := url.Parse("https://" + domain); err != nil || u.Host != domain {
+		return "", fmt.Errorf("%s is not a valid domain", domain)
+	}
+
+	if domain == strings.ToLower(p.config.Host) || domain == strings.ToLower(p.config.AccountDomain) {
+		return "", errors.New("you can't block the domain of this instance")
+	}
+
+	return domain, nil
+}
+
+// removeDomainFollowers removes any followers and follow requests of the given account that are on the given domain,
+// and lets them know that their follow has been rejected.
+func (p *processor) removeDomainFollowers(ctx context.Context, account *gtsmodel.Account, domain string) error {
+	followRequests, err := p.db.GetAccountFollowRequests(ctx, account.ID)
+	if err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("removeDomainFollowers: error getting follow requests: %s", err)
+	}
+
+	for _, fr := range followRequests {
+		if fr.Account == nil || !strings.EqualFold(fr.Account.Domain, domain) {
+			continue
+		}
+
+		if err := p.db.DeleteByID(ctx, fr.ID, &gtsmodel.FollowRequest{}); err != nil {
+			return fmt.Errorf("removeDomainFollowers: error deleting follow request %s: %s", fr.ID, err)
+		}
+
+		p.fromClientAPI <- gtsmodel.FromClientAPI{
+			APObjectType:   gtsmodel.ActivityStreamsFollow,
+			APActivityType: gtsmodel.ActivityStreamsReject,
+			GTSModel:       fr,
+			OriginAccount:  fr.Account,
+			TargetAccount:  account,
+		}
+	}
+
+	follows, err := p.db.GetAccountFollowedBy(ctx, account.ID, false)
+	if err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("removeDomainFollowers: error getting followers: %s", err)
+	}
+
+	for _, f := range follows {
+		follower, err := p.db.GetAccountByID(ctx, f.AccountID)
+		if err != nil {
+			p.log.Debugf("removeDomainFollowers: skipping follow %s: error getting follower: %s", f.ID, err)
+			continue
+		}
+		if !strings.EqualFold(follower.Domain, domain) {
+			continue
+		}
+
+		if err := p.db.DeleteByID(ctx, f.ID, &gtsmodel.Follow{}); err != nil {
+			return fmt.Errorf("removeDomainFollowers: error deleting follow %s: %s", f.ID, err)
+		}
+
+		// an accepted follow is rejected in just the same way as a pending one
+		p.fromClientAPI <- gtsmodel.FromClientAPI{
+			APObjectType:   gtsmodel.ActivityStreamsFollow,
+			APActivityType: gtsmodel.ActivityStreamsReject,
+			GTSModel: &gtsmodel.FollowRequest{
+				ID:              f.ID,
+				CreatedAt:       f.CreatedAt,
+				UpdatedAt:       f.UpdatedAt,
+				AccountID:       f.AccountID,
+				TargetAccountID: f.TargetAccountID,
+				ShowReblogs:     f.ShowReblogs,
+				URI:             f.URI,
+				Notify:          f.Notify,
+			},
+			OriginAccount: follower,
+			TargetAccount: account,
+		}
+	}
+
+	return nil
+}
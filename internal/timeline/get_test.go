@@ -434,6 +434,51 @@ func (suite *GetTestSuite) TestGetXBeforeIDNoStartFromTop() {
 	}
 }
 
+func (suite *GetTestSuite) TestGetFiltered() {
+	ctx := context.Background()
+
+	// local_account_1 has a whole word filter for "fnord" in the home timeline
+	filteredStatus := *suite.testStatuses["local_account_2_status_1"]
+	filteredStatus.Content = "have you heard about FNORD?"
+	suite.NoError(suite.db.UpdateByID(ctx, filteredStatus.ID, &filteredStatus))
+
+	// this only contains the phrase as part of a longer word, so it shouldn't be filtered
+	partialStatus := *suite.testStatuses["admin_account_status_1"]
+	partialStatus.Content = "fnords are great"
+	suite.NoError(suite.db.UpdateByID(ctx, partialStatus.ID, &partialStatus))
+
+	// statuses of the timeline owner are never filtered
+	ownStatus := *suite.testStatuses["local_account_1_status_1"]
+	ownStatus.Content = "fnord fnord fnord"
+	suite.NoError(suite.db.UpdateByID(ctx, ownStatus.ID, &ownStatus))
+
+	tl, err := timeline.NewTimeline(ctx, suite.testAccounts["local_account_1"].ID, suite.db, suite.tc, suite.log)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for _, s := range suite.testStatuses {
+		_, err := tl.IndexAndPrepareOne(ctx, s.CreatedAt, s.ID, s.BoostOfID, s.AccountID, s.BoostOfAccountID)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	statuses, err := tl.Get(ctx, 20, "", "", "", false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statusIDs := []string{}
+	for _, s := range statuses {
+		statusIDs = append(statusIDs, s.ID)
+	}
+	suite.Len(statusIDs, 11)
+	suite.NotContains(statusIDs, filteredStatus.ID)
+	suite.Contains(statusIDs, partialStatus.ID)
+	suite.Contains(statusIDs, ownStatus.ID)
+}
+
 func TestGetTestSuite(t *testing.T) {
 	suite.Run(t, new(GetTestSuite))
 }
@@ -48,10 +48,16 @@ func (t *timeline) IndexBefore(ctx context.Context, statusID string, include boo
 		}
 	}
 
+	// prepare the timeline owner's filters once for all the statuses we grab
+	statusFilter, err := t.statusFilterer(ctx)
+	if err != nil {
+		return fmt.Errorf("IndexBefore: error getting filters: %s", err)
+	}
+
 	i := 0
 grabloop:
 	for ; len(filtered) < amount && i < 5; i = i + 1 { // try the grabloop 5 times only
-		statuses, err := t.db.GetHomeTimeline(ctx, t.accountID, "", "", offsetStatus, amount, false)
+		statuses, err := t.grab(ctx, "", "", offsetStatus, amount)
 		if err != nil {
 			if err == db.ErrNoEntries {
 				break grabloop // we just don't have enough statuses left in the db so index what we've got and then bail
@@ -60,7 +66,7 @@ grabloop:
 		}
 
 		for _, s := range statuses {
-			timelineable, err := t.filter.StatusHometimelineable(ctx, s, t.account)
+			timelineable, err := t.filter(ctx, s, t.account, statusFilter)
 			if err != nil {
 				continue
 			}
@@ -127,11 +133,17 @@ positionLoop:
 		}
 	}
 
+	// prepare the timeline owner's filters once for all the statuses we grab
+	statusFilter, err := t.statusFilterer(ctx)
+	if err != nil {
+		return fmt.Errorf("IndexBehind: error getting filters: %s", err)
+	}
+
 	i := 0
 grabloop:
 	for ; len(filtered) < amount && i < 5; i = i + 1 { // try the grabloop 5 times only
 		l.Tracef("entering grabloop; i is %d; len(filtered) is %d", i, len(filtered))
-		statuses, err := t.db.GetHomeTimeline(ctx, t.accountID, offsetStatus, "", "", amount, false)
+		statuses, err := t.grab(ctx, offsetStatus, "", "", amount)
 		if err != nil {
 			if err == db.ErrNoEntries {
 				break grabloop // we just don't have enough statuses left in the db so index what we've got and then bail
@@ -141,9 +153,9 @@ grabloop:
 		l.Tracef("got %d statuses", len(statuses))
 
 		for _, s := range statuses {
-			timelineable, err := t.filter.StatusHometimelineable(ctx, s, t.account)
+			timelineable, err := t.filter(ctx, s, t.account, statusFilter)
 			if err != nil {
-				l.Tracef("status was not hometimelineable: %s", err)
+				l.Tracef("status was not timelineable: %s", err)
 				continue
 			}
 			if timelineable {
@@ -195,7 +207,11 @@ func (t *timeline) IndexAndPrepareOne(ctx context.Context, statusCreatedAt time.
 	}
 
 	if inserted {
-		if err := t.prepare(ctx, statusID); err != nil {
+		filtered, err := t.statusFilterer(ctx)
+		if err != nil {
+			return inserted, fmt.Errorf("IndexAndPrepareOne: error getting filters: %s", err)
+		}
+		if err := t.prepare(ctx, statusID, filtered); err != nil {
 			return inserted, fmt.Errorf("IndexAndPrepareOne: error preparing: %s", err)
 		}
 	}
@@ -102,6 +102,30 @@ func (suite *IndexTestSuite) TestIndexBehindHighID() {
 	suite.Equal(10, indexLength)
 }
 
+func (suite *IndexTestSuite) TestIndexBehindFiltered() {
+	// index everything this user has hometimelineable
+	err := suite.timeline.IndexBehind(context.Background(), "ZZZZZZZZZZZZZZZZZZZZZZZZZZ", true, 100)
+	suite.NoError(err)
+	unfilteredLength := suite.timeline.PostIndexLength(context.Background())
+
+	// local_account_1 has a whole word filter for "fnord" in the home timeline
+	filteredStatus := *suite.testStatuses["local_account_2_status_1"]
+	filteredStatus.Content = "have you heard about FNORD?"
+	suite.NoError(suite.db.UpdateByID(context.Background(), filteredStatus.ID, &filteredStatus))
+
+	tl, err := timeline.NewTimeline(context.Background(), suite.testAccounts["local_account_1"].ID, suite.db, suite.tc, suite.log)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	err = tl.IndexBehind(context.Background(), "ZZZZZZZZZZZZZZZZZZZZZZZZZZ", true, 100)
+	suite.NoError(err)
+
+	// the filtered status should be left out this time
+	indexLength := tl.PostIndexLength(context.Background())
+	suite.Equal(unfilteredLength-1, indexLength)
+}
+
 func (suite *IndexTestSuite) TestIndexBehindLowID() {
 	// index 10 behind the lowest status ID possible
 	err := suite.timeline.IndexBehind(context.Background(), "00000000000000000000000000", true, 10)
@@ -67,6 +67,23 @@ type Manager interface {
 	// HomeTimeline returns limit n amount of entries from the home timeline of the given account ID, in descending chronological order.
 	// If maxID is provided, it will return entries from that maxID onwards, inclusive.
 	HomeTimeline(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int, local bool) ([]*apimodel.Status, error)
+	// IngestIntoList takes one status and indexes it into the timeline of the given list ID.
+	//
+	// It should already be established before calling this function that the status/post actually belongs in the list timeline!
+	IngestIntoList(ctx context.Context, status *gtsmodel.Status, listID string) (bool, error)
+	// IngestAndPrepareIntoList takes one status and indexes it into the timeline of the given list ID, and then immediately prepares it for serving.
+	//
+	// It should already be established before calling this function that the status/post actually belongs in the list timeline!
+	IngestAndPrepareIntoList(ctx context.Context, status *gtsmodel.Status, listID string) (bool, error)
+	// ListTimeline returns limit n amount of entries from the timeline of the given list ID, in descending chronological order.
+	// If maxID is provided, it will return entries from that maxID onwards, inclusive.
+	ListTimeline(ctx context.Context, listID string, maxID string, sinceID string, minID string, limit int) ([]*apimodel.Status, error)
+	// WipeListTimeline drops the timeline of the given list ID, if it exists, so that it will be freshly indexed the next time it's requested.
+	// This should be called whenever the list itself or its membership changes.
+	WipeListTimeline(ctx context.Context, listID string)
+	// WipeTimelinesForAccountID drops the home timeline and any list timelines owned by the given account ID, so that they will be freshly
+	// indexed the next time they're requested. This should be called whenever something changes which statuses the account should see, eg., filters.
+	WipeTimelinesForAccountID(ctx context.Context, timelineAccountID string)
 	// GetIndexedLength returns the amount of posts/statuses that have been *indexed* for the given account ID.
 	GetIndexedLength(ctx context.Context, timelineAccountID string) int
 	// GetDesiredIndexLength returns the amount of posts that we, ideally, index for each user.
@@ -79,6 +96,8 @@ type Manager interface {
 	Remove(ctx context.Context, timelineAccountID string, statusID string) (int, error)
 	// WipeStatusFromAllTimelines removes one status from the index and prepared posts of all timelines
 	WipeStatusFromAllTimelines(ctx context.Context, statusID string) error
+	// RefreshStatusInAllTimelines re-prepares one status, and any boosts of it, in the prepared posts of all timelines
+	RefreshStatusInAllTimelines(ctx context.Context, statusID string) error
 	// WipeStatusesFromAccountID removes all statuses by the given accountID from the timelineAccountID's timelines.
 	WipeStatusesFromAccountID(ctx context.Context, timelineAccountID string, accountID string) error
 }
@@ -87,6 +106,7 @@ type Manager interface {
 func NewManager(db db.DB, tc typeutils.TypeConverter, config *config.Config, log *logrus.Logger) Manager {
 	return &manager{
 		accountTimelines: sync.Map{},
+		listTimelines:    sync.Map{},
 		db:               db,
 		tc:               tc,
 		config:           config,
@@ -96,6 +116,7 @@ func NewManager(db db.DB, tc typeutils.TypeConverter, config *config.Config, log
 
 type manager struct {
 	accountTimelines sync.Map
+	listTimelines    sync.Map
 	db               db.DB
 	tc               typeutils.TypeConverter
 	config           *config.Config
@@ -134,6 +155,38 @@ func (m *manager) IngestAndPrepare(ctx context.Context, status *gtsmodel.Status,
 	return t.IndexAndPrepareOne(ctx, status.CreatedAt, status.ID, status.BoostOfID, status.AccountID, status.BoostOfAccountID)
 }
 
+func (m *manager) IngestIntoList(ctx context.Context, status *gtsmodel.Status, listID string) (bool, error) {
+	l := m.log.WithFields(logrus.Fields{
+		"func":     "IngestIntoList",
+		"listID":   listID,
+		"statusID": status.ID,
+	})
+
+	t, err := m.getOrCreateListTimeline(ctx, listID)
+	if err != nil {
+		return false, err
+	}
+
+	l.Trace("ingesting status")
+	return t.IndexOne(ctx, status.CreatedAt, status.ID, status.BoostOfID, status.AccountID, status.BoostOfAccountID)
+}
+
+func (m *manager) IngestAndPrepareIntoList(ctx context.Context, status *gtsmodel.Status, listID string) (bool, error) {
+	l := m.log.WithFields(logrus.Fields{
+		"func":     "IngestAndPrepareIntoList",
+		"listID":   listID,
+		"statusID": status.ID,
+	})
+
+	t, err := m.getOrCreateListTimeline(ctx, listID)
+	if err != nil {
+		return false, err
+	}
+
+	l.Trace("ingesting status")
+	return t.IndexAndPrepareOne(ctx, status.CreatedAt, status.ID, status.BoostOfID, status.AccountID, status.BoostOfAccountID)
+}
+
 func (m *manager) Remove(ctx context.Context, timelineAccountID string, statusID string) (int, error) {
 	l := m.log.WithFields(logrus.Fields{
 		"func":              "Remove",
@@ -168,6 +221,45 @@ func (m *manager) HomeTimeline(ctx context.Context, timelineAccountID string, ma
 	return statuses, nil
 }
 
+func (m *manager) ListTimeline(ctx context.Context, listID string, maxID string, sinceID string, minID string, limit int) ([]*apimodel.Status, error) {
+	l := m.log.WithFields(logrus.Fields{
+		"func":   "ListTimelineGet",
+		"listID": listID,
+	})
+
+	t, err := m.getOrCreateListTimeline(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := t.Get(ctx, limit, maxID, sinceID, minID, true)
+	if err != nil {
+		l.Errorf("error getting statuses: %s", err)
+	}
+	return statuses, nil
+}
+
+func (m *manager) WipeListTimeline(ctx context.Context, listID string) {
+	m.listTimelines.Delete(listID)
+}
+
+func (m *manager) WipeTimelinesForAccountID(ctx context.Context, timelineAccountID string) {
+	m.accountTimelines.Delete(timelineAccountID)
+
+	m.listTimelines.Range(func(k interface{}, i interface{}) bool {
+		lt, ok := i.(Timeline)
+		if !ok {
+			panic("couldn't parse entry as Timeline, this should never happen so panic")
+		}
+
+		if lt.AccountID() == timelineAccountID {
+			m.listTimelines.Delete(k)
+		}
+
+		return true
+	})
+}
+
 func (m *manager) GetIndexedLength(ctx context.Context, timelineAccountID string) int {
 	t, err := m.getOrCreateTimeline(ctx, timelineAccountID)
 	if err != nil {
@@ -201,7 +293,7 @@ func (m *manager) PrepareXFromTop(ctx context.Context, timelineAccountID string,
 
 func (m *manager) WipeStatusFromAllTimelines(ctx context.Context, statusID string) error {
 	errors := []string{}
-	m.accountTimelines.Range(func(k interface{}, i interface{}) bool {
+	wipe := func(k interface{}, i interface{}) bool {
 		t, ok := i.(Timeline)
 		if !ok {
 			panic("couldn't parse entry as Timeline, this should never happen so panic")
@@ -212,7 +304,9 @@ func (m *manager) WipeStatusFromAllTimelines(ctx context.Context, statusID strin
 		}
 
 		return true
-	})
+	}
+	m.accountTimelines.Range(wipe)
+	m.listTimelines.Range(wipe)
 
 	var err error
 	if len(errors) > 0 {
@@ -222,13 +316,57 @@ func (m *manager) WipeStatusFromAllTimelines(ctx context.Context, statusID strin
 	return err
 }
 
+func (m *manager) RefreshStatusInAllTimelines(ctx context.Context, statusID string) error {
+	errors := []string{}
+	refresh := func(k interface{}, i interface{}) bool {
+		t, ok := i.(Timeline)
+		if !ok {
+			panic("couldn't parse entry as Timeline, this should never happen so panic")
+		}
+
+		if _, err := t.Refresh(ctx, statusID); err != nil {
+			errors = append(errors, err.Error())
+		}
+
+		return true
+	}
+	m.accountTimelines.Range(refresh)
+	m.listTimelines.Range(refresh)
+
+	var err error
+	if len(errors) > 0 {
+		err = fmt.Errorf("one or more errors refreshing status %s in all timelines: %s", statusID, strings.Join(errors, ";"))
+	}
+
+	return err
+}
+
 func (m *manager) WipeStatusesFromAccountID(ctx context.Context, timelineAccountID string, accountID string) error {
 	t, err := m.getOrCreateTimeline(ctx, timelineAccountID)
 	if err != nil {
 		return err
 	}
 
-	_, err = t.RemoveAllBy(ctx, accountID)
+	if _, err := t.RemoveAllBy(ctx, accountID); err != nil {
+		return err
+	}
+
+	// also remove the statuses from any list timelines owned by timelineAccountID
+	m.listTimelines.Range(func(k interface{}, i interface{}) bool {
+		lt, ok := i.(Timeline)
+		if !ok {
+			panic("couldn't parse entry as Timeline, this should never happen so panic")
+		}
+
+		if lt.AccountID() == timelineAccountID {
+			if _, err = lt.RemoveAllBy(ctx, accountID); err != nil {
+				return false
+			}
+		}
+
+		return true
+	})
+
 	return err
 }
 
@@ -251,3 +389,27 @@ func (m *manager) getOrCreateTimeline(ctx context.Context, timelineAccountID str
 
 	return t, nil
 }
+
+func (m *manager) getOrCreateListTimeline(ctx context.Context, listID string) (Timeline, error) {
+	var t Timeline
+	i, ok := m.listTimelines.Load(listID)
+	if !ok {
+		list, err := m.db.GetListByID(ctx, listID)
+		if err != nil {
+			return nil, err
+		}
+
+		t, err = NewListTimeline(ctx, list, m.db, m.tc, m.log)
+		if err != nil {
+			return nil, err
+		}
+		m.listTimelines.Store(listID, t)
+	} else {
+		t, ok = i.(Timeline)
+		if !ok {
+			panic("couldn't parse entry as Timeline, this should never happen so panic")
+		}
+	}
+
+	return t, nil
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/visibility"
 )
 
 func (t *timeline) prepareNextQuery(ctx context.Context, amount int, maxID string, sinceID string, minID string) error {
@@ -79,6 +80,11 @@ func (t *timeline) PrepareBehind(ctx context.Context, statusID string, amount in
 	var preparing bool
 	t.Lock()
 	defer t.Unlock()
+
+	filtered, err := t.statusFilterer(ctx)
+	if err != nil {
+		return fmt.Errorf("PrepareBehind: error getting filters: %s", err)
+	}
 prepareloop:
 	for e := t.postIndex.data.Front(); e != nil; e = e.Next() {
 		entry, ok := e.Value.(*postIndexEntry)
@@ -94,7 +100,7 @@ prepareloop:
 		}
 
 		if preparing {
-			if err := t.prepare(ctx, entry.statusID); err != nil {
+			if err := t.prepare(ctx, entry.statusID, filtered); err != nil {
 				// there's been an error
 				if err != db.ErrNoEntries {
 					// it's a real error
@@ -129,6 +135,11 @@ func (t *timeline) PrepareBefore(ctx context.Context, statusID string, include b
 		return nil
 	}
 
+	filtered, err := t.statusFilterer(ctx)
+	if err != nil {
+		return fmt.Errorf("PrepareBefore: error getting filters: %s", err)
+	}
+
 	var prepared int
 	var preparing bool
 prepareloop:
@@ -149,7 +160,7 @@ prepareloop:
 		}
 
 		if preparing {
-			if err := t.prepare(ctx, entry.statusID); err != nil {
+			if err := t.prepare(ctx, entry.statusID, filtered); err != nil {
 				// there's been an error
 				if err != db.ErrNoEntries {
 					// it's a real error
@@ -192,6 +203,12 @@ func (t *timeline) PrepareFromTop(ctx context.Context, amount int) error {
 	l.Trace("entering prepareloop")
 	t.Lock()
 	defer t.Unlock()
+
+	filtered, err := t.statusFilterer(ctx)
+	if err != nil {
+		return fmt.Errorf("PrepareFromTop: error getting filters: %s", err)
+	}
+
 	var prepared int
 prepareloop:
 	for e := t.postIndex.data.Front(); e != nil; e = e.Next() {
@@ -204,7 +221,7 @@ prepareloop:
 			return errors.New("PrepareFromTop: could not parse e as a postIndexEntry")
 		}
 
-		if err := t.prepare(ctx, entry.statusID); err != nil {
+		if err := t.prepare(ctx, entry.statusID, filtered); err != nil {
 			// there's been an error
 			if err != db.ErrNoEntries {
 				// it's a real error
@@ -226,7 +243,22 @@ prepareloop:
 	return nil
 }
 
-func (t *timeline) prepare(ctx context.Context, statusID string) error {
+// statusFilterer returns a function for checking statuses against the home filters of the timeline owner, so that
+// a batch of statuses can be prepared without fetching and compiling the filters again for each status.
+func (t *timeline) statusFilterer(ctx context.Context) (visibility.StatusFilterFunc, error) {
+	// if the account pointer hasn't been set on this timeline already, set it lazily here
+	if t.account == nil {
+		timelineOwnerAccount := &gtsmodel.Account{}
+		if err := t.db.GetByID(ctx, t.accountID, timelineOwnerAccount); err != nil {
+			return nil, err
+		}
+		t.account = timelineOwnerAccount
+	}
+
+	return t.visFilter.StatusFilterer(ctx, t.account, gtsmodel.FilterContextHome)
+}
+
+func (t *timeline) prepare(ctx context.Context, statusID string, filtered visibility.StatusFilterFunc) error {
 
 	// start by getting the status out of the database according to its indexed ID
 	gtsStatus := &gtsmodel.Status{}
@@ -234,13 +266,14 @@ func (t *timeline) prepare(ctx context.Context, statusID string) error {
 		return err
 	}
 
-	// if the account pointer hasn't been set on this timeline already, set it lazily here
-	if t.account == nil {
-		timelineOwnerAccount := &gtsmodel.Account{}
-		if err := t.db.GetByID(ctx, t.accountID, timelineOwnerAccount); err != nil {
-			return err
-		}
-		t.account = timelineOwnerAccount
+	// filters may have been created or changed since the status was indexed, so check them again here
+	isFiltered, err := filtered(ctx, gtsStatus)
+	if err != nil {
+		return err
+	}
+	if isFiltered {
+		// don't prepare this status, it should be dropped from the timeline
+		return nil
 	}
 
 	// serialize the status (or, at least, convert it to a form that's ready to be serialized)
@@ -0,0 +1,70 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package timeline
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func (t *timeline) Refresh(ctx context.Context, statusID string) (int, error) {
+	l := t.log.WithFields(logrus.Fields{
+		"func":            "Refresh",
+		"accountTimeline": t.accountID,
+		"statusID":        statusID,
+	})
+	t.Lock()
+	defer t.Unlock()
+	var refreshed int
+
+	if t.preparedPosts == nil || t.preparedPosts.data == nil {
+		return refreshed, nil
+	}
+
+	// re-prepare any entries of the status itself, or of boosts of it
+	for e := t.preparedPosts.data.Front(); e != nil; e = e.Next() {
+		entry, ok := e.Value.(*preparedPostsEntry)
+		if !ok {
+			return refreshed, errors.New("Refresh: could not parse e as a preparedPostsEntry")
+		}
+		if entry.statusID != statusID && entry.boostOfID != statusID {
+			continue
+		}
+
+		gtsStatus := &gtsmodel.Status{}
+		if err := t.db.GetByID(ctx, entry.statusID, gtsStatus); err != nil {
+			return refreshed, err
+		}
+
+		apiModelStatus, err := t.tc.StatusToMasto(ctx, gtsStatus, t.account)
+		if err != nil {
+			return refreshed, err
+		}
+
+		l.Debug("found status in preparedPosts")
+		entry.prepared = apiModelStatus
+		refreshed = refreshed + 1
+	}
+
+	l.Debugf("refreshed %d entries", refreshed)
+	return refreshed, nil
+}
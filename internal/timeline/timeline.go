@@ -106,6 +106,8 @@ type Timeline interface {
 
 	// ActualPostIndexLength returns the actual length of the post index at this point in time.
 	PostIndexLength(ctx context.Context) int
+	// AccountID returns the id of the account that owns this timeline.
+	AccountID() string
 
 	/*
 		UTILITY FUNCTIONS
@@ -123,23 +125,62 @@ type Timeline interface {
 	//
 	// The returned int indicates the amount of entries that were removed.
 	RemoveAllBy(ctx context.Context, accountID string) (int, error)
+	// Refresh re-prepares any prepared entries of the given status, or of boosts of it, so that they reflect
+	// the current state of the status in the database. This should be called after a status has been edited.
+	//
+	// The returned int indicates the amount of entries that were refreshed.
+	Refresh(ctx context.Context, statusID string) (int, error)
 }
 
+// GrabFunction is used by a timeline to fetch more statuses from the database for indexing,
+// in descending order of when they were created (newest first).
+type GrabFunction func(ctx context.Context, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.Status, error)
+
+// FilterFunction is used by a timeline to check whether a grabbed status should be indexed into the timeline of the given account.
+// The filtered function holds the timeline owner's keyword filters, prepared once for each batch of grabbed statuses.
+type FilterFunction func(ctx context.Context, status *gtsmodel.Status, timelineAccount *gtsmodel.Account, filtered visibility.StatusFilterFunc) (bool, error)
+
 // timeline fulfils the Timeline interface
 type timeline struct {
 	postIndex     *postIndex
 	preparedPosts *preparedPosts
 	accountID     string
 	account       *gtsmodel.Account
+	grab          GrabFunction
+	filter        FilterFunction
+	visFilter     visibility.Filter
 	db            db.DB
-	filter        visibility.Filter
 	tc            typeutils.TypeConverter
 	log           *logrus.Logger
 	sync.Mutex
 }
 
-// NewTimeline returns a new Timeline for the given account ID
+// NewTimeline returns a new home Timeline for the given account ID
 func NewTimeline(ctx context.Context, accountID string, db db.DB, typeConverter typeutils.TypeConverter, log *logrus.Logger) (Timeline, error) {
+	grab := func(ctx context.Context, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.Status, error) {
+		return db.GetHomeTimeline(ctx, accountID, maxID, sinceID, minID, limit, false)
+	}
+
+	visFilter := visibility.NewFilter(db, log)
+	return newTimeline(ctx, accountID, grab, visFilter.StatusHometimelineable, visFilter, db, typeConverter, log)
+}
+
+// NewListTimeline returns a new Timeline for the given list, as seen by the account that owns the list.
+func NewListTimeline(ctx context.Context, list *gtsmodel.List, db db.DB, typeConverter typeutils.TypeConverter, log *logrus.Logger) (Timeline, error) {
+	listID := list.ID
+	grab := func(ctx context.Context, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.Status, error) {
+		return db.GetListTimeline(ctx, listID, maxID, sinceID, minID, limit)
+	}
+
+	visFilter := visibility.NewFilter(db, log)
+	filter := func(ctx context.Context, status *gtsmodel.Status, timelineAccount *gtsmodel.Account, filtered visibility.StatusFilterFunc) (bool, error) {
+		return visFilter.StatusListTimelineable(ctx, status, list, timelineAccount, filtered)
+	}
+
+	return newTimeline(ctx, list.AccountID, grab, filter, visFilter, db, typeConverter, log)
+}
+
+func newTimeline(ctx context.Context, accountID string, grab GrabFunction, filter FilterFunction, visFilter visibility.Filter, db db.DB, typeConverter typeutils.TypeConverter, log *logrus.Logger) (Timeline, error) {
 	timelineOwnerAccount := &gtsmodel.Account{}
 	if err := db.GetByID(ctx, accountID, timelineOwnerAccount); err != nil {
 		return nil, err
@@ -150,8 +191,10 @@ func NewTimeline(ctx context.Context, accountID string, db db.DB, typeConverter
 		preparedPosts: &preparedPosts{},
 		accountID:     accountID,
 		account:       timelineOwnerAccount,
+		grab:          grab,
+		filter:        filter,
+		visFilter:     visFilter,
 		db:            db,
-		filter:        visibility.NewFilter(db, log),
 		tc:            typeConverter,
 		log:           log,
 	}, nil
@@ -161,6 +204,10 @@ func (t *timeline) Reset() error {
 	return nil
 }
 
+func (t *timeline) AccountID() string {
+	return t.accountID
+}
+
 func (t *timeline) PostIndexLength(ctx context.Context) int {
 	if t.postIndex == nil || t.postIndex.data == nil {
 		return 0
@@ -158,7 +158,20 @@ func (c *converter) ASRepresentationToAccount(ctx context.Context, accountable a
 
 	// TODO: FeaturedTagsURI
 
-	// TODO: alsoKnownAs
+	// alsoKnownAs
+	// we need these to check whether a Move to this account is legit
+	for _, alias := range ap.ExtractAlsoKnownAs(accountable) {
+		acct.AlsoKnownAs = append(acct.AlsoKnownAs, alias.String())
+	}
+
+	// movedTo
+	// we only take this if we already know the account that's been moved to;
+	// otherwise we'll find out about it properly when the Move activity comes in
+	if movedTo, err := ap.ExtractMovedTo(accountable); err == nil {
+		if movedToAccount, err := c.db.GetAccountByURI(ctx, movedTo.String()); err == nil {
+			acct.MovedToAccountID = movedToAccount.ID
+		}
+	}
 
 	// publicKey
 	pkey, pkeyURL, err := ap.ExtractPublicKeyForOwner(accountable, uri)
@@ -204,7 +217,7 @@ func (c *converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 		status.Attachments = attachments
 	}
 
-	// hashtags to dereference later on
+	// hashtags to store once we know who the status owner is
 	if hashtags, err := ap.ExtractHashtags(statusable); err != nil {
 		l.Infof("ASStatusToStatus: error extracting status hashtags: %s", err)
 	} else {
@@ -241,6 +254,11 @@ func (c *converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 		status.UpdatedAt = published
 	}
 
+	// has this status been edited since it was published?
+	if updated, err := ap.ExtractUpdated(statusable); err == nil && updated.After(status.CreatedAt) {
+		status.EditedAt = updated
+	}
+
 	// which account posted this status?
 	// if we don't know the account yet we can dereference it later
 	attributedTo, err := ap.ExtractAttributedTo(statusable)
@@ -257,6 +275,11 @@ func (c *converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 	status.AccountURI = statusOwner.URI
 	status.Account = statusOwner
 
+	// now we know the status owner we can store the hashtags it uses, so the status turns up in tag timelines
+	if err := c.populateStatusTags(ctx, status); err != nil {
+		l.Infof("ASStatusToStatus: error populating status hashtags: %s", err)
+	}
+
 	// check if there's a post that this is a reply to
 	inReplyToURI := ap.ExtractInReplyToURI(statusable)
 	if inReplyToURI != nil {
@@ -335,6 +358,16 @@ func (c *converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 	// ActivityStreamsType
 	status.ActivityStreamsType = statusable.GetTypeName()
 
+	// poll, if this status is a Question
+	if pollable, ok := statusable.(ap.Pollable); ok {
+		if poll, err := ap.ExtractPoll(pollable); err != nil {
+			l.Infof("ASStatusToStatus: error extracting status poll: %s", err)
+		} else {
+			poll.AccountID = statusOwner.ID
+			status.Poll = poll
+		}
+	}
+
 	return status, nil
 }
 
@@ -489,6 +522,84 @@ func (c *converter) ASBlockToBlock(ctx context.Context, blockable ap.Blockable)
 	}, nil
 }
 
+func (c *converter) ASFlagToReport(ctx context.Context, flaggable ap.Flaggable) (*gtsmodel.Report, error) {
+	idProp := flaggable.GetJSONLDId()
+	if idProp == nil || !idProp.IsIRI() {
+		return nil, errors.New("ASFlagToReport: no id property set on flag, or was not an iri")
+	}
+	uri := idProp.GetIRI().String()
+
+	origin, err := ap.ExtractActor(flaggable)
+	if err != nil {
+		return nil, errors.New("ASFlagToReport: error extracting actor property from flag")
+	}
+	originAccount, err := c.db.GetAccountByURI(ctx, origin.String())
+	if err != nil {
+		return nil, fmt.Errorf("ASFlagToReport: error extracting account with uri %s from the database: %s", origin.String(), err)
+	}
+
+	objects, err := ap.ExtractObjects(flaggable)
+	if err != nil {
+		return nil, errors.New("ASFlagToReport: error extracting object property from flag")
+	}
+
+	// the objects of a flag are the reported account and any of its statuses, in no guaranteed order
+	var targetAccount *gtsmodel.Account
+	statuses := []*gtsmodel.Status{}
+	for _, object := range objects {
+		if object.Host != c.config.Host {
+			// we can only act on reports about our own accounts and statuses
+			continue
+		}
+
+		if a, err := c.db.GetAccountByURI(ctx, object.String()); err == nil {
+			if targetAccount == nil {
+				targetAccount = a
+			}
+			continue
+		} else if err != db.ErrNoEntries {
+			return nil, fmt.Errorf("ASFlagToReport: error getting account with uri %s from the database: %s", object.String(), err)
+		}
+
+		if s, err := c.db.GetStatusByURI(ctx, object.String()); err == nil {
+			statuses = append(statuses, s)
+		} else if err != db.ErrNoEntries {
+			return nil, fmt.Errorf("ASFlagToReport: error getting status with uri %s from the database: %s", object.String(), err)
+		}
+	}
+
+	if targetAccount == nil {
+		// some implementations only send the reported statuses, so fall back to the author of the first one
+		if len(statuses) == 0 {
+			return nil, errors.New("ASFlagToReport: flag didn't reference any local account or status")
+		}
+		targetAccount, err = c.db.GetAccountByID(ctx, statuses[0].AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("ASFlagToReport: error getting account with id %s from the database: %s", statuses[0].AccountID, err)
+		}
+	}
+
+	statusIDs := []string{}
+	for _, s := range statuses {
+		if s.AccountID == targetAccount.ID {
+			statusIDs = append(statusIDs, s.ID)
+		}
+	}
+
+	// the comment is optional
+	comment, _ := ap.ExtractContent(flaggable)
+
+	return &gtsmodel.Report{
+		URI:             uri,
+		AccountID:       originAccount.ID,
+		Account:         originAccount,
+		TargetAccountID: targetAccount.ID,
+		TargetAccount:   targetAccount,
+		StatusIDs:       statusIDs,
+		Comment:         comment,
+	}, nil
+}
+
 func (c *converter) ASAnnounceToStatus(ctx context.Context, announceable ap.Announceable) (*gtsmodel.Status, bool, error) {
 	status := &gtsmodel.Status{}
 	isNew := true
@@ -600,3 +711,35 @@ func isFollowers(ccs []*url.URL, followersURI string) bool {
 	}
 	return false
 }
+
+// populateStatusTags swaps the hashtags extracted from a remote status for the corresponding tags in our database,
+// creating any tags that we haven't seen before, and sets the status' tag IDs accordingly.
+func (c *converter) populateStatusTags(ctx context.Context, status *gtsmodel.Status) error {
+	names := []string{}
+	seen := map[string]bool{}
+	for _, t := range status.Tags {
+		name := strings.ToLower(t.Name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	tags, err := c.db.TagStringsToTags(ctx, names, status.AccountID, status.ID)
+	if err != nil {
+		return fmt.Errorf("error converting hashtags to tags: %s", err)
+	}
+
+	tagIDs := []string{}
+	for _, t := range tags {
+		if err := c.db.Put(ctx, t); err != nil && err != db.ErrAlreadyExists {
+			return fmt.Errorf("error putting tag %s in db: %s", t.Name, err)
+		}
+		tagIDs = append(tagIDs, t.ID)
+	}
+
+	status.Tags = tags
+	status.TagIDs = tagIDs
+	return nil
+}
@@ -23,6 +23,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/go-fed/activity/streams"
 	"github.com/stretchr/testify/assert"
@@ -330,6 +331,40 @@ const (
 		  "url": "https://files.mastodon.social/accounts/headers/000/000/001/original/c91b871f294ea63e.png"
 		}
 	  }`
+	questionAsActivityJson = `{
+		"@context": "https://www.w3.org/ns/activitystreams",
+		"id": "http://fossbros-anonymous.io/users/foss_satan/statuses/106221634728637552",
+		"type": "Question",
+		"attributedTo": "http://fossbros-anonymous.io/users/foss_satan",
+		"content": "<p>which is the better editor?</p>",
+		"published": "2021-09-20T10:40:37Z",
+		"to": [
+		  "https://www.w3.org/ns/activitystreams#Public"
+		],
+		"cc": [
+		  "http://fossbros-anonymous.io/users/foss_satan/followers"
+		],
+		"endTime": "2021-09-21T10:40:37Z",
+		"votersCount": 7,
+		"oneOf": [
+		  {
+			"type": "Note",
+			"name": "vim",
+			"replies": {
+			  "type": "Collection",
+			  "totalItems": 4
+			}
+		  },
+		  {
+			"type": "Note",
+			"name": "emacs",
+			"replies": {
+			  "type": "Collection",
+			  "totalItems": 3
+			}
+		  }
+		]
+	  }`
 )
 
 func (suite *ASToInternalTestSuite) SetupSuite() {
@@ -382,6 +417,34 @@ func (suite *ASToInternalTestSuite) TestParseGargron() {
 
 	fmt.Printf("%+v", acct)
 	// TODO: write assertions here, rn we're just eyeballing the output
+
+	suite.Equal([]string{"https://tooting.ai/users/Gargron"}, acct.AlsoKnownAs)
+	suite.Empty(acct.MovedToAccountID)
+}
+
+func (suite *ASToInternalTestSuite) TestParseQuestion() {
+	m := make(map[string]interface{})
+	err := json.Unmarshal([]byte(questionAsActivityJson), &m)
+	assert.NoError(suite.T(), err)
+
+	t, err := streams.ToType(context.Background(), m)
+	assert.NoError(suite.T(), err)
+
+	rep, ok := t.(ap.Statusable)
+	assert.True(suite.T(), ok)
+
+	status, err := suite.typeconverter.ASStatusToStatus(context.Background(), rep)
+	assert.NoError(suite.T(), err)
+
+	suite.Equal(suite.accounts["remote_account_1"].ID, status.AccountID)
+	suite.NotNil(status.Poll)
+	suite.Equal(suite.accounts["remote_account_1"].ID, status.Poll.AccountID)
+	suite.Equal([]string{"vim", "emacs"}, status.Poll.Options)
+	suite.Equal([]int{4, 3}, status.Poll.Votes)
+	suite.Equal(7, status.Poll.VotersCount)
+	suite.False(status.Poll.Multiple)
+	suite.Equal("2021-09-21T10:40:37Z", status.Poll.ExpiresAt.Format(time.RFC3339))
+	suite.True(status.Poll.Expired())
 }
 
 func (suite *ASToInternalTestSuite) TearDownTest() {
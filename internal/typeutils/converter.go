@@ -34,6 +34,8 @@ import (
 
 const (
 	asPublicURI = "https://www.w3.org/ns/activitystreams#Public"
+	// maxEndorsementsShown is the maximum number of endorsed accounts shown on the representation of an account.
+	maxEndorsementsShown = 40
 )
 
 // TypeConverter is an interface for the common action of converting between apimodule (frontend, serializable) models,
@@ -58,6 +60,10 @@ type TypeConverter interface {
 	// something goes wrong. The returned account will be a bare minimum representation of the account. This function should be used
 	// when someone wants to view an account they've blocked.
 	AccountToMastoBlocked(ctx context.Context, account *gtsmodel.Account) (*model.Account, error)
+	// AccountToMastoMuted takes a db model account and the mute targeting it, and returns the public mastotype representation
+	// of the account, with the expiry time of the mute filled in. This function should be used when someone wants to view
+	// an account they've muted.
+	AccountToMastoMuted(ctx context.Context, account *gtsmodel.Account, mute *gtsmodel.UserMute) (*model.Account, error)
 	// AppToMastoSensitive takes a db model application as a param, and returns a populated mastotype application, or an error
 	// if something goes wrong. The returned application should be ready to serialize on an API level, and may have sensitive fields
 	// (such as client id and client secret), so serve it only to an authorized user who should have permission to see it.
@@ -74,6 +80,8 @@ type TypeConverter interface {
 	EmojiToMasto(ctx context.Context, e *gtsmodel.Emoji) (model.Emoji, error)
 	// TagToMasto converts a gts model tag into its mastodon (frontend) representation for serialization on the API.
 	TagToMasto(ctx context.Context, t *gtsmodel.Tag) (model.Tag, error)
+	// FeaturedTagToMasto converts a gts model featured tag into its mastodon (frontend) representation for serialization on the API.
+	FeaturedTagToMasto(ctx context.Context, ft *gtsmodel.FeaturedTag) (*model.FeaturedTag, error)
 	// StatusToMasto converts a gts model status into its mastodon (frontend) representation for serialization on the API.
 	//
 	// Requesting account can be nil.
@@ -88,6 +96,35 @@ type TypeConverter interface {
 	NotificationToMasto(ctx context.Context, n *gtsmodel.Notification) (*model.Notification, error)
 	// DomainBlockTomasto converts a gts model domin block into a mastodon domain block, for serving at /api/v1/admin/domain_blocks
 	DomainBlockToMasto(ctx context.Context, b *gtsmodel.DomainBlock, export bool) (*model.DomainBlock, error)
+	// ListToMasto converts a gts model list into a mastodon list, for serving at /api/v1/lists
+	ListToMasto(ctx context.Context, l *gtsmodel.List) (*model.List, error)
+	// FilterToMasto converts a gts model filter into a mastodon filter, for serving at /api/v1/filters
+	FilterToMasto(ctx context.Context, f *gtsmodel.Filter) (*model.Filter, error)
+	// PollToMasto converts a gts model poll into a mastodon poll, for serving at /api/v1/polls and attaching to statuses.
+	//
+	// requestingAccount is optional; if set, the voted and own_votes fields will be populated for that account.
+	PollToMasto(ctx context.Context, p *gtsmodel.Poll, requestingAccount *gtsmodel.Account) (*model.Poll, error)
+	// ScheduledStatusToMasto converts a gts model scheduled status into its mastodon representation, for serving at /api/v1/scheduled_statuses
+	ScheduledStatusToMasto(ctx context.Context, s *gtsmodel.ScheduledStatus) (*model.ScheduledStatus, error)
+	// StatusEditToMasto converts a gts model status edit into its mastodon representation, for serving at /api/v1/statuses/:id/history
+	StatusEditToMasto(ctx context.Context, e *gtsmodel.StatusEdit) (*model.StatusEdit, error)
+	// ConversationToMasto converts a gts model conversation into its mastodon representation, for serving at /api/v1/conversations.
+	//
+	// requestingAccount should be the account that owns the conversation.
+	ConversationToMasto(ctx context.Context, c *gtsmodel.Conversation, requestingAccount *gtsmodel.Account) (*model.Conversation, error)
+	// ReportToMasto converts a gts model report into its mastodon representation, for serving to the account that made the report.
+	ReportToMasto(ctx context.Context, r *gtsmodel.Report) (*model.Report, error)
+	// ReportToAdminMasto converts a gts model report into its mastodon admin representation, for serving at /api/v1/admin/reports.
+	//
+	// requestingAccount should be the admin account that's looking at the report.
+	ReportToAdminMasto(ctx context.Context, r *gtsmodel.Report, requestingAccount *gtsmodel.Account) (*model.AdminReport, error)
+	// AccountImportToMasto converts a gts model account import into its mastodon representation, for serving at /api/v1/accounts/imports.
+	AccountImportToMasto(ctx context.Context, i *gtsmodel.AccountImport) (*model.AccountImport, error)
+	// AccountArchiveToMasto converts a gts model account archive into its mastodon representation, for serving at /api/v1/accounts/archives.
+	AccountArchiveToMasto(ctx context.Context, a *gtsmodel.AccountArchive) (*model.AccountArchive, error)
+	// PreferencesToMasto converts the preferences stored on a local account and its user into their mastodon representation,
+	// for serving at /api/v1/preferences.
+	PreferencesToMasto(ctx context.Context, a *gtsmodel.Account, u *gtsmodel.User) (*model.Preferences, error)
 
 	/*
 		FRONTEND (mastodon) MODEL TO INTERNAL (gts) MODEL
@@ -116,6 +153,11 @@ type TypeConverter interface {
 	ASLikeToFave(ctx context.Context, likeable ap.Likeable) (*gtsmodel.StatusFave, error)
 	// ASBlockToBlock converts a remote activity streams 'block' representation into a gts model block.
 	ASBlockToBlock(ctx context.Context, blockable ap.Blockable) (*gtsmodel.Block, error)
+	// ASFlagToReport converts a remote activitystreams 'flag' representation into a gts model report.
+	//
+	// The report will target the first local account found in the flag's objects, and include any statuses
+	// of that account that are also referenced. The returned report has no database ID set yet.
+	ASFlagToReport(ctx context.Context, flaggable ap.Flaggable) (*gtsmodel.Report, error)
 	// ASAnnounceToStatus converts an activitystreams 'announce' into a status.
 	//
 	// The returned bool indicates whether this status is new (true) or not new (false).
@@ -144,10 +186,20 @@ type TypeConverter interface {
 	AccountToASMinimal(ctx context.Context, a *gtsmodel.Account) (vocab.ActivityStreamsPerson, error)
 	// StatusToAS converts a gts model status into an activity streams note, suitable for federation
 	StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.ActivityStreamsNote, error)
+	// StatusToASQuestion converts a gts model status with a poll attached into an activity streams question, suitable for federation
+	StatusToASQuestion(ctx context.Context, s *gtsmodel.Status) (vocab.ActivityStreamsQuestion, error)
+	// StatusesToASFeaturedCollection converts a slice of pinned gts model statuses into an activitystreams featured collection, with the statuses embedded.
+	StatusesToASFeaturedCollection(ctx context.Context, featuredCollectionURI string, statuses []*gtsmodel.Status) (vocab.ActivityStreamsOrderedCollection, error)
+	// PollVoteToASCreates converts a gts model poll vote into one activity streams create per chosen option, suitable for federation to the poll owner
+	PollVoteToASCreates(ctx context.Context, vote *gtsmodel.PollVote) ([]vocab.ActivityStreamsCreate, error)
 	// FollowToASFollow converts a gts model Follow into an activity streams Follow, suitable for federation
 	FollowToAS(ctx context.Context, f *gtsmodel.Follow, originAccount *gtsmodel.Account, targetAccount *gtsmodel.Account) (vocab.ActivityStreamsFollow, error)
 	// MentionToAS converts a gts model mention into an activity streams Mention, suitable for federation
 	MentionToAS(ctx context.Context, m *gtsmodel.Mention) (vocab.ActivityStreamsMention, error)
+	// TagToAS converts a gts model tag into an activity streams Hashtag, suitable for federation.
+	//
+	// Hashtag isn't in the go-fed vocabulary, so the tag is returned as a Link with its type set to Hashtag.
+	TagToAS(ctx context.Context, t *gtsmodel.Tag) (vocab.ActivityStreamsLink, error)
 	// AttachmentToAS converts a gts model media attachment into an activity streams Attachment, suitable for federation
 	AttachmentToAS(ctx context.Context, a *gtsmodel.MediaAttachment) (vocab.ActivityStreamsDocument, error)
 	// FaveToAS converts a gts model status fave into an activityStreams LIKE, suitable for federation.
@@ -156,6 +208,14 @@ type TypeConverter interface {
 	BoostToAS(ctx context.Context, boostWrapperStatus *gtsmodel.Status, boostingAccount *gtsmodel.Account, boostedAccount *gtsmodel.Account) (vocab.ActivityStreamsAnnounce, error)
 	// BlockToAS converts a gts model block into an activityStreams BLOCK, suitable for federation.
 	BlockToAS(ctx context.Context, block *gtsmodel.Block) (vocab.ActivityStreamsBlock, error)
+	// AccountToASDelete creates an activityStreams DELETE of the given account by itself, suitable for federating the deletion of a local account.
+	AccountToASDelete(ctx context.Context, a *gtsmodel.Account) (vocab.ActivityStreamsDelete, error)
+	// AccountToASMove creates an activityStreams MOVE of the origin account to the target account, suitable for federating to the followers of origin.
+	AccountToASMove(ctx context.Context, origin *gtsmodel.Account, target *gtsmodel.Account) (vocab.ActivityStreamsMove, error)
+	// ReportToASFlag converts a gts model report into an activityStreams FLAG, suitable for federation.
+	//
+	// The flag is sent by this instance's actor rather than the reporting account, so that the reporter isn't revealed.
+	ReportToASFlag(ctx context.Context, report *gtsmodel.Report) (vocab.ActivityStreamsFlag, error)
 	// StatusToASRepliesCollection converts a gts model status into an activityStreams REPLIES collection.
 	StatusToASRepliesCollection(ctx context.Context, status *gtsmodel.Status, onlyOtherAccounts bool) (vocab.ActivityStreamsCollection, error)
 	// StatusURIsToASRepliesPage returns a collection page with appropriate next/part of pagination.
@@ -175,6 +235,14 @@ type TypeConverter interface {
 
 	// WrapPersonInUpdate
 	WrapPersonInUpdate(person vocab.ActivityStreamsPerson, originAccount *gtsmodel.Account) (vocab.ActivityStreamsUpdate, error)
+	// WrapQuestionInCreate wraps a question in a create activity, since unlike notes, questions don't get wrapped automatically
+	WrapQuestionInCreate(question vocab.ActivityStreamsQuestion, originAccount *gtsmodel.Account) (vocab.ActivityStreamsCreate, error)
+	// WrapNoteInCreate wraps a note in a create activity, for when the create needs to be serialized rather than sent through the federating actor
+	WrapNoteInCreate(note vocab.ActivityStreamsNote, originAccount *gtsmodel.Account) (vocab.ActivityStreamsCreate, error)
+	// WrapQuestionInUpdate wraps a question in an update activity, for federating changed vote counts or closing of a poll
+	WrapQuestionInUpdate(question vocab.ActivityStreamsQuestion, originAccount *gtsmodel.Account) (vocab.ActivityStreamsUpdate, error)
+	// WrapNoteInUpdate wraps a note in an update activity, for federating edits of a status
+	WrapNoteInUpdate(note vocab.ActivityStreamsNote, originAccount *gtsmodel.Account) (vocab.ActivityStreamsUpdate, error)
 }
 
 type converter struct {
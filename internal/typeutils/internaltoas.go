@@ -27,6 +27,8 @@ import (
 
 	"github.com/go-fed/activity/streams"
 	"github.com/go-fed/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
@@ -105,7 +107,29 @@ func (c *converter) AccountToAS(ctx context.Context, a *gtsmodel.Account) (vocab
 	person.SetTootFeatured(featuredProp)
 
 	// featuredTags
-	// NOT IMPLEMENTED
+	// Set as Hashtags on the tag property instead, see below.
+
+	// endorsements
+	// Accounts featured on this profile, as a collection of account URIs.
+	// This isn't in the go-fed vocabulary, so it's set as an unknown property.
+	endorsements, err := c.db.GetAccountEndorsements(ctx, a.ID, "", "", 0)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, fmt.Errorf("AccountToAS: error getting endorsements: %s", err)
+	}
+	if len(endorsements) != 0 {
+		endorsedURIs := []string{}
+		for _, e := range endorsements {
+			if e.TargetAccount == nil {
+				continue
+			}
+			endorsedURIs = append(endorsedURIs, e.TargetAccount.URI)
+		}
+		person.GetUnknownProperties()[ap.PropertyEndorsements] = map[string]interface{}{
+			"type":       "Collection",
+			"totalItems": len(endorsedURIs),
+			"items":      endorsedURIs,
+		}
+	}
 
 	// preferredUsername
 	// Used for Webfinger lookup. Must be unique on the domain, and must correspond to a Webfinger acct: URI.
@@ -158,7 +182,21 @@ func (c *converter) AccountToAS(ctx context.Context, a *gtsmodel.Account) (vocab
 
 	// alsoKnownAs
 	// Required for Move activity.
-	// TODO: NOT IMPLEMENTED **YET** -- this needs to be added as an activitypub extension to https://github.com/go-fed/activity, see https://github.com/go-fed/activity/tree/master/astool
+	// This isn't in the go-fed vocabulary yet, so we have to set it as an unknown property.
+	if len(a.AlsoKnownAs) != 0 {
+		person.GetUnknownProperties()[ap.PropertyAlsoKnownAs] = a.AlsoKnownAs
+	}
+
+	// movedTo
+	// Set when this account has moved to another account.
+	// Like alsoKnownAs, this isn't in the go-fed vocabulary so it's set as an unknown property.
+	if a.MovedToAccountID != "" {
+		movedToAccount, err := c.db.GetAccountByID(ctx, a.MovedToAccountID)
+		if err != nil {
+			return nil, fmt.Errorf("AccountToAS: error getting moved to account %s: %s", a.MovedToAccountID, err)
+		}
+		person.GetUnknownProperties()[ap.PropertyMovedTo] = movedToAccount.URI
+	}
 
 	// publicKey
 	// Required for signatures.
@@ -201,7 +239,26 @@ func (c *converter) AccountToAS(ctx context.Context, a *gtsmodel.Account) (vocab
 	person.SetW3IDSecurityV1PublicKey(publicKeyProp)
 
 	// tag
+	// Hashtags featured on this profile.
 	// TODO: Any tags used in the summary of this profile
+	featuredTags, err := c.db.GetFeaturedTagsForAccountID(ctx, a.ID)
+	if err != nil {
+		return nil, fmt.Errorf("AccountToAS: error getting featured tags: %s", err)
+	}
+	if len(featuredTags) != 0 {
+		tagProp := streams.NewActivityStreamsTagProperty()
+		for _, ft := range featuredTags {
+			if ft.Tag == nil {
+				continue
+			}
+			asHashtag, err := c.TagToAS(ctx, ft.Tag)
+			if err != nil {
+				return nil, fmt.Errorf("AccountToAS: error converting featured tag %s: %s", ft.ID, err)
+			}
+			tagProp.AppendActivityStreamsLink(asHashtag)
+		}
+		person.SetActivityStreamsTag(tagProp)
+	}
 
 	// attachment
 	// Used for profile fields.
@@ -412,6 +469,13 @@ func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.A
 	publishedProp.Set(s.CreatedAt)
 	status.SetActivityStreamsPublished(publishedProp)
 
+	// updated, if the status has been edited
+	if !s.EditedAt.IsZero() {
+		updatedProp := streams.NewActivityStreamsUpdatedProperty()
+		updatedProp.Set(s.EditedAt)
+		status.SetActivityStreamsUpdated(updatedProp)
+	}
+
 	// url
 	if s.URL != "" {
 		sURL, err := url.Parse(s.URL)
@@ -552,6 +616,94 @@ func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.A
 	return status, nil
 }
 
+func (c *converter) StatusToASQuestion(ctx context.Context, s *gtsmodel.Status) (vocab.ActivityStreamsQuestion, error) {
+	// make sure we have the poll on hand, we always fetch it fresh so that the vote counts are up to date
+	if s.PollID == "" {
+		return nil, fmt.Errorf("StatusToASQuestion: status %s has no poll", s.ID)
+	}
+	poll, err := c.db.GetPollByID(ctx, s.PollID)
+	if err != nil {
+		return nil, fmt.Errorf("StatusToASQuestion: error retrieving poll from db: %s", err)
+	}
+	s.Poll = poll
+
+	// a question is just a note with a poll attached, so build the note first and take its properties
+	note, err := c.StatusToAS(ctx, s)
+	if err != nil {
+		return nil, fmt.Errorf("StatusToASQuestion: error converting status to note: %s", err)
+	}
+
+	question := streams.NewActivityStreamsQuestion()
+	question.SetJSONLDId(note.GetJSONLDId())
+	question.SetActivityStreamsSummary(note.GetActivityStreamsSummary())
+	question.SetActivityStreamsInReplyTo(note.GetActivityStreamsInReplyTo())
+	question.SetActivityStreamsPublished(note.GetActivityStreamsPublished())
+	question.SetActivityStreamsUpdated(note.GetActivityStreamsUpdated())
+	question.SetActivityStreamsUrl(note.GetActivityStreamsUrl())
+	question.SetActivityStreamsAttributedTo(note.GetActivityStreamsAttributedTo())
+	question.SetActivityStreamsTag(note.GetActivityStreamsTag())
+	question.SetActivityStreamsTo(note.GetActivityStreamsTo())
+	question.SetActivityStreamsCc(note.GetActivityStreamsCc())
+	question.SetActivityStreamsContent(note.GetActivityStreamsContent())
+	question.SetActivityStreamsAttachment(note.GetActivityStreamsAttachment())
+	question.SetActivityStreamsReplies(note.GetActivityStreamsReplies())
+
+	// options -- each option is a note with a name, and the votes it's received as the totalItems of its replies
+	oneOfProp := streams.NewActivityStreamsOneOfProperty()
+	anyOfProp := streams.NewActivityStreamsAnyOfProperty()
+	for i, option := range poll.Options {
+		optionNote := streams.NewActivityStreamsNote()
+
+		nameProp := streams.NewActivityStreamsNameProperty()
+		nameProp.AppendXMLSchemaString(option)
+		optionNote.SetActivityStreamsName(nameProp)
+
+		votes := 0
+		if i < len(poll.Votes) {
+			votes = poll.Votes[i]
+		}
+		totalItemsProp := streams.NewActivityStreamsTotalItemsProperty()
+		totalItemsProp.Set(votes)
+		optionReplies := streams.NewActivityStreamsCollection()
+		optionReplies.SetActivityStreamsTotalItems(totalItemsProp)
+		optionRepliesProp := streams.NewActivityStreamsRepliesProperty()
+		optionRepliesProp.SetActivityStreamsCollection(optionReplies)
+		optionNote.SetActivityStreamsReplies(optionRepliesProp)
+
+		if poll.Multiple {
+			anyOfProp.AppendActivityStreamsNote(optionNote)
+		} else {
+			oneOfProp.AppendActivityStreamsNote(optionNote)
+		}
+	}
+	if poll.Multiple {
+		question.SetActivityStreamsAnyOf(anyOfProp)
+	} else {
+		question.SetActivityStreamsOneOf(oneOfProp)
+	}
+
+	// endTime
+	if !poll.ExpiresAt.IsZero() {
+		endTimeProp := streams.NewActivityStreamsEndTimeProperty()
+		endTimeProp.Set(poll.ExpiresAt)
+		question.SetActivityStreamsEndTime(endTimeProp)
+	}
+
+	// closed
+	if !poll.ClosedAt.IsZero() {
+		closedProp := streams.NewActivityStreamsClosedProperty()
+		closedProp.AppendXMLSchemaDateTime(poll.ClosedAt)
+		question.SetActivityStreamsClosed(closedProp)
+	}
+
+	// votersCount
+	votersCountProp := streams.NewTootVotersCountProperty()
+	votersCountProp.Set(poll.VotersCount)
+	question.SetTootVotersCount(votersCountProp)
+
+	return question, nil
+}
+
 func (c *converter) FollowToAS(ctx context.Context, f *gtsmodel.Follow, originAccount *gtsmodel.Account, targetAccount *gtsmodel.Account) (vocab.ActivityStreamsFollow, error) {
 	// parse out the various URIs we need for this
 	// origin account (who's doing the follow)
@@ -598,6 +750,32 @@ func (c *converter) FollowToAS(ctx context.Context, f *gtsmodel.Follow, originAc
 	return follow, nil
 }
 
+func (c *converter) TagToAS(ctx context.Context, t *gtsmodel.Tag) (vocab.ActivityStreamsLink, error) {
+	hashtag := streams.NewActivityStreamsLink()
+
+	// type
+	// go-fed would set this to Link, so override it with Hashtag
+	typeProp := streams.NewJSONLDTypeProperty()
+	typeProp.AppendXMLSchemaString("Hashtag")
+	hashtag.SetJSONLDType(typeProp)
+
+	// href -- this should be the URL of the tag
+	hrefURI, err := url.Parse(t.URL)
+	if err != nil {
+		return nil, fmt.Errorf("TagToAS: error parsing url %s: %s", t.URL, err)
+	}
+	hrefProp := streams.NewActivityStreamsHrefProperty()
+	hrefProp.SetIRI(hrefURI)
+	hashtag.SetActivityStreamsHref(hrefProp)
+
+	// name -- this should be the name of the tag with the hash symbol
+	nameProp := streams.NewActivityStreamsNameProperty()
+	nameProp.AppendXMLSchemaString("#" + t.Name)
+	hashtag.SetActivityStreamsName(nameProp)
+
+	return hashtag, nil
+}
+
 func (c *converter) MentionToAS(ctx context.Context, m *gtsmodel.Mention) (vocab.ActivityStreamsMention, error) {
 	if m.TargetAccount == nil {
 		a, err := c.db.GetAccountByID(ctx, m.TargetAccountID)
@@ -893,6 +1071,98 @@ func (c *converter) BlockToAS(ctx context.Context, b *gtsmodel.Block) (vocab.Act
 	return block, nil
 }
 
+/*
+	the goal is to end up with something like this:
+
+	{
+		"@context": "https://www.w3.org/ns/activitystreams",
+		"id": "https://example.org/users/example.org/reports/01FG9C441MCTW3R2W117V2PQK3",
+		"type": "Flag",
+		"actor": "https://example.org/users/example.org",
+		"content": "this account posts spam",
+		"object": [
+			"https://another.instance/users/spammer",
+			"https://another.instance/users/spammer/statuses/01FG9C441MCTW3R2W117V2PQK4"
+		],
+		"to": "https://another.instance/users/spammer"
+	}
+*/
+func (c *converter) ReportToASFlag(ctx context.Context, r *gtsmodel.Report) (vocab.ActivityStreamsFlag, error) {
+	if r.TargetAccount == nil {
+		a, err := c.db.GetAccountByID(ctx, r.TargetAccountID)
+		if err != nil {
+			return nil, fmt.Errorf("ReportToASFlag: error getting report target account from database: %s", err)
+		}
+		r.TargetAccount = a
+	}
+
+	// flags are sent by the instance actor so that the reporting account stays anonymous
+	instanceAccount, err := c.db.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("ReportToASFlag: error getting instance account from database: %s", err)
+	}
+
+	// create the flag
+	flag := streams.NewActivityStreamsFlag()
+
+	// set the actor property to the instance account's URI
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorIRI, err := url.Parse(instanceAccount.URI)
+	if err != nil {
+		return nil, fmt.Errorf("ReportToASFlag: error parsing uri %s: %s", instanceAccount.URI, err)
+	}
+	actorProp.AppendIRI(actorIRI)
+	flag.SetActivityStreamsActor(actorProp)
+
+	// set the ID property to the report's URI
+	idProp := streams.NewJSONLDIdProperty()
+	idIRI, err := url.Parse(r.URI)
+	if err != nil {
+		return nil, fmt.Errorf("ReportToASFlag: error parsing uri %s: %s", r.URI, err)
+	}
+	idProp.Set(idIRI)
+	flag.SetJSONLDId(idProp)
+
+	// set the content property to the report comment
+	if r.Comment != "" {
+		contentProp := streams.NewActivityStreamsContentProperty()
+		contentProp.AppendXMLSchemaString(r.Comment)
+		flag.SetActivityStreamsContent(contentProp)
+	}
+
+	// set the object property to the target account's URI, followed by the URIs of any reported statuses
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	targetIRI, err := url.Parse(r.TargetAccount.URI)
+	if err != nil {
+		return nil, fmt.Errorf("ReportToASFlag: error parsing uri %s: %s", r.TargetAccount.URI, err)
+	}
+	objectProp.AppendIRI(targetIRI)
+
+	for _, statusID := range r.StatusIDs {
+		s, err := c.db.GetStatusByID(ctx, statusID)
+		if err != nil {
+			if err == db.ErrNoEntries {
+				// the status has been deleted since it was reported
+				continue
+			}
+			return nil, fmt.Errorf("ReportToASFlag: error getting status %s from database: %s", statusID, err)
+		}
+		statusIRI, err := url.Parse(s.URI)
+		if err != nil {
+			return nil, fmt.Errorf("ReportToASFlag: error parsing uri %s: %s", s.URI, err)
+		}
+		objectProp.AppendIRI(statusIRI)
+	}
+	flag.SetActivityStreamsObject(objectProp)
+
+	// set the TO property to the target account's IRI, so the flag is delivered to their instance
+	toProp := streams.NewActivityStreamsToProperty()
+	toProp.AppendIRI(targetIRI)
+	flag.SetActivityStreamsTo(toProp)
+
+	return flag, nil
+}
+
 /*
 	the goal is to end up with something like this:
 
@@ -1027,3 +1297,266 @@ func (c *converter) StatusURIsToASRepliesPage(ctx context.Context, status *gtsmo
 
 	return page, nil
 }
+
+/*
+	the goal is to end up with something like this:
+	{
+		"@context": "https://www.w3.org/ns/activitystreams",
+		"id": "https://example.org/users/whatever/collections/featured",
+		"type": "OrderedCollection",
+		"totalItems": 1,
+		"orderedItems": [
+			{
+				"id": "https://example.org/users/whatever/statuses/01FCNEXAGAKPEX1J7VJRPJP490",
+				"type": "Note",
+				...
+			}
+		]
+	}
+*/
+func (c *converter) StatusesToASFeaturedCollection(ctx context.Context, featuredCollectionURI string, statuses []*gtsmodel.Status) (vocab.ActivityStreamsOrderedCollection, error) {
+	collectionIDURI, err := url.Parse(featuredCollectionURI)
+	if err != nil {
+		return nil, fmt.Errorf("StatusesToASFeaturedCollection: error parsing url %s: %s", featuredCollectionURI, err)
+	}
+
+	collection := streams.NewActivityStreamsOrderedCollection()
+
+	// collection.id
+	collectionIDProp := streams.NewJSONLDIdProperty()
+	collectionIDProp.SetIRI(collectionIDURI)
+	collection.SetJSONLDId(collectionIDProp)
+
+	// collection.totalItems
+	totalItemsProp := streams.NewActivityStreamsTotalItemsProperty()
+	totalItemsProp.Set(len(statuses))
+	collection.SetActivityStreamsTotalItems(totalItemsProp)
+
+	// collection.orderedItems
+	orderedItemsProp := streams.NewActivityStreamsOrderedItemsProperty()
+	for _, s := range statuses {
+		// statuses with a poll attached are represented as a Question rather than a Note
+		if s.PollID != "" {
+			question, err := c.StatusToASQuestion(ctx, s)
+			if err != nil {
+				return nil, fmt.Errorf("StatusesToASFeaturedCollection: error converting status %s: %s", s.ID, err)
+			}
+			orderedItemsProp.AppendActivityStreamsQuestion(question)
+			continue
+		}
+
+		note, err := c.StatusToAS(ctx, s)
+		if err != nil {
+			return nil, fmt.Errorf("StatusesToASFeaturedCollection: error converting status %s: %s", s.ID, err)
+		}
+		orderedItemsProp.AppendActivityStreamsNote(note)
+	}
+	collection.SetActivityStreamsOrderedItems(orderedItemsProp)
+
+	return collection, nil
+}
+
+func (c *converter) PollVoteToASCreates(ctx context.Context, vote *gtsmodel.PollVote) ([]vocab.ActivityStreamsCreate, error) {
+	// ensure prerequisites here before we get stuck in
+	if vote.Account == nil {
+		a, err := c.db.GetAccountByID(ctx, vote.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("PollVoteToASCreates: error retrieving voting account from db: %s", err)
+		}
+		vote.Account = a
+	}
+
+	if vote.Poll == nil {
+		p, err := c.db.GetPollByID(ctx, vote.PollID)
+		if err != nil {
+			return nil, fmt.Errorf("PollVoteToASCreates: error retrieving poll from db: %s", err)
+		}
+		vote.Poll = p
+	}
+
+	pollStatus, err := c.db.GetStatusByID(ctx, vote.Poll.StatusID)
+	if err != nil {
+		return nil, fmt.Errorf("PollVoteToASCreates: error retrieving poll status from db: %s", err)
+	}
+
+	if pollStatus.Account == nil {
+		a, err := c.db.GetAccountByID(ctx, pollStatus.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("PollVoteToASCreates: error retrieving poll owner account from db: %s", err)
+		}
+		pollStatus.Account = a
+	}
+
+	// parse out some URIs we need here
+	voterURI, err := url.Parse(vote.Account.URI)
+	if err != nil {
+		return nil, fmt.Errorf("PollVoteToASCreates: error parsing url %s: %s", vote.Account.URI, err)
+	}
+
+	pollOwnerURI, err := url.Parse(pollStatus.Account.URI)
+	if err != nil {
+		return nil, fmt.Errorf("PollVoteToASCreates: error parsing url %s: %s", pollStatus.Account.URI, err)
+	}
+
+	pollStatusURI, err := url.Parse(pollStatus.URI)
+	if err != nil {
+		return nil, fmt.Errorf("PollVoteToASCreates: error parsing url %s: %s", pollStatus.URI, err)
+	}
+
+	// each choice is sent as a separate Note, named after the chosen option and addressed only to the poll owner
+	creates := []vocab.ActivityStreamsCreate{}
+	for _, choice := range vote.Choices {
+		if choice < 0 || choice >= len(vote.Poll.Options) {
+			return nil, fmt.Errorf("PollVoteToASCreates: choice %d is out of range for poll %s", choice, vote.Poll.ID)
+		}
+
+		noteURI, err := url.Parse(fmt.Sprintf("%s/%d", vote.URI, choice))
+		if err != nil {
+			return nil, fmt.Errorf("PollVoteToASCreates: error parsing vote uri: %s", err)
+		}
+
+		createURI, err := url.Parse(fmt.Sprintf("%s/%d/activity", vote.URI, choice))
+		if err != nil {
+			return nil, fmt.Errorf("PollVoteToASCreates: error parsing vote activity uri: %s", err)
+		}
+
+		note := streams.NewActivityStreamsNote()
+
+		noteIDProp := streams.NewJSONLDIdProperty()
+		noteIDProp.SetIRI(noteURI)
+		note.SetJSONLDId(noteIDProp)
+
+		nameProp := streams.NewActivityStreamsNameProperty()
+		nameProp.AppendXMLSchemaString(vote.Poll.Options[choice])
+		note.SetActivityStreamsName(nameProp)
+
+		attributedToProp := streams.NewActivityStreamsAttributedToProperty()
+		attributedToProp.AppendIRI(voterURI)
+		note.SetActivityStreamsAttributedTo(attributedToProp)
+
+		inReplyToProp := streams.NewActivityStreamsInReplyToProperty()
+		inReplyToProp.AppendIRI(pollStatusURI)
+		note.SetActivityStreamsInReplyTo(inReplyToProp)
+
+		noteToProp := streams.NewActivityStreamsToProperty()
+		noteToProp.AppendIRI(pollOwnerURI)
+		note.SetActivityStreamsTo(noteToProp)
+
+		create := streams.NewActivityStreamsCreate()
+
+		createIDProp := streams.NewJSONLDIdProperty()
+		createIDProp.SetIRI(createURI)
+		create.SetJSONLDId(createIDProp)
+
+		actorProp := streams.NewActivityStreamsActorProperty()
+		actorProp.AppendIRI(voterURI)
+		create.SetActivityStreamsActor(actorProp)
+
+		publishedProp := streams.NewActivityStreamsPublishedProperty()
+		publishedProp.Set(vote.CreatedAt)
+		create.SetActivityStreamsPublished(publishedProp)
+
+		createToProp := streams.NewActivityStreamsToProperty()
+		createToProp.AppendIRI(pollOwnerURI)
+		create.SetActivityStreamsTo(createToProp)
+
+		objectProp := streams.NewActivityStreamsObjectProperty()
+		objectProp.AppendActivityStreamsNote(note)
+		create.SetActivityStreamsObject(objectProp)
+
+		creates = append(creates, create)
+	}
+
+	return creates, nil
+}
+
+func (c *converter) AccountToASDelete(ctx context.Context, a *gtsmodel.Account) (vocab.ActivityStreamsDelete, error) {
+	accountURI, err := url.Parse(a.URI)
+	if err != nil {
+		return nil, fmt.Errorf("AccountToASDelete: error parsing url %s: %s", a.URI, err)
+	}
+
+	// create the delete
+	delete := streams.NewActivityStreamsDelete()
+
+	// set the id, based on the account uri since an account can only be deleted once
+	idString := a.URI + "#delete"
+	idURI, err := url.Parse(idString)
+	if err != nil {
+		return nil, fmt.Errorf("AccountToASDelete: error parsing url %s: %s", idString, err)
+	}
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.SetIRI(idURI)
+	delete.SetJSONLDId(idProp)
+
+	// the account deletes itself, so it's both the actor and the object
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(accountURI)
+	delete.SetActivityStreamsActor(actorProp)
+
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendIRI(accountURI)
+	delete.SetActivityStreamsObject(objectProp)
+
+	// to should be public
+	toURI, err := url.Parse(asPublicURI)
+	if err != nil {
+		return nil, fmt.Errorf("AccountToASDelete: error parsing url %s: %s", asPublicURI, err)
+	}
+	toProp := streams.NewActivityStreamsToProperty()
+	toProp.AppendIRI(toURI)
+	delete.SetActivityStreamsTo(toProp)
+
+	return delete, nil
+}
+
+func (c *converter) AccountToASMove(ctx context.Context, origin *gtsmodel.Account, target *gtsmodel.Account) (vocab.ActivityStreamsMove, error) {
+	originURI, err := url.Parse(origin.URI)
+	if err != nil {
+		return nil, fmt.Errorf("AccountToASMove: error parsing url %s: %s", origin.URI, err)
+	}
+
+	targetURI, err := url.Parse(target.URI)
+	if err != nil {
+		return nil, fmt.Errorf("AccountToASMove: error parsing url %s: %s", target.URI, err)
+	}
+
+	followersURI, err := url.Parse(origin.FollowersURI)
+	if err != nil {
+		return nil, fmt.Errorf("AccountToASMove: error parsing url %s: %s", origin.FollowersURI, err)
+	}
+
+	// create the move
+	move := streams.NewActivityStreamsMove()
+
+	// set the id, based on the origin account uri and the id of the account being moved to
+	idString := origin.URI + "#moves/" + target.ID
+	idURI, err := url.Parse(idString)
+	if err != nil {
+		return nil, fmt.Errorf("AccountToASMove: error parsing url %s: %s", idString, err)
+	}
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.SetIRI(idURI)
+	move.SetJSONLDId(idProp)
+
+	// the origin account moves itself, so it's both the actor and the object
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(originURI)
+	move.SetActivityStreamsActor(actorProp)
+
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendIRI(originURI)
+	move.SetActivityStreamsObject(objectProp)
+
+	// the target is the account being moved to
+	targetProp := streams.NewActivityStreamsTargetProperty()
+	targetProp.AppendIRI(targetURI)
+	move.SetActivityStreamsTarget(targetProp)
+
+	// the move is addressed to the followers of the origin account, since they're the ones who need to act on it
+	toProp := streams.NewActivityStreamsToProperty()
+	toProp.AppendIRI(followersURI)
+	move.SetActivityStreamsTo(toProp)
+
+	return move, nil
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
@@ -72,6 +73,65 @@ func (suite *InternalToASTestSuite) TestAccountToAS() {
 	// TODO: write assertions here, rn we're just eyeballing the output
 }
 
+func (suite *InternalToASTestSuite) TestAccountToASWithAliasesAndMove() {
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.accounts["local_account_2"]
+	testAccount.AlsoKnownAs = []string{"http://fossbros-anonymous.io/users/foss_satan"}
+	testAccount.MovedToAccountID = suite.accounts["local_account_1"].ID
+
+	asPerson, err := suite.typeconverter.AccountToAS(context.Background(), testAccount)
+	suite.NoError(err)
+
+	ser, err := streams.Serialize(asPerson)
+	suite.NoError(err)
+
+	bytes, err := json.Marshal(ser)
+	suite.NoError(err)
+
+	m := make(map[string]interface{})
+	suite.NoError(json.Unmarshal(bytes, &m))
+	suite.Equal([]interface{}{"http://fossbros-anonymous.io/users/foss_satan"}, m["alsoKnownAs"])
+	suite.Equal(suite.accounts["local_account_1"].URI, m["movedTo"])
+}
+
+func (suite *InternalToASTestSuite) TestAccountToASMove() {
+	origin := suite.accounts["local_account_2"]
+	target := suite.accounts["local_account_1"]
+
+	move, err := suite.typeconverter.AccountToASMove(context.Background(), origin, target)
+	suite.NoError(err)
+
+	ser, err := streams.Serialize(move)
+	suite.NoError(err)
+
+	suite.Equal("Move", ser["type"])
+	suite.Equal(origin.URI, ser["actor"])
+	suite.Equal(origin.URI, ser["object"])
+	suite.Equal(target.URI, ser["target"])
+	suite.Equal(origin.FollowersURI, ser["to"])
+}
+
+func (suite *InternalToASTestSuite) TestStatusesToASFeaturedCollection() {
+	testAccount := suite.accounts["local_account_1"]
+	testStatus := testrig.NewTestStatuses()["local_account_1_status_1"]
+
+	collection, err := suite.typeconverter.StatusesToASFeaturedCollection(context.Background(), testAccount.FeaturedCollectionURI, []*gtsmodel.Status{testStatus})
+	suite.NoError(err)
+
+	ser, err := streams.Serialize(collection)
+	suite.NoError(err)
+
+	suite.Equal(testAccount.FeaturedCollectionURI, ser["id"])
+	suite.Equal("OrderedCollection", ser["type"])
+	suite.EqualValues(1, ser["totalItems"])
+
+	// a single item is serialized as an object rather than an array
+	item, ok := ser["orderedItems"].(map[string]interface{})
+	suite.True(ok)
+	suite.Equal(testStatus.URI, item["id"])
+	suite.Equal("Note", item["type"])
+}
+
 func TestInternalToASTestSuite(t *testing.T) {
 	suite.Run(t, new(InternalToASTestSuite))
 }
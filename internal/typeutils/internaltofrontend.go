@@ -57,6 +57,7 @@ func (c *converter) AccountToMastoSensitive(ctx context.Context, a *gtsmodel.Acc
 		Note:                a.Note,
 		Fields:              mastoAccount.Fields,
 		FollowRequestsCount: frc,
+		AlsoKnownAs:         a.AlsoKnownAs,
 	}
 
 	return mastoAccount, nil
@@ -75,6 +76,41 @@ func (c *converter) AccountToMastoPublic(ctx context.Context, a *gtsmodel.Accoun
 		}
 	}
 
+	accountFrontend, err := c.accountToMastoPublic(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+
+	// get the accounts endorsed by this account; we don't include the endorsements of the endorsed accounts
+	// themselves, since that would be pretty noisy, and accounts that endorse each other would never finish
+	endorsements := []model.Account{}
+	gtsEndorsements, err := c.db.GetAccountEndorsements(ctx, a.ID, "", "", maxEndorsementsShown)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, fmt.Errorf("error getting endorsements: %s", err)
+	}
+	for _, e := range gtsEndorsements {
+		if e.TargetAccount == nil || !e.TargetAccount.SuspendedAt.IsZero() {
+			continue
+		}
+		endorsed, err := c.accountToMastoPublic(ctx, e.TargetAccount)
+		if err != nil {
+			return nil, fmt.Errorf("error converting endorsed account %s: %s", e.TargetAccountID, err)
+		}
+		endorsements = append(endorsements, *endorsed)
+	}
+	accountFrontend.Endorsements = endorsements
+
+	// put the account in our cache in case we need it again soon
+	if err := c.frontendCache.Store(a.ID, accountFrontend); err != nil {
+		return nil, err
+	}
+
+	return accountFrontend, nil
+}
+
+// accountToMastoPublic does the work of converting the given account into its public frontend representation,
+// without the endorsements of the account, and without using the frontend cache.
+func (c *converter) accountToMastoPublic(ctx context.Context, a *gtsmodel.Account) (*model.Account, error) {
 	// count followers
 	followersCount, err := c.db.CountAccountFollowedBy(ctx, a.ID, false)
 	if err != nil {
@@ -161,6 +197,33 @@ func (c *converter) AccountToMastoPublic(ctx context.Context, a *gtsmodel.Accoun
 		suspended = true
 	}
 
+	// check if the account has moved somewhere else
+	var moved *model.Account
+	if a.MovedToAccountID != "" {
+		movedToAccount, err := c.db.GetAccountByID(ctx, a.MovedToAccountID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting moved to account %s: %s", a.MovedToAccountID, err)
+		}
+		moved, err = c.AccountToMastoPublic(ctx, movedToAccount)
+		if err != nil {
+			return nil, fmt.Errorf("error converting moved to account %s: %s", a.MovedToAccountID, err)
+		}
+	}
+
+	// get the hashtags featured by this account
+	featuredTags := []model.FeaturedTag{}
+	gtsFeaturedTags, err := c.db.GetFeaturedTagsForAccountID(ctx, a.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting featured tags: %s", err)
+	}
+	for _, ft := range gtsFeaturedTags {
+		featuredTag, err := c.FeaturedTagToMasto(ctx, ft)
+		if err != nil {
+			return nil, fmt.Errorf("error converting featured tag %s: %s", ft.ID, err)
+		}
+		featuredTags = append(featuredTags, *featuredTag)
+	}
+
 	accountFrontend := &model.Account{
 		ID:             a.ID,
 		Username:       a.Username,
@@ -182,11 +245,8 @@ func (c *converter) AccountToMastoPublic(ctx context.Context, a *gtsmodel.Accoun
 		Emojis:         emojis, // TODO: implement this
 		Fields:         fields,
 		Suspended:      suspended,
-	}
-
-	// put the account in our cache in case we need it again soon
-	if err := c.frontendCache.Store(a.ID, accountFrontend); err != nil {
-		return nil, err
+		Moved:          moved,
+		FeaturedTags:   featuredTags,
 	}
 
 	return accountFrontend, nil
@@ -219,6 +279,19 @@ func (c *converter) AccountToMastoBlocked(ctx context.Context, a *gtsmodel.Accou
 	}, nil
 }
 
+func (c *converter) AccountToMastoMuted(ctx context.Context, a *gtsmodel.Account, m *gtsmodel.UserMute) (*model.Account, error) {
+	mastoAccount, err := c.AccountToMastoPublic(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+
+	if !m.ExpiresAt.IsZero() {
+		mastoAccount.MuteExpiresAt = m.ExpiresAt.Format(time.RFC3339)
+	}
+
+	return mastoAccount, nil
+}
+
 func (c *converter) AppToMastoSensitive(ctx context.Context, a *gtsmodel.Application) (*model.Application, error) {
 	return &model.Application{
 		ID:           a.ID,
@@ -315,6 +388,35 @@ func (c *converter) TagToMasto(ctx context.Context, t *gtsmodel.Tag) (model.Tag,
 	}, nil
 }
 
+func (c *converter) FeaturedTagToMasto(ctx context.Context, ft *gtsmodel.FeaturedTag) (*model.FeaturedTag, error) {
+	if ft.Tag == nil {
+		tag := &gtsmodel.Tag{}
+		if err := c.db.GetByID(ctx, ft.TagID, tag); err != nil {
+			return nil, fmt.Errorf("FeaturedTagToMasto: error getting tag %s: %s", ft.TagID, err)
+		}
+		ft.Tag = tag
+	}
+
+	statusesCount, lastStatusAt, err := c.db.CountAccountTagStatuses(ctx, ft.AccountID, ft.TagID)
+	if err != nil {
+		return nil, fmt.Errorf("FeaturedTagToMasto: error counting statuses: %s", err)
+	}
+
+	featuredTag := &model.FeaturedTag{
+		ID:            ft.ID,
+		Name:          ft.Tag.Name,
+		URL:           ft.Tag.URL,
+		StatusesCount: statusesCount,
+	}
+
+	if !lastStatusAt.IsZero() {
+		l := lastStatusAt.Format(time.RFC3339)
+		featuredTag.LastStatusAt = &l
+	}
+
+	return featuredTag, nil
+}
+
 func (c *converter) StatusToMasto(ctx context.Context, s *gtsmodel.Status, requestingAccount *gtsmodel.Account) (*model.Status, error) {
 	l := c.log
 
@@ -508,7 +610,19 @@ func (c *converter) StatusToMasto(ctx context.Context, s *gtsmodel.Status, reque
 	}
 
 	var mastoCard *model.Card
+
 	var mastoPoll *model.Poll
+	if s.PollID != "" {
+		// always fetch the poll fresh from the db so that the vote counts are up to date
+		poll, err := c.db.GetPollByID(ctx, s.PollID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting poll with id %s: %s", s.PollID, err)
+		}
+		mastoPoll, err = c.PollToMasto(ctx, poll, requestingAccount)
+		if err != nil {
+			return nil, fmt.Errorf("error converting poll with id %s: %s", s.PollID, err)
+		}
+	}
 
 	statusInteractions := &statusInteractions{}
 	si, err := c.interactionsWithStatusForAccount(ctx, s, requestingAccount)
@@ -543,10 +657,14 @@ func (c *converter) StatusToMasto(ctx context.Context, s *gtsmodel.Status, reque
 		Tags:               mastoTags,
 		Emojis:             mastoEmojis,
 		Card:               mastoCard, // TODO: implement cards
-		Poll:               mastoPoll, // TODO: implement polls
+		Poll:               mastoPoll,
 		Text:               s.Text,
 	}
 
+	if !s.EditedAt.IsZero() {
+		apiStatus.EditedAt = s.EditedAt.Format(time.RFC3339)
+	}
+
 	if mastoRebloggedStatus != nil {
 		apiStatus.Reblog = &model.StatusReblogged{Status: mastoRebloggedStatus}
 	}
@@ -730,3 +848,436 @@ func (c *converter) DomainBlockToMasto(ctx context.Context, b *gtsmodel.DomainBl
 
 	return domainBlock, nil
 }
+
+func (c *converter) ListToMasto(ctx context.Context, l *gtsmodel.List) (*model.List, error) {
+	return &model.List{
+		ID:            l.ID,
+		Title:         l.Title,
+		RepliesPolicy: string(l.RepliesPolicy),
+	}, nil
+}
+
+func (c *converter) FilterToMasto(ctx context.Context, f *gtsmodel.Filter) (*model.Filter, error) {
+	filterContext := []string{}
+	if f.ContextHome {
+		filterContext = append(filterContext, string(gtsmodel.FilterContextHome))
+	}
+	if f.ContextNotifications {
+		filterContext = append(filterContext, string(gtsmodel.FilterContextNotifications))
+	}
+	if f.ContextPublic {
+		filterContext = append(filterContext, string(gtsmodel.FilterContextPublic))
+	}
+	if f.ContextThread {
+		filterContext = append(filterContext, string(gtsmodel.FilterContextThread))
+	}
+
+	var expiresAt string
+	if !f.ExpiresAt.IsZero() {
+		expiresAt = f.ExpiresAt.Format(time.RFC3339)
+	}
+
+	return &model.Filter{
+		ID:           f.ID,
+		Phrase:       f.Phrase,
+		Context:      filterContext,
+		WholeWord:    f.WholeWord,
+		ExpiresAt:    expiresAt,
+		Irreversible: f.Irreversible,
+	}, nil
+}
+
+func (c *converter) PollToMasto(ctx context.Context, p *gtsmodel.Poll, requestingAccount *gtsmodel.Account) (*model.Poll, error) {
+	var expiresAt string
+	if !p.ExpiresAt.IsZero() {
+		expiresAt = p.ExpiresAt.Format(time.RFC3339)
+	}
+
+	expired := p.Expired()
+
+	var voted bool
+	var ownVotes []int
+	isOwner := requestingAccount != nil && requestingAccount.ID == p.AccountID
+	if requestingAccount != nil {
+		vote, err := c.db.GetPollVoteByAccountID(ctx, p.ID, requestingAccount.ID)
+		if err == nil {
+			voted = true
+			ownVotes = vote.Choices
+		} else if err != db.ErrNoEntries {
+			return nil, fmt.Errorf("PollToMasto: error checking vote of account %s in poll %s: %s", requestingAccount.ID, p.ID, err)
+		}
+	}
+
+	// totals are hidden until the poll ends, unless the poll owner is asking
+	showTotals := !p.HideTotals || expired || isOwner
+
+	options := []model.PollOptions{}
+	for i, title := range p.Options {
+		option := model.PollOptions{
+			Title: title,
+		}
+		if showTotals && i < len(p.Votes) {
+			option.VotesCount = p.Votes[i]
+		}
+		options = append(options, option)
+	}
+
+	mastoPoll := &model.Poll{
+		ID:        p.ID,
+		ExpiresAt: expiresAt,
+		Expired:   expired,
+		Multiple:  p.Multiple,
+		Voted:     voted || isOwner,
+		OwnVotes:  ownVotes,
+		Options:   options,
+		Emojis:    []model.Emoji{},
+	}
+
+	if showTotals {
+		mastoPoll.VotesCount = p.TotalVotes()
+		if p.Multiple {
+			mastoPoll.VotersCount = p.VotersCount
+		}
+	}
+
+	return mastoPoll, nil
+}
+
+func (c *converter) ScheduledStatusToMasto(ctx context.Context, s *gtsmodel.ScheduledStatus) (*model.ScheduledStatus, error) {
+	mastoAttachments := []model.Attachment{}
+	for _, mediaID := range s.MediaIDs {
+		a, err := c.db.GetAttachmentByID(ctx, mediaID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting attachment with id %s: %s", mediaID, err)
+		}
+		mastoAttachment, err := c.AttachmentToMasto(ctx, a)
+		if err != nil {
+			return nil, fmt.Errorf("error converting attachment with id %s: %s", mediaID, err)
+		}
+		mastoAttachments = append(mastoAttachments, mastoAttachment)
+	}
+
+	var visibility string
+	if s.Visibility != "" {
+		visibility = string(c.VisToMasto(ctx, s.Visibility))
+	}
+
+	var mastoPoll *model.PollRequest
+	if len(s.PollOptions) != 0 {
+		mastoPoll = &model.PollRequest{
+			Options:    s.PollOptions,
+			ExpiresIn:  s.PollExpiresIn,
+			Multiple:   s.PollMultiple,
+			HideTotals: s.PollHideTotals,
+		}
+	}
+
+	scheduledAt := s.ScheduledAt.Format(time.RFC3339)
+
+	return &model.ScheduledStatus{
+		ID:          s.ID,
+		ScheduledAt: scheduledAt,
+		Params: &model.StatusParams{
+			Text:          s.Text,
+			InReplyToID:   s.InReplyToID,
+			MediaIDs:      s.MediaIDs,
+			Sensitive:     s.Sensitive,
+			SpoilerText:   s.SpoilerText,
+			Visibility:    visibility,
+			Language:      s.Language,
+			Poll:          mastoPoll,
+			ScheduledAt:   scheduledAt,
+			ApplicationID: s.ApplicationID,
+		},
+		MediaAttachments: mastoAttachments,
+	}, nil
+}
+
+func (c *converter) StatusEditToMasto(ctx context.Context, e *gtsmodel.StatusEdit) (*model.StatusEdit, error) {
+	if e.Account == nil {
+		a, err := c.db.GetAccountByID(ctx, e.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting account with id %s: %s", e.AccountID, err)
+		}
+		e.Account = a
+	}
+
+	mastoAccount, err := c.AccountToMastoPublic(ctx, e.Account)
+	if err != nil {
+		return nil, fmt.Errorf("error converting account with id %s: %s", e.AccountID, err)
+	}
+
+	mastoAttachments := []model.Attachment{}
+	for _, aID := range e.AttachmentIDs {
+		a, err := c.db.GetAttachmentByID(ctx, aID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting attachment with id %s: %s", aID, err)
+		}
+		mastoAttachment, err := c.AttachmentToMasto(ctx, a)
+		if err != nil {
+			return nil, fmt.Errorf("error converting attachment with id %s: %s", aID, err)
+		}
+		mastoAttachments = append(mastoAttachments, mastoAttachment)
+	}
+
+	mastoEmojis := []model.Emoji{}
+	for _, eID := range e.EmojiIDs {
+		gtsEmoji := &gtsmodel.Emoji{}
+		if err := c.db.GetByID(ctx, eID, gtsEmoji); err != nil {
+			return nil, fmt.Errorf("error getting emoji with id %s: %s", eID, err)
+		}
+		mastoEmoji, err := c.EmojiToMasto(ctx, gtsEmoji)
+		if err != nil {
+			return nil, fmt.Errorf("error converting emoji with id %s: %s", eID, err)
+		}
+		mastoEmojis = append(mastoEmojis, mastoEmoji)
+	}
+
+	return &model.StatusEdit{
+		Content:          e.Content,
+		SpoilerText:      e.ContentWarning,
+		Sensitive:        e.Sensitive,
+		CreatedAt:        e.CreatedAt.Format(time.RFC3339),
+		Account:          mastoAccount,
+		MediaAttachments: mastoAttachments,
+		Emojis:           mastoEmojis,
+	}, nil
+}
+
+func (c *converter) ConversationToMasto(ctx context.Context, conversation *gtsmodel.Conversation, requestingAccount *gtsmodel.Account) (*model.Conversation, error) {
+	if conversation.OtherAccounts == nil {
+		otherAccounts := []*gtsmodel.Account{}
+		for _, accountID := range conversation.OtherAccountIDs {
+			a, err := c.db.GetAccountByID(ctx, accountID)
+			if err != nil {
+				return nil, fmt.Errorf("error getting account with id %s: %s", accountID, err)
+			}
+			otherAccounts = append(otherAccounts, a)
+		}
+		conversation.OtherAccounts = otherAccounts
+	}
+
+	// if the owner is only talking to themself, they're the only participant we can show
+	participants := conversation.OtherAccounts
+	if len(participants) == 0 {
+		participants = []*gtsmodel.Account{requestingAccount}
+	}
+
+	mastoAccounts := []model.Account{}
+	for _, a := range participants {
+		mastoAccount, err := c.AccountToMastoPublic(ctx, a)
+		if err != nil {
+			return nil, fmt.Errorf("error converting account with id %s: %s", a.ID, err)
+		}
+		mastoAccounts = append(mastoAccounts, *mastoAccount)
+	}
+
+	if conversation.LastStatus == nil {
+		s, err := c.db.GetStatusByID(ctx, conversation.LastStatusID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting status with id %s: %s", conversation.LastStatusID, err)
+		}
+		conversation.LastStatus = s
+	}
+
+	mastoLastStatus, err := c.StatusToMasto(ctx, conversation.LastStatus, requestingAccount)
+	if err != nil {
+		return nil, fmt.Errorf("error converting status with id %s: %s", conversation.LastStatusID, err)
+	}
+
+	return &model.Conversation{
+		ID:         conversation.ID,
+		Accounts:   mastoAccounts,
+		Unread:     !conversation.Read,
+		LastStatus: mastoLastStatus,
+	}, nil
+}
+
+func (c *converter) ReportToMasto(ctx context.Context, r *gtsmodel.Report) (*model.Report, error) {
+	if r.TargetAccount == nil {
+		a, err := c.db.GetAccountByID(ctx, r.TargetAccountID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting account with id %s: %s", r.TargetAccountID, err)
+		}
+		r.TargetAccount = a
+	}
+
+	mastoTargetAccount, err := c.AccountToMastoPublic(ctx, r.TargetAccount)
+	if err != nil {
+		return nil, fmt.Errorf("error converting account with id %s: %s", r.TargetAccountID, err)
+	}
+
+	report := &model.Report{
+		ID:            r.ID,
+		ActionTaken:   r.Resolved(),
+		Comment:       r.Comment,
+		Forwarded:     r.Forwarded,
+		CreatedAt:     r.CreatedAt.Format(time.RFC3339),
+		StatusIDs:     r.StatusIDs,
+		TargetAccount: mastoTargetAccount,
+	}
+
+	if report.StatusIDs == nil {
+		report.StatusIDs = []string{}
+	}
+
+	if r.Resolved() {
+		actionTakenAt := r.ActionTakenAt.Format(time.RFC3339)
+		report.ActionTakenAt = &actionTakenAt
+	}
+
+	return report, nil
+}
+
+func (c *converter) ReportToAdminMasto(ctx context.Context, r *gtsmodel.Report, requestingAccount *gtsmodel.Account) (*model.AdminReport, error) {
+	// convert the account with the given ID, or return nil if the ID isn't set
+	accountToMasto := func(accountID string) (*model.Account, error) {
+		if accountID == "" {
+			return nil, nil
+		}
+		a, err := c.db.GetAccountByID(ctx, accountID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting account with id %s: %s", accountID, err)
+		}
+		mastoAccount, err := c.AccountToMastoPublic(ctx, a)
+		if err != nil {
+			return nil, fmt.Errorf("error converting account with id %s: %s", accountID, err)
+		}
+		return mastoAccount, nil
+	}
+
+	mastoAccount, err := accountToMasto(r.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	mastoTargetAccount, err := accountToMasto(r.TargetAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	mastoAssignedAccount, err := accountToMasto(r.AssignedAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	mastoActionTakenByAccount, err := accountToMasto(r.ActionTakenByAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	mastoStatuses := []*model.Status{}
+	for _, statusID := range r.StatusIDs {
+		s, err := c.db.GetStatusByID(ctx, statusID)
+		if err != nil {
+			if err == db.ErrNoEntries {
+				// the status has been deleted since it was reported
+				continue
+			}
+			return nil, fmt.Errorf("error getting status with id %s: %s", statusID, err)
+		}
+		mastoStatus, err := c.StatusToMasto(ctx, s, requestingAccount)
+		if err != nil {
+			return nil, fmt.Errorf("error converting status with id %s: %s", statusID, err)
+		}
+		mastoStatuses = append(mastoStatuses, mastoStatus)
+	}
+
+	report := &model.AdminReport{
+		ID:                   r.ID,
+		ActionTaken:          r.Resolved(),
+		ActionTakenType:      string(r.ActionTaken),
+		ActionTakenComment:   r.ActionTakenComment,
+		Comment:              r.Comment,
+		Forwarded:            r.Forwarded,
+		CreatedAt:            r.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:            r.UpdatedAt.Format(time.RFC3339),
+		Account:              mastoAccount,
+		TargetAccount:        mastoTargetAccount,
+		AssignedAccount:      mastoAssignedAccount,
+		ActionTakenByAccount: mastoActionTakenByAccount,
+		Statuses:             mastoStatuses,
+	}
+
+	if r.Resolved() {
+		actionTakenAt := r.ActionTakenAt.Format(time.RFC3339)
+		report.ActionTakenAt = &actionTakenAt
+	}
+
+	return report, nil
+}
+
+func (c *converter) AccountImportToMasto(ctx context.Context, i *gtsmodel.AccountImport) (*model.AccountImport, error) {
+	accountImport := &model.AccountImport{
+		ID:        i.ID,
+		Type:      string(i.Type),
+		Finished:  i.Finished(),
+		CreatedAt: i.CreatedAt.Format(time.RFC3339),
+		Total:     len(i.Entries),
+		Results:   []model.AccountImportResult{},
+	}
+
+	for _, e := range i.Entries {
+		if e.Result == "" {
+			continue
+		}
+
+		accountImport.Processed = accountImport.Processed + 1
+		if e.Result == gtsmodel.AccountImportResultSucceeded {
+			accountImport.Succeeded = accountImport.Succeeded + 1
+		} else {
+			accountImport.Failed = accountImport.Failed + 1
+		}
+
+		accountImport.Results = append(accountImport.Results, model.AccountImportResult{
+			Line:    e.Line,
+			Account: e.Account,
+			Result:  string(e.Result),
+			Error:   e.Error,
+		})
+	}
+
+	return accountImport, nil
+}
+
+func (c *converter) AccountArchiveToMasto(ctx context.Context, a *gtsmodel.AccountArchive) (*model.AccountArchive, error) {
+	archive := &model.AccountArchive{
+		ID:            a.ID,
+		Type:          string(a.Type),
+		Finished:      a.Finished(),
+		CreatedAt:     a.CreatedAt.Format(time.RFC3339),
+		Size:          a.Size,
+		StatusesCount: a.Statuses,
+		Error:         a.Error,
+	}
+
+	if a.Type == gtsmodel.AccountArchiveTypeExport && a.Finished() && a.Error == "" {
+		archive.URL = fmt.Sprintf("%s://%s/api/v1/accounts/archives/%s/download", c.config.Protocol, c.config.Host, a.ID)
+	}
+
+	return archive, nil
+}
+
+func (c *converter) PreferencesToMasto(ctx context.Context, a *gtsmodel.Account, u *gtsmodel.User) (*model.Preferences, error) {
+	if a == nil || u == nil {
+		return nil, fmt.Errorf("given account or user was nil")
+	}
+
+	expandMedia := u.ExpandMedia
+	if expandMedia == "" {
+		expandMedia = "default"
+	}
+
+	return &model.Preferences{
+		PostingDefaultVisibility: string(c.VisToMasto(ctx, a.Privacy)),
+		PostingDefaultSensitive:  a.Sensitive,
+		PostingDefaultLanguage:   a.Language,
+		ReadingExpandMedia:       expandMedia,
+		ReadingExpandSpoilers:    u.ExpandSpoilers,
+
+		NotificationsEmailFollow:        u.EmailNotifyFollow,
+		NotificationsEmailFollowRequest: u.EmailNotifyFollowRequest,
+		NotificationsEmailMention:       u.EmailNotifyMention,
+		NotificationsEmailDirect:        u.EmailNotifyDirect,
+	}, nil
+}
@@ -65,3 +65,170 @@ func (c *converter) WrapPersonInUpdate(person vocab.ActivityStreamsPerson, origi
 
 	return update, nil
 }
+
+func (c *converter) WrapQuestionInCreate(question vocab.ActivityStreamsQuestion, originAccount *gtsmodel.Account) (vocab.ActivityStreamsCreate, error) {
+	create := streams.NewActivityStreamsCreate()
+
+	// set the actor
+	actorURI, err := url.Parse(originAccount.URI)
+	if err != nil {
+		return nil, fmt.Errorf("WrapQuestionInCreate: error parsing url %s: %s", originAccount.URI, err)
+	}
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(actorURI)
+	create.SetActivityStreamsActor(actorProp)
+
+	// set the ID, based on the ID of the question
+	questionIDProp := question.GetJSONLDId()
+	if questionIDProp == nil || !questionIDProp.IsIRI() {
+		return nil, fmt.Errorf("WrapQuestionInCreate: question had no id")
+	}
+	idString := questionIDProp.GetIRI().String() + "/activity"
+	idURI, err := url.Parse(idString)
+	if err != nil {
+		return nil, fmt.Errorf("WrapQuestionInCreate: error parsing url %s: %s", idString, err)
+	}
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.SetIRI(idURI)
+	create.SetJSONLDId(idProp)
+
+	// published should be the same as the question
+	create.SetActivityStreamsPublished(question.GetActivityStreamsPublished())
+
+	// set the question as the object here
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendActivityStreamsQuestion(question)
+	create.SetActivityStreamsObject(objectProp)
+
+	// to and cc should be the same as the question
+	create.SetActivityStreamsTo(question.GetActivityStreamsTo())
+	create.SetActivityStreamsCc(question.GetActivityStreamsCc())
+
+	return create, nil
+}
+
+func (c *converter) WrapNoteInCreate(note vocab.ActivityStreamsNote, originAccount *gtsmodel.Account) (vocab.ActivityStreamsCreate, error) {
+	create := streams.NewActivityStreamsCreate()
+
+	// set the actor
+	actorURI, err := url.Parse(originAccount.URI)
+	if err != nil {
+		return nil, fmt.Errorf("WrapNoteInCreate: error parsing url %s: %s", originAccount.URI, err)
+	}
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(actorURI)
+	create.SetActivityStreamsActor(actorProp)
+
+	// set the ID, based on the ID of the note
+	noteIDProp := note.GetJSONLDId()
+	if noteIDProp == nil || !noteIDProp.IsIRI() {
+		return nil, fmt.Errorf("WrapNoteInCreate: note had no id")
+	}
+	idString := noteIDProp.GetIRI().String() + "/activity"
+	idURI, err := url.Parse(idString)
+	if err != nil {
+		return nil, fmt.Errorf("WrapNoteInCreate: error parsing url %s: %s", idString, err)
+	}
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.SetIRI(idURI)
+	create.SetJSONLDId(idProp)
+
+	// published should be the same as the note
+	create.SetActivityStreamsPublished(note.GetActivityStreamsPublished())
+
+	// set the note as the object here
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendActivityStreamsNote(note)
+	create.SetActivityStreamsObject(objectProp)
+
+	// to and cc should be the same as the note
+	create.SetActivityStreamsTo(note.GetActivityStreamsTo())
+	create.SetActivityStreamsCc(note.GetActivityStreamsCc())
+
+	return create, nil
+}
+
+func (c *converter) WrapQuestionInUpdate(question vocab.ActivityStreamsQuestion, originAccount *gtsmodel.Account) (vocab.ActivityStreamsUpdate, error) {
+	update := streams.NewActivityStreamsUpdate()
+
+	// set the actor
+	actorURI, err := url.Parse(originAccount.URI)
+	if err != nil {
+		return nil, fmt.Errorf("WrapQuestionInUpdate: error parsing url %s: %s", originAccount.URI, err)
+	}
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(actorURI)
+	update.SetActivityStreamsActor(actorProp)
+
+	// set the ID
+	newID, err := id.NewRandomULID()
+	if err != nil {
+		return nil, err
+	}
+
+	idString := util.GenerateURIForUpdate(originAccount.Username, c.config.Protocol, c.config.Host, newID)
+	idURI, err := url.Parse(idString)
+	if err != nil {
+		return nil, fmt.Errorf("WrapQuestionInUpdate: error parsing url %s: %s", idString, err)
+	}
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.SetIRI(idURI)
+	update.SetJSONLDId(idProp)
+
+	// set the question as the object here
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendActivityStreamsQuestion(question)
+	update.SetActivityStreamsObject(objectProp)
+
+	// to and cc should be the same as the question
+	update.SetActivityStreamsTo(question.GetActivityStreamsTo())
+	update.SetActivityStreamsCc(question.GetActivityStreamsCc())
+
+	return update, nil
+}
+
+func (c *converter) WrapNoteInUpdate(note vocab.ActivityStreamsNote, originAccount *gtsmodel.Account) (vocab.ActivityStreamsUpdate, error) {
+	update := streams.NewActivityStreamsUpdate()
+
+	// set the actor
+	actorURI, err := url.Parse(originAccount.URI)
+	if err != nil {
+		return nil, fmt.Errorf("WrapNoteInUpdate: error parsing url %s: %s", originAccount.URI, err)
+	}
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(actorURI)
+	update.SetActivityStreamsActor(actorProp)
+
+	// set the ID
+	newID, err := id.NewRandomULID()
+	if err != nil {
+		return nil, err
+	}
+
+	idString := util.GenerateURIForUpdate(originAccount.Username, c.config.Protocol, c.config.Host, newID)
+	idURI, err := url.Parse(idString)
+	if err != nil {
+		return nil, fmt.Errorf("WrapNoteInUpdate: error parsing url %s: %s", idString, err)
+	}
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.SetIRI(idURI)
+	update.SetJSONLDId(idProp)
+
+	// published should be when the note was last edited
+	if note.GetActivityStreamsUpdated() != nil {
+		publishedProp := streams.NewActivityStreamsPublishedProperty()
+		publishedProp.Set(note.GetActivityStreamsUpdated().Get())
+		update.SetActivityStreamsPublished(publishedProp)
+	}
+
+	// set the note as the object here
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendActivityStreamsNote(note)
+	update.SetActivityStreamsObject(objectProp)
+
+	// to and cc should be the same as the note
+	update.SetActivityStreamsTo(note.GetActivityStreamsTo())
+	update.SetActivityStreamsCc(note.GetActivityStreamsCc())
+
+	return update, nil
+}
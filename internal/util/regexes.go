@@ -44,6 +44,8 @@ var (
 	// HashtagFinderRegex finds possible hashtags in a string.
 	// It returns just the string part of the hashtag, not the # symbol.
 	HashtagFinderRegex = regexp.MustCompile(hashtagFinderRegexString)
+	// hashtagValidationRegex can be used to validate the name of a hashtag, without the # symbol.
+	hashtagValidationRegex = regexp.MustCompile(fmt.Sprintf(`^[a-zA-Z0-9]{1,%d}$`, maximumHashtagLength))
 
 	emojiShortcodeRegexString     = fmt.Sprintf(`\w{2,%d}`, maximumEmojiShortcodeLength)
 	emojiShortcodeValidationRegex = regexp.MustCompile(fmt.Sprintf("^%s$", emojiShortcodeRegexString))
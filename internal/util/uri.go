@@ -52,6 +52,10 @@ const (
 	UpdatePath = "updates"
 	// BlocksPath is used to generate the URI for a block
 	BlocksPath = "blocks"
+	// VotesPath is used to generate the URI for a poll vote
+	VotesPath = "votes"
+	// ReportsPath is used to generate the URI for a report/flag
+	ReportsPath = "reports"
 )
 
 // APContextKey is a type used specifically for settings values on contexts within go-fed AP request chains
@@ -126,12 +130,24 @@ func GenerateURIForUpdate(username string, protocol string, host string, thisUpd
 	return fmt.Sprintf("%s://%s/%s/%s#%s/%s", protocol, host, UsersPath, username, UpdatePath, thisUpdateID)
 }
 
+// GenerateURIForVote returns the AP URI for a new poll vote -- something like:
+// https://example.org/users/whatever_user#votes/01F7XTH1QGBAPMGF49WJZ91XGC
+func GenerateURIForVote(username string, protocol string, host string, thisVoteID string) string {
+	return fmt.Sprintf("%s://%s/%s/%s#%s/%s", protocol, host, UsersPath, username, VotesPath, thisVoteID)
+}
+
 // GenerateURIForBlock returns the AP URI for a new block activity -- something like:
 // https://example.org/users/whatever_user/blocks/01F7XTH1QGBAPMGF49WJZ91XGC
 func GenerateURIForBlock(username string, protocol string, host string, thisBlockID string) string {
 	return fmt.Sprintf("%s://%s/%s/%s/%s/%s", protocol, host, UsersPath, username, BlocksPath, thisBlockID)
 }
 
+// GenerateURIForReport returns the AP URI for a new report/flag activity -- something like:
+// https://example.org/users/whatever_user/reports/01F7XTH1QGBAPMGF49WJZ91XGC
+func GenerateURIForReport(username string, protocol string, host string, thisReportID string) string {
+	return fmt.Sprintf("%s://%s/%s/%s/%s/%s", protocol, host, UsersPath, username, ReportsPath, thisReportID)
+}
+
 // GenerateURIsForAccount throws together a bunch of URIs for the given username, with the given protocol and host.
 func GenerateURIsForAccount(username string, protocol string, host string) *UserURIs {
 	// The below URLs are used for serving web requests
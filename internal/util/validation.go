@@ -36,6 +36,9 @@ const (
 	maximumShortDescriptionLength = 500
 	maximumDescriptionLength      = 5000
 	maximumSiteTermsLength        = 5000
+	maximumListTitleLength        = 200
+	maximumFilterPhraseLength     = 500
+	maximumReportCommentLength    = 1000
 )
 
 // ValidateNewPassword returns an error if the given password is not sufficiently strong, or nil if it's ok.
@@ -120,10 +123,24 @@ func ValidateNote(note string) error {
 	return nil
 }
 
-// ValidatePrivacy checks that the desired privacy setting is valid
+// ValidatePrivacy checks that the desired privacy setting is one of public, unlisted, private, mutuals_only, or direct.
 func ValidatePrivacy(privacy string) error {
-	// TODO: add some validation logic here -- length, characters, etc
-	return nil
+	switch privacy {
+	case "public", "unlisted", "private", "mutuals_only", "direct":
+		return nil
+	default:
+		return fmt.Errorf("privacy %s was not recognized, must be one of public, unlisted, private, mutuals_only, or direct", privacy)
+	}
+}
+
+// ValidateExpandMedia ensures that the given media expansion preference is one of default, show_all, or hide_all.
+func ValidateExpandMedia(expandMedia string) error {
+	switch expandMedia {
+	case "default", "show_all", "hide_all":
+		return nil
+	default:
+		return fmt.Errorf("expand media preference %s was not recognized, must be one of default, show_all, or hide_all", expandMedia)
+	}
 }
 
 // ValidateEmojiShortcode just runs the given shortcode through the regular expression
@@ -136,6 +153,15 @@ func ValidateEmojiShortcode(shortcode string) error {
 	return nil
 }
 
+// ValidateHashtag runs the given hashtag name (without the # symbol) through the regular expression
+// for hashtags, to figure out whether it's a valid hashtag, ie., 1-30 characters, letters and numbers only.
+func ValidateHashtag(name string) error {
+	if !hashtagValidationRegex.MatchString(name) {
+		return fmt.Errorf("hashtag %s did not pass validation, must be between 1 and %d characters, letters and numbers only", name, maximumHashtagLength)
+	}
+	return nil
+}
+
 // ValidateSiteTitle ensures that the given site title is within spec.
 func ValidateSiteTitle(siteTitle string) error {
 	if len(siteTitle) > maximumSiteTitleLength {
@@ -171,3 +197,75 @@ func ValidateSiteTerms(t string) error {
 
 	return nil
 }
+
+// ValidateListTitle ensures that the given list title is within spec.
+func ValidateListTitle(title string) error {
+	if title == "" {
+		return errors.New("list title must be provided")
+	}
+
+	if len(title) > maximumListTitleLength {
+		return fmt.Errorf("list title should be no more than %d chars but given title was %d", maximumListTitleLength, len(title))
+	}
+
+	return nil
+}
+
+// ValidateListRepliesPolicy ensures that the given list replies policy is one of followed, list, or none.
+func ValidateListRepliesPolicy(repliesPolicy string) error {
+	switch repliesPolicy {
+	case "followed", "list", "none":
+		return nil
+	default:
+		return fmt.Errorf("list replies policy %s was not recognized, must be one of followed, list, or none", repliesPolicy)
+	}
+}
+
+// ValidateFilterPhrase ensures that the given filter phrase is within spec.
+func ValidateFilterPhrase(phrase string) error {
+	if phrase == "" {
+		return errors.New("filter phrase must be provided")
+	}
+
+	if len(phrase) > maximumFilterPhraseLength {
+		return fmt.Errorf("filter phrase should be no more than %d chars but given phrase was %d", maximumFilterPhraseLength, len(phrase))
+	}
+
+	return nil
+}
+
+// ValidateFilterContexts ensures that at least one filter context is given, and that all given contexts are one of home, notifications, public, or thread.
+func ValidateFilterContexts(contexts []string) error {
+	if len(contexts) == 0 {
+		return errors.New("at least one filter context must be provided")
+	}
+
+	for _, c := range contexts {
+		switch c {
+		case "home", "notifications", "public", "thread":
+		default:
+			return fmt.Errorf("filter context %s was not recognized, must be one of home, notifications, public, or thread", c)
+		}
+	}
+
+	return nil
+}
+
+// ValidateReportComment ensures that the given report comment is within spec. The comment may be empty.
+func ValidateReportComment(comment string) error {
+	if len(comment) > maximumReportCommentLength {
+		return fmt.Errorf("report comment should be no more than %d chars but given comment was %d", maximumReportCommentLength, len(comment))
+	}
+
+	return nil
+}
+
+// ValidateReportAction ensures that the given report action is one of none, sensitive, silence, or suspend.
+func ValidateReportAction(action string) error {
+	switch action {
+	case "none", "sensitive", "silence", "suspend":
+		return nil
+	default:
+		return fmt.Errorf("report action %s was not recognized, must be one of none, sensitive, silence, or suspend", action)
+	}
+}
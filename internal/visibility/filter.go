@@ -34,9 +34,29 @@ type Filter interface {
 	StatusVisible(ctx context.Context, targetStatus *gtsmodel.Status, requestingAccount *gtsmodel.Account) (bool, error)
 
 	// StatusHometimelineable returns true if targetStatus should be in the home timeline of the requesting account.
+	// The filtered function should come from StatusFilterer for the requesting account and the home context, so that
+	// a batch of statuses can be checked against the same prepared filters.
 	//
 	// This function will call StatusVisible internally, so it's not necessary to call it beforehand.
-	StatusHometimelineable(ctx context.Context, targetStatus *gtsmodel.Status, requestingAccount *gtsmodel.Account) (bool, error)
+	StatusHometimelineable(ctx context.Context, targetStatus *gtsmodel.Status, requestingAccount *gtsmodel.Account, filtered StatusFilterFunc) (bool, error)
+
+	// StatusListTimelineable returns true if targetStatus should be in the given list timeline of the list owner account,
+	// taking account of the replies policy of the list.
+	//
+	// This function will call StatusHometimelineable internally, so it's not necessary to call it beforehand.
+	StatusListTimelineable(ctx context.Context, targetStatus *gtsmodel.Status, list *gtsmodel.List, timelineOwnerAccount *gtsmodel.Account, filtered StatusFilterFunc) (bool, error)
+
+	// StatusFiltered returns true if targetStatus matches one of the requesting account's active, irreversible
+	// keyword filters for the given context, which means the status should be dropped rather than shown to the requester.
+	//
+	// Boosts are checked against the content of the boosted status.
+	StatusFiltered(ctx context.Context, targetStatus *gtsmodel.Status, requestingAccount *gtsmodel.Account, filterContext gtsmodel.FilterContext) (bool, error)
+
+	// StatusFilterer fetches and compiles the requesting account's active, irreversible keyword filters for the given context,
+	// and returns a function that checks statuses against them in the same way as StatusFiltered.
+	//
+	// Use this instead of StatusFiltered when checking lots of statuses at once, so the filters are only prepared one time.
+	StatusFilterer(ctx context.Context, requestingAccount *gtsmodel.Account, filterContext gtsmodel.FilterContext) (StatusFilterFunc, error)
 
 	// StatusPublictimelineable returns true if targetStatus should be in the public timeline of the requesting account.
 	//
@@ -44,6 +64,9 @@ type Filter interface {
 	StatusPublictimelineable(ctx context.Context, targetStatus *gtsmodel.Status, timelineOwnerAccount *gtsmodel.Account) (bool, error)
 }
 
+// StatusFilterFunc returns true if targetStatus matches one of a set of keyword filters that were prepared by StatusFilterer.
+type StatusFilterFunc func(ctx context.Context, targetStatus *gtsmodel.Status) (bool, error)
+
 type filter struct {
 	db  db.DB
 	log *logrus.Logger
@@ -0,0 +1,179 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package visibility
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+// nonWordChars matches a single character that's not a 'word constituent character', ie., not a letter, mark, decimal number, or connector punctuation.
+const nonWordChars = `[^\p{L}\p{M}\p{Nd}\p{Pc}]`
+
+// compiledFilter is a keyword filter whose phrase has been compiled into an expression, ready to be matched against statuses.
+type compiledFilter struct {
+	filter *gtsmodel.Filter
+	// re is only set for whole word filters; other filters are matched with a plain case-insensitive substring search.
+	re *regexp.Regexp
+}
+
+func (f *filter) StatusFiltered(ctx context.Context, targetStatus *gtsmodel.Status, requestingAccount *gtsmodel.Account, filterContext gtsmodel.FilterContext) (bool, error) {
+	// nobody to filter for, or the requester is looking at their own status, so don't bother fetching any filters
+	if requestingAccount == nil || targetStatus.AccountID == requestingAccount.ID {
+		return false, nil
+	}
+
+	filtered, err := f.StatusFilterer(ctx, requestingAccount, filterContext)
+	if err != nil {
+		return false, err
+	}
+
+	return filtered(ctx, targetStatus)
+}
+
+func (f *filter) StatusFilterer(ctx context.Context, requestingAccount *gtsmodel.Account, filterContext gtsmodel.FilterContext) (StatusFilterFunc, error) {
+	l := f.log.WithFields(logrus.Fields{
+		"func":          "StatusFilterer",
+		"filterContext": filterContext,
+	})
+
+	// nobody to filter for
+	if requestingAccount == nil {
+		return noStatusFilter, nil
+	}
+
+	filters, err := f.db.GetActiveFiltersForAccountID(ctx, requestingAccount.ID, filterContext, true)
+	if err != nil {
+		return nil, fmt.Errorf("StatusFilterer: error getting filters for account %s: %s", requestingAccount.ID, err)
+	}
+
+	compiled := make([]*compiledFilter, 0, len(filters))
+	for _, filter := range filters {
+		c, err := compileFilter(filter)
+		if err != nil {
+			l.Debugf("error compiling filter %s: %s", filter.ID, err)
+			continue
+		}
+		compiled = append(compiled, c)
+	}
+
+	if len(compiled) == 0 {
+		return noStatusFilter, nil
+	}
+
+	return func(ctx context.Context, targetStatus *gtsmodel.Status) (bool, error) {
+		// the requester is looking at their own status
+		if targetStatus.AccountID == requestingAccount.ID {
+			return false, nil
+		}
+
+		// for a boost, we want to check the content of the boosted status
+		if targetStatus.BoostOfID != "" {
+			if targetStatus.BoostOf == nil {
+				bs, err := f.db.GetStatusByID(ctx, targetStatus.BoostOfID)
+				if err != nil {
+					return false, fmt.Errorf("StatusFiltered: error getting boosted status with id %s: %s", targetStatus.BoostOfID, err)
+				}
+				targetStatus.BoostOf = bs
+			}
+			targetStatus = targetStatus.BoostOf
+		}
+
+		searchable := []string{
+			targetStatus.ContentWarning,
+			text.RemoveHTML(targetStatus.Content),
+		}
+		for _, a := range targetStatus.Attachments {
+			searchable = append(searchable, a.Description)
+		}
+		statusText := strings.Join(searchable, "\n")
+
+		for _, c := range compiled {
+			if filterMatches(c, statusText) {
+				l.Tracef("status %s matched filter %s", targetStatus.ID, c.filter.ID)
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}, nil
+}
+
+// noStatusFilter is the StatusFilterFunc for an account that has no filters to apply.
+func noStatusFilter(ctx context.Context, targetStatus *gtsmodel.Status) (bool, error) {
+	return false, nil
+}
+
+// compileFilter prepares the given filter for matching against statuses.
+//
+// If the filter is set to whole word, then its phrase is compiled into an expression that only matches
+// when the phrase isn't surrounded by other word characters.
+func compileFilter(filter *gtsmodel.Filter) (*compiledFilter, error) {
+	c := &compiledFilter{
+		filter: filter,
+	}
+
+	if filter.Phrase == "" || !filter.WholeWord {
+		return c, nil
+	}
+
+	expr := regexp.QuoteMeta(filter.Phrase)
+
+	if first, _ := utf8.DecodeRuneInString(filter.Phrase); isWordChar(first) {
+		expr = `(?:^|` + nonWordChars + `)` + expr
+	}
+
+	if last, _ := utf8.DecodeLastRuneInString(filter.Phrase); isWordChar(last) {
+		expr = expr + `(?:$|` + nonWordChars + `)`
+	}
+
+	re, err := regexp.Compile(`(?i)` + expr)
+	if err != nil {
+		return nil, err
+	}
+	c.re = re
+
+	return c, nil
+}
+
+// filterMatches returns true if the phrase of the given filter can be found in the given text, case-insensitively.
+func filterMatches(c *compiledFilter, statusText string) bool {
+	if c.filter.Phrase == "" {
+		return false
+	}
+
+	if c.re == nil {
+		return strings.Contains(strings.ToLower(statusText), strings.ToLower(c.filter.Phrase))
+	}
+
+	return c.re.MatchString(statusText)
+}
+
+// isWordChar returns true if the given rune is a letter, mark, decimal number, or connector punctuation.
+func isWordChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsDigit(r) || unicode.Is(unicode.Pc, r)
+}
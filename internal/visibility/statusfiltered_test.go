@@ -0,0 +1,83 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package visibility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type StatusFilteredTestSuite struct {
+	suite.Suite
+}
+
+func (suite *StatusFilteredTestSuite) TestFilterMatches() {
+	for _, test := range []struct {
+		name      string
+		phrase    string
+		wholeWord bool
+		text      string
+		matches   bool
+	}{
+		{name: "empty phrase", phrase: "", wholeWord: false, text: "anything at all", matches: false},
+		{name: "substring", phrase: "fnord", wholeWord: false, text: "some fnords here", matches: true},
+		{name: "substring not found", phrase: "fnord", wholeWord: false, text: "nothing to see", matches: false},
+		{name: "substring case", phrase: "FnOrD", wholeWord: false, text: "FNORDS!", matches: true},
+		{name: "whole word alone", phrase: "fnord", wholeWord: true, text: "fnord", matches: true},
+		{name: "whole word in sentence", phrase: "fnord", wholeWord: true, text: "i saw a fnord today", matches: true},
+		{name: "whole word next to punctuation", phrase: "fnord", wholeWord: true, text: "what's a fnord?", matches: true},
+		{name: "whole word at start of line", phrase: "fnord", wholeWord: true, text: "first line\nfnord on the second", matches: true},
+		{name: "whole word case", phrase: "fnord", wholeWord: true, text: "FNORD ALERT", matches: true},
+		{name: "whole word as prefix", phrase: "fnord", wholeWord: true, text: "fnords everywhere", matches: false},
+		{name: "whole word as suffix", phrase: "fnord", wholeWord: true, text: "superfnord", matches: false},
+		{name: "whole word next to digit", phrase: "fnord", wholeWord: true, text: "fnord2", matches: false},
+		{name: "whole word next to underscore", phrase: "fnord", wholeWord: true, text: "fnord_fan", matches: false},
+		{name: "whole word phrase", phrase: "fnord eggs", wholeWord: true, text: "green fnord eggs and ham", matches: true},
+		{name: "whole word phrase split", phrase: "fnord eggs", wholeWord: true, text: "fnord and eggs", matches: false},
+		{name: "phrase starting with punctuation", phrase: "#fnord", wholeWord: true, text: "tagged #fnord", matches: true},
+		{name: "phrase starting with punctuation after word", phrase: "#fnord", wholeWord: true, text: "tagged#fnord", matches: true},
+		{name: "phrase starting with punctuation as prefix", phrase: "#fnord", wholeWord: true, text: "tagged #fnords", matches: false},
+		{name: "phrase ending with punctuation", phrase: "fnord!", wholeWord: true, text: "fnord!!!", matches: true},
+		{name: "phrase ending with punctuation as suffix", phrase: "fnord!", wholeWord: true, text: "superfnord!", matches: false},
+		{name: "phrase with regexp characters", phrase: "f.o+d", wholeWord: true, text: "what is f.o+d anyway", matches: true},
+		{name: "phrase with regexp characters not interpreted", phrase: "f.o+d", wholeWord: true, text: "what is fxood anyway", matches: false},
+		{name: "non-ascii word", phrase: "café", wholeWord: true, text: "meet me at the café.", matches: true},
+		{name: "non-ascii word case", phrase: "CAFÉ", wholeWord: true, text: "meet me at the café", matches: true},
+		{name: "non-ascii word as prefix", phrase: "café", wholeWord: true, text: "cafés are nice", matches: false},
+		{name: "non-ascii neighbour", phrase: "fnord", wholeWord: true, text: "éfnord", matches: false},
+		{name: "cyrillic", phrase: "привет", wholeWord: true, text: "ну, привет!", matches: true},
+		{name: "cyrillic as prefix", phrase: "привет", wholeWord: true, text: "приветствую", matches: false},
+		{name: "cjk", phrase: "猫", wholeWord: false, text: "私の猫です", matches: true},
+	} {
+		c, err := compileFilter(&gtsmodel.Filter{
+			Phrase:    test.phrase,
+			WholeWord: test.wholeWord,
+		})
+		if !suite.NoError(err, test.name) {
+			continue
+		}
+		suite.Equal(test.matches, filterMatches(c, test.text), test.name)
+	}
+}
+
+func TestStatusFilteredTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusFilteredTestSuite))
+}
@@ -26,7 +26,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
-func (f *filter) StatusHometimelineable(ctx context.Context, targetStatus *gtsmodel.Status, timelineOwnerAccount *gtsmodel.Account) (bool, error) {
+func (f *filter) StatusHometimelineable(ctx context.Context, targetStatus *gtsmodel.Status, timelineOwnerAccount *gtsmodel.Account, filtered StatusFilterFunc) (bool, error) {
 	l := f.log.WithFields(logrus.Fields{
 		"func":     "StatusHometimelineable",
 		"statusID": targetStatus.ID,
@@ -47,6 +47,28 @@ func (f *filter) StatusHometimelineable(ctx context.Context, targetStatus *gtsmo
 		return false, nil
 	}
 
+	// drop the status if it matches one of the timeline owner's irreversible filters
+	isFiltered, err := filtered(ctx, targetStatus)
+	if err != nil {
+		return false, fmt.Errorf("StatusHometimelineable: error checking filters for status with id %s: %s", targetStatus.ID, err)
+	}
+
+	if isFiltered {
+		l.Debug("status is not hometimelineable because it matches one of the timeline owner's filters")
+		return false, nil
+	}
+
+	// drop the status if the timeline owner has muted any of the accounts involved in it
+	muted, err := f.statusMuted(ctx, targetStatus, timelineOwnerAccount)
+	if err != nil {
+		return false, fmt.Errorf("StatusHometimelineable: error checking mutes for status with id %s: %s", targetStatus.ID, err)
+	}
+
+	if muted {
+		l.Debug("status is not hometimelineable because the timeline owner has muted an account involved in it")
+		return false, nil
+	}
+
 	for _, m := range targetStatus.Mentions {
 		if m.TargetAccountID == timelineOwnerAccount.ID {
 			// if we're mentioned we should be able to see the post
@@ -99,3 +121,36 @@ func (f *filter) StatusHometimelineable(ctx context.Context, targetStatus *gtsmo
 
 	return true, nil
 }
+
+// statusMuted returns true if the timeline owner has an active mute against the author of the
+// target status, the author of the status it boosts, or the author of the status it replies to.
+func (f *filter) statusMuted(ctx context.Context, targetStatus *gtsmodel.Status, timelineOwnerAccount *gtsmodel.Account) (bool, error) {
+	if timelineOwnerAccount == nil {
+		return false, nil
+	}
+
+	accountIDs := []string{targetStatus.AccountID}
+	if targetStatus.BoostOfAccountID != "" {
+		accountIDs = append(accountIDs, targetStatus.BoostOfAccountID)
+	}
+	if targetStatus.InReplyToAccountID != "" {
+		accountIDs = append(accountIDs, targetStatus.InReplyToAccountID)
+	}
+
+	for _, accountID := range accountIDs {
+		if accountID == timelineOwnerAccount.ID {
+			continue
+		}
+
+		muted, err := f.db.IsMuted(ctx, timelineOwnerAccount.ID, accountID)
+		if err != nil {
+			return false, err
+		}
+
+		if muted {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,76 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package visibility
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func (f *filter) StatusListTimelineable(ctx context.Context, targetStatus *gtsmodel.Status, list *gtsmodel.List, timelineOwnerAccount *gtsmodel.Account, filtered StatusFilterFunc) (bool, error) {
+	l := f.log.WithFields(logrus.Fields{
+		"func":     "StatusListTimelineable",
+		"statusID": targetStatus.ID,
+		"listID":   list.ID,
+	})
+
+	// a status that can't be in the home timeline can't be in a list timeline either
+	timelineable, err := f.StatusHometimelineable(ctx, targetStatus, timelineOwnerAccount, filtered)
+	if err != nil {
+		return false, fmt.Errorf("StatusListTimelineable: error checking hometimelineability of status with id %s: %s", targetStatus.ID, err)
+	}
+
+	if !timelineable {
+		return false, nil
+	}
+
+	// if it's not a reply, or it's a reply to the list owner, or a self-reply, then the replies policy doesn't come into play
+	if targetStatus.InReplyToAccountID == "" ||
+		targetStatus.InReplyToAccountID == timelineOwnerAccount.ID ||
+		targetStatus.InReplyToAccountID == targetStatus.AccountID {
+		return true, nil
+	}
+
+	switch list.RepliesPolicy {
+	case gtsmodel.RepliesPolicyNone:
+		l.Debug("status is not listtimelineable because the list doesn't show replies")
+		return false, nil
+	case gtsmodel.RepliesPolicyList:
+		// make sure the replied-to account is also a member of this list
+		entries, err := f.db.GetListEntries(ctx, list.ID, "", "", "", 0)
+		if err != nil {
+			return false, fmt.Errorf("StatusListTimelineable: error getting entries of list with id %s: %s", list.ID, err)
+		}
+
+		for _, e := range entries {
+			if e.Follow != nil && e.Follow.TargetAccountID == targetStatus.InReplyToAccountID {
+				return true, nil
+			}
+		}
+
+		l.Debug("status is not listtimelineable because the replied-to account is not a member of the list")
+		return false, nil
+	default:
+		// StatusHometimelineable already established that the replied-to account is followed
+		return true, nil
+	}
+}
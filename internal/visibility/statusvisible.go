@@ -125,6 +125,19 @@ func (f *filter) StatusVisible(ctx context.Context, targetStatus *gtsmodel.Statu
 		return true, nil
 	}
 
+	// don't show statuses by--or boosts of--accounts on domains that the requesting account has blocked
+	for _, a := range []*gtsmodel.Account{targetAccount, relevantAccounts.BoostedAccount} {
+		if a == nil || a.Domain == "" {
+			continue
+		}
+		if blocked, err := f.db.IsDomainBlockedByAccount(ctx, requestingAccount.ID, a.Domain); err != nil {
+			return false, err
+		} else if blocked {
+			l.Trace("requesting account has blocked the domain of the target account or boosted account")
+			return false, nil
+		}
+	}
+
 	// At this point we have a populated targetAccount, targetStatus, and requestingAccount, so we can check for blocks and whathaveyou
 	// First check if a block exists directly between the target account (which authored the status) and the requesting account.
 	if blocked, err := f.db.IsBlocked(ctx, targetAccount.ID, requestingAccount.ID, true); err != nil {
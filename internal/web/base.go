@@ -101,6 +101,13 @@ func (m *Module) Route(s router.Router) error {
 	// serve front-page
 	s.AttachHandler(http.MethodGet, "/", m.baseHandler)
 
+	// email confirmation and password reset pages
+	s.AttachHandler(http.MethodGet, confirmEmailPath, m.confirmEmailGETHandler)
+	s.AttachHandler(http.MethodGet, resetPasswordPath, m.resetPasswordGETHandler)
+	s.AttachHandler(http.MethodPost, resetPasswordPath, m.resetPasswordPOSTHandler)
+	s.AttachHandler(http.MethodGet, resetPasswordChangePath, m.resetPasswordChangeGETHandler)
+	s.AttachHandler(http.MethodPost, resetPasswordChangePath, m.resetPasswordChangePOSTHandler)
+
 	// 404 handler
 	s.AttachNoRouteHandler(m.NotFoundHandler)
 
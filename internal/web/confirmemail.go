@@ -0,0 +1,59 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// confirmEmailPath is the path at which email confirmation links are handled.
+	confirmEmailPath = "/confirm_email"
+	// tokenKey is the query or form key of a confirmation or reset password token.
+	tokenKey = "token"
+)
+
+func (m *Module) confirmEmailGETHandler(c *gin.Context) {
+	l := m.log.WithField("func", "confirmEmailGETHandler")
+	l.Trace("serving email confirmation page")
+
+	instance, err := m.processor.InstanceGet(c.Request.Context(), m.config.Host)
+	if err != nil {
+		l.Debugf("error getting instance from processor: %s", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	user, errWithCode := m.processor.UserConfirmEmail(c.Request.Context(), c.Query(tokenKey))
+	if errWithCode != nil {
+		l.Debugf("error confirming email: %s", errWithCode.Error())
+		c.HTML(errWithCode.Code(), "confirmed.tmpl", gin.H{
+			"instance": instance,
+			"error":    errWithCode.Safe(),
+		})
+		return
+	}
+
+	c.HTML(http.StatusOK, "confirmed.tmpl", gin.H{
+		"instance": instance,
+		"email":    user.Email,
+	})
+}
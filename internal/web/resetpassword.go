@@ -0,0 +1,130 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// resetPasswordPath is the path at which a password reset email can be requested.
+	resetPasswordPath = "/reset_password"
+	// resetPasswordChangePath is the path linked to from password reset emails, where a new password can be set.
+	resetPasswordChangePath = "/reset_password/change"
+)
+
+func (m *Module) resetPasswordGETHandler(c *gin.Context) {
+	l := m.log.WithField("func", "resetPasswordGETHandler")
+	l.Trace("serving reset password page")
+
+	instance, err := m.processor.InstanceGet(c.Request.Context(), m.config.Host)
+	if err != nil {
+		l.Debugf("error getting instance from processor: %s", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.HTML(http.StatusOK, "reset-password.tmpl", gin.H{
+		"instance": instance,
+	})
+}
+
+func (m *Module) resetPasswordPOSTHandler(c *gin.Context) {
+	l := m.log.WithField("func", "resetPasswordPOSTHandler")
+
+	instance, err := m.processor.InstanceGet(c.Request.Context(), m.config.Host)
+	if err != nil {
+		l.Debugf("error getting instance from processor: %s", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if errWithCode := m.processor.UserResetPasswordRequest(c.Request.Context(), c.PostForm("email")); errWithCode != nil {
+		l.Debugf("error requesting password reset: %s", errWithCode.Error())
+		c.HTML(errWithCode.Code(), "reset-password.tmpl", gin.H{
+			"instance": instance,
+			"error":    errWithCode.Safe(),
+		})
+		return
+	}
+
+	c.HTML(http.StatusOK, "reset-password.tmpl", gin.H{
+		"instance": instance,
+		"sent":     true,
+	})
+}
+
+func (m *Module) resetPasswordChangeGETHandler(c *gin.Context) {
+	l := m.log.WithField("func", "resetPasswordChangeGETHandler")
+	l.Trace("serving reset password change page")
+
+	instance, err := m.processor.InstanceGet(c.Request.Context(), m.config.Host)
+	if err != nil {
+		l.Debugf("error getting instance from processor: %s", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	token := c.Query(tokenKey)
+	if token == "" {
+		c.HTML(http.StatusNotFound, "reset-password-change.tmpl", gin.H{
+			"instance": instance,
+			"error":    "reset link is not valid, please ask for a new one",
+		})
+		return
+	}
+
+	c.HTML(http.StatusOK, "reset-password-change.tmpl", gin.H{
+		"instance": instance,
+		"token":    token,
+	})
+}
+
+func (m *Module) resetPasswordChangePOSTHandler(c *gin.Context) {
+	l := m.log.WithField("func", "resetPasswordChangePOSTHandler")
+
+	instance, err := m.processor.InstanceGet(c.Request.Context(), m.config.Host)
+	if err != nil {
+		l.Debugf("error getting instance from processor: %s", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	token := c.PostForm(tokenKey)
+	if errWithCode := m.processor.UserResetPassword(c.Request.Context(), token, c.PostForm("password")); errWithCode != nil {
+		l.Debugf("error resetting password: %s", errWithCode.Error())
+		h := gin.H{
+			"instance": instance,
+			"error":    errWithCode.Safe(),
+		}
+		if errWithCode.Code() == http.StatusBadRequest {
+			// the token is fine but the password isn't, so let the user try again
+			h["token"] = token
+		}
+		c.HTML(errWithCode.Code(), "reset-password-change.tmpl", h)
+		return
+	}
+
+	c.HTML(http.StatusOK, "reset-password-change.tmpl", gin.H{
+		"instance": instance,
+		"done":     true,
+	})
+}
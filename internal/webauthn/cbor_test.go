@@ -0,0 +1,73 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package webauthn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CBORTestSuite struct {
+	suite.Suite
+}
+
+func (suite *CBORTestSuite) TestDecode() {
+	// {1: 2, "a": [-1, h'0102', true, null]} followed by a trailing byte
+	b := []byte{0xa2, 0x01, 0x02, 0x61, 0x61, 0x84, 0x20, 0x42, 0x01, 0x02, 0xf5, 0xf6, 0xff}
+
+	decoded, rest, err := decodeCBOR(b)
+	suite.NoError(err)
+	suite.Equal([]byte{0xff}, rest)
+	suite.Equal(map[interface{}]interface{}{
+		int64(1): int64(2),
+		"a":      []interface{}{int64(-1), []byte{0x01, 0x02}, true, nil},
+	}, decoded)
+}
+
+func (suite *CBORTestSuite) TestDecodeDuplicateKey() {
+	_, _, err := decodeCBOR([]byte{0xa2, 0x01, 0x02, 0x01, 0x03})
+	suite.EqualError(err, "cbor: duplicate map key 1")
+}
+
+func (suite *CBORTestSuite) TestDecodeTruncated() {
+	_, _, err := decodeCBOR([]byte{0x45, 0x01, 0x02})
+	suite.EqualError(err, "cbor: string is longer than remaining data")
+}
+
+func (suite *CBORTestSuite) TestDecodeTooDeep() {
+	b := []byte{}
+	for i := 0; i < 20; i++ {
+		b = append(b, 0x81)
+	}
+	b = append(b, 0x00)
+
+	_, _, err := decodeCBOR(b)
+	suite.EqualError(err, "cbor: nested too deeply")
+}
+
+func (suite *CBORTestSuite) TestDecodeUnsupported() {
+	// a half precision float
+	_, _, err := decodeCBOR([]byte{0xf9, 0x3c, 0x00})
+	suite.EqualError(err, "cbor: unsupported simple value or float 25")
+}
+
+func TestCBORTestSuite(t *testing.T) {
+	suite.Run(t, new(CBORTestSuite))
+}
@@ -0,0 +1,108 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package webauthn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// UserCredentials returns all the credentials registered by the given user, oldest first.
+func UserCredentials(ctx context.Context, database db.DB, userID string) ([]*gtsmodel.WebauthnCredential, error) {
+	credentials := []*gtsmodel.WebauthnCredential{}
+	if err := database.GetWhere(ctx, []db.Where{{Key: "user_id", Value: userID}}, &credentials); err != nil && err != db.ErrNoEntries {
+		return nil, fmt.Errorf("UserCredentials: error getting credentials: %s", err)
+	}
+
+	sort.Slice(credentials, func(i, j int) bool {
+		if !credentials[i].CreatedAt.Equal(credentials[j].CreatedAt) {
+			return credentials[i].CreatedAt.Before(credentials[j].CreatedAt)
+		}
+		return credentials[i].ID < credentials[j].ID
+	})
+
+	return credentials, nil
+}
+
+// CredentialIDs returns the credential ids of the given credentials, for passing to RegistrationOptions or LoginOptions.
+func CredentialIDs(credentials []*gtsmodel.WebauthnCredential) []string {
+	ids := make([]string, 0, len(credentials))
+	for _, c := range credentials {
+		ids = append(ids, c.CredentialID)
+	}
+	return ids
+}
+
+// Login checks an assertion given by a user on the sign in page, and returns the user that the credential belongs to.
+// The signature counter and last used time of the credential are updated.
+//
+// If userID is set, the user has already given their password and the credential is being used as a second factor,
+// so it must belong to that user. Otherwise, the credential is being used instead of a password, so the authenticator
+// must have verified the user.
+func Login(ctx context.Context, database db.DB, rp *RelyingParty, challenge string, resp *AssertionResponse, userID string) (*gtsmodel.User, error) {
+	// normalise the id so that it matches what we stored
+	rawID, err := Encoding.DecodeString(resp.ID)
+	if err != nil {
+		return nil, fmt.Errorf("Login: error decoding credential id: %s", err)
+	}
+
+	credential := &gtsmodel.WebauthnCredential{}
+	if err := database.GetWhere(ctx, []db.Where{{Key: "credential_id", Value: Encoding.EncodeToString(rawID)}}, credential); err != nil {
+		return nil, fmt.Errorf("Login: error getting credential: %s", err)
+	}
+
+	passwordless := userID == ""
+	if !passwordless && credential.UserID != userID {
+		return nil, errors.New("Login: credential belongs to a different user")
+	}
+
+	user := &gtsmodel.User{}
+	if err := database.GetByID(ctx, credential.UserID, user); err != nil {
+		return nil, fmt.Errorf("Login: error getting user: %s", err)
+	}
+
+	if resp.Response.UserHandle != "" && resp.Response.UserHandle != user.WebauthnID {
+		return nil, errors.New("Login: user handle didn't match credential")
+	}
+
+	publicKey, err := Encoding.DecodeString(credential.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("Login: error decoding stored public key: %s", err)
+	}
+
+	signCount, err := rp.VerifyAssertion(challenge, resp, publicKey, uint32(credential.SignCount), passwordless)
+	if err != nil {
+		return nil, fmt.Errorf("Login: %s", err)
+	}
+
+	credential.SignCount = int64(signCount)
+	credential.LastUsedAt = time.Now()
+	credential.UpdatedAt = time.Now()
+	if err := database.UpdateByID(ctx, credential.ID, credential); err != nil {
+		return nil, fmt.Errorf("Login: error updating credential: %s", err)
+	}
+
+	return user, nil
+}
@@ -0,0 +1,442 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package webauthn implements the relying party side of Web Authentication (https://www.w3.org/TR/webauthn-2/),
+// so that users can sign in with security keys and passkeys.
+//
+// Only what we need is implemented: we ask for no attestation and don't check attestation statements, so any
+// authenticator that can make ES256, EdDSA, or RS256 signatures can be registered.
+package webauthn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+// Timeout is how long users have to complete a registration or sign in once they've been given a challenge.
+const Timeout = 5 * time.Minute
+
+const (
+	randomLength = 32
+
+	flagUserPresent            byte = 0x01
+	flagUserVerified           byte = 0x04
+	flagAttestedCredentialData byte = 0x40
+
+	// UserVerificationRequired means that the authenticator must check that it's really the user, eg., with a PIN or fingerprint.
+	UserVerificationRequired = "required"
+	// UserVerificationPreferred means that the authenticator should check that it's really the user if it can.
+	UserVerificationPreferred = "preferred"
+)
+
+// Encoding is used for all binary values that are passed to and from browsers as JSON.
+var Encoding = base64.RawURLEncoding
+
+// RelyingParty describes this instance as a WebAuthn relying party. Credentials are scoped to the
+// instance's host name, and are only accepted from pages served by the instance itself.
+type RelyingParty struct {
+	ID     string
+	Name   string
+	Origin string
+}
+
+// NewRelyingParty returns the relying party for the instance with the given config.
+func NewRelyingParty(c *config.Config) (*RelyingParty, error) {
+	u, err := url.Parse(c.Protocol + "://" + c.Host)
+	if err != nil {
+		return nil, fmt.Errorf("NewRelyingParty: error parsing host: %s", err)
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("NewRelyingParty: could not get hostname from %s", c.Host)
+	}
+
+	return &RelyingParty{
+		ID:     u.Hostname(),
+		Name:   c.Host,
+		Origin: u.Scheme + "://" + u.Host,
+	}, nil
+}
+
+// NewChallenge returns a new random challenge, encoded with Encoding.
+func NewChallenge() (string, error) {
+	return randomString()
+}
+
+// NewUserHandle returns a new random user handle, encoded with Encoding. User handles are stored by
+// authenticators alongside discoverable credentials, so they shouldn't reveal anything about the user.
+func NewUserHandle() (string, error) {
+	return randomString()
+}
+
+func randomString() (string, error) {
+	b := make([]byte, randomLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error reading random bytes: %s", err)
+	}
+	return Encoding.EncodeToString(b), nil
+}
+
+// CredentialDescriptor identifies a credential.
+type CredentialDescriptor struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// CredentialCreationOptions should be passed to navigator.credentials.create(), after decoding the binary values.
+type CredentialCreationOptions struct {
+	PublicKey PublicKeyCredentialCreationOptions `json:"publicKey"`
+}
+
+// PublicKeyCredentialCreationOptions is described at https://www.w3.org/TR/webauthn-2/#dictionary-makecredentialoptions
+type PublicKeyCredentialCreationOptions struct {
+	Challenge string `json:"challenge"`
+	RP        struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"rp"`
+	User struct {
+		ID          string `json:"id"`
+		Name        string `json:"name"`
+		DisplayName string `json:"displayName"`
+	} `json:"user"`
+	PubKeyCredParams       []PublicKeyCredentialParameters `json:"pubKeyCredParams"`
+	Timeout                int64                           `json:"timeout"`
+	ExcludeCredentials     []CredentialDescriptor          `json:"excludeCredentials"`
+	AuthenticatorSelection struct {
+		ResidentKey      string `json:"residentKey"`
+		UserVerification string `json:"userVerification"`
+	} `json:"authenticatorSelection"`
+	Attestation string `json:"attestation"`
+}
+
+// PublicKeyCredentialParameters names a type of credential that we accept.
+type PublicKeyCredentialParameters struct {
+	Type string `json:"type"`
+	Alg  int64  `json:"alg"`
+}
+
+// CredentialRequestOptions should be passed to navigator.credentials.get(), after decoding the binary values.
+type CredentialRequestOptions struct {
+	PublicKey PublicKeyCredentialRequestOptions `json:"publicKey"`
+}
+
+// PublicKeyCredentialRequestOptions is described at https://www.w3.org/TR/webauthn-2/#dictionary-assertion-options
+type PublicKeyCredentialRequestOptions struct {
+	Challenge        string                 `json:"challenge"`
+	Timeout          int64                  `json:"timeout"`
+	RPID             string                 `json:"rpId"`
+	AllowCredentials []CredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                 `json:"userVerification"`
+}
+
+// AttestationResponse is the result of navigator.credentials.create(), with binary values encoded with Encoding.
+type AttestationResponse struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Response struct {
+		ClientDataJSON    string `json:"clientDataJSON"`
+		AttestationObject string `json:"attestationObject"`
+	} `json:"response"`
+}
+
+// AssertionResponse is the result of navigator.credentials.get(), with binary values encoded with Encoding.
+type AssertionResponse struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Response struct {
+		ClientDataJSON    string `json:"clientDataJSON"`
+		AuthenticatorData string `json:"authenticatorData"`
+		Signature         string `json:"signature"`
+		UserHandle        string `json:"userHandle"`
+	} `json:"response"`
+}
+
+// Credential is a newly registered credential.
+type Credential struct {
+	// ID of the credential, chosen by the authenticator.
+	ID []byte
+	// PublicKey of the credential, COSE encoded.
+	PublicKey []byte
+	// SignCount is the signature counter of the authenticator when the credential was registered.
+	SignCount uint32
+}
+
+// RegistrationOptions returns options for registering a new credential for a user, with the user handle from
+// NewUserHandle. Credentials that are already registered by the user should be passed as excludeCredentialIDs,
+// so that authenticators don't make a second one.
+func (rp *RelyingParty) RegistrationOptions(challenge string, userHandle string, username string, displayName string, excludeCredentialIDs []string) *CredentialCreationOptions {
+	o := &CredentialCreationOptions{}
+	o.PublicKey.Challenge = challenge
+	o.PublicKey.RP.ID = rp.ID
+	o.PublicKey.RP.Name = rp.Name
+	o.PublicKey.User.ID = userHandle
+	o.PublicKey.User.Name = username
+	o.PublicKey.User.DisplayName = displayName
+	o.PublicKey.PubKeyCredParams = []PublicKeyCredentialParameters{
+		{Type: "public-key", Alg: AlgES256},
+		{Type: "public-key", Alg: AlgEdDSA},
+		{Type: "public-key", Alg: AlgRS256},
+	}
+	o.PublicKey.Timeout = Timeout.Milliseconds()
+	o.PublicKey.ExcludeCredentials = descriptors(excludeCredentialIDs)
+	// discoverable credentials are what let users sign in without a password
+	o.PublicKey.AuthenticatorSelection.ResidentKey = "preferred"
+	o.PublicKey.AuthenticatorSelection.UserVerification = UserVerificationPreferred
+	o.PublicKey.Attestation = "none"
+	return o
+}
+
+// LoginOptions returns options for signing in. If allowCredentialIDs is empty, the authenticator may use any
+// discoverable credential it has for this relying party, which is how passwordless sign in works.
+func (rp *RelyingParty) LoginOptions(challenge string, allowCredentialIDs []string, userVerification string) *CredentialRequestOptions {
+	o := &CredentialRequestOptions{}
+	o.PublicKey.Challenge = challenge
+	o.PublicKey.Timeout = Timeout.Milliseconds()
+	o.PublicKey.RPID = rp.ID
+	o.PublicKey.AllowCredentials = descriptors(allowCredentialIDs)
+	o.PublicKey.UserVerification = userVerification
+	return o
+}
+
+func descriptors(ids []string) []CredentialDescriptor {
+	d := make([]CredentialDescriptor, 0, len(ids))
+	for _, id := range ids {
+		d = append(d, CredentialDescriptor{Type: "public-key", ID: id})
+	}
+	return d
+}
+
+// VerifyRegistration checks the response from navigator.credentials.create() against the challenge that was
+// given in the registration options, and returns the new credential if it's valid.
+//
+// See https://www.w3.org/TR/webauthn-2/#sctn-registering-a-new-credential
+func (rp *RelyingParty) VerifyRegistration(challenge string, resp *AttestationResponse) (*Credential, error) {
+	if resp.Type != "public-key" {
+		return nil, fmt.Errorf("VerifyRegistration: unexpected credential type %q", resp.Type)
+	}
+
+	if _, err := rp.verifyClientData(resp.Response.ClientDataJSON, "webauthn.create", challenge); err != nil {
+		return nil, fmt.Errorf("VerifyRegistration: %s", err)
+	}
+
+	attestationObject, err := Encoding.DecodeString(resp.Response.AttestationObject)
+	if err != nil {
+		return nil, fmt.Errorf("VerifyRegistration: error decoding attestation object: %s", err)
+	}
+
+	decoded, rest, err := decodeCBOR(attestationObject)
+	if err != nil {
+		return nil, fmt.Errorf("VerifyRegistration: error decoding attestation object: %s", err)
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("VerifyRegistration: trailing data after attestation object")
+	}
+
+	m, ok := decoded.(map[interface{}]interface{})
+	if !ok {
+		return nil, errors.New("VerifyRegistration: attestation object was not a map")
+	}
+
+	// we asked for no attestation, so there's nothing to check in the attestation statement even if
+	// the authenticator gave us one anyway; we treat every credential as unattested
+	if _, ok := m["fmt"].(string); !ok {
+		return nil, errors.New("VerifyRegistration: attestation object had no fmt")
+	}
+
+	rawAuthData, ok := m["authData"].([]byte)
+	if !ok {
+		return nil, errors.New("VerifyRegistration: attestation object had no authData")
+	}
+
+	authData, err := rp.parseAuthenticatorData(rawAuthData, false)
+	if err != nil {
+		return nil, fmt.Errorf("VerifyRegistration: %s", err)
+	}
+
+	if authData.flags&flagAttestedCredentialData == 0 {
+		return nil, errors.New("VerifyRegistration: authenticator data had no attested credential data")
+	}
+
+	if _, err := parsePublicKey(authData.publicKey); err != nil {
+		return nil, fmt.Errorf("VerifyRegistration: %s", err)
+	}
+
+	if resp.ID != Encoding.EncodeToString(authData.credentialID) {
+		return nil, errors.New("VerifyRegistration: credential id didn't match authenticator data")
+	}
+
+	return &Credential{
+		ID:        authData.credentialID,
+		PublicKey: authData.publicKey,
+		SignCount: authData.signCount,
+	}, nil
+}
+
+// VerifyAssertion checks the response from navigator.credentials.get() against the challenge that was given in
+// the login options, and the stored public key and signature counter of the credential it claims to be from.
+// If requireUserVerification is true, the authenticator must have verified the user, eg., with a PIN or fingerprint.
+// The new signature counter is returned, which should be stored on the credential.
+//
+// See https://www.w3.org/TR/webauthn-2/#sctn-verifying-assertion
+func (rp *RelyingParty) VerifyAssertion(challenge string, resp *AssertionResponse, cosePublicKey []byte, storedSignCount uint32, requireUserVerification bool) (uint32, error) {
+	if resp.Type != "public-key" {
+		return 0, fmt.Errorf("VerifyAssertion: unexpected credential type %q", resp.Type)
+	}
+
+	clientDataJSON, err := rp.verifyClientData(resp.Response.ClientDataJSON, "webauthn.get", challenge)
+	if err != nil {
+		return 0, fmt.Errorf("VerifyAssertion: %s", err)
+	}
+
+	rawAuthData, err := Encoding.DecodeString(resp.Response.AuthenticatorData)
+	if err != nil {
+		return 0, fmt.Errorf("VerifyAssertion: error decoding authenticator data: %s", err)
+	}
+
+	authData, err := rp.parseAuthenticatorData(rawAuthData, requireUserVerification)
+	if err != nil {
+		return 0, fmt.Errorf("VerifyAssertion: %s", err)
+	}
+
+	signature, err := Encoding.DecodeString(resp.Response.Signature)
+	if err != nil {
+		return 0, fmt.Errorf("VerifyAssertion: error decoding signature: %s", err)
+	}
+
+	key, err := parsePublicKey(cosePublicKey)
+	if err != nil {
+		return 0, fmt.Errorf("VerifyAssertion: error parsing stored public key: %s", err)
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(append([]byte{}, rawAuthData...), clientDataHash[:]...)
+	if err := key.verify(signed, signature); err != nil {
+		return 0, fmt.Errorf("VerifyAssertion: %s", err)
+	}
+
+	// a counter that hasn't gone up means the credential may have been cloned, unless the authenticator doesn't keep a counter at all
+	if (authData.signCount != 0 || storedSignCount != 0) && authData.signCount <= storedSignCount {
+		return 0, fmt.Errorf("VerifyAssertion: signature counter %d was not greater than stored counter %d", authData.signCount, storedSignCount)
+	}
+
+	return authData.signCount, nil
+}
+
+// clientData is described at https://www.w3.org/TR/webauthn-2/#dictionary-client-data
+type clientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+// verifyClientData decodes the given client data, checks it, and returns the raw JSON for hashing.
+func (rp *RelyingParty) verifyClientData(encoded string, expectedType string, challenge string) ([]byte, error) {
+	raw, err := Encoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding client data: %s", err)
+	}
+
+	c := &clientData{}
+	if err := json.Unmarshal(raw, c); err != nil {
+		return nil, fmt.Errorf("error unmarshalling client data: %s", err)
+	}
+
+	if c.Type != expectedType {
+		return nil, fmt.Errorf("client data type was %q, expected %q", c.Type, expectedType)
+	}
+
+	if challenge == "" || subtle.ConstantTimeCompare([]byte(c.Challenge), []byte(challenge)) != 1 {
+		return nil, errors.New("client data challenge did not match")
+	}
+
+	if c.Origin != rp.Origin {
+		return nil, fmt.Errorf("client data origin was %q, expected %q", c.Origin, rp.Origin)
+	}
+
+	return raw, nil
+}
+
+// authenticatorData is described at https://www.w3.org/TR/webauthn-2/#sctn-authenticator-data
+type authenticatorData struct {
+	flags     byte
+	signCount uint32
+
+	// only set if flagAttestedCredentialData is set
+	credentialID []byte
+	publicKey    []byte
+}
+
+// parseAuthenticatorData parses the given authenticator data, and checks that it's for this relying party and that the user was present.
+func (rp *RelyingParty) parseAuthenticatorData(b []byte, requireUserVerification bool) (*authenticatorData, error) {
+	if len(b) < 37 {
+		return nil, errors.New("authenticator data too short")
+	}
+
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+	if !bytes.Equal(b[:32], rpIDHash[:]) {
+		return nil, errors.New("authenticator data was for a different relying party")
+	}
+
+	d := &authenticatorData{
+		flags:     b[32],
+		signCount: binary.BigEndian.Uint32(b[33:37]),
+	}
+
+	if d.flags&flagUserPresent == 0 {
+		return nil, errors.New("user was not present")
+	}
+
+	if requireUserVerification && d.flags&flagUserVerified == 0 {
+		return nil, errors.New("user was not verified")
+	}
+
+	if d.flags&flagAttestedCredentialData != 0 {
+		rest := b[37:]
+		// 16 bytes of aaguid followed by a two byte credential id length
+		if len(rest) < 18 {
+			return nil, errors.New("attested credential data too short")
+		}
+		idLength := int(binary.BigEndian.Uint16(rest[16:18]))
+		rest = rest[18:]
+		if len(rest) < idLength {
+			return nil, errors.New("credential id longer than authenticator data")
+		}
+		d.credentialID = rest[:idLength]
+		rest = rest[idLength:]
+
+		// the public key runs up to whatever extension data follows it, so decode it to find its length
+		_, afterKey, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding credential public key: %s", err)
+		}
+		d.publicKey = rest[:len(rest)-len(afterKey)]
+	}
+
+	return d, nil
+}
@@ -0,0 +1,228 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package webauthn_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/webauthn"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type WebauthnTestSuite struct {
+	suite.Suite
+	config *config.Config
+	db     db.DB
+
+	testUsers map[string]*gtsmodel.User
+
+	rp            *webauthn.RelyingParty
+	authenticator *testrig.WebauthnAuthenticator
+}
+
+func (suite *WebauthnTestSuite) SetupSuite() {
+	suite.testUsers = testrig.NewTestUsers()
+}
+
+func (suite *WebauthnTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	testrig.StandardDBSetup(suite.db, nil)
+
+	rp, err := webauthn.NewRelyingParty(suite.config)
+	suite.NoError(err)
+	suite.rp = rp
+	suite.authenticator = testrig.NewWebauthnAuthenticator(rp.Origin)
+}
+
+func (suite *WebauthnTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+}
+
+func (suite *WebauthnTestSuite) challenge() string {
+	challenge, err := webauthn.NewChallenge()
+	suite.NoError(err)
+	return challenge
+}
+
+// register creates a credential with the software authenticator and stores it for the given user.
+func (suite *WebauthnTestSuite) register(user *gtsmodel.User) *gtsmodel.WebauthnCredential {
+	challenge := suite.challenge()
+	resp, err := suite.authenticator.Create(suite.rp.RegistrationOptions(challenge, user.WebauthnID, user.Email, user.Email, nil))
+	suite.NoError(err)
+
+	credential, err := suite.rp.VerifyRegistration(challenge, resp)
+	suite.NoError(err)
+
+	credentialID, err := id.NewRandomULID()
+	suite.NoError(err)
+
+	c := &gtsmodel.WebauthnCredential{
+		ID:           credentialID,
+		UserID:       user.ID,
+		Name:         "test key",
+		CredentialID: webauthn.Encoding.EncodeToString(credential.ID),
+		PublicKey:    webauthn.Encoding.EncodeToString(credential.PublicKey),
+		SignCount:    int64(credential.SignCount),
+	}
+	suite.NoError(suite.db.Put(context.Background(), c))
+	return c
+}
+
+func (suite *WebauthnTestSuite) TestNewRelyingParty() {
+	suite.Equal("localhost", suite.rp.ID)
+	suite.Equal("localhost:8080", suite.rp.Name)
+	suite.Equal("http://localhost:8080", suite.rp.Origin)
+}
+
+func (suite *WebauthnTestSuite) TestRegistrationOptions() {
+	options := suite.rp.RegistrationOptions("challenge", "handle", "zork", "Zork", []string{"existing"})
+
+	b, err := json.Marshal(options)
+	suite.NoError(err)
+	suite.Equal(`{"publicKey":{"challenge":"challenge","rp":{"id":"localhost","name":"localhost:8080"},"user":{"id":"handle","name":"zork","displayName":"Zork"},"pubKeyCredParams":[{"type":"public-key","alg":-7},{"type":"public-key","alg":-8},{"type":"public-key","alg":-257}],"timeout":300000,"excludeCredentials":[{"type":"public-key","id":"existing"}],"authenticatorSelection":{"residentKey":"preferred","userVerification":"preferred"},"attestation":"none"}}`, string(b))
+}
+
+func (suite *WebauthnTestSuite) TestVerifyRegistrationWrongChallenge() {
+	resp, err := suite.authenticator.Create(suite.rp.RegistrationOptions(suite.challenge(), "handle", "zork", "zork", nil))
+	suite.NoError(err)
+
+	_, err = suite.rp.VerifyRegistration(suite.challenge(), resp)
+	suite.EqualError(err, "VerifyRegistration: client data challenge did not match")
+}
+
+func (suite *WebauthnTestSuite) TestVerifyRegistrationWrongOrigin() {
+	suite.authenticator.Origin = "https://evil.example.org"
+	challenge := suite.challenge()
+	resp, err := suite.authenticator.Create(suite.rp.RegistrationOptions(challenge, "handle", "zork", "zork", nil))
+	suite.NoError(err)
+
+	_, err = suite.rp.VerifyRegistration(challenge, resp)
+	suite.EqualError(err, `VerifyRegistration: client data origin was "https://evil.example.org", expected "http://localhost:8080"`)
+}
+
+func (suite *WebauthnTestSuite) TestVerifyRegistrationWrongRP() {
+	challenge := suite.challenge()
+	options := suite.rp.RegistrationOptions(challenge, "handle", "zork", "zork", nil)
+	options.PublicKey.RP.ID = "example.org"
+	resp, err := suite.authenticator.Create(options)
+	suite.NoError(err)
+
+	_, err = suite.rp.VerifyRegistration(challenge, resp)
+	suite.EqualError(err, "VerifyRegistration: authenticator data was for a different relying party")
+}
+
+func (suite *WebauthnTestSuite) TestLoginSecondFactor() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+	c := suite.register(user)
+
+	challenge := suite.challenge()
+	resp, err := suite.authenticator.Get(suite.rp.LoginOptions(challenge, []string{c.CredentialID}, webauthn.UserVerificationPreferred))
+	suite.NoError(err)
+
+	loggedIn, err := webauthn.Login(ctx, suite.db, suite.rp, challenge, resp, user.ID)
+	suite.NoError(err)
+	suite.Equal(user.ID, loggedIn.ID)
+
+	// the counter and last used time should be updated
+	dbCredential := &gtsmodel.WebauthnCredential{}
+	suite.NoError(suite.db.GetByID(ctx, c.ID, dbCredential))
+	suite.EqualValues(1, dbCredential.SignCount)
+	suite.False(dbCredential.LastUsedAt.IsZero())
+
+	// the same response can't be used again, since the challenge is used up and the counter hasn't gone up
+	_, err = webauthn.Login(ctx, suite.db, suite.rp, challenge, resp, user.ID)
+	suite.EqualError(err, "Login: VerifyAssertion: signature counter 1 was not greater than stored counter 1")
+}
+
+func (suite *WebauthnTestSuite) TestLoginSecondFactorWrongUser() {
+	c := suite.register(suite.testUsers["local_account_1"])
+
+	challenge := suite.challenge()
+	resp, err := suite.authenticator.Get(suite.rp.LoginOptions(challenge, []string{c.CredentialID}, webauthn.UserVerificationPreferred))
+	suite.NoError(err)
+
+	_, err = webauthn.Login(context.Background(), suite.db, suite.rp, challenge, resp, suite.testUsers["local_account_2"].ID)
+	suite.EqualError(err, "Login: credential belongs to a different user")
+}
+
+func (suite *WebauthnTestSuite) TestLoginPasswordless() {
+	user := suite.testUsers["local_account_1"]
+	suite.register(user)
+
+	challenge := suite.challenge()
+	resp, err := suite.authenticator.Get(suite.rp.LoginOptions(challenge, nil, webauthn.UserVerificationRequired))
+	suite.NoError(err)
+
+	loggedIn, err := webauthn.Login(context.Background(), suite.db, suite.rp, challenge, resp, "")
+	suite.NoError(err)
+	suite.Equal(user.ID, loggedIn.ID)
+}
+
+func (suite *WebauthnTestSuite) TestLoginPasswordlessNeedsUserVerification() {
+	suite.register(suite.testUsers["local_account_1"])
+	suite.authenticator.SkipUserVerification = true
+
+	challenge := suite.challenge()
+	resp, err := suite.authenticator.Get(suite.rp.LoginOptions(challenge, nil, webauthn.UserVerificationRequired))
+	suite.NoError(err)
+
+	_, err = webauthn.Login(context.Background(), suite.db, suite.rp, challenge, resp, "")
+	suite.EqualError(err, "Login: VerifyAssertion: user was not verified")
+}
+
+func (suite *WebauthnTestSuite) TestLoginBadSignature() {
+	user := suite.testUsers["local_account_1"]
+	c := suite.register(user)
+
+	challenge := suite.challenge()
+	resp, err := suite.authenticator.Get(suite.rp.LoginOptions(challenge, []string{c.CredentialID}, webauthn.UserVerificationPreferred))
+	suite.NoError(err)
+
+	// sign a different challenge, and swap the signature in
+	other, err := suite.authenticator.Get(suite.rp.LoginOptions(suite.challenge(), []string{c.CredentialID}, webauthn.UserVerificationPreferred))
+	suite.NoError(err)
+	resp.Response.Signature = other.Response.Signature
+
+	_, err = webauthn.Login(context.Background(), suite.db, suite.rp, challenge, resp, user.ID)
+	suite.EqualError(err, "Login: VerifyAssertion: invalid ES256 signature")
+}
+
+func (suite *WebauthnTestSuite) TestLoginUnknownCredential() {
+	challenge := suite.challenge()
+	suite.register(suite.testUsers["local_account_1"])
+	resp, err := suite.authenticator.Get(suite.rp.LoginOptions(challenge, nil, webauthn.UserVerificationRequired))
+	suite.NoError(err)
+
+	resp.ID = webauthn.Encoding.EncodeToString([]byte("not a real credential"))
+	_, err = webauthn.Login(context.Background(), suite.db, suite.rp, challenge, resp, "")
+	suite.Error(err)
+}
+
+func TestWebauthnTestSuite(t *testing.T) {
+	suite.Run(t, new(WebauthnTestSuite))
+}
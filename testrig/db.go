@@ -35,8 +35,11 @@ var testModels []interface{} = []interface{}{
 	&gtsmodel.Block{},
 	&gtsmodel.DomainBlock{},
 	&gtsmodel.EmailDomainBlock{},
+	&gtsmodel.Filter{},
 	&gtsmodel.Follow{},
 	&gtsmodel.FollowRequest{},
+	&gtsmodel.List{},
+	&gtsmodel.ListEntry{},
 	&gtsmodel.MediaAttachment{},
 	&gtsmodel.Mention{},
 	&gtsmodel.Status{},
@@ -47,6 +50,25 @@ var testModels []interface{} = []interface{}{
 	&gtsmodel.StatusMute{},
 	&gtsmodel.Tag{},
 	&gtsmodel.User{},
+	&gtsmodel.UserMute{},
+	&gtsmodel.UserDomainBlock{},
+	&gtsmodel.Poll{},
+	&gtsmodel.PollVote{},
+	&gtsmodel.ScheduledStatus{},
+	&gtsmodel.StatusEdit{},
+	&gtsmodel.TagFollow{},
+	&gtsmodel.Conversation{},
+	&gtsmodel.ConversationToStatus{},
+	&gtsmodel.Report{},
+	&gtsmodel.AccountImport{},
+	&gtsmodel.AccountArchive{},
+	&gtsmodel.FeaturedTag{},
+	&gtsmodel.Endorsement{},
+	&gtsmodel.Suggestion{},
+	&gtsmodel.SuggestionDismissal{},
+	&gtsmodel.FeaturedAccount{},
+	&gtsmodel.QueuedNotificationEmail{},
+	&gtsmodel.WebauthnCredential{},
 	&gtsmodel.Emoji{},
 	&gtsmodel.Instance{},
 	&gtsmodel.Notification{},
@@ -174,6 +196,24 @@ func StandardDBSetup(db db.DB, accounts map[string]*gtsmodel.Account) {
 		}
 	}
 
+	for _, v := range NewTestLists() {
+		if err := db.Put(ctx, v); err != nil {
+			logrus.Panic(err)
+		}
+	}
+
+	for _, v := range NewTestListEntries() {
+		if err := db.Put(ctx, v); err != nil {
+			logrus.Panic(err)
+		}
+	}
+
+	for _, v := range NewTestFilters() {
+		if err := db.Put(ctx, v); err != nil {
+			logrus.Panic(err)
+		}
+	}
+
 	for _, v := range NewTestNotifications() {
 		if err := db.Put(ctx, v); err != nil {
 			logrus.Panic(err)
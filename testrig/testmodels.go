@@ -292,7 +292,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			FollowingURI:            "http://localhost:8080/users/weed_lord420/following",
 			FeaturedCollectionURI:   "http://localhost:8080/users/weed_lord420/collections/featured",
 			ActorType:               gtsmodel.ActivityStreamsPerson,
-			AlsoKnownAs:             "",
+			AlsoKnownAs:             []string{},
 			PrivateKey:              &rsa.PrivateKey{},
 			PublicKey:               &rsa.PublicKey{},
 			PublicKeyURI:            "http://localhost:8080/users/weed_lord420#main-key",
@@ -331,7 +331,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			FollowingURI:            "http://localhost:8080/users/admin/following",
 			FeaturedCollectionURI:   "http://localhost:8080/users/admin/collections/featured",
 			ActorType:               gtsmodel.ActivityStreamsPerson,
-			AlsoKnownAs:             "",
+			AlsoKnownAs:             []string{},
 			PrivateKey:              &rsa.PrivateKey{},
 			PublicKey:               &rsa.PublicKey{},
 			SensitizedAt:            time.Time{},
@@ -368,7 +368,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			FollowingURI:            "http://localhost:8080/users/the_mighty_zork/following",
 			FeaturedCollectionURI:   "http://localhost:8080/users/the_mighty_zork/collections/featured",
 			ActorType:               gtsmodel.ActivityStreamsPerson,
-			AlsoKnownAs:             "",
+			AlsoKnownAs:             []string{},
 			PrivateKey:              &rsa.PrivateKey{},
 			PublicKey:               &rsa.PublicKey{},
 			PublicKeyURI:            "http://localhost:8080/users/the_mighty_zork#main-key",
@@ -406,7 +406,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			FollowingURI:            "http://localhost:8080/users/1happyturtle/following",
 			FeaturedCollectionURI:   "http://localhost:8080/users/1happyturtle/collections/featured",
 			ActorType:               gtsmodel.ActivityStreamsPerson,
-			AlsoKnownAs:             "",
+			AlsoKnownAs:             []string{},
 			PrivateKey:              &rsa.PrivateKey{},
 			PublicKey:               &rsa.PublicKey{},
 			PublicKeyURI:            "http://localhost:8080/users/1happyturtle#main-key",
@@ -441,7 +441,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			FollowingURI:          "http://fossbros-anonymous.io/users/foss_satan/following",
 			FeaturedCollectionURI: "http://fossbros-anonymous.io/users/foss_satan/collections/featured",
 			ActorType:             gtsmodel.ActivityStreamsPerson,
-			AlsoKnownAs:           "",
+			AlsoKnownAs:           []string{},
 			PrivateKey:            &rsa.PrivateKey{},
 			PublicKey:             &rsa.PublicKey{},
 			PublicKeyURI:          "http://fossbros-anonymous.io/users/foss_satan/main-key",
@@ -1208,6 +1208,61 @@ func NewTestFollows() map[string]*gtsmodel.Follow {
 	}
 }
 
+// NewTestLists returns a map of gts model lists, keyed by a short description.
+func NewTestLists() map[string]*gtsmodel.List {
+	return map[string]*gtsmodel.List{
+		"local_account_1_list_1": {
+			ID:            "01FXKN6R3SWQ2PCE4ABK5AWCWS",
+			CreatedAt:     time.Now().Add(-30 * time.Minute),
+			UpdatedAt:     time.Now().Add(-30 * time.Minute),
+			Title:         "Cool Ass Posters From This Instance",
+			AccountID:     "01F8MH1H7YV1Z7D2C8K2730QBF",
+			RepliesPolicy: gtsmodel.RepliesPolicyFollowed,
+		},
+	}
+}
+
+// NewTestListEntries returns a map of gts model list entries, keyed by a short description.
+func NewTestListEntries() map[string]*gtsmodel.ListEntry {
+	return map[string]*gtsmodel.ListEntry{
+		"local_account_1_list_1_entry_1": {
+			ID:        "01FXKNF5JK3FQ8ZXT1S8TSVF5K",
+			CreatedAt: time.Now().Add(-20 * time.Minute),
+			UpdatedAt: time.Now().Add(-20 * time.Minute),
+			ListID:    "01FXKN6R3SWQ2PCE4ABK5AWCWS",
+			FollowID:  "01F8PYDCE8XE23GRE5DPZJDZDP",
+		},
+	}
+}
+
+// NewTestFilters returns a map of gts model filters, keyed by a short description.
+func NewTestFilters() map[string]*gtsmodel.Filter {
+	return map[string]*gtsmodel.Filter{
+		"local_account_1_filter_1": {
+			ID:                   "01FXQ2GHK0WPGB8JAFPZ2T7SVE",
+			CreatedAt:            time.Now().Add(-10 * time.Minute),
+			UpdatedAt:            time.Now().Add(-10 * time.Minute),
+			AccountID:            "01F8MH1H7YV1Z7D2C8K2730QBF",
+			Phrase:               "fnord",
+			ContextHome:          true,
+			ContextNotifications: true,
+			WholeWord:            true,
+			Irreversible:         true,
+		},
+		"local_account_1_filter_2_expired": {
+			ID:            "01FXQ2HW8NB1A3VRG6XBQE1R0Z",
+			CreatedAt:     time.Now().Add(-48 * time.Hour),
+			UpdatedAt:     time.Now().Add(-48 * time.Hour),
+			ExpiresAt:     time.Now().Add(-24 * time.Hour),
+			AccountID:     "01F8MH1H7YV1Z7D2C8K2730QBF",
+			Phrase:        "eggs",
+			ContextHome:   true,
+			ContextPublic: true,
+			Irreversible:  true,
+		},
+	}
+}
+
 // ActivityWithSignature wraps a pub.Activity along with its signature headers, for testing.
 type ActivityWithSignature struct {
 	Activity        pub.Activity
@@ -1256,6 +1311,12 @@ func NewTestFediPeople() map[string]vocab.ActivityStreamsPerson {
 	}
 	newPerson1Pub := &newPerson1Priv.PublicKey
 
+	newPerson2Priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	newPerson2Pub := &newPerson2Priv.PublicKey
+
 	return map[string]vocab.ActivityStreamsPerson{
 		"https://unknown-instance.com/users/brand_new_person": newPerson(
 			URLMustParse("https://unknown-instance.com/users/brand_new_person"),
@@ -1277,6 +1338,26 @@ func NewTestFediPeople() map[string]vocab.ActivityStreamsPerson {
 			"image/png",
 			false,
 		),
+		"https://unknown-instance.com/users/another_new_person": newPerson(
+			URLMustParse("https://unknown-instance.com/users/another_new_person"),
+			URLMustParse("https://unknown-instance.com/users/another_new_person/following"),
+			URLMustParse("https://unknown-instance.com/users/another_new_person/followers"),
+			URLMustParse("https://unknown-instance.com/users/another_new_person/inbox"),
+			URLMustParse("https://unknown-instance.com/users/another_new_person/outbox"),
+			URLMustParse("https://unknown-instance.com/users/another_new_person/collections/featured"),
+			"another_new_person",
+			"Another New Person",
+			"i'm also new here",
+			URLMustParse("https://unknown-instance.com/@another_new_person"),
+			true,
+			URLMustParse("https://unknown-instance.com/users/another_new_person#main-key"),
+			newPerson2Pub,
+			nil,
+			"image/jpeg",
+			nil,
+			"image/png",
+			false,
+		),
 	}
 }
 
@@ -1315,6 +1396,20 @@ func NewTestFediStatuses() map[string]vocab.ActivityStreamsNote {
 				),
 			},
 		),
+		"https://unknown-instance.com/users/another_new_person/statuses/01FGXBRWJ6JNSY0M4NA5PQ8WZW": newNote(
+			URLMustParse("https://unknown-instance.com/users/another_new_person/statuses/01FGXBRWJ6JNSY0M4NA5PQ8WZW"),
+			URLMustParse("https://unknown-instance.com/users/@another_new_person/01FGXBRWJ6JNSY0M4NA5PQ8WZW"),
+			time.Now(),
+			"Hello from another new person!",
+			"",
+			URLMustParse("https://unknown-instance.com/users/another_new_person"),
+			[]*url.URL{
+				URLMustParse("https://www.w3.org/ns/activitystreams#Public"),
+			},
+			[]*url.URL{},
+			false,
+			[]vocab.ActivityStreamsMention{},
+		),
 	}
 }
 
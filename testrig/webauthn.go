@@ -0,0 +1,273 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package testrig
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/superseriousbusiness/gotosocial/internal/webauthn"
+)
+
+// WebauthnAuthenticator is a software security key, which can be used in tests in place of a browser and
+// a real authenticator. It creates discoverable ES256 credentials, and doesn't give any attestation.
+type WebauthnAuthenticator struct {
+	// Origin is given in the client data, as a browser would.
+	Origin string
+	// SkipUserVerification makes the authenticator report that the user was present but not verified,
+	// like a security key without a PIN.
+	SkipUserVerification bool
+
+	credentials []*softCredential
+}
+
+type softCredential struct {
+	id         []byte
+	rpID       string
+	userHandle string
+	key        *ecdsa.PrivateKey
+	signCount  uint32
+}
+
+// NewWebauthnAuthenticator returns a software authenticator that acts as if it's being used from a page
+// served at the given origin, eg., http://localhost:8080.
+func NewWebauthnAuthenticator(origin string) *WebauthnAuthenticator {
+	return &WebauthnAuthenticator{
+		Origin: origin,
+	}
+}
+
+// Create makes a new credential from the given options, like navigator.credentials.create() would.
+func (a *WebauthnAuthenticator) Create(options *webauthn.CredentialCreationOptions) (*webauthn.AttestationResponse, error) {
+	o := options.PublicKey
+
+	for _, excluded := range o.ExcludeCredentials {
+		if c := a.find(o.RP.ID, excluded.ID); c != nil {
+			return nil, errors.New("authenticator already has a credential for this user")
+		}
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+
+	c := &softCredential{
+		id:         id,
+		rpID:       o.RP.ID,
+		userHandle: o.User.ID,
+		key:        key,
+	}
+	a.credentials = append(a.credentials, c)
+
+	clientDataJSON, err := a.clientData("webauthn.create", o.Challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	authData := a.authenticatorData(c)
+	authData = append(authData, make([]byte, 16)...) // aaguid, all zeroes since we don't give attestation
+	idLength := make([]byte, 2)
+	binary.BigEndian.PutUint16(idLength, uint16(len(c.id)))
+	authData = append(authData, idLength...)
+	authData = append(authData, c.id...)
+	authData = append(authData, cborMap(map[interface{}]interface{}{
+		int64(1):  int64(2), // kty: EC2
+		int64(3):  webauthn.AlgES256,
+		int64(-1): int64(1), // crv: P-256
+		int64(-2): padTo32(key.X.Bytes()),
+		int64(-3): padTo32(key.Y.Bytes()),
+	})...)
+	// set the attested credential data flag
+	authData[32] |= 0x40
+
+	attestationObject := cborMap(map[interface{}]interface{}{
+		"fmt":      "none",
+		"attStmt":  map[interface{}]interface{}{},
+		"authData": authData,
+	})
+
+	resp := &webauthn.AttestationResponse{
+		ID:   webauthn.Encoding.EncodeToString(c.id),
+		Type: "public-key",
+	}
+	resp.Response.ClientDataJSON = webauthn.Encoding.EncodeToString(clientDataJSON)
+	resp.Response.AttestationObject = webauthn.Encoding.EncodeToString(attestationObject)
+	return resp, nil
+}
+
+// Get signs in with a credential from the given options, like navigator.credentials.get() would.
+// If the options don't list any allowed credentials, the most recently created credential for the
+// relying party is used.
+func (a *WebauthnAuthenticator) Get(options *webauthn.CredentialRequestOptions) (*webauthn.AssertionResponse, error) {
+	o := options.PublicKey
+
+	var c *softCredential
+	if len(o.AllowCredentials) == 0 {
+		for _, candidate := range a.credentials {
+			if candidate.rpID == o.RPID {
+				c = candidate
+			}
+		}
+	} else {
+		for _, allowed := range o.AllowCredentials {
+			if c = a.find(o.RPID, allowed.ID); c != nil {
+				break
+			}
+		}
+	}
+	if c == nil {
+		return nil, errors.New("authenticator has no credential for this relying party")
+	}
+
+	clientDataJSON, err := a.clientData("webauthn.get", o.Challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	c.signCount++
+	authData := a.authenticatorData(c)
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, c.key, signed[:])
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &webauthn.AssertionResponse{
+		ID:   webauthn.Encoding.EncodeToString(c.id),
+		Type: "public-key",
+	}
+	resp.Response.ClientDataJSON = webauthn.Encoding.EncodeToString(clientDataJSON)
+	resp.Response.AuthenticatorData = webauthn.Encoding.EncodeToString(authData)
+	resp.Response.Signature = webauthn.Encoding.EncodeToString(signature)
+	resp.Response.UserHandle = c.userHandle
+	return resp, nil
+}
+
+func (a *WebauthnAuthenticator) find(rpID string, encodedID string) *softCredential {
+	for _, c := range a.credentials {
+		if c.rpID == rpID && webauthn.Encoding.EncodeToString(c.id) == encodedID {
+			return c
+		}
+	}
+	return nil
+}
+
+func (a *WebauthnAuthenticator) clientData(t string, challenge string) ([]byte, error) {
+	return json.Marshal(map[string]string{
+		"type":      t,
+		"challenge": challenge,
+		"origin":    a.Origin,
+	})
+}
+
+// authenticatorData returns the rp id hash, flags and counter for the given credential.
+func (a *WebauthnAuthenticator) authenticatorData(c *softCredential) []byte {
+	rpIDHash := sha256.Sum256([]byte(c.rpID))
+	flags := byte(0x01) // user present
+	if !a.SkipUserVerification {
+		flags |= 0x04 // user verified
+	}
+
+	d := append([]byte{}, rpIDHash[:]...)
+	d = append(d, flags)
+	counter := make([]byte, 4)
+	binary.BigEndian.PutUint32(counter, c.signCount)
+	return append(d, counter...)
+}
+
+func padTo32(b []byte) []byte {
+	return append(make([]byte, 32-len(b)), b...)
+}
+
+// cborMap encodes the given map as CBOR, supporting just enough types for
+// attestation objects and COSE keys. Keys are sorted so the output is stable.
+func cborMap(m map[interface{}]interface{}) []byte {
+	keys := make([]interface{}, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+
+	buf := &bytes.Buffer{}
+	cborHead(buf, 5, uint64(len(m)))
+	for _, k := range keys {
+		cborValue(buf, k)
+		cborValue(buf, m[k])
+	}
+	return buf.Bytes()
+}
+
+func cborValue(buf *bytes.Buffer, v interface{}) {
+	switch v := v.(type) {
+	case int64:
+		if v >= 0 {
+			cborHead(buf, 0, uint64(v))
+		} else {
+			cborHead(buf, 1, uint64(-1-v))
+		}
+	case []byte:
+		cborHead(buf, 2, uint64(len(v)))
+		buf.Write(v)
+	case string:
+		cborHead(buf, 3, uint64(len(v)))
+		buf.WriteString(v)
+	case map[interface{}]interface{}:
+		buf.Write(cborMap(v))
+	default:
+		panic(fmt.Sprintf("cborValue: unsupported type %T", v))
+	}
+}
+
+func cborHead(buf *bytes.Buffer, major byte, n uint64) {
+	switch {
+	case n < 24:
+		buf.WriteByte(major<<5 | byte(n))
+	case n <= 0xff:
+		buf.WriteByte(major<<5 | 24)
+		buf.WriteByte(byte(n))
+	case n <= 0xffff:
+		buf.WriteByte(major<<5 | 25)
+		b := make([]byte, 2)
+		binary.BigEndian.PutUint16(b, uint16(n))
+		buf.Write(b)
+	default:
+		buf.WriteByte(major<<5 | 26)
+		b := make([]byte, 4)
+		binary.BigEndian.PutUint32(b, uint32(n))
+		buf.Write(b)
+	}
+}
@@ -0,0 +1,96 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+"use strict";
+
+// Signs in with a security key or passkey, using the endpoints under /auth/webauthn.
+// Binary fields are sent to and from the server as unpadded base64url strings.
+(function () {
+	function decode(s) {
+		s = s.replace(/-/g, "+").replace(/_/g, "/");
+		while (s.length % 4 != 0) {
+			s += "=";
+		}
+		return Uint8Array.from(atob(s), (c) => c.charCodeAt(0));
+	}
+
+	function encode(buf) {
+		let s = "";
+		new Uint8Array(buf).forEach((b) => {
+			s += String.fromCharCode(b);
+		});
+		return btoa(s).replace(/\+/g, "-").replace(/\//g, "_").replace(/=+$/, "");
+	}
+
+	function post(url, body) {
+		return fetch(url, {
+			method: "POST",
+			credentials: "same-origin",
+			headers: {"Content-Type": "application/json"},
+			body: JSON.stringify(body || {})
+		}).then((res) => {
+			return res.json().then((json) => {
+				if (!res.ok) {
+					throw new Error(json.error || res.statusText);
+				}
+				return json;
+			});
+		});
+	}
+
+	function showError(err) {
+		const el = document.getElementById("webauthn-error");
+		if (el) {
+			el.textContent = "Signing in with your security key didn't work: " + err.message;
+			el.hidden = false;
+		}
+	}
+
+	function signIn() {
+		return post("/auth/webauthn/options").then((options) => {
+			const publicKey = options.publicKey;
+			publicKey.challenge = decode(publicKey.challenge);
+			(publicKey.allowCredentials || []).forEach((c) => {
+				c.id = decode(c.id);
+			});
+			return navigator.credentials.get({publicKey: publicKey});
+		}).then((credential) => {
+			return post("/auth/webauthn", {
+				id: credential.id,
+				type: credential.type,
+				response: {
+					clientDataJSON: encode(credential.response.clientDataJSON),
+					authenticatorData: encode(credential.response.authenticatorData),
+					signature: encode(credential.response.signature),
+					userHandle: credential.response.userHandle ? encode(credential.response.userHandle) : ""
+				}
+			});
+		}).then((result) => {
+			window.location = result.redirect;
+		}).catch(showError);
+	}
+
+	if (!window.PublicKeyCredential) {
+		return;
+	}
+
+	document.querySelectorAll("[data-webauthn-sign-in]").forEach((button) => {
+		button.hidden = false;
+		button.addEventListener("click", signIn);
+	});
+})();